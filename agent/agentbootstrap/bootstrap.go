@@ -115,6 +115,11 @@ func InitializeState(
 		cloudCredentials[cloudCredentialTag] = *args.ControllerCloudCredential
 	}
 
+	secretsMasterKey, err := agent.SecretsMasterKey(c)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	logger.Debugf("initializing address %v", info.Addrs)
 	ctlr, st, err := state.Initialize(state.InitializeParams{
 		Clock: clock.WallClock,
@@ -137,6 +142,7 @@ func InitializeState(
 		MongoSession:              session,
 		AdminPassword:             info.Password,
 		NewPolicy:                 newPolicy,
+		SecretsMasterKey:          secretsMasterKey,
 	})
 	if err != nil {
 		return nil, nil, errors.Errorf("failed to initialize state: %v", err)
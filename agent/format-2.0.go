@@ -56,6 +56,7 @@ type format_2_0Serialization struct {
 	StatePort          int    `yaml:"stateport,omitempty"`
 	SharedSecret       string `yaml:"sharedsecret,omitempty"`
 	SystemIdentity     string `yaml:"systemidentity,omitempty"`
+	SecretsMasterKey   string `yaml:"secretsmasterkey,omitempty"`
 	MongoVersion       string `yaml:"mongoversion,omitempty"`
 	MongoMemoryProfile string `yaml:"mongomemoryprofile,omitempty"`
 }
@@ -113,13 +114,14 @@ func (formatter_2_0) unmarshal(data []byte) (*configInternal, error) {
 	}
 	if len(format.ControllerKey) != 0 {
 		config.servingInfo = &params.StateServingInfo{
-			Cert:           format.ControllerCert,
-			PrivateKey:     format.ControllerKey,
-			CAPrivateKey:   format.CAPrivateKey,
-			APIPort:        format.APIPort,
-			StatePort:      format.StatePort,
-			SharedSecret:   format.SharedSecret,
-			SystemIdentity: format.SystemIdentity,
+			Cert:             format.ControllerCert,
+			PrivateKey:       format.ControllerKey,
+			CAPrivateKey:     format.CAPrivateKey,
+			APIPort:          format.APIPort,
+			StatePort:        format.StatePort,
+			SharedSecret:     format.SharedSecret,
+			SystemIdentity:   format.SystemIdentity,
+			SecretsMasterKey: format.SecretsMasterKey,
 		}
 		// If private key is not present, infer it from the ports in the state addresses.
 		if config.servingInfo.StatePort == 0 {
@@ -181,6 +183,7 @@ func (formatter_2_0) marshal(config *configInternal) ([]byte, error) {
 		format.StatePort = config.servingInfo.StatePort
 		format.SharedSecret = config.servingInfo.SharedSecret
 		format.SystemIdentity = config.servingInfo.SystemIdentity
+		format.SecretsMasterKey = config.servingInfo.SecretsMasterKey
 		format.StatePassword = config.statePassword
 	}
 	if config.apiDetails != nil {
@@ -0,0 +1,44 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+// secretsMasterKeyLen is the length of the AES-256 key used to
+// wrap model secret keys.
+const secretsMasterKeyLen = 32
+
+// GenerateSecretsMasterKey returns a new random secrets master key,
+// base64 encoded for storing in the agent's state serving info.
+func GenerateSecretsMasterKey() (string, error) {
+	key := make([]byte, secretsMasterKeyLen)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", errors.Annotate(err, "cannot generate secrets master key")
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// SecretsMasterKey returns the decoded secrets master key held in the
+// agent's state serving info. It returns nil if the agent has no key,
+// in which case model secrets are not supported.
+func SecretsMasterKey(c Config) ([]byte, error) {
+	info, ok := c.StateServingInfo()
+	if !ok || info.SecretsMasterKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(info.SecretsMasterKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode secrets master key")
+	}
+	if len(key) != secretsMasterKeyLen {
+		return nil, errors.NotValidf("secrets master key of length %d", len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"encoding/base64"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) config(c *gc.C, key string) agent.Config {
+	servingInfo := stateServingInfo()
+	servingInfo.SecretsMasterKey = key
+	conf, err := agent.NewStateMachineConfig(attributeParams, servingInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	return conf
+}
+
+func (s *secretsSuite) TestSecretsMasterKey(c *gc.C) {
+	encoded, err := agent.GenerateSecretsMasterKey()
+	c.Assert(err, jc.ErrorIsNil)
+	key, err := agent.SecretsMasterKey(s.config(c, encoded))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(base64.StdEncoding.EncodeToString(key), gc.Equals, encoded)
+	c.Assert(key, gc.HasLen, 32)
+}
+
+func (s *secretsSuite) TestSecretsMasterKeyMissing(c *gc.C) {
+	key, err := agent.SecretsMasterKey(s.config(c, ""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.IsNil)
+}
+
+func (s *secretsSuite) TestSecretsMasterKeyInvalid(c *gc.C) {
+	_, err := agent.SecretsMasterKey(s.config(c, "c2hvcnQ="))
+	c.Assert(err, gc.ErrorMatches, "secrets master key of length 5 not valid")
+}
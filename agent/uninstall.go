@@ -4,11 +4,20 @@
 package agent
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+	"github.com/juju/os/series"
+	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/juju/paths"
 )
 
 const (
@@ -47,3 +56,75 @@ func CanUninstall(a Agent) bool {
 	logger.Infof("agent already marked ready for uninstall")
 	return true
 }
+
+// UninstallScript returns a bash script that removes the juju agents
+// from a machine of the given series, along with everything they left
+// behind: the agent services, the jujud symlinks, the data, log and
+// configuration directories, and the proxy and package settings written
+// by juju. It is run over SSH to clean up manually provisioned machines
+// whose agents are not able to uninstall themselves.
+func UninstallScript(machineSeries string) (string, error) {
+	machineOS, err := series.GetOSFromSeries(machineSeries)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if machineOS == jujuos.Windows {
+		return "", errors.NotSupportedf("uninstalling agents from %s machines", machineOS)
+	}
+	dataDir := paths.MustSucceed(paths.DataDir(machineSeries))
+	logDir := paths.MustSucceed(paths.LogDir(machineSeries))
+	confDir := paths.MustSucceed(paths.ConfDir(machineSeries))
+	symlinks := []string{
+		paths.MustSucceed(paths.JujuRun(machineSeries)),
+		paths.MustSucceed(paths.JujuDumpLogs(machineSeries)),
+		paths.MustSucceed(paths.JujuIntrospect(machineSeries)),
+		paths.MustSucceed(paths.JujuUpdateSeries(machineSeries)),
+	}
+	quotedSymlinks := make([]string, len(symlinks))
+	for i, link := range symlinks {
+		quotedSymlinks[i] = utils.ShQuote(link)
+	}
+	return fmt.Sprintf(
+		uninstallScript,
+		// WARNING: this is linked with the use of uninstallFile above.
+		// Don't change it without extreme care, and handling for
+		// mismatches with already-deployed agents.
+		utils.ShQuote(path.Join(dataDir, UninstallFile)),
+		strings.Join(quotedSymlinks, " "),
+		utils.ShQuote(dataDir),
+		utils.ShQuote(path.Join(logDir, "juju")),
+		utils.ShQuote(confDir),
+	), nil
+}
+
+const uninstallScript = `
+# An agent that is still running will uninstall itself when it
+# finds the uninstall file and is aborted.
+touch %[1]s
+pkill -SIGABRT jujud
+for i in {1..30}; do
+    pgrep jujud > /dev/null || break
+    sleep 1
+done
+pkill -SIGKILL jujud
+
+for unit in /etc/systemd/system/jujud-*.service; do
+    [ -e "$unit" ] || continue
+    systemctl stop "$(basename "$unit")"
+    systemctl disable "$(basename "$unit")"
+done
+for job in /etc/init/jujud-*.conf; do
+    [ -e "$job" ] || continue
+    stop "$(basename "$job" .conf)"
+done
+rm -f /etc/systemd/system{,/multi-user.target.wants}/juju*
+rm -f /etc/init/juju*
+command -v systemctl > /dev/null && systemctl daemon-reload
+
+rm -f %[2]s
+rm -f /etc/profile.d/juju-*.sh
+rm -f /etc/apt/apt.conf.d/*-juju-proxy-settings
+rm -f /etc/apt/preferences.d/50-cloud-tools
+rm -fr %[3]s %[4]s %[5]s
+exit 0
+`
@@ -0,0 +1,32 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/testing"
+)
+
+type uninstallSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&uninstallSuite{})
+
+func (s *uninstallSuite) TestUninstallScript(c *gc.C) {
+	script, err := agent.UninstallScript("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(script, jc.Contains, "touch '/var/lib/juju/uninstall-agent'\n")
+	c.Check(script, jc.Contains, "pkill -SIGABRT jujud\n")
+	c.Check(script, jc.Contains, "rm -f '/usr/bin/juju-run' '/usr/bin/juju-dumplogs' '/usr/bin/juju-introspect' '/usr/bin/juju-updateseries'\n")
+	c.Check(script, jc.Contains, "rm -fr '/var/lib/juju' '/var/log/juju' '/etc/juju'\n")
+}
+
+func (s *uninstallSuite) TestUninstallScriptWindows(c *gc.C) {
+	_, err := agent.UninstallScript("win2012r2")
+	c.Assert(err, gc.ErrorMatches, "uninstalling agents from Windows machines not supported")
+}
@@ -712,6 +712,33 @@ func (c *Client) SetRelationSuspended(relationIds []int, suspended bool, message
 	return results.Combine()
 }
 
+// SetUnitsPaused pauses or resumes hook execution for the specified
+// units.
+func (c *Client) SetUnitsPaused(units []string, paused bool) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("pausing units on this version of Juju")
+	}
+	var args params.Entities
+	for _, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("unit name %q", unit)
+		}
+		args.Entities = append(args.Entities, params.Entity{Tag: names.NewUnitTag(unit).String()})
+	}
+	method := "ResumeUnits"
+	if paused {
+		method = "PauseUnits"
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(args.Entities) {
+		return errors.Errorf("expected %d results, got %d", len(args.Entities), len(results.Results))
+	}
+	return results.Combine()
+}
+
 // Consume adds a remote application to the model.
 func (c *Client) Consume(arg crossmodel.ConsumeApplicationArgs) (string, error) {
 	var consumeRes params.ErrorResults
@@ -650,6 +650,41 @@ func (s *applicationSuite) TestSetRelationSuspended(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetUnitsPaused(c *gc.C) {
+	for _, paused := range []bool{true, false} {
+		called := false
+		apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, a, result interface{}) error {
+			if paused {
+				c.Assert(request, gc.Equals, "PauseUnits")
+			} else {
+				c.Assert(request, gc.Equals, "ResumeUnits")
+			}
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}, {Tag: "unit-mysql-1"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*result.(*params.ErrorResults) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {}},
+			}
+			called = true
+			return nil
+		})
+		client := application.NewClient(basetesting.BestVersionCaller{apiCaller, 8})
+		err := client.SetUnitsPaused([]string{"mysql/0", "mysql/1"}, paused)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(called, jc.IsTrue)
+	}
+}
+
+func (s *applicationSuite) TestSetUnitsPausedNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	err := client.SetUnitsPaused([]string{"mysql/0"}, true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestSetRelationSuspendedArity(c *gc.C) {
 	called := false
 	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
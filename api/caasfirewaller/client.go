@@ -130,6 +130,107 @@ func (c *Client) IsExposed(appName string) (bool, error) {
 	return results.Results[0].Result, nil
 }
 
+// WatchRelations returns a StringsWatcher that notifies of changes
+// to the relations of the specified application.
+func (c *Client) WatchRelations(appName string) (watcher.StringsWatcher, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchRelations", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
+// RelatedApplications returns the names of the applications
+// related to the specified application.
+func (c *Client) RelatedApplications(appName string) ([]string, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.StringsResults
+	if err := c.facade.FacadeCall("RelatedApplications", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// IngressNetworks returns the networks from which the consumers of
+// the specified application's cross model relations may connect.
+func (c *Client) IngressNetworks(appName string) ([]string, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.StringsResults
+	if err := c.facade.FacadeCall("IngressNetworks", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// WatchIngressNetworks returns a NotifyWatcher that notifies of
+// changes to the ingress networks of the specified application's
+// relations.
+func (c *Client) WatchIngressNetworks(appName string) (watcher.NotifyWatcher, error) {
+	return c.watchApplication("WatchIngressNetworks", appName)
+}
+
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the config of the specified application.
+func (c *Client) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	return c.watchApplication("WatchApplicationsConfig", appName)
+}
+
+func (c *Client) watchApplication(method, appName string) (watcher.NotifyWatcher, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
 // maybeNotFound returns an error satisfying errors.IsNotFound
 // if the supplied error has a CodeNotFound error.
 func maybeNotFound(err *params.Error) error {
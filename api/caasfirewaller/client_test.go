@@ -196,3 +196,123 @@ func (s *FirewallerSuite) TestApplicationConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg, jc.DeepEquals, application.ConfigAttributes{"foo": "bar"})
 }
+
+func (s *FirewallerSuite) TestWatchRelations(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchRelations")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	watcher, err := client.WatchRelations("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *FirewallerSuite) TestRelatedApplications(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RelatedApplications")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Result: []string{"mysql", "redis"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	related, err := client.RelatedApplications("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(related, jc.DeepEquals, []string{"mysql", "redis"})
+}
+
+func (s *FirewallerSuite) TestRelatedApplicationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: "bletch",
+			}}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.RelatedApplications("gitlab")
+	c.Assert(err, gc.ErrorMatches, "bletch")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FirewallerSuite) TestIngressNetworks(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "IngressNetworks")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Result: []string{"10.0.0.0/24"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	cidrs, err := client.IngressNetworks("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/24"})
+}
+
+func (s *FirewallerSuite) TestWatchIngressNetworks(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchIngressNetworks")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.WatchIngressNetworks("gitlab")
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
@@ -4,7 +4,10 @@
 package caasoperator
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -13,6 +16,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/status"
@@ -254,3 +258,77 @@ func (c *Client) SetVersion(appName string, v version.Binary) error {
 	}
 	return results.OneError()
 }
+
+// ClaimOperatorLease claims, or extends, on behalf of the named operator
+// instance, the lease entitling it to run the charm for the specified
+// application. If another instance holds the lease, lease.ErrClaimDenied
+// is returned.
+func (c *Client) ClaimOperatorLease(application, holder string, duration time.Duration) error {
+	tag, err := c.appTag(application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args := params.OperatorLeaseClaims{
+		Claims: []params.OperatorLeaseClaim{{
+			ApplicationTag: tag.String(),
+			Holder:         holder,
+			Duration:       duration,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ClaimOperatorLease", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if err := results.OneError(); err != nil {
+		if params.IsCodeLeaseClaimDenied(err) {
+			return lease.ErrClaimDenied
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// WaitOperatorLeaseExpired blocks until no operator instance
+// holds the lease for the specified application.
+func (c *Client) WaitOperatorLeaseExpired(application string) error {
+	tag, err := c.appTag(application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("WaitOperatorLeaseExpired", entities(tag), &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ExecInWorkload runs commands in the workload container
+// of the specified unit, returning their output and exit code.
+func (c *Client) ExecInWorkload(unitName, commands string, timeout time.Duration) (*exec.ExecResponse, error) {
+	if !names.IsValidUnit(unitName) {
+		return nil, errors.NotValidf("unit name %q", unitName)
+	}
+	args := params.WorkloadExecArgs{
+		Args: []params.WorkloadExecArg{{
+			Tag:      names.NewUnitTag(unitName).String(),
+			Commands: commands,
+			Timeout:  timeout,
+		}},
+	}
+	var results params.WorkloadExecResults
+	if err := c.facade.FacadeCall("ExecInWorkload", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, maybeNotFound(result.Error)
+	}
+	return &exec.ExecResponse{
+		Code:   result.Code,
+		Stdout: result.Stdout,
+		Stderr: result.Stderr,
+	}, nil
+}
@@ -4,9 +4,12 @@
 package caasoperator_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -14,6 +17,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/caasoperator"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 )
@@ -331,3 +335,111 @@ func (s *operatorSuite) TestSetVersionInvalidApplicationName(c *gc.C) {
 	err := client.SetVersion("", version.Binary{})
 	c.Assert(err, gc.ErrorMatches, `application name "" not valid`)
 }
+
+func (s *operatorSuite) TestClaimOperatorLease(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASOperator")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ClaimOperatorLease")
+		c.Check(arg, jc.DeepEquals, params.OperatorLeaseClaims{
+			Claims: []params.OperatorLeaseClaim{{
+				ApplicationTag: "application-gitlab",
+				Holder:         "juju-operator-gitlab-1",
+				Duration:       time.Minute,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+
+	client := caasoperator.NewClient(apiCaller)
+	err := client.ClaimOperatorLease("gitlab", "juju-operator-gitlab-1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *operatorSuite) TestClaimOperatorLeaseDenied(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Code: params.CodeLeaseClaimDenied, Message: "lease claim denied"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasoperator.NewClient(apiCaller)
+	err := client.ClaimOperatorLease("gitlab", "juju-operator-gitlab-1", time.Minute)
+	c.Assert(err, gc.Equals, lease.ErrClaimDenied)
+}
+
+func (s *operatorSuite) TestWaitOperatorLeaseExpired(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASOperator")
+		c.Check(request, gc.Equals, "WaitOperatorLeaseExpired")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-gitlab"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasoperator.NewClient(apiCaller)
+	err := client.WaitOperatorLeaseExpired("gitlab")
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *operatorSuite) TestExecInWorkload(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASOperator")
+		c.Check(request, gc.Equals, "ExecInWorkload")
+		c.Check(arg, jc.DeepEquals, params.WorkloadExecArgs{
+			Args: []params.WorkloadExecArg{{
+				Tag:      "unit-gitlab-0",
+				Commands: "echo hello",
+				Timeout:  time.Minute,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.WorkloadExecResults{})
+		*(result.(*params.WorkloadExecResults)) = params.WorkloadExecResults{
+			Results: []params.WorkloadExecResult{{
+				Code:   1,
+				Stdout: []byte("hello"),
+				Stderr: []byte("world"),
+			}},
+		}
+		return nil
+	})
+
+	client := caasoperator.NewClient(apiCaller)
+	response, err := client.ExecInWorkload("gitlab/0", "echo hello", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(response, jc.DeepEquals, &exec.ExecResponse{
+		Code:   1,
+		Stdout: []byte("hello"),
+		Stderr: []byte("world"),
+	})
+}
+
+func (s *operatorSuite) TestExecInWorkloadError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.WorkloadExecResults)) = params.WorkloadExecResults{
+			Results: []params.WorkloadExecResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasoperator.NewClient(apiCaller)
+	_, err := client.ExecInWorkload("gitlab/0", "echo hello", 0)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
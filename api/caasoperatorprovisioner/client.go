@@ -45,6 +45,20 @@ func (c *Client) WatchApplications() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchApplicationsConfig returns a NotifyWatcher that notifies of
+// changes to the config of the CAAS applications in the current model.
+func (c *Client) WatchApplicationsConfig() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchApplicationsConfig", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // ApplicationPassword holds parameters for setting
 // an application password.
 type ApplicationPassword struct {
@@ -120,3 +134,26 @@ func (c *Client) OperatorProvisioningInfo() (OperatorProvisioningInfo, error) {
 		Version:   result.Version,
 	}, nil
 }
+
+// OperatorReplicas returns the number of operator pods
+// to run for the specified CAAS application.
+func (c *Client) OperatorReplicas(appName string) (int, error) {
+	if !names.IsValidApplication(appName) {
+		return 0, errors.NotValidf("application name %q", appName)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(appName).String()}},
+	}
+
+	var results params.IntResults
+	if err := c.facade.FacadeCall("OperatorReplicas", args, &results); err != nil {
+		return 0, err
+	}
+	if n := len(results.Results); n != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return 0, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
@@ -46,6 +46,25 @@ func (s *provisionerSuite) TestWatchApplications(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 }
 
+func (s *provisionerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "CAASOperatorProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "WatchApplicationsConfig")
+		c.Assert(a, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "FAIL"},
+		}
+		return nil
+	})
+	_, err := client.WatchApplicationsConfig()
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(called, jc.IsTrue)
+}
+
 func (s *provisionerSuite) TestSetPasswords(c *gc.C) {
 	passwords := []caasoperatorprovisioner.ApplicationPassword{
 		{Name: "app", Password: "secret"},
@@ -175,3 +194,37 @@ func (s *provisionerSuite) OperatorProvisioningInfo(c *gc.C) {
 		Version:   vers,
 	})
 }
+
+func (s *provisionerSuite) TestOperatorReplicas(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASOperatorProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "OperatorReplicas")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-gitlab"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.IntResults{})
+		*(result.(*params.IntResults)) = params.IntResults{
+			Results: []params.IntResult{{Result: 3}},
+		}
+		return nil
+	})
+	replicas, err := client.OperatorReplicas("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replicas, gc.Equals, 3)
+}
+
+func (s *provisionerSuite) TestOperatorReplicasError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.IntResults)) = params.IntResults{
+			Results: []params.IntResult{{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: "bletch",
+			}}},
+		}
+		return nil
+	})
+	_, err := client.OperatorReplicas("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "bletch")
+}
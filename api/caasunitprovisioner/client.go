@@ -102,6 +102,37 @@ func (c *Client) WatchUnits(application string) (watcher.StringsWatcher, error)
 	return w, nil
 }
 
+// UnitProviderIds returns the provider ids of the units of the
+// specified CAAS application, keyed by unit name. Units which are
+// not yet associated with a cloud container are omitted.
+func (c *Client) UnitProviderIds(application string) (map[string]string, error) {
+	applicationTag, err := applicationTag(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(applicationTag)
+
+	var results params.UnitProviderIdsResults
+	if err := c.facade.FacadeCall("UnitsProviderIds", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	providerIds := make(map[string]string)
+	for tagString, providerId := range results.Results[0].ProviderIds {
+		tag, err := names.ParseUnitTag(tagString)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		providerIds[tag.Id()] = providerId
+	}
+	return providerIds, nil
+}
+
 // WatchPodSpec returns a NotifyWatcher that notifies of
 // changes to the pod spec of the specified CAAS application in
 // the current model.
@@ -126,6 +157,30 @@ func (c *Client) WatchPodSpec(application string) (watcher.NotifyWatcher, error)
 	return w, nil
 }
 
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the config of the specified CAAS application in the
+// current model.
+func (c *Client) WatchApplicationConfig(application string) (watcher.NotifyWatcher, error) {
+	appTag, err := applicationTag(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsConfig", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
 // ProvisioningInfo holds unit provisioning info.
 type ProvisioningInfo struct {
 	PodSpec     string
@@ -18,17 +18,17 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  7,
+	"Application":                  8,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
 	"Backups":                      2,
 	"Block":                        2,
 	"Bundle":                       2,
 	"CAASAgent":                    1,
-	"CAASFirewaller":               1,
-	"CAASOperator":                 1,
-	"CAASOperatorProvisioner":      1,
-	"CAASUnitProvisioner":          1,
+	"CAASFirewaller":               2,
+	"CAASOperator":                 3,
+	"CAASOperatorProvisioner":      2,
+	"CAASUnitProvisioner":          2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
@@ -45,7 +45,7 @@ var facadeVersions = map[string]int{
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   5,
+	"Firewaller":                   6,
 	"FirewallRules":                1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
@@ -60,7 +60,7 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               5,
+	"MachineManager":               6,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -88,8 +88,10 @@ var facadeVersions = map[string]int{
 	"RemoteRelations":              1,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
+	"ResourceTagger":               1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
 	"Singular":                     2,
 	"Spaces":                       3,
 	"SSHClient":                    2,
@@ -100,7 +102,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       8,
+	"Uniter":                       9,
 	"Upgrader":                     1,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
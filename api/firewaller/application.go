@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/common"
@@ -75,3 +76,74 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// RelatedApplications returns the names of the applications in the
+// model that this application is related to.
+func (s *Application) RelatedApplications() ([]string, error) {
+	if s.st.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("RelatedApplications on API version %d", s.st.BestAPIVersion())
+	}
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetRelatedApplications", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// LoadBalancerAddresses returns the recorded addresses of the provider
+// load balancers in front of the application's exposed endpoints, keyed
+// by endpoint port range.
+func (s *Application) LoadBalancerAddresses() (map[string][]string, error) {
+	if s.st.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("LoadBalancerAddresses on API version %d", s.st.BestAPIVersion())
+	}
+	var results params.ApplicationLoadBalancerAddressesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("ApplicationLoadBalancerAddresses", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Addresses, nil
+}
+
+// SetLoadBalancerAddresses records the addresses of the provider load
+// balancers in front of the application's exposed endpoints, keyed by
+// endpoint port range. No addresses records that the application has
+// no load balancers.
+func (s *Application) SetLoadBalancerAddresses(addresses map[string][]string) error {
+	if s.st.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("SetLoadBalancerAddresses on API version %d", s.st.BestAPIVersion())
+	}
+	var results params.ErrorResults
+	args := params.SetApplicationsLoadBalancerAddresses{
+		Applications: []params.ApplicationLoadBalancerAddresses{{
+			Tag:       s.tag.String(),
+			Addresses: addresses,
+		}},
+	}
+	err := s.st.facade.FacadeCall("SetApplicationLoadBalancerAddresses", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -91,3 +91,32 @@ func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *applicationSuite) TestRelatedApplications(c *gc.C) {
+	related, err := s.apiApplication.RelatedApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(related, jc.DeepEquals, []string{"mysql"})
+}
+
+func (s *applicationSuite) TestSetLoadBalancerAddresses(c *gc.C) {
+	addresses := map[string][]string{"80/tcp": {"10.0.0.1"}}
+	err := s.apiApplication.SetLoadBalancerAddresses(addresses)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.LoadBalancerAddresses(), jc.DeepEquals, addresses)
+
+	recorded, err := s.apiApplication.LoadBalancerAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, jc.DeepEquals, addresses)
+
+	err = s.apiApplication.SetLoadBalancerAddresses(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.LoadBalancerAddresses(), gc.HasLen, 0)
+
+	recorded, err = s.apiApplication.LoadBalancerAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, gc.HasLen, 0)
+}
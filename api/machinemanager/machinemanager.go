@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 )
 
 const machineManagerFacade = "MachineManager"
@@ -182,3 +183,40 @@ func (client *Client) UpgradeSeriesComplete(machineName string) error {
 
 	return nil
 }
+
+// InstanceTypes returns the instance types available in the model's cloud
+// region that match the supplied constraints.
+func (client *Client) InstanceTypes(cons constraints.Value) (params.InstanceTypesResult, error) {
+	args := params.ModelInstanceTypesConstraints{
+		Constraints: []params.ModelInstanceTypesConstraint{{Value: &cons}},
+	}
+	var results params.InstanceTypesResults
+	if err := client.facade.FacadeCall("InstanceTypes", args, &results); err != nil {
+		return params.InstanceTypesResult{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.InstanceTypesResult{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.InstanceTypesResult{}, result.Error
+	}
+	return result, nil
+}
+
+// PreviewInstances returns the instance the provider would start for each
+// of the supplied machines, without starting anything.
+func (client *Client) PreviewInstances(args []params.PreviewInstanceArg) ([]params.PreviewInstanceResult, error) {
+	if client.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("previewing instances")
+	}
+	var results params.PreviewInstanceResults
+	err := client.facade.FacadeCall("PreviewInstances", params.PreviewInstancesArgs{Args: args}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(args) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args), n)
+	}
+	return results.Results, nil
+}
@@ -13,6 +13,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -210,3 +211,82 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestInstanceTypes(c *gc.C) {
+	expected := params.InstanceTypesResult{
+		InstanceTypes: []params.InstanceType{{
+			Name:     "m3.medium",
+			Arches:   []string{"amd64"},
+			CPUCores: 1,
+			Memory:   3840,
+		}},
+		CostUnit:     "$USD/hour",
+		CostCurrency: "USD",
+		CostDivisor:  1000,
+	}
+	cons := constraints.MustParse("mem=2G")
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "InstanceTypes")
+		c.Assert(a, jc.DeepEquals, params.ModelInstanceTypesConstraints{
+			Constraints: []params.ModelInstanceTypesConstraint{{Value: &cons}},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.InstanceTypesResults{})
+		out := response.(*params.InstanceTypesResults)
+		*out = params.InstanceTypesResults{Results: []params.InstanceTypesResult{expected}}
+		return nil
+	})
+	result, err := client.InstanceTypes(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *MachinemanagerSuite) TestInstanceTypesResultError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		out := response.(*params.InstanceTypesResults)
+		*out = params.InstanceTypesResults{Results: []params.InstanceTypesResult{{
+			Error: &params.Error{Message: "not implemented"},
+		}}}
+		return nil
+	})
+	_, err := client.InstanceTypes(constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "not implemented")
+}
+
+func (s *MachinemanagerSuite) TestPreviewInstances(c *gc.C) {
+	args := []params.PreviewInstanceArg{{
+		Series:      "bionic",
+		Constraints: constraints.MustParse("mem=4G"),
+	}}
+	expected := []params.PreviewInstanceResult{{
+		Series:       "bionic",
+		Constraints:  constraints.MustParse("mem=4G"),
+		InstanceType: &params.InstanceType{Name: "m3.medium"},
+		ImageId:      "ami-bionic",
+	}}
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "PreviewInstances")
+			c.Assert(a, jc.DeepEquals, params.PreviewInstancesArgs{Args: args})
+			c.Assert(response, gc.FitsTypeOf, &params.PreviewInstanceResults{})
+			out := response.(*params.PreviewInstanceResults)
+			*out = params.PreviewInstanceResults{Results: expected}
+			return nil
+		},
+		BestVersion: 6,
+	})
+	results, err := client.PreviewInstances(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *MachinemanagerSuite) TestPreviewInstancesNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 5,
+	})
+	_, err := client.PreviewInstances(nil)
+	c.Assert(err, gc.ErrorMatches, "previewing instances not supported")
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/watcher"
+)
+
+// NewWatcherFunc exists to let us test WatchResourceTags.
+type NewWatcherFunc func(base.APICaller, params.NotifyWatchResult) watcher.NotifyWatcher
+
+// API provides access to the resource tagger API facade.
+type API struct {
+	facade     base.FacadeCaller
+	newWatcher NewWatcherFunc
+}
+
+// NewAPI creates a new client-side resource tagger facade.
+func NewAPI(caller base.APICaller, newWatcher NewWatcherFunc) *API {
+	return &API{
+		facade:     base.NewFacadeCaller(caller, "ResourceTagger"),
+		newWatcher: newWatcher,
+	}
+}
+
+// VolumeTags holds the tags to set on a provider volume.
+type VolumeTags struct {
+	VolumeId string
+	Provider storage.ProviderType
+	Tags     map[string]string
+}
+
+// ResourceTags holds the tags to set on the provider resources of
+// the model.
+type ResourceTags struct {
+	// ModelTags holds the tags to set on the resources shared by
+	// the model, such as security groups.
+	ModelTags map[string]string
+
+	// Instances holds the tags to set on each provisioned instance.
+	Instances map[instance.Id]map[string]string
+
+	// Volumes holds the tags to set on each provisioned volume.
+	Volumes []VolumeTags
+}
+
+// ResourceTags returns the tags to set on the model's provider
+// resources.
+func (api *API) ResourceTags() (*ResourceTags, error) {
+	var result params.ResourceTagsResult
+	if err := api.facade.FacadeCall("ResourceTags", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	resourceTags := &ResourceTags{
+		ModelTags: result.Result.ModelTags,
+		Instances: make(map[instance.Id]map[string]string),
+	}
+	for _, inst := range result.Result.Instances {
+		resourceTags.Instances[instance.Id(inst.InstanceId)] = inst.Tags
+	}
+	for _, v := range result.Result.Volumes {
+		resourceTags.Volumes = append(resourceTags.Volumes, VolumeTags{
+			VolumeId: v.VolumeId,
+			Provider: storage.ProviderType(v.Provider),
+			Tags:     v.Tags,
+		})
+	}
+	return resourceTags, nil
+}
+
+// WatchResourceTags returns a NotifyWatcher that triggers whenever
+// the model or application resource tags change.
+func (api *API) WatchResourceTags() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchResourceTags", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return api.newWatcher(api.facade.RawAPICaller(), result), nil
+}
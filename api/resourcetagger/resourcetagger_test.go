@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+)
+
+type resourceTaggerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&resourceTaggerSuite{})
+
+func (s *resourceTaggerSuite) TestResourceTags(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ResourceTagger")
+		c.Check(request, gc.Equals, "ResourceTags")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ResourceTagsResult{})
+		*result.(*params.ResourceTagsResult) = params.ResourceTagsResult{
+			Result: &params.ResourceTags{
+				ModelTags: map[string]string{"team": "ops"},
+				Instances: []params.InstanceResourceTags{{
+					InstanceId: "inst-0",
+					Tags:       map[string]string{"team": "dba"},
+				}},
+				Volumes: []params.VolumeResourceTags{{
+					VolumeId: "vol-0",
+					Provider: "ebs",
+					Tags:     map[string]string{"team": "dba"},
+				}},
+			},
+		}
+		return nil
+	}
+	api := resourcetagger.NewAPI(testing.APICallerFunc(caller), nil)
+	resourceTags, err := api.ResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resourceTags, jc.DeepEquals, &resourcetagger.ResourceTags{
+		ModelTags: map[string]string{"team": "ops"},
+		Instances: map[instance.Id]map[string]string{
+			"inst-0": {"team": "dba"},
+		},
+		Volumes: []resourcetagger.VolumeTags{{
+			VolumeId: "vol-0",
+			Provider: "ebs",
+			Tags:     map[string]string{"team": "dba"},
+		}},
+	})
+}
+
+func (s *resourceTaggerSuite) TestResourceTagsError(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		*result.(*params.ResourceTagsResult) = params.ResourceTagsResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	}
+	api := resourcetagger.NewAPI(testing.APICallerFunc(caller), nil)
+	_, err := api.ResourceTags()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTags(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchResourceTags")
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*result.(*params.NotifyWatchResult) = params.NotifyWatchResult{NotifyWatcherId: "2"}
+		return nil
+	}
+	expectWatcher := &struct{ watcher.NotifyWatcher }{}
+	newWatcher := func(wcaller base.APICaller, result params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Check(wcaller, gc.NotNil) // not comparable
+		c.Check(result, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "2"})
+		return expectWatcher
+	}
+	api := resourcetagger.NewAPI(testing.APICallerFunc(caller), newWatcher)
+	w, err := api.WatchResourceTags()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(w, gc.Equals, expectWatcher)
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTagsError(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	}
+	api := resourcetagger.NewAPI(testing.APICallerFunc(caller), nil)
+	w, err := api.WatchResourceTags()
+	c.Check(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the secrets API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the secrets api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ListSecrets returns the metadata of all the secrets in the model.
+// Secret values are never returned.
+func (c *Client) ListSecrets() ([]params.ListSecretResult, error) {
+	var results params.ListSecretResults
+	if err := c.facade.FacadeCall("ListSecrets", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
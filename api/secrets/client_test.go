@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSecrets")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ListSecretResults{})
+			*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+				Results: []params.ListSecretResult{{
+					Id:       "secret-1",
+					Owner:    "mysql",
+					Revision: 2,
+				}},
+			}
+			return nil
+		})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.ListSecretResult{{
+		Id:       "secret-1",
+		Owner:    "mysql",
+		Revision: 2,
+	}})
+}
+
+func (s *SecretsSuite) TestListSecretsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -21,6 +23,7 @@ type Unit struct {
 	tag          names.UnitTag
 	life         params.Life
 	resolvedMode params.ResolvedMode
+	paused       bool
 	series       string
 }
 
@@ -54,6 +57,12 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// Paused returns whether the unit has been paused, in which case
+// no hooks should be run for it.
+func (u *Unit) Paused() bool {
+	return u.paused
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -76,6 +85,7 @@ func (u *Unit) Refresh() error {
 
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
+	u.paused = result.Paused
 	u.series = result.Series
 	return nil
 }
@@ -454,6 +464,211 @@ func (u *Unit) AvailabilityZone() (string, error) {
 	return result.Result, nil
 }
 
+// HookTimeout returns how long the unit's charm hooks may run before
+// they are killed. A zero duration means there is no timeout, as is
+// always the case for controllers without hook timeouts.
+func (u *Unit) HookTimeout() (time.Duration, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return 0, nil
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("HookTimeout", args, &results); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, errors.Trace(result.Error)
+	}
+	timeout, err := time.ParseDuration(result.Result)
+	if err != nil {
+		return 0, errors.Annotate(err, "parsing hook timeout")
+	}
+	return timeout, nil
+}
+
+// CharmState returns the key-value state persisted by the unit's charm.
+func (u *Unit) CharmState() (map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotImplementedf("CharmState() (need V9+)")
+	}
+	var results params.CharmStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("CharmState", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// SetCharmState replaces the key-value state persisted by the unit's charm.
+func (u *Unit) SetCharmState(charmState map[string]string) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return errors.NotImplementedf("SetCharmState() (need V9+)")
+	}
+	var result params.ErrorResults
+	args := params.SetCharmStateArgs{
+		Args: []params.SetCharmStateArg{{Tag: u.tag.String(), State: charmState}},
+	}
+	if err := u.st.facade.FacadeCall("SetCharmState", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// RecordHookExecution records a single execution of the named hook in
+// the unit's hook history. The relation id should be -1 if the hook was
+// not run for a relation. Controllers without hook histories ignore it.
+func (u *Unit) RecordHookExecution(hookName string, relationId int, started time.Time, duration time.Duration, exitCode int) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil
+	}
+	arg := params.RecordHookExecutionArg{
+		Tag:      u.tag.String(),
+		Hook:     hookName,
+		Started:  started,
+		Duration: duration,
+		ExitCode: exitCode,
+	}
+	if relationId >= 0 {
+		arg.RelationId = &relationId
+	}
+	var result params.ErrorResults
+	args := params.RecordHookExecutionArgs{Args: []params.RecordHookExecutionArg{arg}}
+	if err := u.st.facade.FacadeCall("RecordHookExecutions", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// CreateSecret creates a secret owned by the unit's application, and
+// returns its id. The unit must be the application leader.
+func (u *Unit) CreateSecret(description string, rotateInterval time.Duration, data map[string]string) (string, error) {
+	var results params.StringResults
+	args := params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			UnitTag:        u.tag.String(),
+			Description:    description,
+			RotateInterval: rotateInterval,
+			Data:           data,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("CreateSecrets", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// SecretValue returns the values of the given revision of a secret
+// that the unit's application owns or has been granted access to.
+// A zero revision returns the latest values.
+func (u *Unit) SecretValue(id string, revision int) (map[string]string, error) {
+	var results params.SecretValueResults
+	args := params.GetSecretArgs{
+		Args: []params.GetSecretArg{{
+			UnitTag:  u.tag.String(),
+			SecretId: id,
+			Revision: revision,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("GetSecretValues", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Data, nil
+}
+
+// RotateSecret records new values for a secret owned by the unit's
+// application. The unit must be the application leader.
+func (u *Unit) RotateSecret(id string, data map[string]string) error {
+	var result params.ErrorResults
+	args := params.RotateSecretArgs{
+		Args: []params.RotateSecretArg{{
+			UnitTag:  u.tag.String(),
+			SecretId: id,
+			Data:     data,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("RotateSecrets", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// GrantSecret allows the remote application of the given relation to
+// read a secret owned by the unit's application.
+func (u *Unit) GrantSecret(id string, relationId int) error {
+	return u.changeSecretGrant("GrantSecrets", id, relationId)
+}
+
+// RevokeSecret stops the remote application of the given relation
+// from reading a secret owned by the unit's application.
+func (u *Unit) RevokeSecret(id string, relationId int) error {
+	return u.changeSecretGrant("RevokeSecrets", id, relationId)
+}
+
+func (u *Unit) changeSecretGrant(method, id string, relationId int) error {
+	var result params.ErrorResults
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{
+			UnitTag:    u.tag.String(),
+			SecretId:   id,
+			RelationId: relationId,
+		}},
+	}
+	if err := u.st.facade.FacadeCall(method, args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// SecretsToRotate returns the ids of the secrets owned by the unit's
+// application that are due to be rotated. The result is always empty
+// if the unit is not the application leader.
+func (u *Unit) SecretsToRotate() ([]string, error) {
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("SecretsToRotate", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
 // OpenPorts sets the policy of the port range with protocol to be
 // opened.
 func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
@@ -619,6 +834,32 @@ func (u *Unit) WatchAddresses() (watcher.NotifyWatcher, error) {
 	return w, nil
 }
 
+// WatchGoalState returns a watcher that fires when the goal state of
+// the unit's application changes; that is, when relations or the units
+// taking part in them are added or removed.
+func (u *Unit) WatchGoalState() (watcher.NotifyWatcher, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotImplementedf("WatchGoalState() (need V9+)")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchGoalState", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchActionNotifications returns a StringsWatcher for observing the
 // ids of Actions added to the Unit. The initial event will contain the
 // ids of any Actions pending at the time the Watcher is made.
@@ -240,6 +240,18 @@ func (s *unitSuite) TestRefreshResolve(c *gc.C) {
 	c.Assert(mode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *unitSuite) TestRefreshPaused(c *gc.C) {
+	c.Assert(s.apiUnit.Paused(), jc.IsFalse)
+
+	err := s.wordpressUnit.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.Paused(), jc.IsFalse)
+
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.Paused(), jc.IsTrue)
+}
+
 func (s *unitSuite) TestRefreshSeries(c *gc.C) {
 	c.Assert(s.apiUnit.Series(), gc.Equals, "quantal")
 	err := s.wordpressMachine.UpdateMachineSeries("xenial", true)
@@ -415,6 +427,141 @@ func (s *unitSuite) TestAvailabilityZone(c *gc.C) {
 	c.Check(zone, gc.Equals, "a-zone")
 }
 
+func (s *unitSuite) TestHookTimeout(c *gc.C) {
+	uniter.PatchUnitResponse(s, s.apiUnit, "HookTimeout",
+		func(result interface{}) error {
+			if results, ok := result.(*params.StringResults); ok {
+				results.Results = []params.StringResult{{
+					Result: "1h30m0s",
+				}}
+			}
+			return nil
+		},
+	)
+
+	timeout, err := s.apiUnit.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(timeout, gc.Equals, 90*time.Minute)
+}
+
+func (s *unitSuite) TestHookTimeoutOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 8,
+	}
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("wordpress/0"))
+
+	timeout, err := unit.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(timeout, gc.Equals, time.Duration(0))
+}
+
+func (s *unitSuite) TestCharmState(c *gc.C) {
+	charmState, err := s.apiUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+
+	err = s.apiUnit.SetCharmState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err = s.apiUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"initialised": "true"})
+	charmState, err = s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"initialised": "true"})
+}
+
+func (s *unitSuite) TestCharmStateOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 8,
+	}
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("wordpress/0"))
+
+	_, err := unit.CharmState()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err = unit.SetCharmState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestWatchGoalStateOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 8,
+	}
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("wordpress/0"))
+
+	_, err := unit.WatchGoalState()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestRecordHookExecution(c *gc.C) {
+	started := time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC)
+	err := s.apiUnit.RecordHookExecution("config-changed", -1, started, 3*time.Second, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.wordpressUnit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{{
+		Hook:     "config-changed",
+		Started:  started,
+		Duration: 3 * time.Second,
+		ExitCode: 1,
+	}})
+}
+
+func (s *unitSuite) TestRecordHookExecutionOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 8,
+	}
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("wordpress/0"))
+
+	err := unit.RecordHookExecution("config-changed", -1, time.Now(), time.Second, 0)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *unitSuite) TestSecrets(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err := s.apiUnit.CreateSecret("db password", 0, map[string]string{"password": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := s.apiUnit.SecretValue(id, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+
+	err = s.apiUnit.RotateSecret(id, map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	value, err = s.apiUnit.SecretValue(id, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "n3w"})
+	value, err = s.apiUnit.SecretValue(id, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+
+	ids, err := s.apiUnit.SecretsToRotate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, gc.HasLen, 0)
+}
+
 func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	ports, err := s.wordpressUnit.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
@@ -836,6 +983,26 @@ func (s *unitSuite) TestWatchAddresses(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *unitSuite) TestWatchGoalState(c *gc.C) {
+	w, err := s.apiUnit.WatchGoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	// Adding a unit is reported.
+	_, err = s.wordpressApplication.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Other changes to the application are not.
+	err = s.wordpressApplication.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *unitSuite) TestWatchAddressesErrors(c *gc.C) {
 	err := s.wordpressUnit.UnassignFromMachine()
 	c.Assert(err, jc.ErrorIsNil)
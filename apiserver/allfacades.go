@@ -62,6 +62,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelmanager"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
 	"github.com/juju/juju/apiserver/facades/client/resources"
+	"github.com/juju/juju/apiserver/facades/client/secrets"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/storage"
@@ -89,6 +90,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
+	"github.com/juju/juju/apiserver/facades/controller/resourcetagger"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
@@ -141,6 +143,7 @@ func AllFacades() *facade.Registry {
 	reg("Application", 5, application.NewFacadeV5) // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
 	reg("Application", 6, application.NewFacadeV6)
 	reg("Application", 7, application.NewFacadeV7)
+	reg("Application", 8, application.NewFacadeV8) // adds PauseUnits & ResumeUnits
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
@@ -160,11 +163,16 @@ func AllFacades() *facade.Registry {
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
-	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacade)
-	reg("CAASOperator", 1, caasoperator.NewStateFacade)
+	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeV1)
+	reg("CAASFirewaller", 2, caasfirewaller.NewStateFacade) // adds WatchRelations, RelatedApplications, IngressNetworks, WatchIngressNetworks & WatchApplicationsConfig
+	reg("CAASOperator", 1, caasoperator.NewStateFacadeV1)
+	reg("CAASOperator", 2, caasoperator.NewStateFacadeV2) // adds ClaimOperatorLease & WaitOperatorLeaseExpired
+	reg("CAASOperator", 3, caasoperator.NewStateFacade)   // adds ExecInWorkload
 	reg("CAASAgent", 1, caasagent.NewStateFacade)
-	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI)
-	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacade)
+	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPIV1)
+	reg("CAASOperatorProvisioner", 2, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI) // adds OperatorReplicas & WatchApplicationsConfig
+	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacadeV1)
+	reg("CAASUnitProvisioner", 2, caasunitprovisioner.NewStateFacade) // adds WatchApplicationsConfig
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
@@ -181,6 +189,7 @@ func AllFacades() *facade.Registry {
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6)
 	reg("FirewallRules", 1, firewallrules.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
@@ -204,6 +213,7 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Version 3 adds DestroyMachine and ForceDestroyMachine.
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Version 4 adds DestroyMachineWithParams.
 	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Version 5 adds UpgradeSeriesPrepare.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6) // Version 6 adds PreviewInstances.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPI)
@@ -245,9 +255,11 @@ func AllFacades() *facade.Registry {
 
 	reg("Resources", 1, resources.NewPublicFacade)
 	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacade)
+	reg("ResourceTagger", 1, resourcetagger.NewFacade)
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("Secrets", 1, secrets.NewFacade)
 	reg("Singular", 2, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
@@ -271,7 +283,8 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 5, uniter.NewUniterAPIV5)
 	reg("Uniter", 6, uniter.NewUniterAPIV6)
 	reg("Uniter", 7, uniter.NewUniterAPIV7)
-	reg("Uniter", 8, uniter.NewUniterAPI)
+	reg("Uniter", 8, uniter.NewUniterAPIV8)
+	reg("Uniter", 9, uniter.NewUniterAPI) // adds HookTimeout, CharmState, SetCharmState, RecordHookExecutions, WatchGoalState & secrets
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
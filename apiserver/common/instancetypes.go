@@ -16,24 +16,29 @@ import (
 func toParamsInstanceTypeResult(itypes []instances.InstanceType) []params.InstanceType {
 	result := make([]params.InstanceType, len(itypes))
 	for i, t := range itypes {
-		virtType := ""
-		if t.VirtType != nil {
-			virtType = *t.VirtType
-		}
-		result[i] = params.InstanceType{
-			Name:         t.Name,
-			Arches:       t.Arches,
-			CPUCores:     int(t.CpuCores),
-			Memory:       int(t.Mem),
-			RootDiskSize: int(t.RootDisk),
-			VirtType:     virtType,
-			Deprecated:   t.Deprecated,
-			Cost:         int(t.Cost),
-		}
+		result[i] = ToParamsInstanceType(t)
 	}
 	return result
 }
 
+// ToParamsInstanceType converts an instance type to its API representation.
+func ToParamsInstanceType(t instances.InstanceType) params.InstanceType {
+	virtType := ""
+	if t.VirtType != nil {
+		virtType = *t.VirtType
+	}
+	return params.InstanceType{
+		Name:         t.Name,
+		Arches:       t.Arches,
+		CPUCores:     int(t.CpuCores),
+		Memory:       int(t.Mem),
+		RootDiskSize: int(t.RootDisk),
+		VirtType:     virtType,
+		Deprecated:   t.Deprecated,
+		Cost:         int(t.Cost),
+	}
+}
+
 // NewInstanceTypeConstraints returns an instanceTypeConstraints with the passed
 // parameters.
 func NewInstanceTypeConstraints(env environs.Environ, ctx context.ProviderCallContext, constraints constraints.Value) instanceTypeConstraints {
@@ -48,6 +48,10 @@ func canMachineBeDown(machineStatus status.StatusInfo) bool {
 	switch machineStatus.Status {
 	case status.Pending, status.Stopped:
 		return false
+	case status.Down:
+		// The controller has already marked the machine down,
+		// and the recorded message says why.
+		return false
 	}
 	return true
 }
@@ -111,3 +111,13 @@ func (s *MachineStatusSuite) TestNotDownIfPending(c *gc.C) {
 	s.machine.status = status.Pending
 	s.checkUntouched(c)
 }
+
+func (s *MachineStatusSuite) TestMarkedDownKeepsMessage(c *gc.C) {
+	s.ctx.Presence = agentsDown()
+	s.machine.status = status.Down
+	agent, err := s.ctx.MachineStatus(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agent, jc.DeepEquals, status.StatusInfo{
+		Status: status.Down,
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+)
+
+// ApplicationResourceTagsKey is the application config key holding
+// the tags to set on the provider resources used by the application's
+// units, as space-separated key=value pairs.
+const ApplicationResourceTagsKey = "resource-tags"
+
+// ApplicationConfigGetter is implemented by applications, whose
+// config may specify resource tags.
+type ApplicationConfigGetter interface {
+	ApplicationConfig() (application.ConfigAttributes, error)
+}
+
+// ApplicationResourceTags returns the resource tags specified in the
+// application's config, with template variables unexpanded.
+func ApplicationResourceTags(app ApplicationConfigGetter) (map[string]string, error) {
+	cfg, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value := cfg.GetString(ApplicationResourceTagsKey, "")
+	if value == "" {
+		return nil, nil
+	}
+	return tags.ParseResourceTags(value)
+}
+
+// UnitResourceTags returns the user-specified tags to set on a provider
+// resource used by the named units: the model's resource tags, and
+// those of the units' applications, with template variables expanded.
+// Units whose application cannot be found are ignored.
+func UnitResourceTags(
+	cfg *config.Config,
+	unitNames []string,
+	getApplication func(name string) (ApplicationConfigGetter, error),
+) (map[string]string, error) {
+	modelTags, _ := cfg.ResourceTagTemplates()
+	applicationTags := make(map[string]map[string]string)
+	for _, unitName := range unitNames {
+		applicationName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := applicationTags[applicationName]; ok {
+			continue
+		}
+		app, err := getApplication(applicationName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appTags, err := ApplicationResourceTags(app)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting resource tags of application %q", applicationName)
+		}
+		applicationTags[applicationName] = appTags
+	}
+	return tags.UnitResourceTags(cfg.Name(), modelTags, unitNames, applicationTags), nil
+}
+
+// StorageOwner is implemented by storage instances, whose owner's
+// application may specify resource tags.
+type StorageOwner interface {
+	Owner() (names.Tag, bool)
+}
+
+// StorageResourceTags returns the user-specified tags to set on a
+// provider resource backing the given storage instance, which may be
+// nil. The resource tags of the application of the unit owning the
+// storage are included; storage not owned by a unit gets the model's
+// resource tags only.
+func StorageResourceTags(
+	cfg *config.Config,
+	storageInstance StorageOwner,
+	getApplication func(name string) (ApplicationConfigGetter, error),
+) (map[string]string, error) {
+	var unitNames []string
+	if storageInstance != nil {
+		if owner, ok := storageInstance.Owner(); ok && owner.Kind() == names.UnitTagKind {
+			unitNames = []string{owner.Id()}
+		}
+	}
+	return UnitResourceTags(cfg, unitNames, getApplication)
+}
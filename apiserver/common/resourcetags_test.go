@@ -0,0 +1,83 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type resourceTagsSuite struct{}
+
+var _ = gc.Suite(&resourceTagsSuite{})
+
+type fakeApplication map[string]interface{}
+
+func (a fakeApplication) ApplicationConfig() (application.ConfigAttributes, error) {
+	return application.ConfigAttributes(a), nil
+}
+
+type fakeStorageInstance struct {
+	owner names.Tag
+}
+
+func (s fakeStorageInstance) Owner() (names.Tag, bool) {
+	return s.owner, s.owner != nil
+}
+
+func getFakeApplication(name string) (common.ApplicationConfigGetter, error) {
+	apps := map[string]common.ApplicationConfigGetter{
+		"mysql":     fakeApplication{"resource-tags": "team=dba unit={{unit}}"},
+		"wordpress": fakeApplication{},
+	}
+	app, ok := apps[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (s *resourceTagsSuite) TestUnitResourceTags(c *gc.C) {
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"resource-tags": "model={{model}} team=ops",
+	})
+	unitTags, err := common.UnitResourceTags(cfg, []string{"mysql/0", "wordpress/1", "gone/0"}, getFakeApplication)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitTags, jc.DeepEquals, map[string]string{
+		"model": cfg.Name(),
+		"team":  "dba",
+		"unit":  "mysql/0",
+	})
+}
+
+func (s *resourceTagsSuite) TestApplicationResourceTagsInvalid(c *gc.C) {
+	_, err := common.ApplicationResourceTags(fakeApplication{"resource-tags": "juju-team=dba"})
+	c.Assert(err, gc.ErrorMatches, `tag "juju-team" uses reserved prefix "juju-"`)
+}
+
+func (s *resourceTagsSuite) TestStorageResourceTags(c *gc.C) {
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"resource-tags": "team=ops",
+	})
+	storageTags, err := common.StorageResourceTags(cfg, fakeStorageInstance{names.NewUnitTag("mysql/1")}, getFakeApplication)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTags, jc.DeepEquals, map[string]string{
+		"team": "dba",
+		"unit": "mysql/1",
+	})
+
+	storageTags, err = common.StorageResourceTags(cfg, fakeStorageInstance{names.NewApplicationTag("mysql")}, getFakeApplication)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTags, jc.DeepEquals, map[string]string{"team": "ops"})
+
+	storageTags, err = common.StorageResourceTags(cfg, nil, getFakeApplication)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTags, jc.DeepEquals, map[string]string{"team": "ops"})
+}
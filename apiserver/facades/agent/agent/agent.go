@@ -7,6 +7,8 @@
 package agent
 
 import (
+	"encoding/base64"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -123,6 +125,11 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		SharedSecret:   info.SharedSecret,
 		SystemIdentity: info.SystemIdentity,
 	}
+	// The secrets master key is held by the controller agents, not
+	// in the database, so new controllers get it from this one.
+	if key := api.st.SecretsMasterKey(); len(key) > 0 {
+		result.SecretsMasterKey = base64.StdEncoding.EncodeToString(key)
+	}
 
 	return result, nil
 }
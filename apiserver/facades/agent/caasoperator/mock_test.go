@@ -4,14 +4,19 @@
 package caasoperator_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
+	"github.com/juju/utils/exec"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/agent/caasoperator"
+	"github.com/juju/juju/caas"
 	_ "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -26,6 +31,7 @@ type mockState struct {
 	app      mockApplication
 	unit     mockUnit
 	model    mockModel
+	claimer  mockLeaseClaimer
 }
 
 func newMockState() *mockState {
@@ -45,7 +51,8 @@ func newMockState() *mockState {
 			watcher:      statetesting.NewMockNotifyWatcher(appChanges),
 		},
 		unit: mockUnit{
-			life: state.Dying,
+			life:       state.Dying,
+			providerId: "gitlab-uuid",
 		},
 	}
 	st.entities[st.app.Tag().String()] = &st.app
@@ -61,6 +68,14 @@ func (st *mockState) Application(id string) (caasoperator.Application, error) {
 	return &st.app, nil
 }
 
+func (st *mockState) Unit(name string) (caasoperator.Unit, error) {
+	st.MethodCall(st, "Unit", name)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return &st.unit, nil
+}
+
 func (st *mockState) Model() (caasoperator.Model, error) {
 	st.MethodCall(st, "Model")
 	if err := st.NextErr(); err != nil {
@@ -81,6 +96,25 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	return entity, nil
 }
 
+func (st *mockState) OperatorLeaseClaimer() lease.Claimer {
+	st.MethodCall(st, "OperatorLeaseClaimer")
+	return &st.claimer
+}
+
+type mockLeaseClaimer struct {
+	testing.Stub
+}
+
+func (c *mockLeaseClaimer) Claim(leaseName, holderName string, duration time.Duration) error {
+	c.MethodCall(c, "Claim", leaseName, holderName, duration)
+	return c.NextErr()
+}
+
+func (c *mockLeaseClaimer) WaitUntilExpired(leaseName string, cancel <-chan struct{}) error {
+	c.MethodCall(c, "WaitUntilExpired", leaseName)
+	return c.NextErr()
+}
+
 type mockModel struct {
 	testing.Stub
 }
@@ -175,7 +209,8 @@ func (a *mockApplication) SetAgentVersion(vers version.Binary) error {
 
 type mockUnit struct {
 	testing.Stub
-	life state.Life
+	life       state.Life
+	providerId string
 }
 
 func (*mockUnit) Tag() names.Tag {
@@ -197,6 +232,39 @@ func (u *mockUnit) EnsureDead() error {
 	return nil
 }
 
+func (u *mockUnit) ContainerInfo() (state.CloudContainer, error) {
+	u.MethodCall(u, "ContainerInfo")
+	if err := u.NextErr(); err != nil {
+		return nil, err
+	}
+	return &mockCloudContainer{providerId: u.providerId}, nil
+}
+
+type mockCloudContainer struct {
+	state.CloudContainer
+	providerId string
+}
+
+func (c *mockCloudContainer) ProviderId() string {
+	return c.providerId
+}
+
+type mockWorkloadExecutor struct {
+	testing.Stub
+}
+
+func (e *mockWorkloadExecutor) ExecInPod(appName, unitID string, params caas.ExecParams) (*exec.ExecResponse, error) {
+	e.MethodCall(e, "ExecInPod", appName, unitID, params)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	return &exec.ExecResponse{
+		Code:   1,
+		Stdout: []byte("hello"),
+		Stderr: []byte("world"),
+	}, nil
+}
+
 type mockCharm struct {
 	url    *charm.URL
 	sha256 string
@@ -4,7 +4,12 @@
 package caasoperator
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -12,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
 )
@@ -26,13 +32,56 @@ type Facade struct {
 	*common.ToolsSetter
 
 	model Model
+
+	// newExecutor creates the broker used to run commands in
+	// workload pods; it is only created when first required.
+	newExecutor func() (WorkloadExecutor, error)
+	mu          sync.Mutex
+	executor    WorkloadExecutor
+}
+
+// FacadeV2 provides version 2 of the CAASOperator facade.
+type FacadeV2 struct {
+	*Facade
+}
+
+// FacadeV1 provides version 1 of the CAASOperator facade.
+type FacadeV1 struct {
+	*FacadeV2
+}
+
+// NewStateFacadeV1 provides the signature required for version 1
+// facade registration.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	api, err := NewStateFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{api}, nil
+}
+
+// NewStateFacadeV2 provides the signature required for version 2
+// facade registration.
+func NewStateFacadeV2(ctx facade.Context) (*FacadeV2, error) {
+	api, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV2{api}, nil
 }
 
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
 	resources := ctx.Resources()
-	return NewFacade(resources, authorizer, stateShim{ctx.State()})
+	newExecutor := func() (WorkloadExecutor, error) {
+		broker, err := stateenvirons.GetNewCAASBrokerFunc(caas.New)(ctx.State())
+		if err != nil {
+			return nil, errors.Annotate(err, "getting caas client")
+		}
+		return broker, nil
+	}
+	return NewFacade(resources, authorizer, stateShim{ctx.State()}, newExecutor)
 }
 
 // NewFacade returns a new CAASOperator facade.
@@ -40,6 +89,7 @@ func NewFacade(
 	resources facade.Resources,
 	authorizer facade.Authorizer,
 	st CAASOperatorState,
+	newExecutor func() (WorkloadExecutor, error),
 ) (*Facade, error) {
 	if !authorizer.AuthApplicationAgent() {
 		return nil, common.ErrPerm
@@ -86,6 +136,7 @@ func NewFacade(
 		resources:          resources,
 		state:              st,
 		model:              model,
+		newExecutor:        newExecutor,
 	}, nil
 }
 
@@ -242,3 +293,140 @@ func (f *Facade) watchUnits(tagString string) (string, []string, error) {
 	}
 	return "", nil, watcher.EnsureErr(w)
 }
+
+// ExecInWorkload isn't on the V2 API.
+func (*FacadeV2) ExecInWorkload(_, _ struct{}) {}
+
+// ExecInWorkload runs commands in the workload containers of the
+// specified units, which must belong to the operator's application.
+func (f *Facade) ExecInWorkload(args params.WorkloadExecArgs) (params.WorkloadExecResults, error) {
+	results := params.WorkloadExecResults{
+		Results: make([]params.WorkloadExecResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		response, err := f.execInWorkload(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Code = response.Code
+		results.Results[i].Stdout = response.Stdout
+		results.Results[i].Stderr = response.Stderr
+	}
+	return results, nil
+}
+
+func (f *Facade) execInWorkload(arg params.WorkloadExecArg) (*exec.ExecResponse, error) {
+	tag, err := names.ParseUnitTag(arg.Tag)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	appName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !f.auth.AuthOwner(names.NewApplicationTag(appName)) {
+		return nil, common.ErrPerm
+	}
+	unit, err := f.state.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := unit.ContainerInfo()
+	if errors.IsNotFound(err) || (err == nil && info.ProviderId() == "") {
+		return nil, errors.NotProvisionedf("workload pod of unit %q", tag.Id())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	executor, err := f.workloadExecutor()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return executor.ExecInPod(appName, info.ProviderId(), caas.ExecParams{
+		Commands: arg.Commands,
+		Timeout:  arg.Timeout,
+	})
+}
+
+// workloadExecutor returns the executor used to run commands in
+// workload pods, creating it on first use.
+func (f *Facade) workloadExecutor() (WorkloadExecutor, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.executor == nil {
+		executor, err := f.newExecutor()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		f.executor = executor
+	}
+	return f.executor, nil
+}
+
+// maxOperatorLeaseDuration is the longest an operator instance may hold
+// the operator lease without extending it. It bounds how long standby
+// operators wait before taking over from one that has failed.
+const maxOperatorLeaseDuration = time.Minute
+
+// ClaimOperatorLease isn't on the V1 API.
+func (*FacadeV1) ClaimOperatorLease(_, _ struct{}) {}
+
+// ClaimOperatorLease claims, or extends, the lease which entitles one of
+// an application's operator instances to run the application's charm.
+// A claim which cannot be granted because another instance holds the
+// lease fails with a lease claim denied error.
+func (f *Facade) ClaimOperatorLease(args params.OperatorLeaseClaims) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Claims)),
+	}
+	claimer := f.state.OperatorLeaseClaimer()
+	for i, claim := range args.Claims {
+		tag, err := f.authApplication(claim.ApplicationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if claim.Duration < time.Second || claim.Duration > maxOperatorLeaseDuration {
+			results.Results[i].Error = common.ServerError(errors.NotValidf("lease duration %v", claim.Duration))
+			continue
+		}
+		err = claimer.Claim(tag.Id(), claim.Holder, claim.Duration)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// WaitOperatorLeaseExpired isn't on the V1 API.
+func (*FacadeV1) WaitOperatorLeaseExpired(_, _ struct{}) {}
+
+// WaitOperatorLeaseExpired blocks until the operator lease for each of
+// the specified applications is not held by any operator instance.
+func (f *Facade) WaitOperatorLeaseExpired(ctx context.Context, args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	claimer := f.state.OperatorLeaseClaimer()
+	for i, entity := range args.Entities {
+		tag, err := f.authApplication(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = claimer.WaitUntilExpired(tag.Id(), ctx.Done())
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// authApplication returns the application tag parsed from the supplied
+// string, if it is the tag of the authenticated application agent.
+func (f *Facade) authApplication(tagString string) (names.ApplicationTag, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return names.ApplicationTag{}, common.ErrPerm
+	}
+	if tag != f.auth.GetAuthTag() {
+		return names.ApplicationTag{}, common.ErrPerm
+	}
+	return tag, nil
+}
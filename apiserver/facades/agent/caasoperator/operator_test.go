@@ -4,7 +4,11 @@
 package caasoperator_test
 
 import (
+	"context"
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -14,6 +18,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/caasoperator"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/workertest"
@@ -28,6 +34,7 @@ type CAASOperatorSuite struct {
 	authorizer *apiservertesting.FakeAuthorizer
 	facade     *caasoperator.Facade
 	st         *mockState
+	executor   *mockWorkloadExecutor
 }
 
 func (s *CAASOperatorSuite) SetUpTest(c *gc.C) {
@@ -45,16 +52,26 @@ func (s *CAASOperatorSuite) SetUpTest(c *gc.C) {
 		workertest.CleanKill(c, s.st.app.unitsWatcher)
 	})
 
-	facade, err := caasoperator.NewFacade(s.resources, s.authorizer, s.st)
+	s.executor = &mockWorkloadExecutor{}
+
+	facade, err := caasoperator.NewFacade(s.resources, s.authorizer, s.st, s.newExecutor)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
 
+func (s *CAASOperatorSuite) newExecutor() (caasoperator.WorkloadExecutor, error) {
+	s.executor.MethodCall(s.executor, "NewExecutor")
+	if err := s.executor.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.executor, nil
+}
+
 func (s *CAASOperatorSuite) TestPermission(c *gc.C) {
 	s.authorizer = &apiservertesting.FakeAuthorizer{
 		Tag: names.NewMachineTag("0"),
 	}
-	_, err := caasoperator.NewFacade(s.resources, s.authorizer, s.st)
+	_, err := caasoperator.NewFacade(s.resources, s.authorizer, s.st, s.newExecutor)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
@@ -320,3 +337,105 @@ func (s *CAASOperatorSuite) TestSetTools(c *gc.C) {
 	})
 	s.st.app.CheckCall(c, 0, "SetAgentVersion", vers)
 }
+
+func (s *CAASOperatorSuite) TestExecInWorkload(c *gc.C) {
+	results, err := s.facade.ExecInWorkload(params.WorkloadExecArgs{
+		Args: []params.WorkloadExecArg{
+			{Tag: "unit-gitlab-0", Commands: "echo hello", Timeout: time.Minute},
+			{Tag: "unit-mysql-0", Commands: "echo hello"},
+			{Tag: "application-gitlab", Commands: "echo hello"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.WorkloadExecResults{
+		Results: []params.WorkloadExecResult{
+			{Code: 1, Stdout: []byte("hello"), Stderr: []byte("world")},
+			{Error: &params.Error{Code: params.CodeUnauthorized, Message: "permission denied"}},
+			{Error: &params.Error{Code: params.CodeUnauthorized, Message: "permission denied"}},
+		},
+	})
+	s.st.CheckCall(c, 1, "Unit", "gitlab/0")
+	s.executor.CheckCalls(c, []testing.StubCall{
+		{"NewExecutor", nil},
+		{"ExecInPod", []interface{}{"gitlab", "gitlab-uuid", caas.ExecParams{
+			Commands: "echo hello",
+			Timeout:  time.Minute,
+		}}},
+	})
+}
+
+func (s *CAASOperatorSuite) TestExecInWorkloadNotProvisioned(c *gc.C) {
+	s.st.unit.providerId = ""
+	results, err := s.facade.ExecInWorkload(params.WorkloadExecArgs{
+		Args: []params.WorkloadExecArg{
+			{Tag: "unit-gitlab-0", Commands: "echo hello"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.WorkloadExecResults{
+		Results: []params.WorkloadExecResult{{
+			Error: &params.Error{
+				Code:    params.CodeNotProvisioned,
+				Message: `workload pod of unit "gitlab/0" not provisioned`,
+			},
+		}},
+	})
+	s.executor.CheckNoCalls(c)
+}
+
+func (s *CAASOperatorSuite) TestExecInWorkloadBrokerError(c *gc.C) {
+	s.executor.SetErrors(errors.New("no cluster"))
+	results, err := s.facade.ExecInWorkload(params.WorkloadExecArgs{
+		Args: []params.WorkloadExecArg{
+			{Tag: "unit-gitlab-0", Commands: "echo hello"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "no cluster")
+	s.executor.CheckCallNames(c, "NewExecutor")
+}
+
+func (s *CAASOperatorSuite) TestClaimOperatorLease(c *gc.C) {
+	s.st.claimer.SetErrors(nil, lease.ErrClaimDenied)
+	results, err := s.facade.ClaimOperatorLease(params.OperatorLeaseClaims{
+		Claims: []params.OperatorLeaseClaim{
+			{ApplicationTag: "application-gitlab", Holder: "juju-operator-gitlab-0", Duration: 30 * time.Second},
+			{ApplicationTag: "application-gitlab", Holder: "juju-operator-gitlab-1", Duration: 30 * time.Second},
+			{ApplicationTag: "application-gitlab", Holder: "juju-operator-gitlab-1", Duration: time.Hour},
+			{ApplicationTag: "application-mysql", Holder: "juju-operator-mysql-0", Duration: 30 * time.Second},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Code: params.CodeLeaseClaimDenied, Message: "lease claim denied"}},
+			{Error: &params.Error{Code: params.CodeNotValid, Message: "lease duration 1h0m0s not valid"}},
+			{Error: &params.Error{Code: params.CodeUnauthorized, Message: "permission denied"}},
+		},
+	})
+	s.st.claimer.CheckCalls(c, []testing.StubCall{
+		{"Claim", []interface{}{"gitlab", "juju-operator-gitlab-0", 30 * time.Second}},
+		{"Claim", []interface{}{"gitlab", "juju-operator-gitlab-1", 30 * time.Second}},
+	})
+}
+
+func (s *CAASOperatorSuite) TestWaitOperatorLeaseExpired(c *gc.C) {
+	results, err := s.facade.WaitOperatorLeaseExpired(context.Background(), params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Code: params.CodeUnauthorized, Message: "permission denied"}},
+		},
+	})
+	s.st.claimer.CheckCalls(c, []testing.StubCall{
+		{"WaitUntilExpired", []interface{}{"gitlab"}},
+	})
+}
@@ -4,9 +4,12 @@
 package caasoperator
 
 import (
+	"github.com/juju/utils/exec"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -16,8 +19,10 @@ import (
 // required by the CAAS operator facade.
 type CAASOperatorState interface {
 	Application(string) (Application, error)
+	Unit(string) (Unit, error)
 	Model() (Model, error)
 	FindEntity(names.Tag) (state.Entity, error)
+	OperatorLeaseClaimer() lease.Claimer
 }
 
 // Model provides the subset of CAAS model state required
@@ -59,6 +64,14 @@ func (s stateShim) Application(id string) (Application, error) {
 	return applicationShim{app}, nil
 }
 
+func (s stateShim) Unit(name string) (Unit, error) {
+	unit, err := s.State.Unit(name)
+	if err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
+
 func (s stateShim) Model() (Model, error) {
 	model, err := s.State.Model()
 	if err != nil {
@@ -87,6 +100,15 @@ func (a applicationShim) AllUnits() ([]Unit, error) {
 	return result, nil
 }
 
+// Unit provides the subset of unit state required
+// by the CAAS operator facade.
 type Unit interface {
 	Tag() names.Tag
+	ContainerInfo() (state.CloudContainer, error)
+}
+
+// WorkloadExecutor provides the subset of the CAAS
+// broker required by the CAAS operator facade.
+type WorkloadExecutor interface {
+	ExecInPod(appName, unitID string, params caas.ExecParams) (*exec.ExecResponse, error)
 }
@@ -159,6 +159,13 @@ func (p *ProvisionerAPI) machineVolumeParams(
 		if err != nil {
 			return nil, nil, errors.Annotatef(err, "getting volume %q parameters", volumeTag.Id())
 		}
+		userTags, err := common.StorageResourceTags(modelConfig, storageInstance, p.getApplication)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "getting volume %q resource tags", volumeTag.Id())
+		}
+		for k, v := range userTags {
+			volumeParams.Tags[k] = v
+		}
 		if _, err := env.StorageProvider(storage.ProviderType(volumeParams.Provider)); errors.IsNotFound(err) {
 			// This storage type is not managed by the environ
 			// provider, so ignore it. It'll be managed by one
@@ -203,13 +210,17 @@ func (p *ProvisionerAPI) machineVolumeParams(
 	return allVolumeParams, allVolumeAttachmentParams, nil
 }
 
+// getApplication returns the named application, for computing the
+// application's resource tags.
+func (p *ProvisionerAPI) getApplication(name string) (common.ApplicationConfigGetter, error) {
+	return p.st.Application(name)
+}
+
 // machineTags returns machine-specific tags to set on the instance.
 func (p *ProvisionerAPI) machineTags(m *state.Machine, jobs []multiwatcher.MachineJob) (map[string]string, error) {
-	// Names of all units deployed to the machine.
-	//
-	// TODO(axw) 2015-06-02 #1461358
-	// We need a worker that periodically updates
-	// instance tags with current deployment info.
+	// Names of all units deployed to the machine. The
+	// resource tagger worker updates the instance tags
+	// when the model or application resource tags change.
 	units, err := m.Units()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -232,6 +243,13 @@ func (p *ProvisionerAPI) machineTags(m *state.Machine, jobs []multiwatcher.Machi
 		return nil, errors.Trace(err)
 	}
 	machineTags := instancecfg.InstanceTags(cfg.UUID(), controllerCfg.ControllerUUID(), cfg, jobs)
+	userTags, err := common.UnitResourceTags(cfg, unitNames, p.getApplication)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for k, v := range userTags {
+		machineTags[k] = v
+	}
 	if len(unitNames) > 0 {
 		machineTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
 	}
@@ -6,12 +6,14 @@ package provisioner_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/agent/provisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
@@ -239,6 +241,42 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithEndpointBindings(c *gc.
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithApplicationResourceTags(c *gc.C) {
+	wordpressMachine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpressService := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err = wordpressService.UpdateApplicationConfig(application.ConfigAttributes{
+		"resource-tags": "team=blog owner={{application}}",
+	}, nil, environschema.Fields{
+		"resource-tags": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpressUnit, err := wordpressService.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpressUnit.AssignToMachine(wordpressMachine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: wordpressMachine.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[0].Result.Tags, jc.DeepEquals, map[string]string{
+		tags.JujuController:    coretesting.ControllerTag.Id(),
+		tags.JujuModel:         coretesting.ModelTag.Id(),
+		tags.JujuMachine:       "controller-machine-5",
+		tags.JujuUnitsDeployed: wordpressUnit.Name(),
+		"team":                 "blog",
+		"owner":                "wordpress",
+	})
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoWithUnsuitableSpacesConstraints(c *gc.C) {
 	// Add an empty space.
 	_, err := s.State.AddSpace("empty", "", nil, true)
@@ -568,6 +568,20 @@ func (s *StorageProvisionerAPIv3) FilesystemAttachments(args params.MachineStora
 	return results, nil
 }
 
+// getApplication returns the named application, for computing the
+// resource tags of the volumes its units own.
+func (s *StorageProvisionerAPIv3) getApplication(name string) (common.ApplicationConfigGetter, error) {
+	entity, err := s.st.FindEntity(names.NewApplicationTag(name))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, ok := entity.(common.ApplicationConfigGetter)
+	if !ok {
+		return nil, errors.NotValidf("application %q entity", name)
+	}
+	return app, nil
+}
+
 // VolumeParams returns the parameters for creating or destroying
 // the volumes with the specified tags.
 func (s *StorageProvisionerAPIv3) VolumeParams(args params.Entities) (params.VolumeParamsResults, error) {
@@ -615,6 +629,13 @@ func (s *StorageProvisionerAPIv3) VolumeParams(args params.Entities) (params.Vol
 		if err != nil {
 			return params.VolumeParams{}, err
 		}
+		userTags, err := common.StorageResourceTags(modelCfg, storageInstance, s.getApplication)
+		if err != nil {
+			return params.VolumeParams{}, err
+		}
+		for k, v := range userTags {
+			volumeParams.Tags[k] = v
+		}
 		if len(volumeAttachments) == 1 {
 			// There is exactly one attachment to be made, so make
 			// it immediately. Otherwise we will defer attachments
@@ -759,6 +780,13 @@ func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params
 		if err != nil {
 			return params.FilesystemParams{}, err
 		}
+		userTags, err := common.StorageResourceTags(modelConfig, storageInstance, s.getApplication)
+		if err != nil {
+			return params.FilesystemParams{}, err
+		}
+		for k, v := range userTags {
+			filesystemParams.Tags[k] = v
+		}
 		return filesystemParams, nil
 	}
 	for i, arg := range args.Entities {
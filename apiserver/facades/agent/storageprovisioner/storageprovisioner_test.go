@@ -9,12 +9,14 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
@@ -542,6 +544,50 @@ func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestFilesystemParamsWithApplicationResourceTags(c *gc.C) {
+	app := s.factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.factory.MakeCharm(c, &factory.CharmParams{
+			Name: "storage-filesystem",
+		}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {
+				Count: 1,
+				Size:  1,
+				Pool:  "modelscoped",
+			},
+		},
+	})
+	err := app.UpdateApplicationConfig(application.ConfigAttributes{
+		"resource-tags": "team=storage owner={{application}}",
+	}, nil, environschema.Fields{
+		"resource-tags": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.factory.MakeUnit(c, &factory.UnitParams{
+		Application: app,
+	})
+	storage, err := s.storageBackend.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage, gc.HasLen, 1)
+	storageFilesystem, err := s.storageBackend.StorageInstanceFilesystem(storage[0].StorageTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.FilesystemParams(params.Entities{
+		Entities: []params.Entity{{storageFilesystem.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Result.Tags, jc.DeepEquals, map[string]string{
+		tags.JujuController:      testing.ControllerTag.Id(),
+		tags.JujuModel:           testing.ModelTag.Id(),
+		tags.JujuStorageInstance: storage[0].StorageTag().Id(),
+		tags.JujuStorageOwner:    unit.Name(),
+		"team":                   "storage",
+		"owner":                  app.Name(),
+	})
+}
+
 func (s *provisionerSuite) TestRemoveFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 
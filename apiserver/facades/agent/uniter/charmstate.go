@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const (
+	// maxCharmStateKeySize is the maximum size, in bytes, of a
+	// charm state key.
+	maxCharmStateKeySize = 256
+
+	// maxCharmStateSize is the maximum total size, in bytes, of the
+	// keys and values making up a unit's charm state.
+	maxCharmStateSize = 64 * 1024
+)
+
+// CharmState returns the key-value state persisted by the charm of
+// each given unit.
+func (u *UniterAPI) CharmState(args params.Entities) (params.CharmStateResults, error) {
+	result := params.CharmStateResults{
+		Results: make([]params.CharmStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.CharmStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		charmState, err := unit.CharmState()
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Result = charmState
+	}
+	return result, nil
+}
+
+// SetCharmState replaces the key-value state persisted by the charm of
+// each given unit.
+func (u *UniterAPI) SetCharmState(args params.SetCharmStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if err := validateCharmState(arg.State); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Error = common.ServerError(unit.SetCharmState(arg.State))
+	}
+	return result, nil
+}
+
+func validateCharmState(charmState map[string]string) error {
+	var size int
+	for key, value := range charmState {
+		if key == "" {
+			return errors.NotValidf("empty charm state key")
+		}
+		if len(key) > maxCharmStateKeySize {
+			return errors.NewNotValid(nil, fmt.Sprintf(
+				"charm state key %q... is longer than %d bytes", key[:32], maxCharmStateKeySize))
+		}
+		size += len(key) + len(value)
+	}
+	if size > maxCharmStateSize {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"charm state of %d bytes exceeds the limit of %d bytes", size, maxCharmStateSize))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// RecordHookExecutions records the given hook executions in the hook
+// history of each unit.
+func (u *UniterAPI) RecordHookExecutions(args params.RecordHookExecutionArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		execution := state.HookExecution{
+			Hook:     arg.Hook,
+			Started:  arg.Started,
+			Duration: arg.Duration,
+			ExitCode: arg.ExitCode,
+		}
+		if arg.RelationId != nil {
+			execution.Relation = u.hookRelation(*arg.RelationId)
+		}
+		result.Results[i].Error = common.ServerError(unit.RecordHookExecution(execution))
+	}
+	return result, nil
+}
+
+// hookRelation returns the key of the relation with the given id. The
+// relation may already have been removed by the time a relation-broken
+// hook is recorded, in which case the id is used instead.
+func (u *UniterAPI) hookRelation(relationId int) string {
+	rel, err := u.st.Relation(relationId)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Warningf("cannot get relation %d for hook history: %v", relationId, err)
+		}
+		return fmt.Sprintf("relation %d", relationId)
+	}
+	return rel.String()
+}
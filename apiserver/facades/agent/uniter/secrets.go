@@ -0,0 +1,234 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// CreateSecrets creates secrets owned by the applications of the
+// given units. Only the leader unit of an application may create its
+// secrets.
+func (u *UniterAPI) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, arg := range args.Args {
+		unit, err := u.secretsLeaderUnit(canAccess, arg.UnitTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		secret, err := u.st.CreateSecret(state.CreateSecretParams{
+			Owner:          unit.ApplicationName(),
+			Description:    arg.Description,
+			RotateInterval: arg.RotateInterval,
+			Data:           arg.Data,
+		})
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = secret.Id()
+	}
+	return result, nil
+}
+
+// GetSecretValues returns the values of secrets that the applications
+// of the given units own, or have been granted access to.
+func (u *UniterAPI) GetSecretValues(args params.GetSecretArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretValueResults{}, err
+	}
+	for i, arg := range args.Args {
+		data, err := u.getSecretValue(canAccess, arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Data = data
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) getSecretValue(canAccess common.AuthFunc, arg params.GetSecretArg) (map[string]string, error) {
+	unit, err := u.secretsUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secret, err := u.st.Secret(arg.SecretId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !secret.CanRead(unit.ApplicationName()) {
+		return nil, common.ErrPerm
+	}
+	return secret.Value(arg.Revision)
+}
+
+// RotateSecrets records new values for secrets owned by the
+// applications of the given units.
+func (u *UniterAPI) RotateSecrets(args params.RotateSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		secret, _, err := u.ownedSecret(canAccess, arg.UnitTag, arg.SecretId)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Error = common.ServerError(secret.Rotate(arg.Data))
+	}
+	return result, nil
+}
+
+// GrantSecrets allows the remote applications of the given relations
+// to read secrets owned by the applications of the given units.
+func (u *UniterAPI) GrantSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return u.changeSecretGrants(args, (*state.Secret).Grant)
+}
+
+// RevokeSecrets stops the remote applications of the given relations
+// from reading secrets owned by the applications of the given units.
+func (u *UniterAPI) RevokeSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return u.changeSecretGrants(args, (*state.Secret).Revoke)
+}
+
+func (u *UniterAPI) changeSecretGrants(
+	args params.GrantSecretArgs,
+	change func(*state.Secret, *state.Relation) error,
+) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		secret, unit, err := u.ownedSecret(canAccess, arg.UnitTag, arg.SecretId)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rel, err := u.unitRelation(unit, arg.RelationId)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Error = common.ServerError(change(secret, rel))
+	}
+	return result, nil
+}
+
+// SecretsToRotate returns the ids of the secrets that are due to be
+// rotated by each given unit. Only leader units rotate secrets; other
+// units always get an empty list.
+func (u *UniterAPI) SecretsToRotate(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	checker := u.st.LeadershipChecker()
+	for i, entity := range args.Entities {
+		unit, err := u.secretsUnit(canAccess, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		token := checker.LeadershipCheck(unit.ApplicationName(), unit.Name())
+		if err := token.Check(nil); err != nil {
+			continue
+		}
+		secrets, err := u.st.ApplicationSecretsToRotate(unit.ApplicationName())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		ids := make([]string, len(secrets))
+		for j, secret := range secrets {
+			ids[j] = secret.Id()
+		}
+		result.Results[i].Result = ids
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) secretsUnit(canAccess common.AuthFunc, unitTag string) (*state.Unit, error) {
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return u.getUnit(tag)
+}
+
+// secretsLeaderUnit returns the unit with the given tag, if it is the
+// leader of its application.
+func (u *UniterAPI) secretsLeaderUnit(canAccess common.AuthFunc, unitTag string) (*state.Unit, error) {
+	unit, err := u.secretsUnit(canAccess, unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	token := u.st.LeadershipChecker().LeadershipCheck(unit.ApplicationName(), unit.Name())
+	if err := token.Check(nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
+
+// ownedSecret returns the secret with the given id, if it is owned by
+// the application led by the unit with the given tag.
+func (u *UniterAPI) ownedSecret(canAccess common.AuthFunc, unitTag, secretId string) (*state.Secret, *state.Unit, error) {
+	unit, err := u.secretsLeaderUnit(canAccess, unitTag)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	secret, err := u.st.Secret(secretId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if secret.Owner() != unit.ApplicationName() {
+		return nil, nil, common.ErrPerm
+	}
+	return secret, unit, nil
+}
+
+// unitRelation returns the relation with the given id, if the unit's
+// application takes part in it.
+func (u *UniterAPI) unitRelation(unit *state.Unit, relationId int) (*state.Relation, error) {
+	rel, err := u.st.Relation(relationId)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := rel.Endpoint(unit.ApplicationName()); err != nil {
+		return nil, common.ErrPerm
+	}
+	return rel, nil
+}
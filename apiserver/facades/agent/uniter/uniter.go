@@ -8,6 +8,7 @@ package uniter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -63,9 +64,15 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV8 doesn't have the HookTimeout, CharmState, SetCharmState,
+// RecordHookExecutions, WatchGoalState or secrets methods.
+type UniterAPIV8 struct {
+	UniterAPI
+}
+
 // UniterAPIV7 adds CMR support to NetworkInfo.
 type UniterAPIV7 struct {
-	UniterAPI
+	UniterAPIV8
 }
 
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
@@ -244,14 +251,25 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV7 creates an instance of the V7 uniter API.
 func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV8(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV7{
-		UniterAPI: *uniterAPI,
+		UniterAPIV8: *uniterAPI,
 	}, nil
 }
 
@@ -828,6 +846,65 @@ func (u *UniterAPI) WorkloadVersion(args params.Entities) (params.StringResults,
 	return result, nil
 }
 
+// HookTimeout returns how long charm hooks may run for each given unit,
+// formatted as a duration. The application's hook-timeout config takes
+// precedence over the model's; a zero duration means no timeout.
+func (u *UniterAPI) HookTimeout(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		timeout, err := u.hookTimeout(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Result = timeout.String()
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) hookTimeout(tag names.UnitTag) (time.Duration, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if raw := appConfig.GetString(application.HookTimeoutConfigOptionName, ""); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout < 0 {
+			return 0, errors.NotValidf("hook timeout %q for application %q", raw, app.Name())
+		}
+		return timeout, nil
+	}
+	modelConfig, err := u.m.ModelConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return modelConfig.HookTimeout(), nil
+}
+
 // SetWorkloadVersion sets the workload version for each given unit. An error will
 // be returned if a unit is dead.
 func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (params.ErrorResults, error) {
@@ -1254,6 +1331,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 				result.Results[i].Series = unit.Series()
 				result.Results[i].Life = params.Life(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].Paused = unit.Paused()
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -2405,6 +2483,39 @@ func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
 
+// HookTimeout isn't on the V8 API.
+func (u *UniterAPIV8) HookTimeout(_, _ struct{}) {}
+
+// CharmState isn't on the V8 API.
+func (u *UniterAPIV8) CharmState(_, _ struct{}) {}
+
+// SetCharmState isn't on the V8 API.
+func (u *UniterAPIV8) SetCharmState(_, _ struct{}) {}
+
+// RecordHookExecutions isn't on the V8 API.
+func (u *UniterAPIV8) RecordHookExecutions(_, _ struct{}) {}
+
+// WatchGoalState isn't on the V8 API.
+func (u *UniterAPIV8) WatchGoalState(_, _ struct{}) {}
+
+// CreateSecrets isn't on the V8 API.
+func (u *UniterAPIV8) CreateSecrets(_, _ struct{}) {}
+
+// GetSecretValues isn't on the V8 API.
+func (u *UniterAPIV8) GetSecretValues(_, _ struct{}) {}
+
+// RotateSecrets isn't on the V8 API.
+func (u *UniterAPIV8) RotateSecrets(_, _ struct{}) {}
+
+// GrantSecrets isn't on the V8 API.
+func (u *UniterAPIV8) GrantSecrets(_, _ struct{}) {}
+
+// RevokeSecrets isn't on the V8 API.
+func (u *UniterAPIV8) RevokeSecrets(_, _ struct{}) {}
+
+// SecretsToRotate isn't on the V8 API.
+func (u *UniterAPIV8) SecretsToRotate(_, _ struct{}) {}
+
 func networkInfoResultsToV6(v7Results params.NetworkInfoResults) params.NetworkInfoResultsV6 {
 	results := make(map[string]params.NetworkInfoResultV6)
 	for k, v6Result := range v7Results.Results {
@@ -2542,6 +2653,53 @@ func (u *UniterAPI) GoalStates(args params.Entities) (params.GoalStateResults, e
 	return result, nil
 }
 
+// WatchGoalState returns a NotifyWatcher for each given unit, that
+// triggers when the goal state of the unit's application changes.
+func (u *UniterAPI) WatchGoalState(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchOneGoalState(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchOneGoalState(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return "", err
+	}
+	watch := application.WatchGoalState()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
 // goalStateResult creates the structure with an application units and unit relations.
 func (u *UniterAPI) goalStateResult(application *state.Application) (*params.GoalState, error) {
 	gs := params.GoalState{}
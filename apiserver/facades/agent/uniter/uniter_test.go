@@ -5,6 +5,7 @@ package uniter_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -875,6 +876,279 @@ func (s *uniterSuite) TestWorkloadVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestHookTimeout(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{config.HookTimeout: "30m"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.HookTimeout(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "30m0s"},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+
+	// The application's hook timeout overrides the model's.
+	conf := map[string]interface{}{application.HookTimeoutConfigOptionName: "2h"}
+	fields := map[string]environschema.Attr{application.HookTimeoutConfigOptionName: {Type: environschema.Tstring}}
+	err = s.wordpress.UpdateApplicationConfig(conf, nil, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.uniter.HookTimeout(params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.DeepEquals, []params.StringResult{{Result: "2h0m0s"}})
+}
+
+func (s *uniterSuite) TestCharmState(c *gc.C) {
+	err := s.wordpressUnit.SetCharmState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.CharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CharmStateResults{
+		Results: []params.CharmStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: map[string]string{"initialised": "true"}},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetCharmState(c *gc.C) {
+	args := params.SetCharmStateArgs{Args: []params.SetCharmStateArg{
+		{Tag: "unit-mysql-0", State: map[string]string{"a": "b"}},
+		{Tag: "unit-wordpress-0", State: map[string]string{"initialised": "true"}},
+	}}
+	result, err := s.uniter.SetCharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+		},
+	})
+
+	charmState, err := s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"initialised": "true"})
+}
+
+func (s *uniterSuite) TestCreateSecrets(c *gc.C) {
+	args := params.CreateSecretArgs{Args: []params.CreateSecretArg{
+		{UnitTag: "unit-mysql-0", Data: map[string]string{"a": "b"}},
+		{UnitTag: "unit-wordpress-0", Description: "db password", Data: map[string]string{"password": "s3cret"}},
+	}}
+	result, err := s.uniter.CreateSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, ".*not leader.*")
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CreateSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	secret, err := s.State.Secret(result.Results[1].Result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Owner(), gc.Equals, "wordpress")
+	c.Assert(secret.Description(), gc.Equals, "db password")
+}
+
+func (s *uniterSuite) TestGetSecretValues(c *gc.C) {
+	owned, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: "wordpress",
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: "mysql",
+		Data:  map[string]string{"root": "pa55"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.GetSecretArgs{Args: []params.GetSecretArg{
+		{UnitTag: "unit-wordpress-0", SecretId: owned.Id()},
+		{UnitTag: "unit-wordpress-0", SecretId: other.Id()},
+		{UnitTag: "unit-mysql-0", SecretId: other.Id()},
+	}}
+	result, err := s.uniter.GetSecretValues(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretValueResults{
+		Results: []params.SecretValueResult{
+			{Data: map[string]string{"password": "s3cret"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err = other.Grant(rel)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.GetSecretValues(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1], jc.DeepEquals, params.SecretValueResult{
+		Data: map[string]string{"root": "pa55"},
+	})
+}
+
+func (s *uniterSuite) TestRotateSecrets(c *gc.C) {
+	secret, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: "wordpress",
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.RotateSecrets(params.RotateSecretArgs{Args: []params.RotateSecretArg{
+		{UnitTag: "unit-wordpress-0", SecretId: secret.Id(), Data: map[string]string{"password": "n3w"}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{nil}}})
+
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 2)
+}
+
+func (s *uniterSuite) TestGrantRevokeSecrets(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	secret, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: "wordpress",
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.GrantSecretArgs{Args: []params.GrantSecretArg{
+		{UnitTag: "unit-wordpress-0", SecretId: secret.Id(), RelationId: rel.Id()},
+		{UnitTag: "unit-wordpress-0", SecretId: secret.Id(), RelationId: 1234},
+	}}
+	result, err := s.uniter.GrantSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), jc.DeepEquals, []string{"mysql"})
+
+	result, err = s.uniter.RevokeSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), gc.HasLen, 0)
+}
+
+func (s *uniterSuite) TestSecretsToRotate(c *gc.C) {
+	secret, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner:          "wordpress",
+		RotateInterval: time.Nanosecond,
+		Data:           map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+	}}
+	result, err := s.uniter.SecretsToRotate(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.SecretsToRotate(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0], jc.DeepEquals, params.StringsResult{
+		Result: []string{secret.Id()},
+	})
+}
+
+func (s *uniterSuite) TestSetCharmStateLimits(c *gc.C) {
+	args := params.SetCharmStateArgs{Args: []params.SetCharmStateArg{
+		{Tag: "unit-wordpress-0", State: map[string]string{"": "b"}},
+		{Tag: "unit-wordpress-0", State: map[string]string{strings.Repeat("k", 257): "b"}},
+		{Tag: "unit-wordpress-0", State: map[string]string{"big": strings.Repeat("v", 64*1024)}},
+	}}
+	result, err := s.uniter.SetCharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "empty charm state key not valid")
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `charm state key "k+"... is longer than 256 bytes`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, "charm state of 65539 bytes exceeds the limit of 65536 bytes")
+
+	charmState, err := s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *uniterSuite) TestRecordHookExecutions(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relId := rel.Id()
+	missingId := relId + 100
+	started := time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC)
+	args := params.RecordHookExecutionArgs{Args: []params.RecordHookExecutionArg{
+		{Tag: "unit-mysql-0", Hook: "install", Started: started},
+		{Tag: "unit-wordpress-0", Hook: "install", Started: started, Duration: time.Minute},
+		{Tag: "unit-wordpress-0", Hook: "db-relation-joined", RelationId: &relId, Started: started, ExitCode: 1},
+		{Tag: "unit-wordpress-0", Hook: "db-relation-broken", RelationId: &missingId, Started: started},
+	}}
+	result, err := s.uniter.RecordHookExecutions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{nil},
+			{nil},
+		},
+	})
+
+	executions, err := s.wordpressUnit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{{
+		Hook:     "install",
+		Started:  started,
+		Duration: time.Minute,
+	}, {
+		Hook:     "db-relation-joined",
+		Relation: rel.String(),
+		Started:  started,
+		ExitCode: 1,
+	}, {
+		Hook:     "db-relation-broken",
+		Relation: fmt.Sprintf("relation %d", missingId),
+		Started:  started,
+	}})
+}
+
 func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	currentVersion, err := s.wordpressUnit.WorkloadVersion()
 	c.Assert(err, jc.ErrorIsNil)
@@ -2448,6 +2722,44 @@ func (s *uniterSuite) TestWatchUnitAddresses(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *uniterSuite) TestWatchGoalState(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "machine-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.WatchGoalState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	// Adding a unit changes the goal state.
+	_, err = s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestWatchCAASUnitAddresses(c *gc.C) {
 	_, cm, _, _ := s.setupCAASModel(c)
 	c.Assert(s.resources.Count(), gc.Equals, 0)
@@ -3036,6 +3348,21 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshPaused(c *gc.C) {
+	err := s.wordpressUnit.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{
+		Results: []params.UnitRefreshResult{
+			{Life: params.Alive, Resolved: params.ResolvedNone, Paused: true, Series: "quantal"},
+		},
+	})
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
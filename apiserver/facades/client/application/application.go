@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -57,6 +58,11 @@ type APIv6 struct {
 
 // APIv7 provides the Application API facade for version 7.
 type APIv7 struct {
+	*APIv8
+}
+
+// APIv8 provides the Application API facade for version 8.
+type APIv8 struct {
 	*APIBase
 }
 
@@ -117,13 +123,23 @@ func NewFacadeV6(ctx facade.Context) (*APIv6, error) {
 // NewFacadeV7 provides the signature required for facade registration
 // for version 7.
 func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV8(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv7{api}, nil
 }
 
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
+func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -311,7 +327,12 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		fields, err := AddHookTimeoutSchema(trustFields)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields, err = AddResourceTagsSchema(fields)
+		return fields, trustDefaults, err
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -319,7 +340,12 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
-	return AddTrustSchemaAndDefaults(schema, defaults)
+	schema, defaults, err = AddTrustSchemaAndDefaults(schema, defaults)
+	if err != nil {
+		return nil, nil, err
+	}
+	schema, err = AddHookTimeoutSchema(schema)
+	return schema, defaults, err
 }
 
 func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[string]string) (
@@ -404,6 +430,12 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateResourceTags(appConfigAttrs); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateHookTimeout(appConfigAttrs); err != nil {
+		return errors.Trace(err)
+	}
 
 	var applicationConfig *application.Config
 	schema, defaults, err := applicationConfigSchema(modelType)
@@ -1386,7 +1418,43 @@ func (api *APIBase) AddRelation(args params.AddRelation) (_ params.AddRelationRe
 			Scope:     string(outEp.Relation.Scope),
 		}
 	}
-	return params.AddRelationResults{Endpoints: outEps}, nil
+	return params.AddRelationResults{
+		Endpoints: outEps,
+		Warnings:  api.relationSchemaWarnings(inEps),
+	}, nil
+}
+
+// relationSchemaWarnings returns a description of each way in which the
+// relation data schemas declared by the charms of the given endpoints
+// are incompatible. Remote applications declare no schemas. Failure to
+// read the schemas is logged rather than returned, since it must not
+// prevent the relation from being added.
+func (api *APIBase) relationSchemaWarnings(eps []state.Endpoint) []string {
+	if len(eps) != 2 {
+		return nil
+	}
+	var schemas [2]relation.EndpointSchema
+	for i, ep := range eps {
+		app, err := api.backend.Application(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			logger.Warningf("cannot check relation schemas for %q: %v", ep, err)
+			return nil
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			logger.Warningf("cannot check relation schemas for %q: %v", ep, err)
+			return nil
+		}
+		appSchemas, err := ch.RelationSchemas()
+		if err != nil {
+			logger.Warningf("cannot check relation schemas for %q: %v", ep, err)
+			return nil
+		}
+		schemas[i] = appSchemas[ep.Relation.Name]
+	}
+	return relation.Incompatibilities(eps[0].String(), schemas[0], eps[1].String(), schemas[1])
 }
 
 // DestroyRelation removes the relation between the
@@ -1715,6 +1783,12 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateResourceTags(appConfigAttrs); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateHookTimeout(appConfigAttrs); err != nil {
+		return errors.Trace(err)
+	}
 	schema, defaults, err := applicationConfigSchema(api.modelType)
 	if err != nil {
 		return errors.Trace(err)
@@ -1839,3 +1913,48 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 	}
 	return result, nil
 }
+
+// PauseUnits isn't on the v7 API.
+func (u *APIv7) PauseUnits(_, _ struct{}) {}
+
+// PauseUnits stops the agents of the specified units from running any
+// further hooks or actions until they are resumed.
+func (api *APIBase) PauseUnits(args params.Entities) (params.ErrorResults, error) {
+	return api.setUnitsPaused(args, true)
+}
+
+// ResumeUnits isn't on the v7 API.
+func (u *APIv7) ResumeUnits(_, _ struct{}) {}
+
+// ResumeUnits allows the agents of the specified paused units to run
+// hooks and actions again.
+func (api *APIBase) ResumeUnits(args params.Entities) (params.ErrorResults, error) {
+	return api.setUnitsPaused(args, false)
+}
+
+func (api *APIBase) setUnitsPaused(args params.Entities, paused bool) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = unit.SetPaused(paused)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
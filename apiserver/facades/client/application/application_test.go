@@ -47,7 +47,7 @@ type applicationSuite struct {
 	apiservertesting.CharmStoreSuite
 	commontesting.BlockHelper
 
-	applicationAPI *application.APIv7
+	applicationAPI *application.APIv8
 	application    *state.Application
 	authorizer     *apiservertesting.FakeAuthorizer
 }
@@ -84,7 +84,7 @@ func (s *applicationSuite) TearDownTest(c *gc.C) {
 	s.JujuConnSuite.TearDownTest(c)
 }
 
-func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv7 {
+func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv8 {
 	resources := common.NewResources()
 	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
 	storageAccess, err := application.GetStorageState(s.State)
@@ -103,7 +103,7 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv7 {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv7{api}
+	return &application.APIv8{api}
 }
 
 func (s *applicationSuite) TestGetConfig(c *gc.C) {
@@ -1734,6 +1734,56 @@ func (s *applicationSuite) TestApplicationSet(c *gc.C) {
 	}))
 }
 
+func (s *applicationSuite) TestSetApplicationsConfigResourceTags(c *gc.C) {
+	ch := s.AddTestingCharm(c, "dummy")
+	dummy := s.AddTestingApplication(c, "dummy", ch)
+
+	result, err := s.applicationAPI.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "dummy",
+			Config:          map[string]string{"resource-tags": "team={{application}}"},
+		}, {
+			ApplicationName: "dummy",
+			Config:          map[string]string{"resource-tags": "juju-team=finance"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `invalid resource-tags: tag "juju-team" uses reserved prefix "juju-"`)
+
+	appConfig, err := dummy.ApplicationConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appConfig.GetString("resource-tags", ""), gc.Equals, "team={{application}}")
+}
+
+func (s *applicationSuite) TestSetApplicationsConfigHookTimeout(c *gc.C) {
+	ch := s.AddTestingCharm(c, "dummy")
+	dummy := s.AddTestingApplication(c, "dummy", ch)
+
+	result, err := s.applicationAPI.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "dummy",
+			Config:          map[string]string{"hook-timeout": "30m"},
+		}, {
+			ApplicationName: "dummy",
+			Config:          map[string]string{"hook-timeout": "forever"},
+		}, {
+			ApplicationName: "dummy",
+			Config:          map[string]string{"hook-timeout": "-5m"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `invalid hook-timeout: time: invalid duration "?forever"?`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `hook-timeout -5m0s cannot be negative`)
+
+	appConfig, err := dummy.ApplicationConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appConfig.GetString("hook-timeout", ""), gc.Equals, "30m")
+}
+
 func (s *applicationSuite) assertApplicationSetBlocked(c *gc.C, dummy *state.Application, msg string) {
 	err := s.applicationAPI.Set(params.ApplicationSet{
 		ApplicationName: "dummy",
@@ -20,6 +20,7 @@ import (
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -39,7 +40,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv7
+	api          *application.APIv8
 }
 
 var _ = gc.Suite(&ApplicationSuite{})
@@ -61,7 +62,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv7{api}
+	s.api = &application.APIv8{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -1040,6 +1041,56 @@ func (s *ApplicationSuite) TestRemoteRelationDisAllowedCIDR(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `CIDR "0.0.0.0/0" not allowed`)
 }
 
+func (s *ApplicationSuite) TestAddRelationSchemaWarnings(c *gc.C) {
+	s.endpoints = []state.Endpoint{{
+		ApplicationName: "postgresql",
+		Relation:        charm.Relation{Name: "db", Role: charm.RoleProvider},
+	}, {
+		ApplicationName: "postgresql-subordinate",
+		Relation:        charm.Relation{Name: "db", Role: charm.RoleRequirer},
+	}}
+	s.backend.applications["postgresql"].charm.schemas = relation.Schemas{
+		"db": {
+			Publishes: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"port": map[string]interface{}{"type": "integer"},
+				},
+			},
+		},
+	}
+	s.backend.applications["postgresql-subordinate"].charm.schemas = relation.Schemas{
+		"db": {
+			Expects: map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"host"},
+				"properties": map[string]interface{}{
+					"port": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+	result, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"postgresql", "postgresql-subordinate"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Warnings, jc.DeepEquals, []string{
+		`postgresql-subordinate:db expects "host", which postgresql:db does not publish`,
+		`postgresql-subordinate:db expects "port" of type string, but postgresql:db publishes type integer`,
+	})
+}
+
+func (s *ApplicationSuite) TestAddRelationNoSchemaWarningsForRemoteApplication(c *gc.C) {
+	s.endpoints = []state.Endpoint{{
+		ApplicationName: "postgresql",
+		Relation:        charm.Relation{Name: "db", Role: charm.RoleProvider},
+	}, {
+		ApplicationName: "hosted-db2",
+		Relation:        charm.Relation{Name: "db", Role: charm.RoleRequirer},
+	}}
+	result, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"postgresql", "hosted-db2"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Warnings, gc.HasLen, 0)
+}
+
 func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
@@ -1060,6 +1111,8 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
+	schema, err = application.AddHookTimeoutSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
@@ -1104,6 +1157,8 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
+	schema, err = application.AddHookTimeoutSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
 		[]string{"juju-external-hostname"}, schema, defaults)
@@ -1184,6 +1239,47 @@ func (s *ApplicationSuite) TestResolveUnitErrorsPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestPauseUnits(c *gc.C) {
+	result, err := s.api.PauseUnits(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "application-postgresql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.CheckCallNames(c, "SetPaused")
+	unit.CheckCall(c, 0, "SetPaused", true)
+}
+
+func (s *ApplicationSuite) TestResumeUnits(c *gc.C) {
+	result, err := s.api.ResumeUnits(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	unit := s.backend.applications["postgresql"].units[1]
+	unit.CheckCallNames(c, "SetPaused")
+	unit.CheckCall(c, 0, "SetPaused", false)
+}
+
+func (s *ApplicationSuite) TestBlockPauseUnits(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.PauseUnits(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestPauseUnitsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.PauseUnits(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *ApplicationSuite) TestCAASExposeWithoutHostname(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	err := s.api.Expose(params.ApplicationExpose{
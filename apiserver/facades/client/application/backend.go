@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -88,6 +89,7 @@ type Application interface {
 // the same names.
 type Charm interface {
 	charm.Charm
+	RelationSchemas() (relation.Schemas, error)
 }
 
 // Machine defines a subset of the functionality provided by the
@@ -122,6 +124,7 @@ type Unit interface {
 	IsPrincipal() bool
 	Life() state.Life
 	Resolve(retryHooks bool) error
+	SetPaused(bool) error
 
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
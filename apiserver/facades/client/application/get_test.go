@@ -29,7 +29,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv7
+	applicationAPI *application.APIv8
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -57,12 +57,12 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv7{api}
+	s.applicationAPI = &application.APIv8{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmoketestV4(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v4 := &application.APIv4{&application.APIv5{&application.APIv6{s.applicationAPI}}}
+	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{s.applicationAPI}}}}
 	results, err := v4.Get(params.ApplicationGet{"wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -82,7 +82,7 @@ func (s *getSuite) TestClientApplicationGetSmoketestV4(c *gc.C) {
 
 func (s *getSuite) TestClientApplicationGetSmoketestV5(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v5 := &application.APIv5{&application.APIv6{s.applicationAPI}}
+	v5 := &application.APIv5{&application.APIv6{&application.APIv7{s.applicationAPI}}}
 	results, err := v5.Get(params.ApplicationGet{"wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -125,6 +125,16 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmoketest(c *gc.C) {
 				"source":      "default",
 				"type":        environschema.Tbool,
 				"value":       false,
+			},
+			"hook-timeout": map[string]interface{}{
+				"description": "How long a charm hook may run before it is killed, in human-readable time format (default model hook-timeout)",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"resource-tags": map[string]interface{}{
+				"description": "Space-separated key=value tags to set on the cloud resources used by the application, in addition to the model's resource-tags; values may use the {{model}}, {{application}} and {{unit}} template variables",
+				"source":      "unset",
+				"type":        environschema.Tstring,
 			}},
 		Series: "quantal",
 	})
@@ -143,6 +153,8 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmoketest(c *gc.C) {
 
 	schemaFields, defaults, err = application.AddTrustSchemaAndDefaults(schemaFields, defaults)
 	c.Assert(err, jc.ErrorIsNil)
+	schemaFields, err = application.AddHookTimeoutSchema(schemaFields)
+	c.Assert(err, jc.ErrorIsNil)
 
 	appConfig, err := coreapplication.NewConfig(map[string]interface{}{"juju-external-hostname": "ext"}, schemaFields, defaults)
 	c.Assert(err, jc.ErrorIsNil)
@@ -194,7 +206,7 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmoketest(c *gc.C) {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV7 := &application.APIv7{api}
+	apiV7 := &application.APIv7{&application.APIv8{api}}
 
 	results, err := apiV7.Get(params.ApplicationGet{"wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -274,6 +286,16 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"description": "How long a charm hook may run before it is killed, in human-readable time format (default model hook-timeout)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"resource-tags": map[string]interface{}{
+				"description": "Space-separated key=value tags to set on the cloud resources used by the application, in addition to the model's resource-tags; values may use the {{model}}, {{application}} and {{unit}} template variables",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 	},
@@ -332,6 +354,16 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"description": "How long a charm hook may run before it is killed, in human-readable time format (default model hook-timeout)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"resource-tags": map[string]interface{}{
+				"description": "Space-separated key=value tags to set on the cloud resources used by the application, in addition to the model's resource-tags; values may use the {{model}}, {{application}} and {{unit}} template variables",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 	},
@@ -349,6 +381,16 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"description": "How long a charm hook may run before it is killed, in human-readable time format (default model hook-timeout)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"resource-tags": map[string]interface{}{
+				"description": "Space-separated key=value tags to set on the cloud resources used by the application, in addition to the model's resource-tags; values may use the {{model}}, {{application}} and {{unit}} template variables",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 	},
 }}
@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+)
+
+// HookTimeoutConfigOptionName is the option name used to set how long
+// the application's charm hooks may run before they are killed. An
+// empty value means the model's hook-timeout applies.
+const HookTimeoutConfigOptionName = "hook-timeout"
+
+var hookTimeoutFields = environschema.Fields{
+	HookTimeoutConfigOptionName: {
+		Description: "How long a charm hook may run before it is killed, in human-readable time format (default model hook-timeout)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// AddHookTimeoutSchema adds the hook timeout schema field to an existing set of schema fields.
+// There is no default; an unset value defers to the model.
+func AddHookTimeoutSchema(extra environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range hookTimeoutFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := hookTimeoutFields[name]; ok {
+			return nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// validateHookTimeout returns an error if the application config
+// attributes hold a hook timeout which is not a non-negative duration.
+func validateHookTimeout(attrs map[string]interface{}) error {
+	value, ok := attrs[HookTimeoutConfigOptionName].(string)
+	if !ok || value == "" {
+		return nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", HookTimeoutConfigOptionName)
+	}
+	if timeout < 0 {
+		return errors.Errorf("%s %v cannot be negative", HookTimeoutConfigOptionName, timeout)
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -50,8 +51,9 @@ type mockCharm struct {
 	jtesting.Stub
 
 	charm.Charm
-	config *charm.Config
-	meta   *charm.Meta
+	config  *charm.Config
+	meta    *charm.Meta
+	schemas relation.Schemas
 }
 
 func (m *mockCharm) Meta() *charm.Meta {
@@ -64,6 +66,11 @@ func (c *mockCharm) Config() *charm.Config {
 	return c.config
 }
 
+func (c *mockCharm) RelationSchemas() (relation.Schemas, error) {
+	c.MethodCall(c, "RelationSchemas")
+	return c.schemas, c.NextErr()
+}
+
 type mockApplication struct {
 	jtesting.Stub
 	application.Application
@@ -323,6 +330,21 @@ func (m *mockBackend) EndpointsRelation(endpoints ...state.Endpoint) (applicatio
 	return nil, errors.NotFoundf("relation")
 }
 
+func (m *mockBackend) AddRelation(endpoints ...state.Endpoint) (application.Relation, error) {
+	m.MethodCall(m, "AddRelation", endpoints)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	rel := m.relations[123]
+	rel.endpoints = endpoints
+	return rel, nil
+}
+
+func (m *mockBackend) SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error) {
+	m.MethodCall(m, "SaveEgressNetworks", relationKey, cidrs)
+	return nil, m.NextErr()
+}
+
 func (m *mockBackend) Relation(id int) (application.Relation, error) {
 	m.MethodCall(m, "Relation", id)
 	if err := m.NextErr(); err != nil {
@@ -492,6 +514,7 @@ type mockRelation struct {
 	jtesting.Stub
 
 	tag             names.Tag
+	endpoints       []state.Endpoint
 	status          status.Status
 	message         string
 	suspended       bool
@@ -531,6 +554,15 @@ func (r *mockRelation) Destroy() error {
 	return r.NextErr()
 }
 
+func (r *mockRelation) Endpoint(applicationName string) (state.Endpoint, error) {
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName == applicationName {
+			return ep, nil
+		}
+	}
+	return state.Endpoint{}, errors.NotFoundf("endpoint for %q", applicationName)
+}
+
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
@@ -552,6 +584,11 @@ func (u *mockUnit) DestroyOperation() *state.DestroyUnitOperation {
 	return &state.DestroyUnitOperation{}
 }
 
+func (u *mockUnit) SetPaused(paused bool) error {
+	u.MethodCall(u, "SetPaused", paused)
+	return u.NextErr()
+}
+
 func (u *mockUnit) AssignWithPolicy(policy state.AssignmentPolicy) error {
 	u.MethodCall(u, "AssignWithPolicy", policy)
 	return u.NextErr()
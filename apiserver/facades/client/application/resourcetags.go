@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs/tags"
+)
+
+// ResourceTagsConfigOptionName is the option name used to set the
+// tags of the provider resources used by the application's units, in
+// addition to the model's resource-tags.
+const ResourceTagsConfigOptionName = common.ApplicationResourceTagsKey
+
+var resourceTagsFields = environschema.Fields{
+	ResourceTagsConfigOptionName: {
+		Description: "Space-separated key=value tags to set on the cloud resources used by the application, in addition to the model's resource-tags; values may use the {{model}}, {{application}} and {{unit}} template variables",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// AddResourceTagsSchema adds the resource tags schema field to an existing set of schema fields.
+// There is no default; an unset value means only the model's resource tags apply.
+func AddResourceTagsSchema(extra environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range resourceTagsFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := resourceTagsFields[name]; ok {
+			return nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// validateResourceTags returns an error if the application config
+// attributes hold resource tags which are not valid.
+func validateResourceTags(attrs map[string]interface{}) error {
+	value, ok := attrs[ResourceTagsConfigOptionName].(string)
+	if !ok {
+		return nil
+	}
+	if _, err := tags.ParseResourceTags(value); err != nil {
+		return errors.Annotatef(err, "invalid %s", ResourceTagsConfigOptionName)
+	}
+	return nil
+}
@@ -92,6 +92,7 @@ type Unit interface {
 	PrivateAddress() (network.Address, error)
 	Resolve(retryHooks bool) error
 	AgentHistory() status.StatusHistoryGetter
+	HookHistory() status.StatusHistoryGetter
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -74,6 +74,13 @@ func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHi
 		}
 		statuses = append(statuses, agentStatusFromStatusInfo(agentStatuses, status.KindUnitAgent)...)
 	}
+	if kind == status.KindHook {
+		hookStatuses, err := unit.HookHistory().StatusHistory(filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses = agentStatusFromStatusInfo(hookStatuses, status.KindHook)
+	}
 
 	sort.Sort(byTime(statuses))
 	if kind == status.KindUnit && filter.Size > 0 {
@@ -140,7 +147,7 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 		kind := status.HistoryKind(request.Kind)
 		err = errors.NotValidf("%q requires a unit, got %T", kind, request.Tag)
 		switch kind {
-		case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+		case status.KindUnit, status.KindWorkload, status.KindUnitAgent, status.KindHook:
 			var u names.UnitTag
 			if u, err = names.ParseUnitTag(request.Tag); err == nil {
 				hist, err = c.unitStatusHistory(u, filter, kind)
@@ -974,6 +981,8 @@ func (context *statusContext) processApplication(application *state.Application)
 		Exposed:      application.IsExposed(),
 		Life:         processLife(application),
 		CharmVersion: applicationCharm.Version(),
+
+		LoadBalancerAddresses: application.LoadBalancerAddresses(),
 	}
 
 	if latestCharm, ok := context.allAppsUnitsCharmBindings.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
@@ -1059,6 +1068,16 @@ func (context *statusContext) processApplication(application *state.Application)
 			if len(serviceInfo.Addresses()) > 0 {
 				processedStatus.PublicAddress = serviceInfo.Addresses()[0].Value
 			}
+			if serviceStatus, err := application.ServiceStatus(); err == nil {
+				processedStatus.ServiceStatus = &params.DetailedStatus{
+					Status: serviceStatus.Status.String(),
+					Info:   serviceStatus.Message,
+					Data:   serviceStatus.Data,
+					Since:  serviceStatus.Since,
+				}
+			} else if !errors.IsNotFound(err) {
+				return params.ApplicationStatus{Err: common.ServerError(err)}
+			}
 		} else {
 			logger.Debugf("no service details for %v: %v", application.Name(), err)
 		}
@@ -222,10 +222,40 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryHooks(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{{
+		Status:  status.Active,
+		Message: "working",
+	}})
+	s.st.hookHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Error,
+			Message: `"config-changed" hook failed with exit code 1 (took 2s)`,
+		},
+		{
+			Status:  status.Idle,
+			Message: `ran "install" hook (took 1m0s)`,
+		},
+	})
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindHook.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.hookHistory))
+	for _, detailed := range h.Results[0].History.Statuses {
+		c.Check(detailed.Kind, gc.Equals, status.KindHook.String())
+	}
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+	hookHistory  []status.StatusInfo
 }
 
 func (m *mockState) ModelUUID() string {
@@ -247,12 +277,14 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 	return &mockUnit{
 		status: m.unitHistory,
 		agent:  &mockUnitAgent{m.agentHistory},
+		hooks:  m.hookHistory,
 	}, nil
 }
 
 type mockUnit struct {
 	status statuses
 	agent  *mockUnitAgent
+	hooks  statuses
 	client.Unit
 }
 
@@ -264,6 +296,10 @@ func (m *mockUnit) AgentHistory() status.StatusHistoryGetter {
 	return m.agent
 }
 
+func (m *mockUnit) HookHistory() status.StatusHistoryGetter {
+	return m.hooks
+}
+
 type mockUnitAgent struct {
 	statuses
 }
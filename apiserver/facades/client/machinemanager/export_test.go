@@ -3,4 +3,7 @@
 
 package machinemanager
 
-var InstanceTypes = instanceTypes
+var (
+	InstanceTypes    = instanceTypes
+	PreviewInstances = previewInstances
+)
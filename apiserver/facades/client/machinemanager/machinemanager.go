@@ -62,6 +62,11 @@ type MachineManagerAPIV5 struct {
 	*MachineManagerAPI
 }
 
+// Version 6 of Machine Manager API. Adds PreviewInstances.
+type MachineManagerAPIV6 struct {
+	*MachineManagerAPI
+}
+
 // NewFacadeV4 creates a new server-side MachineManager API facade.
 func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	machineManagerAPIV5, err := NewFacadeV5(ctx)
@@ -79,6 +84,15 @@ func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
 	return &MachineManagerAPIV5{machineManagerAPI}, nil
 }
 
+// NewFacadeV6 creates a new server-side MachineManager API facade.
+func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPI, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPI}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(
 	backend Backend,
@@ -0,0 +1,313 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/instance"
+	providercommon "github.com/juju/juju/provider/common"
+	"github.com/juju/juju/state/cloudimagemetadata"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// PreviewInstances reports the instance the provider would start for each
+// of the supplied machines, without starting anything or changing the
+// model. Constraints are merged with the model constraints and validated,
+// the provider prechecks the instance, and the instance type, image and
+// availability zone are chosen as the provisioner would.
+func (mm *MachineManagerAPIV6) PreviewInstances(args params.PreviewInstancesArgs) (params.PreviewInstanceResults, error) {
+	return previewInstances(mm.MachineManagerAPI, environs.GetEnviron, args)
+}
+
+func previewInstances(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.PreviewInstancesArgs,
+) (params.PreviewInstanceResults, error) {
+	results := params.PreviewInstanceResults{
+		Results: make([]params.PreviewInstanceResult, len(args.Args)),
+	}
+	model, err := mm.st.Model()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	modelCons, err := mm.st.ModelConstraints()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	cloudSpec := func() (environs.CloudSpec, error) {
+		credentialTag, _ := model.CloudCredential()
+		return stateenvirons.CloudSpec(mm.st, model.Cloud(), model.CloudRegion(), credentialTag)
+	}
+	env, err := getEnviron(common.EnvironConfigGetterFuncs{
+		CloudSpecFunc:   cloudSpec,
+		ModelConfigFunc: model.Config,
+	}, environs.New)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+
+	p := &instancePreviewer{
+		mm:        mm,
+		env:       env,
+		cfg:       cfg,
+		modelCons: modelCons,
+	}
+	for i, arg := range args.Args {
+		result, err := p.preview(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// instancePreviewer resolves the instances that would be started for a
+// set of machines in the same model. It remembers the zones chosen for
+// earlier machines so that later ones are spread across zones in the same
+// way the provisioner would spread them.
+type instancePreviewer struct {
+	mm        *MachineManagerAPI
+	env       environs.Environ
+	cfg       *config.Config
+	modelCons constraints.Value
+
+	zones       []providercommon.AvailabilityZoneInstances
+	zonesLoaded bool
+}
+
+func (p *instancePreviewer) preview(arg params.PreviewInstanceArg) (params.PreviewInstanceResult, error) {
+	var result params.PreviewInstanceResult
+	result.Series = arg.Series
+	if result.Series == "" {
+		result.Series = config.PreferredSeries(p.cfg)
+	}
+
+	validator, err := p.env.ConstraintsValidator(p.mm.callContext)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	cons, err := validator.Merge(p.modelCons, arg.Constraints)
+	if err != nil {
+		return result, errors.Annotate(err, "merging constraints")
+	}
+	result.Constraints = cons
+	if result.Unsupported, err = validator.Validate(cons); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	var placement string
+	if arg.Placement != nil {
+		if arg.Placement.Scope != p.mm.modelTag.Id() {
+			return result, errors.NotSupportedf("previewing placement %q", arg.Placement)
+		}
+		placement = arg.Placement.Directive
+	}
+	if err := p.env.PrecheckInstance(p.mm.callContext, environs.PrecheckInstanceParams{
+		Series:      result.Series,
+		Constraints: cons,
+		Placement:   placement,
+	}); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	if err := p.previewInstanceSpec(&result); err != nil {
+		return result, errors.Trace(err)
+	}
+	zone, err := p.chooseZone(cons, placement)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.AvailabilityZone = zone
+	return result, nil
+}
+
+// previewInstanceSpec fills in the instance type and image that best
+// match the result's constraints. Providers that do not expose instance
+// types or image metadata leave the corresponding fields empty.
+func (p *instancePreviewer) previewInstanceSpec(result *params.PreviewInstanceResult) error {
+	itypes, err := p.env.InstanceTypes(p.mm.callContext, result.Constraints)
+	if errors.IsNotImplemented(err) || errors.IsNotSupported(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "listing instance types")
+	}
+	if len(itypes.InstanceTypes) == 0 {
+		return errors.NotFoundf("instance types matching constraints %q", result.Constraints)
+	}
+
+	images, region, err := p.availableImages(result.Series, result.Constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(images) == 0 {
+		itype := common.ToParamsInstanceType(itypes.InstanceTypes[0])
+		result.InstanceType = &itype
+		return nil
+	}
+	var arches []string
+	if result.Constraints.HasArch() {
+		arches = []string{*result.Constraints.Arch}
+	}
+	spec, err := instances.FindInstanceSpec(images, &instances.InstanceConstraint{
+		Region:      region,
+		Series:      result.Series,
+		Arches:      arches,
+		Constraints: result.Constraints,
+	}, itypes.InstanceTypes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	itype := common.ToParamsInstanceType(spec.InstanceType)
+	result.InstanceType = &itype
+	result.ImageId = spec.Image.Id
+	return nil
+}
+
+// availableImages returns the images known for the given series and
+// constraints, looking first at the metadata cached in the controller and
+// then at the model's image metadata sources. Unlike the provisioner, it
+// does not save metadata found in the data sources.
+func (p *instancePreviewer) availableImages(series string, cons constraints.Value) ([]instances.Image, string, error) {
+	lookup := simplestreams.LookupParams{
+		Series: []string{series},
+		Stream: p.cfg.ImageStream(),
+	}
+	if cons.HasArch() {
+		lookup.Arches = []string{*cons.Arch}
+	}
+	if hasRegion, ok := p.env.(simplestreams.HasRegion); ok {
+		spec, err := hasRegion.Region()
+		if err != nil {
+			return nil, "", errors.Annotate(err, "getting provider region information (cloud spec)")
+		}
+		lookup.CloudSpec = spec
+	}
+	imageConstraint := imagemetadata.NewImageConstraint(lookup)
+
+	stored, err := p.mm.st.FindCloudImageMetadata(cloudimagemetadata.MetadataFilter{
+		Series: imageConstraint.Series,
+		Arches: imageConstraint.Arches,
+		Region: imageConstraint.Region,
+		Stream: imageConstraint.Stream,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Infof("could not get image metadata from controller: %v", err)
+	}
+	var images []instances.Image
+	for _, metadata := range stored {
+		for _, m := range metadata {
+			images = append(images, instances.Image{
+				Id:       m.ImageId,
+				Arch:     m.Arch,
+				VirtType: m.VirtType,
+			})
+		}
+	}
+	if len(images) > 0 {
+		return images, lookup.Region, nil
+	}
+
+	sources, err := environs.ImageMetadataSources(p.env)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	for _, source := range sources {
+		found, _, err := imagemetadata.Fetch([]simplestreams.DataSource{source}, imageConstraint)
+		if err != nil {
+			logger.Debugf("encountered %v while getting published images metadata from %v", err, source.Description())
+			continue
+		}
+		for _, m := range found {
+			images = append(images, instances.Image{
+				Id:       m.Id,
+				Arch:     m.Arch,
+				VirtType: m.VirtType,
+			})
+		}
+		if len(images) > 0 {
+			break
+		}
+	}
+	return images, lookup.Region, nil
+}
+
+// chooseZone returns the availability zone the instance would be started
+// in, or "" if the provider does not support availability zones. Without
+// a zone placement, the least populated zone permitted by the constraints
+// is chosen, as the provisioner does.
+func (p *instancePreviewer) chooseZone(cons constraints.Value, placement string) (string, error) {
+	zonedEnv, ok := p.env.(providercommon.ZonedEnviron)
+	if !ok {
+		return "", nil
+	}
+	derived, err := zonedEnv.DeriveAvailabilityZones(p.mm.callContext, environs.StartInstanceParams{
+		Constraints: cons,
+		Placement:   placement,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(derived) > 0 {
+		return derived[0], nil
+	}
+
+	if !p.zonesLoaded {
+		p.zones, err = providercommon.AvailabilityZoneAllocations(zonedEnv, p.mm.callContext, nil)
+		if errors.IsNotImplemented(err) {
+			p.zonesLoaded = true
+			return "", nil
+		} else if err != nil {
+			return "", errors.Annotate(err, "getting availability zones")
+		}
+		p.zonesLoaded = true
+	}
+	for i, zone := range p.zones {
+		if cons.HasZones() && !containsString(*cons.Zones, zone.ZoneName) {
+			continue
+		}
+		// Count the previewed instance against the zone, so that
+		// subsequent machines are spread across the other zones.
+		p.zones[i].Instances = append(p.zones[i].Instances, instance.Id(""))
+		name := zone.ZoneName
+		sort.Slice(p.zones, func(i, j int) bool {
+			ni, nj := len(p.zones[i].Instances), len(p.zones[j].Instances)
+			if ni != nj {
+				return ni < nj
+			}
+			return p.zones[i].ZoneName < p.zones[j].ZoneName
+		})
+		return name, nil
+	}
+	if cons.HasZones() {
+		return "", errors.Errorf("no availability zones match the zones constraint (%s)", strings.Join(*cons.Zones, ","))
+	}
+	return "", nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/instance"
+	providercommon "github.com/juju/juju/provider/common"
+	"github.com/juju/juju/state/cloudimagemetadata"
+)
+
+type previewInstancesSuite struct {
+	backend *previewBackend
+	env     *previewEnviron
+	api     *machinemanager.MachineManagerAPI
+}
+
+var _ = gc.Suite(&previewInstancesSuite{})
+
+func (s *previewInstancesSuite) SetUpTest(c *gc.C) {
+	s.backend = &previewBackend{
+		modelCons: constraints.MustParse("mem=4G"),
+		metadata: map[string][]cloudimagemetadata.Metadata{
+			"released": {{
+				MetadataAttributes: cloudimagemetadata.MetadataAttributes{
+					Series: "xenial",
+					Arch:   "amd64",
+				},
+				ImageId: "ami-xenial",
+			}},
+		},
+	}
+	s.env = &previewEnviron{
+		instanceTypes: []instances.InstanceType{{
+			Name:     "small",
+			Arches:   []string{"amd64"},
+			CpuCores: 1,
+			Mem:      2048,
+			Cost:     1,
+		}, {
+			Name:     "medium",
+			Arches:   []string{"amd64"},
+			CpuCores: 2,
+			Mem:      4096,
+			Cost:     2,
+		}, {
+			Name:     "large",
+			Arches:   []string{"amd64"},
+			CpuCores: 4,
+			Mem:      8192,
+			Cost:     4,
+		}},
+	}
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("admin"), Controller: true}
+	var err error
+	s.api, err = machinemanager.NewMachineManagerAPI(
+		s.backend, s.backend, &mockPool{}, authorizer, s.backend.ModelTag(), context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *previewInstancesSuite) preview(c *gc.C, env environs.Environ, args ...params.PreviewInstanceArg) []params.PreviewInstanceResult {
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	results, err := machinemanager.PreviewInstances(s.api, getEnviron, params.PreviewInstancesArgs{Args: args})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, len(args))
+	return results.Results
+}
+
+func (s *previewInstancesSuite) TestPreviewInstance(c *gc.C) {
+	results := s.preview(c, s.env, params.PreviewInstanceArg{
+		Series:      "xenial",
+		Constraints: constraints.MustParse("cores=2 tags=foo"),
+	})
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Check(results[0].Series, gc.Equals, "xenial")
+	c.Check(results[0].Constraints, jc.DeepEquals, constraints.MustParse("mem=4G cores=2 tags=foo"))
+	c.Check(results[0].Unsupported, jc.DeepEquals, []string{"tags"})
+	c.Assert(results[0].InstanceType, gc.NotNil)
+	c.Check(results[0].InstanceType.Name, gc.Equals, "medium")
+	c.Check(results[0].ImageId, gc.Equals, "ami-xenial")
+	c.Check(results[0].AvailabilityZone, gc.Equals, "")
+	c.Check(s.env.prechecked, jc.DeepEquals, []environs.PrecheckInstanceParams{{
+		Series:      "xenial",
+		Constraints: constraints.MustParse("mem=4G cores=2 tags=foo"),
+	}})
+}
+
+func (s *previewInstancesSuite) TestPreviewInstanceConflictingConstraints(c *gc.C) {
+	results := s.preview(c, s.env, params.PreviewInstanceArg{
+		Series:      "xenial",
+		Constraints: constraints.MustParse("instance-type=large cores=8"),
+	})
+	c.Assert(results[0].Error, gc.ErrorMatches, `merging constraints: ambiguous constraints: "cores" overlaps with "instance-type"`)
+	c.Check(s.env.prechecked, gc.HasLen, 0)
+}
+
+func (s *previewInstancesSuite) TestPreviewInstancePrecheckFails(c *gc.C) {
+	s.env.precheckErr = errors.New("no capacity")
+	results := s.preview(c, s.env, params.PreviewInstanceArg{Series: "xenial"})
+	c.Assert(results[0].Error, gc.ErrorMatches, "no capacity")
+	c.Check(results[0].InstanceType, gc.IsNil)
+}
+
+func (s *previewInstancesSuite) TestPreviewInstanceNoInstanceTypes(c *gc.C) {
+	s.env.instanceTypesErr = errors.NotImplementedf("InstanceTypes")
+	results := s.preview(c, s.env, params.PreviewInstanceArg{Series: "xenial"})
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Check(results[0].InstanceType, gc.IsNil)
+	c.Check(results[0].ImageId, gc.Equals, "")
+}
+
+func (s *previewInstancesSuite) TestPreviewInstanceUnsupportedPlacement(c *gc.C) {
+	results := s.preview(c, s.env, params.PreviewInstanceArg{
+		Series:    "xenial",
+		Placement: &instance.Placement{Scope: instance.MachineScope, Directive: "0"},
+	})
+	c.Assert(results[0].Error, gc.ErrorMatches, `previewing placement "#:0" not supported`)
+}
+
+func (s *previewInstancesSuite) TestPreviewInstancesSpreadAcrossZones(c *gc.C) {
+	env := &previewZonedEnviron{
+		previewEnviron: s.env,
+		zones:          []string{"zone-a", "zone-b"},
+	}
+	results := s.preview(c, env,
+		params.PreviewInstanceArg{Series: "xenial"},
+		params.PreviewInstanceArg{Series: "xenial"},
+		params.PreviewInstanceArg{
+			Series:    "xenial",
+			Placement: &instance.Placement{Scope: s.backend.ModelTag().Id(), Directive: "zone=zone-b"},
+		},
+		params.PreviewInstanceArg{Series: "xenial", Constraints: constraints.MustParse("zones=zone-b")},
+	)
+	var zones []string
+	for _, result := range results {
+		c.Assert(result.Error, gc.IsNil)
+		zones = append(zones, result.AvailabilityZone)
+	}
+	c.Assert(zones, jc.DeepEquals, []string{"zone-a", "zone-b", "zone-b", "zone-b"})
+}
+
+type previewBackend struct {
+	mockBackend
+	modelCons constraints.Value
+	metadata  map[string][]cloudimagemetadata.Metadata
+}
+
+func (b *previewBackend) ModelConstraints() (constraints.Value, error) {
+	return b.modelCons, nil
+}
+
+func (b *previewBackend) FindCloudImageMetadata(cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error) {
+	return b.metadata, nil
+}
+
+type previewEnviron struct {
+	environs.Environ
+
+	instanceTypes    []instances.InstanceType
+	instanceTypesErr error
+	precheckErr      error
+	prechecked       []environs.PrecheckInstanceParams
+}
+
+func (e *previewEnviron) ConstraintsValidator(context.ProviderCallContext) (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported([]string{constraints.Tags})
+	validator.RegisterConflicts(
+		[]string{constraints.InstanceType},
+		[]string{constraints.Mem, constraints.Cores},
+	)
+	return validator, nil
+}
+
+func (e *previewEnviron) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	e.prechecked = append(e.prechecked, args)
+	return e.precheckErr
+}
+
+func (e *previewEnviron) InstanceTypes(context.ProviderCallContext, constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	if e.instanceTypesErr != nil {
+		return instances.InstanceTypesWithCostMetadata{}, e.instanceTypesErr
+	}
+	return instances.InstanceTypesWithCostMetadata{InstanceTypes: e.instanceTypes}, nil
+}
+
+type previewZonedEnviron struct {
+	*previewEnviron
+	zones []string
+}
+
+var _ providercommon.ZonedEnviron = (*previewZonedEnviron)(nil)
+
+func (e *previewZonedEnviron) AllInstances(context.ProviderCallContext) ([]instance.Instance, error) {
+	return nil, nil
+}
+
+func (e *previewZonedEnviron) AvailabilityZones(context.ProviderCallContext) ([]providercommon.AvailabilityZone, error) {
+	zones := make([]providercommon.AvailabilityZone, len(e.zones))
+	for i, name := range e.zones {
+		zones[i] = &testing.FakeZone{ZoneName: name, ZoneAvailable: true}
+	}
+	return zones, nil
+}
+
+func (e *previewZonedEnviron) InstanceAvailabilityZoneNames(context.ProviderCallContext, []instance.Id) ([]string, error) {
+	return nil, environs.ErrNoInstances
+}
+
+func (e *previewZonedEnviron) DeriveAvailabilityZones(ctx context.ProviderCallContext, args environs.StartInstanceParams) ([]string, error) {
+	if args.Placement == "zone=zone-b" {
+		return []string{"zone-b"}, nil
+	}
+	return nil, nil
+}
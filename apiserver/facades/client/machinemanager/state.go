@@ -8,9 +8,11 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/errors"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
 type Backend interface {
@@ -18,6 +20,8 @@ type Backend interface {
 
 	Machine(string) (Machine, error)
 	Model() (Model, error)
+	ModelConstraints() (constraints.Value, error)
+	FindCloudImageMetadata(cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error)
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
@@ -67,6 +71,10 @@ func (s stateShim) Model() (Model, error) {
 	return s.State.Model()
 }
 
+func (s stateShim) FindCloudImageMetadata(filter cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error) {
+	return s.State.CloudImageMetadataStorage.FindMetadata(filter)
+}
+
 type poolShim struct {
 	pool *state.StatePool
 }
@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the secrets
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllSecrets() ([]Secret, error)
+}
+
+// Secret defines the secret metadata used by the secrets facade.
+// It is implemented by *state.Secret.
+type Secret interface {
+	Id() string
+	Owner() string
+	Description() string
+	Revision() int
+	RotateInterval() time.Duration
+	NextRotateTime() time.Time
+	Grants() []string
+	CreateTime() time.Time
+	UpdateTime() time.Time
+}
+
+type stateShim struct {
+	st *state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return s.st.ModelTag()
+}
+
+func (s stateShim) AllSecrets() ([]Secret, error) {
+	secrets, err := s.st.AllSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Secret, len(secrets))
+	for i, secret := range secrets {
+		result[i] = secret
+	}
+	return result, nil
+}
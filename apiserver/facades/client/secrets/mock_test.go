@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	modelUUID string
+	secrets   []secrets.Secret
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	m.MethodCall(m, "ModelTag")
+	m.PopNoErr()
+	return names.NewModelTag(m.modelUUID)
+}
+
+func (m *mockBackend) AllSecrets() ([]secrets.Secret, error) {
+	m.MethodCall(m, "AllSecrets")
+	return m.secrets, m.NextErr()
+}
+
+type mockSecret struct {
+	id             string
+	owner          string
+	description    string
+	revision       int
+	rotateInterval time.Duration
+	nextRotateTime time.Time
+	grants         []string
+	created        time.Time
+	updated        time.Time
+}
+
+func (s *mockSecret) Id() string                    { return s.id }
+func (s *mockSecret) Owner() string                 { return s.owner }
+func (s *mockSecret) Description() string           { return s.description }
+func (s *mockSecret) Revision() int                 { return s.revision }
+func (s *mockSecret) RotateInterval() time.Duration { return s.rotateInterval }
+func (s *mockSecret) NextRotateTime() time.Time     { return s.nextRotateTime }
+func (s *mockSecret) Grants() []string              { return s.grants }
+func (s *mockSecret) CreateTime() time.Time         { return s.created }
+func (s *mockSecret) UpdateTime() time.Time         { return s.updated }
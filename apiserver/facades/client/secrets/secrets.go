@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// API provides the secrets facade APIs for v1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth())
+}
+
+// NewAPI returns a new secrets API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkAdmin() error {
+	allowed, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets returns the metadata of all the secrets in the model.
+// Secret values are never returned.
+func (api *API) ListSecrets() (params.ListSecretResults, error) {
+	var result params.ListSecretResults
+	if err := api.checkAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	secrets, err := api.backend.AllSecrets()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ListSecretResult, len(secrets))
+	for i, secret := range secrets {
+		item := params.ListSecretResult{
+			Id:             secret.Id(),
+			Owner:          secret.Owner(),
+			Description:    secret.Description(),
+			Revision:       secret.Revision(),
+			RotateInterval: secret.RotateInterval(),
+			Grants:         secret.Grants(),
+			CreateTime:     secret.CreateTime(),
+			UpdateTime:     secret.UpdateTime(),
+		}
+		if item.RotateInterval > 0 {
+			next := secret.NextRotateTime()
+			item.NextRotateTime = &next
+		}
+		result.Results[i] = item
+	}
+	return result, nil
+}
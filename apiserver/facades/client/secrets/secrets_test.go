@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	testing.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *secrets.API
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = mockBackend{
+		modelUUID: coretesting.ModelTag.Id(),
+	}
+	s.setAPIUser(c, names.NewUserTag("admin"))
+}
+
+func (s *SecretsSuite) setAPIUser(c *gc.C, user names.UserTag) {
+	s.authorizer.Tag = user
+	api, err := secrets.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *SecretsSuite) TestNewAPINonClient(c *gc.C) {
+	_, err := secrets.NewAPI(&s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUnitTag("mysql/0"),
+	})
+	c.Assert(err, gc.Equals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	created := time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC)
+	next := created.Add(time.Hour)
+	s.backend.secrets = []secrets.Secret{
+		&mockSecret{
+			id:          "secret-1",
+			owner:       "mysql",
+			description: "root password",
+			revision:    2,
+			grants:      []string{"wordpress"},
+			created:     created,
+			updated:     created,
+		},
+		&mockSecret{
+			id:             "secret-2",
+			owner:          "wordpress",
+			revision:       1,
+			rotateInterval: time.Hour,
+			nextRotateTime: next,
+			created:        created,
+			updated:        created,
+		},
+	}
+	result, err := s.api.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListSecretResults{
+		Results: []params.ListSecretResult{{
+			Id:          "secret-1",
+			Owner:       "mysql",
+			Description: "root password",
+			Revision:    2,
+			Grants:      []string{"wordpress"},
+			CreateTime:  created,
+			UpdateTime:  created,
+		}, {
+			Id:             "secret-2",
+			Owner:          "wordpress",
+			Revision:       1,
+			RotateInterval: time.Hour,
+			NextRotateTime: &next,
+			CreateTime:     created,
+			UpdateTime:     created,
+		}},
+	})
+}
+
+func (s *SecretsSuite) TestListSecretsError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	_, err := s.api.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SecretsSuite) TestListSecretsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("someone"))
+	_, err := s.api.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
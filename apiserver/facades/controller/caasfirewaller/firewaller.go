@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -20,6 +21,21 @@ type Facade struct {
 	state     CAASFirewallerState
 }
 
+// FacadeV1 provides version 1 of the CAAS firewaller facade.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeV1 provides the signature required for version 1
+// facade registration.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	api, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{api}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -123,3 +139,158 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 	}
 	return app.ApplicationConfig()
 }
+
+// WatchRelations isn't on the V1 API.
+func (*FacadeV1) WatchRelations(_, _ struct{}) {}
+
+// WatchRelations starts a StringsWatcher for each of the specified
+// applications, notifying of changes to the relations they participate in.
+func (f *Facade) WatchRelations(args params.Entities) (params.StringsWatchResults, error) {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, changes, err := f.watchRelations(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].StringsWatcherId = id
+		results.Results[i].Changes = changes
+	}
+	return results, nil
+}
+
+func (f *Facade) watchRelations(tagString string) (string, []string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	watch := app.WatchRelations()
+	if changes, ok := <-watch.Changes(); ok {
+		return f.resources.Register(watch), changes, nil
+	}
+	return "", nil, watcher.EnsureErr(watch)
+}
+
+// RelatedApplications isn't on the V1 API.
+func (*FacadeV1) RelatedApplications(_, _ struct{}) {}
+
+// RelatedApplications returns the names of the applications
+// related to each of the specified applications.
+func (f *Facade) RelatedApplications(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		related, err := f.relatedApplications(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = related
+	}
+	return results, nil
+}
+
+func (f *Facade) relatedApplications(tagString string) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.RelatedApplications()
+}
+
+// IngressNetworks isn't on the V1 API.
+func (*FacadeV1) IngressNetworks(_, _ struct{}) {}
+
+// IngressNetworks returns the networks from which the consumers of
+// the cross model relations of each of the specified applications may
+// connect to it. Such consumers have no pods in the model, so network
+// policies must admit them by address.
+func (f *Facade) IngressNetworks(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		cidrs, err := f.ingressNetworks(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = cidrs
+	}
+	return results, nil
+}
+
+func (f *Facade) ingressNetworks(tagString string) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.IngressNetworks()
+}
+
+// WatchIngressNetworks isn't on the V1 API.
+func (*FacadeV1) WatchIngressNetworks(_, _ struct{}) {}
+
+// WatchIngressNetworks starts a NotifyWatcher for each of the specified
+// applications, notifying of changes to the ingress networks of their
+// relations.
+func (f *Facade) WatchIngressNetworks(args params.Entities) (params.NotifyWatchResults, error) {
+	return f.watchApplications(args, Application.WatchRelationIngressNetworks)
+}
+
+// WatchApplicationsConfig isn't on the V1 API.
+func (*FacadeV1) WatchApplicationsConfig(_, _ struct{}) {}
+
+// WatchApplicationsConfig starts a NotifyWatcher for each of the
+// specified applications, notifying of changes to their config.
+func (f *Facade) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	return f.watchApplications(args, Application.WatchApplicationConfig)
+}
+
+func (f *Facade) watchApplications(
+	args params.Entities, watch func(Application) state.NotifyWatcher,
+) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplication(arg.Tag, watch)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplication(tagString string, watch func(Application) state.NotifyWatcher) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := watch(app)
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
@@ -26,6 +26,9 @@ type CAASFirewallerSuite struct {
 	st                  *mockState
 	applicationsChanges chan []string
 	appExposedChanges   chan struct{}
+	relationsChanges    chan []string
+	configChanges       chan struct{}
+	ingressChanges      chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -37,17 +40,29 @@ func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationsChanges = make(chan []string, 1)
 	s.appExposedChanges = make(chan struct{}, 1)
+	s.relationsChanges = make(chan []string, 1)
 	appExposedWatcher := statetesting.NewMockNotifyWatcher(s.appExposedChanges)
+	relationsWatcher := statetesting.NewMockStringsWatcher(s.relationsChanges)
+	s.configChanges = make(chan struct{}, 1)
+	s.ingressChanges = make(chan struct{}, 1)
+	configWatcher := statetesting.NewMockNotifyWatcher(s.configChanges)
+	ingressWatcher := statetesting.NewMockNotifyWatcher(s.ingressChanges)
 	s.st = &mockState{
 		application: mockApplication{
-			life:    state.Alive,
-			watcher: appExposedWatcher,
+			life:             state.Alive,
+			watcher:          appExposedWatcher,
+			relationsWatcher: relationsWatcher,
+			configWatcher:    configWatcher,
+			ingressWatcher:   ingressWatcher,
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		appExposedWatcher:   appExposedWatcher,
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.appExposedWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, relationsWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, configWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, ingressWatcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -158,3 +173,106 @@ func (s *CAASFirewallerSuite) TestApplicationConfig(c *gc.C) {
 	})
 	c.Assert(results.Results[0].Config, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
 }
+
+func (s *CAASFirewallerSuite) TestWatchRelations(c *gc.C) {
+	s.relationsChanges <- []string{"gitlab:db mysql:server"}
+
+	results, err := s.facade.WatchRelations(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[0].Changes, jc.DeepEquals, []string{"gitlab:db mysql:server"})
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.relationsWatcher)
+}
+
+func (s *CAASFirewallerSuite) TestRelatedApplications(c *gc.C) {
+	s.st.application.related = []string{"mysql", "redis"}
+	results, err := s.facade.RelatedApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"mysql", "redis"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+	s.st.CheckCall(c, 0, "Application", "gitlab")
+}
+
+func (s *CAASFirewallerSuite) TestIngressNetworks(c *gc.C) {
+	s.st.application.ingressNetworks = []string{"10.0.0.0/24"}
+	results, err := s.facade.IngressNetworks(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"10.0.0.0/24"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+	s.st.CheckCall(c, 0, "Application", "gitlab")
+	s.st.application.CheckCallNames(c, "IngressNetworks")
+}
+
+func (s *CAASFirewallerSuite) TestWatchIngressNetworks(c *gc.C) {
+	s.ingressChanges <- struct{}{}
+
+	results, err := s.facade.WatchIngressNetworks(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.ingressWatcher)
+}
+
+func (s *CAASFirewallerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	s.configChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.configWatcher)
+}
@@ -43,9 +43,14 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 
 type mockApplication struct {
 	testing.Stub
-	life    state.Life
-	exposed bool
-	watcher state.NotifyWatcher
+	life             state.Life
+	exposed          bool
+	related          []string
+	ingressNetworks  []string
+	watcher          state.NotifyWatcher
+	relationsWatcher state.StringsWatcher
+	configWatcher    state.NotifyWatcher
+	ingressWatcher   state.NotifyWatcher
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -70,3 +75,28 @@ func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, err
 func (a *mockApplication) Watch() state.NotifyWatcher {
 	return a.watcher
 }
+
+func (a *mockApplication) WatchRelations() state.StringsWatcher {
+	a.MethodCall(a, "WatchRelations")
+	return a.relationsWatcher
+}
+
+func (a *mockApplication) RelatedApplications() ([]string, error) {
+	a.MethodCall(a, "RelatedApplications")
+	return a.related, a.NextErr()
+}
+
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
+func (a *mockApplication) WatchRelationIngressNetworks() state.NotifyWatcher {
+	a.MethodCall(a, "WatchRelationIngressNetworks")
+	return a.ingressWatcher
+}
+
+func (a *mockApplication) IngressNetworks() ([]string, error) {
+	a.MethodCall(a, "IngressNetworks")
+	return a.ingressNetworks, a.NextErr()
+}
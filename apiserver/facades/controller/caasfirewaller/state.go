@@ -4,6 +4,8 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/application"
@@ -24,6 +26,11 @@ type Application interface {
 	IsExposed() bool
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Watch() state.NotifyWatcher
+	WatchRelations() state.StringsWatcher
+	RelatedApplications() ([]string, error)
+	WatchApplicationConfig() state.NotifyWatcher
+	WatchRelationIngressNetworks() state.NotifyWatcher
+	IngressNetworks() ([]string, error)
 }
 
 type stateShim struct {
@@ -31,5 +38,57 @@ type stateShim struct {
 }
 
 func (s stateShim) Application(id string) (Application, error) {
-	return s.State.Application(id)
+	app, err := s.State.Application(id)
+	if err != nil {
+		return nil, err
+	}
+	return applicationShim{Application: app, st: s.State}, nil
+}
+
+type applicationShim struct {
+	*state.Application
+	st *state.State
+}
+
+// RelatedApplications returns the names of the applications
+// at the other end of the application's relations.
+func (a applicationShim) RelatedApplications() ([]string, error) {
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		endpoints, err := rel.RelatedEndpoints(a.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range endpoints {
+			if ep.ApplicationName != a.Name() {
+				related.Add(ep.ApplicationName)
+			}
+		}
+	}
+	return related.SortedValues(), nil
+}
+
+// IngressNetworks returns the ingress networks recorded for
+// the application's cross model relations.
+func (a applicationShim) IngressNetworks() ([]string, error) {
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ingress := state.NewRelationIngressNetworks(a.st)
+	cidrs := set.NewStrings()
+	for _, rel := range relations {
+		networks, err := ingress.Networks(rel.Tag().Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs = cidrs.Union(set.NewStrings(networks.CIDRS()...))
+	}
+	return cidrs.SortedValues(), nil
 }
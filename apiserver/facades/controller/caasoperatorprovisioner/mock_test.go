@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
@@ -36,6 +37,11 @@ func (st *mockState) WatchApplications() state.StringsWatcher {
 	return st.applicationWatcher
 }
 
+func (st *mockState) WatchApplicationsConfig() state.NotifyWatcher {
+	st.MethodCall(st, "WatchApplicationsConfig")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
 func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	if st.app.tag == tag {
 		return st.app, nil
@@ -63,6 +69,7 @@ type mockApplication struct {
 	state.Authenticator
 	tag      names.Tag
 	password string
+	config   application.ConfigAttributes
 }
 
 func (m *mockApplication) Tag() names.Tag {
@@ -78,6 +85,10 @@ func (a *mockApplication) Life() state.Life {
 	return state.Alive
 }
 
+func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
+	return a.config, nil
+}
+
 type mockWatcher struct {
 	testing.Stub
 	tomb.Tomb
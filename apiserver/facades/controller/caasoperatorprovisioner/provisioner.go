@@ -6,11 +6,14 @@ package caasoperatorprovisioner
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/version"
 )
@@ -26,6 +29,21 @@ type API struct {
 	state CAASOperatorProvisionerState
 }
 
+// APIV1 provides version 1 of the CAAS operator provisioner facade.
+type APIV1 struct {
+	*API
+}
+
+// NewStateCAASOperatorProvisionerAPIV1 provides the signature required
+// for version 1 facade registration.
+func NewStateCAASOperatorProvisionerAPIV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewStateCAASOperatorProvisionerAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewStateCAASOperatorProvisionerAPI provides the signature required for facade registration.
 func NewStateCAASOperatorProvisionerAPI(ctx facade.Context) (*API, error) {
 
@@ -67,6 +85,23 @@ func (a *API) WatchApplications() (params.StringsWatchResult, error) {
 	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
 }
 
+// WatchApplicationsConfig isn't on the V1 API.
+func (*APIV1) WatchApplicationsConfig(_, _ struct{}) {}
+
+// WatchApplicationsConfig starts a NotifyWatcher to watch changes
+// to the config of the applications in this model, which may alter
+// the number of operator replicas to run.
+func (a *API) WatchApplicationsConfig() (params.NotifyWatchResult, error) {
+	watch := a.state.WatchApplicationsConfig()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: a.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
 // OperatorProvisioningInfo returns the info needed to provision an operator.
 func (a *API) OperatorProvisioningInfo() (params.OperatorProvisioningInfo, error) {
 	cfg, err := a.state.ControllerConfig()
@@ -86,3 +121,54 @@ func (a *API) OperatorProvisioningInfo() (params.OperatorProvisioningInfo, error
 		Version:   version.Current,
 	}, nil
 }
+
+// applicationConfigGetter is implemented by applications
+// whose config can be read by the provisioner.
+type applicationConfigGetter interface {
+	ApplicationConfig() (application.ConfigAttributes, error)
+}
+
+// OperatorReplicas isn't on the V1 API.
+func (*APIV1) OperatorReplicas(_, _ struct{}) {}
+
+// OperatorReplicas returns the number of operator pods to run for
+// each of the specified applications. Applications which have not
+// configured standby operators run a single operator.
+func (a *API) OperatorReplicas(args params.Entities) (params.IntResults, error) {
+	result := params.IntResults{
+		Results: make([]params.IntResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		replicas, err := a.operatorReplicas(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = replicas
+	}
+	return result, nil
+}
+
+func (a *API) operatorReplicas(tagString string) (int, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return 0, common.ErrPerm
+	}
+	entity, err := a.state.FindEntity(tag)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	app, ok := entity.(applicationConfigGetter)
+	if !ok {
+		return 0, errors.NotValidf("entity %q", tag)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	replicas := config.GetInt(caas.JujuOperatorReplicasKey, 1)
+	if replicas < 1 {
+		replicas = 1
+	}
+	return replicas, nil
+}
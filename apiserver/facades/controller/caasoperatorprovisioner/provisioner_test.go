@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -135,6 +136,43 @@ func (s *CAASProvisionerSuite) TestOperatorProvisioningInfo(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) TestOperatorReplicas(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:    names.NewApplicationTag("app"),
+		config: application.ConfigAttributes{"juju-operator-replicas": 3},
+	}
+	results, err := s.api.OperatorReplicas(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-app"},
+			{Tag: "application-another"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.IntResults{
+		Results: []params.IntResult{{
+			Result: 3,
+		}, {
+			Error: &params.Error{Message: "entity application-another not found", Code: "not found"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *CAASProvisionerSuite) TestOperatorReplicasDefault(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag: names.NewApplicationTag("app"),
+	}
+	results, err := s.api.OperatorReplicas(params.Entities{
+		Entities: []params.Entity{{Tag: "application-app"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.IntResults{
+		Results: []params.IntResult{{Result: 1}},
+	})
+}
+
 func (s *CAASProvisionerSuite) TestAddresses(c *gc.C) {
 	_, err := s.api.APIAddresses()
 	c.Assert(err, jc.ErrorIsNil)
@@ -146,3 +184,15 @@ func (s *CAASProvisionerSuite) TestWatchAPIHostPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.st.CheckCallNames(c, "WatchAPIHostPortsForAgents")
 }
+
+func (s *CAASProvisionerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	result, err := s.api.WatchApplicationsConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	s.st.CheckCallNames(c, "WatchApplicationsConfig")
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.NotNil)
+	c.Assert(resource, gc.Implements, new(state.NotifyWatcher))
+}
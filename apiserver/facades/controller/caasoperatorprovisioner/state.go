@@ -16,6 +16,7 @@ import (
 type CAASOperatorProvisionerState interface {
 	ControllerConfig() (controller.Config, error)
 	WatchApplications() state.StringsWatcher
+	WatchApplicationsConfig() state.NotifyWatcher
 	FindEntity(tag names.Tag) (state.Entity, error)
 	Addresses() ([]string, error)
 	ModelUUID() string
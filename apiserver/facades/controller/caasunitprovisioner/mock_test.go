@@ -105,14 +105,16 @@ func (m *mockModel) Containers(providerIds ...string) ([]state.CloudContainer, e
 
 type mockApplication struct {
 	testing.Stub
-	life         state.Life
-	unitsWatcher *statetesting.MockStringsWatcher
+	life          state.Life
+	unitsWatcher  *statetesting.MockStringsWatcher
+	configWatcher *statetesting.MockNotifyWatcher
 
-	tag        names.Tag
-	units      []caasunitprovisioner.Unit
-	ops        *state.UpdateUnitsOperation
-	providerId string
-	addresses  []network.Address
+	tag           names.Tag
+	units         []caasunitprovisioner.Unit
+	ops           *state.UpdateUnitsOperation
+	providerId    string
+	addresses     []network.Address
+	serviceStatus status.StatusInfo
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -134,6 +136,11 @@ func (a *mockApplication) WatchUnits() state.StringsWatcher {
 	return a.unitsWatcher
 }
 
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
 func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig")
 	return application.ConfigAttributes{"foo": "bar"}, a.NextErr()
@@ -163,6 +170,12 @@ func (m *mockApplication) UpdateCloudService(providerId string, addreses []netwo
 	return nil
 }
 
+func (m *mockApplication) SetServiceStatus(statusInfo status.StatusInfo) error {
+	m.MethodCall(m, "SetServiceStatus", statusInfo)
+	m.serviceStatus = statusInfo
+	return m.NextErr()
+}
+
 var addOp = &state.AddUnitOperation{}
 
 func (m *mockApplication) AddOperation(props state.UnitUpdateProperties) *state.AddUnitOperation {
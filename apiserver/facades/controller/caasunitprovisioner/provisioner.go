@@ -40,6 +40,21 @@ type Facade struct {
 	clock                   clock.Clock
 }
 
+// FacadeV1 provides version 1 of the CAAS unit provisioner facade.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeV1 provides the signature required for version 1
+// facade registration.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	api, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{api}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -151,6 +166,54 @@ func (f *Facade) watchUnits(tagString string) (string, []string, error) {
 	return "", nil, watcher.EnsureErr(w)
 }
 
+// UnitsProviderIds returns the provider ids of the units of the
+// specified applications. Units which are not yet associated
+// with a cloud container are omitted.
+func (f *Facade) UnitsProviderIds(args params.Entities) (params.UnitProviderIdsResults, error) {
+	results := params.UnitProviderIdsResults{
+		Results: make([]params.UnitProviderIdsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		providerIds, err := f.unitsProviderIds(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].ProviderIds = providerIds
+	}
+	return results, nil
+}
+
+func (f *Facade) unitsProviderIds(tagString string) (map[string]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	providerIds := make(map[string]string)
+	for _, u := range units {
+		info, err := u.ContainerInfo()
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.ProviderId() == "" {
+			continue
+		}
+		providerIds[u.UnitTag().String()] = info.ProviderId()
+	}
+	return providerIds, nil
+}
+
 // WatchPodSpec starts a NotifyWatcher to watch changes to the
 // pod spec for specified units in this model.
 func (f *Facade) WatchPodSpec(args params.Entities) (params.NotifyWatchResults, error) {
@@ -187,6 +250,42 @@ func (f *Facade) watchPodSpec(model Model, tagString string) (string, error) {
 	return "", watcher.EnsureErr(w)
 }
 
+// WatchApplicationsConfig isn't on the V1 API.
+func (*FacadeV1) WatchApplicationsConfig(_, _ struct{}) {}
+
+// WatchApplicationsConfig starts a NotifyWatcher to watch changes
+// to the config of the specified applications in this model.
+func (f *Facade) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
 // ProvisioningInfo returns the provisioning info for specified applications in this model.
 func (f *Facade) ProvisioningInfo(args params.Entities) (params.KubernetesProvisioningInfoResults, error) {
 	model, err := f.state.Model()
@@ -1097,6 +1196,23 @@ func (a *Facade) UpdateApplicationsService(args params.UpdateApplicationServiceA
 		}
 		if err := app.UpdateCloudService(appUpdate.ProviderId, params.NetworkAddresses(appUpdate.Addresses...)); err != nil {
 			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if appUpdate.Status == "" {
+			continue
+		}
+		// The service status reports the progress of any rolling
+		// update of the application's units. It is kept apart from
+		// the application status, which belongs to the charm.
+		now := a.clock.Now()
+		err = app.SetServiceStatus(status.StatusInfo{
+			Status:  status.Status(appUpdate.Status),
+			Message: appUpdate.Info,
+			Data:    appUpdate.Data,
+			Since:   &now,
+		})
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
 		}
 	}
 	return result, nil
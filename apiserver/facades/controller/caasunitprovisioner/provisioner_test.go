@@ -39,6 +39,7 @@ type CAASProvisionerSuite struct {
 	applicationsChanges     chan []string
 	podSpecChanges          chan struct{}
 	unitsChanges            chan []string
+	configChanges           chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -51,11 +52,13 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.applicationsChanges = make(chan []string, 1)
 	s.podSpecChanges = make(chan struct{}, 1)
 	s.unitsChanges = make(chan []string, 1)
+	s.configChanges = make(chan struct{}, 1)
 	s.st = &mockState{
 		application: mockApplication{
-			tag:          names.NewApplicationTag("gitlab"),
-			life:         state.Alive,
-			unitsWatcher: statetesting.NewMockStringsWatcher(s.unitsChanges),
+			tag:           names.NewApplicationTag("gitlab"),
+			life:          state.Alive,
+			unitsWatcher:  statetesting.NewMockStringsWatcher(s.unitsChanges),
+			configWatcher: statetesting.NewMockNotifyWatcher(s.configChanges),
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		model: mockModel{
@@ -76,6 +79,7 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.unitsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.podSpecWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.configWatcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -133,6 +137,27 @@ func (s *CAASProvisionerSuite) TestWatchPodSpec(c *gc.C) {
 	c.Assert(resource, gc.Equals, s.st.model.podSpecWatcher)
 }
 
+func (s *CAASProvisionerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	s.configChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.configWatcher)
+}
+
 func (s *CAASProvisionerSuite) TestWatchUnits(c *gc.C) {
 	s.unitsChanges <- []string{"gitlab/0", "gitlab/1"}
 
@@ -155,6 +180,34 @@ func (s *CAASProvisionerSuite) TestWatchUnits(c *gc.C) {
 	c.Assert(resource, gc.Equals, s.st.application.unitsWatcher)
 }
 
+func (s *CAASProvisionerSuite) TestUnitsProviderIds(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", life: state.Alive, containerInfo: &mockContainerInfo{providerId: "uuid0"}},
+		&mockUnit{name: "gitlab/1", life: state.Alive},
+		&mockUnit{name: "gitlab/2", life: state.Dying, containerInfo: &mockContainerInfo{providerId: "uuid2"}},
+	}
+
+	results, err := s.facade.UnitsProviderIds(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UnitProviderIdsResults{
+		Results: []params.UnitProviderIdsResult{{
+			ProviderIds: map[string]string{
+				"unit-gitlab-0": "uuid0",
+				"unit-gitlab-2": "uuid2",
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
 func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", life: state.Alive},
@@ -501,4 +554,27 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsService(c *gc.C) {
 	})
 	c.Assert(s.st.application.providerId, gc.Equals, "id")
 	c.Assert(s.st.application.addresses, jc.DeepEquals, []network.Address{{Value: "10.0.0.1"}})
+	s.st.application.CheckNoCalls(c)
+}
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsServiceWithStatus(c *gc.C) {
+	results, err := s.facade.UpdateApplicationsService(params.UpdateApplicationServiceArgs{
+		Args: []params.UpdateApplicationServiceArg{{
+			ApplicationTag: "application-gitlab",
+			ProviderId:     "id",
+			Addresses:      []params.Address{{Value: "10.0.0.1"}},
+			Status:         "maintenance",
+			Info:           "rolling update: 1 of 3 units updated",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.st.application.CheckCallNames(c, "SetServiceStatus")
+	now := s.clock.Now()
+	c.Assert(s.st.application.serviceStatus, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: "rolling update: 1 of 3 units updated",
+		Since:   &now,
+	})
 }
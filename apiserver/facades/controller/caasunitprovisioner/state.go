@@ -65,6 +65,8 @@ type Application interface {
 	AddOperation(state.UnitUpdateProperties) *state.AddUnitOperation
 	UpdateUnits(*state.UpdateUnitsOperation) error
 	UpdateCloudService(providerId string, addreses []network.Address) error
+	SetServiceStatus(status.StatusInfo) error
+	WatchApplicationConfig() state.NotifyWatcher
 	DeviceConstraints() (map[string]state.DeviceConstraints, error)
 	Life() state.Life
 	Name() string
@@ -4,6 +4,7 @@
 package firewaller
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -51,6 +52,11 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -90,6 +96,17 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -532,3 +549,108 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// GetRelatedApplications returns, for each given application, the
+// names of the applications in the model that it is related to.
+// Applications related by a peer relation are related to themselves;
+// remote applications are not included.
+func (f *FirewallerAPIV6) GetRelatedApplications(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result, err = relatedApplications(application)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ApplicationLoadBalancerAddresses returns, for each given application,
+// the recorded addresses of the provider load balancers in front of its
+// exposed endpoints, keyed by endpoint port range.
+func (f *FirewallerAPIV6) ApplicationLoadBalancerAddresses(args params.Entities) (params.ApplicationLoadBalancerAddressesResults, error) {
+	result := params.ApplicationLoadBalancerAddressesResults{
+		Results: make([]params.ApplicationLoadBalancerAddressesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ApplicationLoadBalancerAddressesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Addresses = application.LoadBalancerAddresses()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetApplicationLoadBalancerAddresses records, for each given
+// application, the addresses of the provider load balancers in front of
+// its exposed endpoints, keyed by endpoint port range. No addresses
+// records that the application has no load balancers.
+func (f *FirewallerAPIV6) SetApplicationLoadBalancerAddresses(args params.SetApplicationsLoadBalancerAddresses) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Applications)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Applications {
+		tag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			err = application.SetLoadBalancerAddresses(arg.Addresses)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func relatedApplications(application *state.Application) ([]string, error) {
+	relations, err := application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		crossModel, err := rel.IsCrossModel()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if crossModel {
+			continue
+		}
+		eps, err := rel.RelatedEndpoints(application.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range eps {
+			related.Add(ep.ApplicationName)
+		}
+	}
+	return related.SortedValues(), nil
+}
@@ -270,3 +270,77 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetRelatedApplications(c *gc.C) {
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: names.NewApplicationTag("mysql").String()},
+	}})
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+
+	result, err := apiv6.GetRelatedApplications(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"mysql"}},
+			{Result: []string{"wordpress"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestSetApplicationLoadBalancerAddresses(c *gc.C) {
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+
+	result, err := apiv6.SetApplicationLoadBalancerAddresses(params.SetApplicationsLoadBalancerAddresses{
+		Applications: []params.ApplicationLoadBalancerAddresses{
+			{Tag: s.application.Tag().String(), Addresses: map[string][]string{"80/tcp": {"10.0.0.1"}}},
+			{Tag: s.units[0].Tag().String(), Addresses: map[string][]string{"80/tcp": {"10.0.0.2"}}},
+			{Tag: "application-bar", Addresses: map[string][]string{"80/tcp": {"10.0.0.3"}}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: nil},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+		},
+	})
+
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.LoadBalancerAddresses(), jc.DeepEquals, map[string][]string{"80/tcp": {"10.0.0.1"}})
+
+	addresses, err := apiv6.ApplicationLoadBalancerAddresses(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.application.Tag().String()},
+			{Tag: s.units[0].Tag().String()},
+			{Tag: "application-bar"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, params.ApplicationLoadBalancerAddressesResults{
+		Results: []params.ApplicationLoadBalancerAddressesResult{
+			{Addresses: map[string][]string{"80/tcp": {"10.0.0.1"}}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+		},
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 
@@ -17,6 +18,8 @@ import (
 	"github.com/juju/juju/status"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.instancepoller")
+
 // InstancePollerAPI provides access to the InstancePoller API facade.
 type InstancePollerAPI struct {
 	*common.LifeGetter
@@ -194,7 +197,9 @@ func (a *InstancePollerAPI) InstanceStatus(args params.Entities) (params.StatusR
 }
 
 // SetInstanceStatus updates the instance status for each given
-// entity. Only machine tags are accepted.
+// entity. Only machine tags are accepted. Alive machines whose
+// instances have been terminated by the provider are marked down,
+// and replaced if the model is configured to do so.
 func (a *InstancePollerAPI) SetInstanceStatus(args params.SetStatus) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -220,12 +225,43 @@ func (a *InstancePollerAPI) SetInstanceStatus(args params.SetStatus) (params.Err
 					err = machine.SetStatus(s)
 				}
 			}
+			if status.Status(arg.Status) == status.Terminated && err == nil {
+				err = a.instanceTerminated(machine, arg.Info)
+			}
 		}
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result, nil
 }
 
+// instanceTerminated marks the given machine, whose instance has been
+// terminated by the provider, as down, and replaces it with a new
+// machine if the model is configured to do so.
+func (a *InstancePollerAPI) instanceTerminated(machine StateMachine, message string) error {
+	if machine.Life() != state.Alive {
+		return nil
+	}
+	if message == "" {
+		message = "instance terminated by the provider"
+	}
+	if err := machine.SetDown(message); err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := a.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !cfg.ReplaceTerminatedMachines() {
+		return nil
+	}
+	replacement, err := machine.Replace()
+	if err != nil {
+		return errors.Annotatef(err, "cannot replace machine %s", machine.Id())
+	}
+	logger.Infof("replaced machine %s, whose instance was terminated, with machine %s", machine.Id(), replacement.Id())
+	return nil
+}
+
 // AreManuallyProvisioned returns whether each given entity is
 // manually provisioned or not. Only machine tags are accepted.
 func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params.BoolResults, error) {
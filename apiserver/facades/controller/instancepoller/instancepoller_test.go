@@ -657,6 +657,53 @@ func (s *InstancePollerSuite) TestSetInstanceStatusFailure(c *gc.C) {
 	s.st.CheckFindEntityCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestSetInstanceStatusTerminated(c *gc.C) {
+	s.st.SetConfig(c, coretesting.ModelConfig(c))
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceStatus: statusInfo("running"), life: state.Alive})
+	s.st.SetMachineInfo(c, machineInfo{id: "2", instanceStatus: statusInfo("running"), life: state.Dying})
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "terminated", Info: "preempted"},
+			{Tag: "machine-2", Status: "terminated"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {}},
+	})
+
+	now := s.clock.Now()
+	s.st.CheckCallNames(c,
+		"FindEntity", "SetInstanceStatus", "Life", "SetDown", "ModelConfig",
+		"FindEntity", "SetInstanceStatus", "Life",
+	)
+	s.st.CheckCall(c, 1, "SetInstanceStatus", status.StatusInfo{Status: status.Terminated, Message: "preempted", Since: &now})
+	s.st.CheckCall(c, 3, "SetDown", "preempted")
+}
+
+func (s *InstancePollerSuite) TestSetInstanceStatusTerminatedReplace(c *gc.C) {
+	modelConfig, err := coretesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-terminated-machines": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceStatus: statusInfo("running"), life: state.Alive})
+
+	result, err := s.api.SetInstanceStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "terminated"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	s.st.CheckCallNames(c, "FindEntity", "SetInstanceStatus", "Life", "SetDown", "ModelConfig", "Replace")
+	s.st.CheckCall(c, 3, "SetDown", "instance terminated by the provider")
+}
+
 func (s *InstancePollerSuite) TestAreManuallyProvisionedSuccess(c *gc.C) {
 	s.st.SetMachineInfo(c, machineInfo{id: "1", isManual: true})
 	s.st.SetMachineInfo(c, machineInfo{id: "2", isManual: false})
@@ -289,6 +289,31 @@ func (m *mockMachine) SetInstanceStatus(instanceStatus status.StatusInfo) error
 	return nil
 }
 
+// SetDown implements StateMachine.
+func (m *mockMachine) SetDown(message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "SetDown", message)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.status = status.StatusInfo{Status: status.Down, Message: message}
+	return nil
+}
+
+// Replace implements StateMachine.
+func (m *mockMachine) Replace() (*state.Machine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Replace")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &state.Machine{}, nil
+}
+
 // Life implements StateMachine.
 func (m *mockMachine) Life() state.Life {
 	m.mu.Lock()
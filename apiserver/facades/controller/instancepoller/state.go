@@ -21,6 +21,8 @@ type StateMachine interface {
 	InstanceStatus() (status.StatusInfo, error)
 	SetInstanceStatus(status.StatusInfo) error
 	SetStatus(status.StatusInfo) error
+	SetDown(string) error
+	Replace() (*state.Machine, error)
 	String() string
 	Refresh() error
 	Life() state.Life
@@ -0,0 +1,120 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the resource tagger facade needs from
+// state.
+type Backend interface {
+	// ModelConfig returns the model's config.
+	ModelConfig() (*config.Config, error)
+
+	// WatchForModelConfigChanges returns a NotifyWatcher that
+	// triggers whenever the model config changes.
+	WatchForModelConfigChanges() state.NotifyWatcher
+
+	// WatchApplicationsConfig returns a NotifyWatcher that triggers
+	// whenever the application config of any application changes.
+	WatchApplicationsConfig() state.NotifyWatcher
+
+	// AllMachines returns all of the model's machines.
+	AllMachines() ([]Machine, error)
+
+	// AllApplications returns all of the model's applications.
+	AllApplications() ([]Application, error)
+
+	// Application returns the named application, whose config may
+	// specify resource tags.
+	Application(name string) (common.ApplicationConfigGetter, error)
+
+	// AllVolumes returns all of the model's volumes.
+	AllVolumes() ([]state.Volume, error)
+
+	// StorageInstance returns the storage instance with the given
+	// tag, whose owner's resource tags apply to its volume.
+	StorageInstance(names.StorageTag) (state.StorageInstance, error)
+}
+
+// Machine defines the methods we need from state.Machine.
+type Machine interface {
+	// InstanceId returns the provider ID of the machine's instance.
+	InstanceId() (instance.Id, error)
+
+	// Principals returns the names of the principal units deployed
+	// to the machine.
+	Principals() []string
+
+	// IsContainer returns whether the machine is a container.
+	IsContainer() bool
+}
+
+// Application defines the methods we need from state.Application.
+type Application interface {
+	common.ApplicationConfigGetter
+
+	// Name returns the application's name.
+	Name() string
+}
+
+// storageBackend defines the methods we need from the state storage
+// backend.
+type storageBackend interface {
+	AllVolumes() ([]state.Volume, error)
+	StorageInstance(names.StorageTag) (state.StorageInstance, error)
+}
+
+type backendShim struct {
+	*state.State
+	*state.Model
+	sb storageBackend
+}
+
+// AllMachines implements Backend.
+func (b *backendShim) AllMachines() ([]Machine, error) {
+	machines, err := b.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+// AllApplications implements Backend.
+func (b *backendShim) AllApplications() ([]Application, error) {
+	applications, err := b.State.AllApplications()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Application, len(applications))
+	for i, app := range applications {
+		result[i] = app
+	}
+	return result, nil
+}
+
+// Application implements Backend.
+func (b *backendShim) Application(name string) (common.ApplicationConfigGetter, error) {
+	return b.State.Application(name)
+}
+
+// AllVolumes implements Backend.
+func (b *backendShim) AllVolumes() ([]state.Volume, error) {
+	return b.sb.AllVolumes()
+}
+
+// StorageInstance implements Backend.
+func (b *backendShim) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
+	return b.sb.StorageInstance(tag)
+}
@@ -0,0 +1,190 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/resourcetagger"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+type mockBackend struct {
+	testing.Stub
+
+	// mu guards config and applications, which are read by the
+	// resource tags watcher's goroutine.
+	mu               sync.Mutex
+	config           *config.Config
+	machines         []resourcetagger.Machine
+	applications     map[string]application.ConfigAttributes
+	volumes          []state.Volume
+	storageInstances map[names.StorageTag]state.StorageInstance
+
+	modelConfigWatcher *apiservertesting.FakeNotifyWatcher
+	appsConfigWatcher  *apiservertesting.FakeNotifyWatcher
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.config, b.NextErr()
+}
+
+func (b *mockBackend) setModelConfig(cfg *config.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config = cfg
+}
+
+func (b *mockBackend) setApplicationConfig(name string, attrs application.ConfigAttributes) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.applications[name] = attrs
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchForModelConfigChanges")
+	return b.modelConfigWatcher
+}
+
+func (b *mockBackend) WatchApplicationsConfig() state.NotifyWatcher {
+	b.MethodCall(b, "WatchApplicationsConfig")
+	return b.appsConfigWatcher
+}
+
+func (b *mockBackend) AllMachines() ([]resourcetagger.Machine, error) {
+	b.MethodCall(b, "AllMachines")
+	return b.machines, b.NextErr()
+}
+
+func (b *mockBackend) AllApplications() ([]resourcetagger.Application, error) {
+	b.MethodCall(b, "AllApplications")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	appNames := make([]string, 0, len(b.applications))
+	for name := range b.applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+	result := make([]resourcetagger.Application, len(appNames))
+	for i, name := range appNames {
+		result[i] = &mockApplication{name: name, config: b.applications[name]}
+	}
+	return result, b.NextErr()
+}
+
+func (b *mockBackend) Application(name string) (common.ApplicationConfigGetter, error) {
+	b.MethodCall(b, "Application", name)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	attrs, ok := b.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return &mockApplication{name: name, config: attrs}, nil
+}
+
+func (b *mockBackend) AllVolumes() ([]state.Volume, error) {
+	b.MethodCall(b, "AllVolumes")
+	return b.volumes, b.NextErr()
+}
+
+func (b *mockBackend) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
+	b.MethodCall(b, "StorageInstance", tag)
+	storageInstance, ok := b.storageInstances[tag]
+	if !ok {
+		return nil, errors.NotFoundf("storage instance %q", tag.Id())
+	}
+	return storageInstance, nil
+}
+
+type mockApplication struct {
+	name   string
+	config application.ConfigAttributes
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
+	return a.config, nil
+}
+
+type mockMachine struct {
+	instanceId  instance.Id
+	principals  []string
+	isContainer bool
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine")
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) Principals() []string {
+	return m.principals
+}
+
+func (m *mockMachine) IsContainer() bool {
+	return m.isContainer
+}
+
+type mockVolume struct {
+	state.Volume
+	tag     names.VolumeTag
+	info    *state.VolumeInfo
+	storage names.StorageTag
+}
+
+func (v *mockVolume) Tag() names.Tag {
+	return v.tag
+}
+
+func (v *mockVolume) Info() (state.VolumeInfo, error) {
+	if v.info == nil {
+		return state.VolumeInfo{}, errors.NotProvisionedf("volume %v", v.tag.Id())
+	}
+	return *v.info, nil
+}
+
+func (v *mockVolume) StorageInstance() (names.StorageTag, error) {
+	if v.storage == (names.StorageTag{}) {
+		return names.StorageTag{}, errors.NotAssignedf("volume %v", v.tag.Id())
+	}
+	return v.storage, nil
+}
+
+type mockStorageInstance struct {
+	state.StorageInstance
+	owner names.Tag
+}
+
+func (s *mockStorageInstance) Owner() (names.Tag, bool) {
+	return s.owner, s.owner != nil
+}
+
+type mockPoolManager struct {
+	poolmanager.PoolManager
+}
+
+func (pm *mockPoolManager) Get(name string) (*storage.Config, error) {
+	return nil, errors.NotFoundf("pool %q", name)
+}
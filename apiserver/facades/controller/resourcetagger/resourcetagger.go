@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// API implements the API facade used by the resource tagger, which
+// updates the tags of a model's provider resources when the model or
+// application resource tags change.
+type API struct {
+	backend     Backend
+	resources   facade.Resources
+	registry    storage.ProviderRegistry
+	poolManager poolmanager.PoolManager
+}
+
+// NewAPI returns a new resource tagger API facade.
+func NewAPI(
+	backend Backend,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	registry storage.ProviderRegistry,
+	poolManager poolmanager.PoolManager,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return &API{
+		backend:     backend,
+		resources:   resources,
+		registry:    registry,
+		poolManager: poolManager,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*API, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sb, err := state.NewStorageBackend(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+	return NewAPI(&backendShim{State: st, Model: m, sb: sb}, res, auth, registry, pm)
+}
+
+// WatchResourceTags returns a NotifyWatcher that triggers whenever
+// the resource tags in the model config, or in the config of any
+// application, change.
+func (api *API) WatchResourceTags() (params.NotifyWatchResult, error) {
+	watch, err := newResourceTagsWatcher(api.backend)
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// ResourceTags returns the user-specified tags to set on the model's
+// shared provider resources, and on each of its provisioned instances
+// and volumes.
+func (api *API) ResourceTags() (params.ResourceTagsResult, error) {
+	result, err := api.resourceTags()
+	if err != nil {
+		return params.ResourceTagsResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ResourceTagsResult{Result: result}, nil
+}
+
+func (api *API) resourceTags() (*params.ResourceTags, error) {
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelTags, _ := cfg.ResourceTags()
+	result := &params.ResourceTags{ModelTags: modelTags}
+
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.IsContainer() {
+			// Containers are not provider resources, and
+			// cannot be tagged.
+			continue
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitNames := append([]string(nil), m.Principals()...)
+		sort.Strings(unitNames)
+		instanceTags, err := common.UnitResourceTags(cfg, unitNames, api.backend.Application)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting resource tags of instance %q", instId)
+		}
+		if len(unitNames) > 0 {
+			instanceTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
+		}
+		result.Instances = append(result.Instances, params.InstanceResourceTags{
+			InstanceId: string(instId),
+			Tags:       instanceTags,
+		})
+	}
+
+	volumes, err := api.backend.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, api.poolManager, api.registry)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting storage provider of volume %q", names.ReadableString(v.Tag()))
+		}
+		storageInstance, err := storagecommon.MaybeAssignedStorageInstance(v.StorageInstance, api.backend.StorageInstance)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeTags, err := common.StorageResourceTags(cfg, storageInstance, api.backend.Application)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting resource tags of volume %q", names.ReadableString(v.Tag()))
+		}
+		result.Volumes = append(result.Volumes, params.VolumeResourceTags{
+			VolumeId: info.VolumeId,
+			Provider: string(providerType),
+			Tags:     volumeTags,
+		})
+	}
+	return result, nil
+}
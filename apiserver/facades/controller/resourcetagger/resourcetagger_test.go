@@ -0,0 +1,172 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/resourcetagger"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type resourceTaggerSuite struct {
+	testing.IsolationSuite
+
+	backend   *mockBackend
+	resources *common.Resources
+	api       *resourcetagger.API
+}
+
+var _ = gc.Suite(&resourceTaggerSuite{})
+
+func (s *resourceTaggerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.backend = &mockBackend{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"resource-tags": "team=ops model={{model}}",
+		}),
+		machines: []resourcetagger.Machine{
+			&mockMachine{instanceId: "inst-0", principals: []string{"wordpress/1", "mysql/0"}},
+			&mockMachine{instanceId: "inst-1"},
+			&mockMachine{principals: []string{"mysql/1"}},
+			&mockMachine{instanceId: "juju-c0ffee-0-lxd-0", principals: []string{"mysql/2"}, isContainer: true},
+		},
+		applications: map[string]application.ConfigAttributes{
+			"mysql":     {"resource-tags": "team=dba owner={{application}}"},
+			"wordpress": {},
+		},
+		volumes: []state.Volume{
+			&mockVolume{
+				tag:     names.NewVolumeTag("0"),
+				info:    &state.VolumeInfo{VolumeId: "vol-0", Pool: "ebs"},
+				storage: names.NewStorageTag("data/0"),
+			},
+			&mockVolume{
+				tag:  names.NewVolumeTag("1"),
+				info: &state.VolumeInfo{VolumeId: "vol-1", Pool: "ebs"},
+			},
+			&mockVolume{tag: names.NewVolumeTag("2")},
+		},
+		storageInstances: map[names.StorageTag]state.StorageInstance{
+			names.NewStorageTag("data/0"): &mockStorageInstance{owner: names.NewUnitTag("mysql/0")},
+		},
+		modelConfigWatcher: apiservertesting.NewFakeNotifyWatcher(),
+		appsConfigWatcher:  apiservertesting.NewFakeNotifyWatcher(),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	registry := storage.StaticProviderRegistry{
+		Providers: map[storage.ProviderType]storage.Provider{
+			"ebs": &dummy.StorageProvider{},
+		},
+	}
+	api, err := resourcetagger.NewAPI(
+		s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: true},
+		registry, &mockPoolManager{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *resourceTaggerSuite) TestRequiresController(c *gc.C) {
+	_, err := resourcetagger.NewAPI(
+		s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: false},
+		storage.StaticProviderRegistry{}, &mockPoolManager{},
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTags(c *gc.C) {
+	result, err := s.api.WatchResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	s.backend.CheckCallNames(c, "WatchForModelConfigChanges", "WatchApplicationsConfig", "ModelConfig", "AllApplications")
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTagsFiltersChanges(c *gc.C) {
+	result, err := s.api.WatchResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	w, ok := s.resources.Get(result.NotifyWatcherId).(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+	wc := statetesting.NewNotifyWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertNoChange()
+
+	// Changes to config other than the resource tags are ignored.
+	cfg, err := s.backend.config.Apply(map[string]interface{}{"logging-config": "<root>=DEBUG"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.setModelConfig(cfg)
+	s.backend.modelConfigWatcher.C <- struct{}{}
+	s.backend.setApplicationConfig("wordpress", application.ConfigAttributes{"trust": true})
+	s.backend.appsConfigWatcher.C <- struct{}{}
+	wc.AssertNoChange()
+
+	cfg, err = cfg.Apply(map[string]interface{}{"resource-tags": "team=dev"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.setModelConfig(cfg)
+	s.backend.modelConfigWatcher.C <- struct{}{}
+	wc.AssertOneChange()
+
+	s.backend.setApplicationConfig("wordpress", application.ConfigAttributes{"resource-tags": "team=web"})
+	s.backend.appsConfigWatcher.C <- struct{}{}
+	wc.AssertOneChange()
+}
+
+func (s *resourceTaggerSuite) TestResourceTags(c *gc.C) {
+	modelName := s.backend.config.Name()
+	result, err := s.api.ResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ResourceTagsResult{
+		Result: &params.ResourceTags{
+			ModelTags: map[string]string{"team": "ops", "model": modelName},
+			Instances: []params.InstanceResourceTags{{
+				InstanceId: "inst-0",
+				Tags: map[string]string{
+					"team":                "dba",
+					"model":               modelName,
+					"owner":               "mysql",
+					"juju-units-deployed": "mysql/0 wordpress/1",
+				},
+			}, {
+				InstanceId: "inst-1",
+				Tags:       map[string]string{"team": "ops", "model": modelName},
+			}},
+			Volumes: []params.VolumeResourceTags{{
+				VolumeId: "vol-0",
+				Provider: "ebs",
+				Tags:     map[string]string{"team": "dba", "model": modelName, "owner": "mysql"},
+			}, {
+				VolumeId: "vol-1",
+				Provider: "ebs",
+				Tags:     map[string]string{"team": "ops", "model": modelName},
+			}},
+		},
+	})
+}
+
+func (s *resourceTaggerSuite) TestResourceTagsError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	result, err := s.api.ResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+type nopSyncStarter struct{}
+
+func (nopSyncStarter) StartSync() {}
@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// resourceTagSettings holds the resource tags specified in the model
+// config and in the config of each application, with template
+// variables unexpanded.
+type resourceTagSettings struct {
+	model        map[string]string
+	applications map[string]map[string]string
+}
+
+// resourceTagsWatcher filters the model config and application config
+// changes down to those that change the model's resource tags.
+type resourceTagsWatcher struct {
+	catacomb catacomb.Catacomb
+	backend  Backend
+	out      chan struct{}
+}
+
+// newResourceTagsWatcher returns a NotifyWatcher that triggers
+// whenever the resource tags in the model config, or in the config
+// of any application, change. Changes to other config are ignored.
+func newResourceTagsWatcher(backend Backend) (state.NotifyWatcher, error) {
+	w := &resourceTagsWatcher{
+		backend: backend,
+		out:     make(chan struct{}),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+func (w *resourceTagsWatcher) loop() error {
+	defer close(w.out)
+	configw := common.NewMultiNotifyWatcher(
+		w.backend.WatchForModelConfigChanges(),
+		w.backend.WatchApplicationsConfig(),
+	)
+	if err := w.catacomb.Add(configw); err != nil {
+		return errors.Trace(err)
+	}
+	var (
+		out  chan struct{}
+		last *resourceTagSettings
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case out <- struct{}{}:
+			out = nil
+		case _, ok := <-configw.Changes():
+			if !ok {
+				return w.catacomb.ErrDying()
+			}
+			settings, err := w.resourceTagSettings()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if last != nil && reflect.DeepEqual(settings, *last) {
+				continue
+			}
+			last = &settings
+			out = w.out
+		}
+	}
+}
+
+func (w *resourceTagsWatcher) resourceTagSettings() (resourceTagSettings, error) {
+	cfg, err := w.backend.ModelConfig()
+	if err != nil {
+		return resourceTagSettings{}, errors.Trace(err)
+	}
+	modelTags, _ := cfg.ResourceTagTemplates()
+	applications, err := w.backend.AllApplications()
+	if err != nil {
+		return resourceTagSettings{}, errors.Trace(err)
+	}
+	settings := resourceTagSettings{
+		model:        modelTags,
+		applications: make(map[string]map[string]string),
+	}
+	for _, app := range applications {
+		appTags, err := common.ApplicationResourceTags(app)
+		if err != nil {
+			return resourceTagSettings{}, errors.Annotatef(err, "getting resource tags of application %q", app.Name())
+		}
+		if len(appTags) > 0 {
+			settings.applications[app.Name()] = appTags
+		}
+	}
+	return settings, nil
+}
+
+// Changes implements state.NotifyWatcher.
+func (w *resourceTagsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+// Err implements state.NotifyWatcher.
+func (w *resourceTagsWatcher) Err() error {
+	return w.catacomb.Err()
+}
+
+// Kill implements state.NotifyWatcher.
+func (w *resourceTagsWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Stop implements state.NotifyWatcher.
+func (w *resourceTagsWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Wait implements state.NotifyWatcher.
+func (w *resourceTagsWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
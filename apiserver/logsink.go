@@ -16,6 +16,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/httpcontext"
 	"github.com/juju/juju/apiserver/logsink"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
@@ -35,6 +36,7 @@ type agentLoggingStrategy struct {
 	releaser   func()
 	version    version.Number
 	entity     names.Tag
+	controller bool
 	filePrefix string
 }
 
@@ -143,6 +145,9 @@ func (s *agentLoggingStrategy) init(ctxt httpContext, req *http.Request) error {
 	}
 	s.version = ver
 	s.entity = entity.Tag()
+	if authInfo, ok := httpcontext.RequestAuthInfo(req); ok {
+		s.controller = authInfo.Controller
+	}
 	s.filePrefix = st.ModelUUID() + ":"
 	s.dblogger = s.dbloggers.get(st.State)
 	s.releaser = func() {
@@ -166,9 +171,10 @@ func (s *agentLoggingStrategy) Close() error {
 // WriteLog is part of the logsink.LogWriteCloser interface.
 func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 	level, _ := loggo.ParseLevel(m.Level)
+	entity := s.recordEntity(m)
 	dbErr := errors.Annotate(s.dblogger.Log([]state.LogRecord{{
 		Time:     m.Time,
-		Entity:   s.entity,
+		Entity:   entity,
 		Version:  s.version,
 		Module:   m.Module,
 		Location: m.Location,
@@ -176,7 +182,7 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 		Message:  m.Message,
 	}}), "logging to DB failed")
 
-	m.Entity = s.entity.String()
+	m.Entity = entity.String()
 	fileErr := errors.Annotate(
 		logToFile(s.fileLogger, s.filePrefix, m),
 		"logging to logsink.log failed",
@@ -190,6 +196,21 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 	return err
 }
 
+// recordEntity returns the entity to which the log record is attributed.
+// Records are attributed to the authenticated agent, except that controller
+// agents may attribute records to units, as when forwarding the output of
+// CAAS workloads.
+func (s *agentLoggingStrategy) recordEntity(m params.LogRecord) names.Tag {
+	if !s.controller || m.Entity == "" {
+		return s.entity
+	}
+	tag, err := names.ParseUnitTag(m.Entity)
+	if err != nil {
+		return s.entity
+	}
+	return tag
+}
+
 // logToFile writes a single log message to the logsink log file.
 func logToFile(writer io.Writer, prefix string, m params.LogRecord) error {
 	_, err := writer.Write([]byte(strings.Join([]string{
@@ -177,6 +177,57 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	}
 }
 
+func (s *logsinkSuite) TestControllerLoggingForUnit(c *gc.C) {
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: s.nonce,
+		Jobs:  []state.MachineJob{state.JobManageModel},
+	})
+	header := utils.BasicAuthHeader(m.Tag().String(), password)
+	header.Add(params.MachineNonceHeader, s.nonce)
+	conn, _, err := dialWebsocketFromURL(c, s.url, header)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	s.assertLoggedEntity(c, conn, "unit-gitlab-0", "unit-gitlab-0")
+}
+
+func (s *logsinkSuite) TestAgentLoggingForUnitIgnored(c *gc.C) {
+	conn := s.dialWebsocket(c)
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	s.assertLoggedEntity(c, conn, "unit-gitlab-0", s.machineTag.String())
+}
+
+func (s *logsinkSuite) assertLoggedEntity(c *gc.C, conn *websocket.Conn, entity, expected string) {
+	err := conn.WriteJSON(&params.LogRecord{
+		Time:     time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:   "workload.gitlab",
+		Location: "gitlab",
+		Level:    loggo.INFO.String(),
+		Message:  "listening on :80",
+		Entity:   entity,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	logsColl := s.State.MongoSession().DB("logs").C("logs." + s.State.ModelUUID())
+	var docs []bson.M
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		err := logsColl.Find(nil).All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		if len(docs) > 0 {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for log writes")
+		}
+	}
+	c.Assert(docs, gc.HasLen, 1)
+	c.Assert(docs[0]["n"], gc.Equals, expected)
+	c.Assert(docs[0]["x"], gc.Equals, "listening on :80")
+}
+
 func (s *logsinkSuite) TestReceiveErrorBreaksConn(c *gc.C) {
 	conn := s.dialWebsocket(c)
 	defer conn.Close()
@@ -3,7 +3,10 @@
 
 package params
 
-import "github.com/juju/juju/constraints"
+import (
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
 
 // CloudInstanceTypesConstraints contains a slice of CloudInstanceTypesConstraint.
 type CloudInstanceTypesConstraints struct {
@@ -72,3 +75,58 @@ type InstanceType struct {
 	Deprecated   bool     `json:"deprecated,omitempty"`
 	Cost         int      `json:"cost,omitempty"`
 }
+
+// PreviewInstancesArgs holds the machines for which to preview the
+// instances that would be started.
+type PreviewInstancesArgs struct {
+	Args []PreviewInstanceArg `json:"args"`
+}
+
+// PreviewInstanceArg describes a machine whose instance is to be previewed.
+type PreviewInstanceArg struct {
+	// Series is the series of the machine. If empty, the model's
+	// default series is used.
+	Series string `json:"series,omitempty"`
+
+	// Constraints holds the machine or application constraints. They
+	// are merged with the model constraints before being resolved.
+	Constraints constraints.Value `json:"constraints"`
+
+	// Placement, if specified, holds a provider placement directive
+	// for the machine.
+	Placement *instance.Placement `json:"placement,omitempty"`
+}
+
+// PreviewInstanceResults holds the results of previewing instances.
+type PreviewInstanceResults struct {
+	Results []PreviewInstanceResult `json:"results"`
+}
+
+// PreviewInstanceResult describes the instance the provider would start
+// for a machine, without starting it.
+type PreviewInstanceResult struct {
+	// Series is the series the instance would run.
+	Series string `json:"series,omitempty"`
+
+	// Constraints holds the effective constraints, after merging
+	// with the model constraints.
+	Constraints constraints.Value `json:"constraints"`
+
+	// Unsupported holds the names of constraints the provider
+	// would ignore.
+	Unsupported []string `json:"unsupported,omitempty"`
+
+	// InstanceType is the instance type that would be chosen, if the
+	// provider exposes instance types.
+	InstanceType *InstanceType `json:"instance-type,omitempty"`
+
+	// ImageId is the ID of the image that would be used, if the
+	// provider uses image metadata.
+	ImageId string `json:"image-id,omitempty"`
+
+	// AvailabilityZone is the zone the instance would be started in,
+	// if the provider supports availability zones.
+	AvailabilityZone string `json:"availability-zone,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
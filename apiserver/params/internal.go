@@ -764,6 +764,19 @@ type SingularClaims struct {
 	Claims []SingularClaim `json:"claims"`
 }
 
+// OperatorLeaseClaim represents a request by one of an application's
+// operator instances for the exclusive right to run its charm.
+type OperatorLeaseClaim struct {
+	ApplicationTag string        `json:"application-tag"`
+	Holder         string        `json:"holder"`
+	Duration       time.Duration `json:"duration"`
+}
+
+// OperatorLeaseClaims holds any number of OperatorLeaseClaim~s.
+type OperatorLeaseClaims struct {
+	Claims []OperatorLeaseClaim `json:"claims"`
+}
+
 // GUIArchiveVersion holds information on a specific GUI archive version.
 type GUIArchiveVersion struct {
 	// Version holds the Juju GUI version number.
@@ -814,6 +827,7 @@ type ResourceUploadResult struct {
 type UnitRefreshResult struct {
 	Life     Life
 	Resolved ResolvedMode
+	Paused   bool
 	Series   string
 	Error    *Error
 }
@@ -861,3 +875,74 @@ type GoalState struct {
 	Units     UnitsGoalState            `json:"units"`
 	Relations map[string]UnitsGoalState `json:"relations"`
 }
+
+// CharmStateResults holds the results of a CharmState API call.
+type CharmStateResults struct {
+	Results []CharmStateResult `json:"results"`
+}
+
+// CharmStateResult holds the charm state of a unit, or an error.
+type CharmStateResult struct {
+	Result map[string]string `json:"result"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// SetCharmStateArgs holds the arguments for setting the charm
+// state of a set of units.
+type SetCharmStateArgs struct {
+	Args []SetCharmStateArg `json:"args"`
+}
+
+// SetCharmStateArg holds the charm state to set for a unit,
+// replacing any state set previously.
+type SetCharmStateArg struct {
+	Tag   string            `json:"tag"`
+	State map[string]string `json:"state"`
+}
+
+// RecordHookExecutionArgs holds the hook executions to record for a
+// set of units.
+type RecordHookExecutionArgs struct {
+	Args []RecordHookExecutionArg `json:"args"`
+}
+
+// RecordHookExecutionArg describes a single execution of a charm hook
+// by a unit.
+type RecordHookExecutionArg struct {
+	Tag        string        `json:"tag"`
+	Hook       string        `json:"hook"`
+	RelationId *int          `json:"relation-id,omitempty"`
+	Started    time.Time     `json:"started"`
+	Duration   time.Duration `json:"duration"`
+	ExitCode   int           `json:"exit-code"`
+}
+
+// InstanceResourceTags holds the tags to set on a provider instance.
+type InstanceResourceTags struct {
+	InstanceId string            `json:"instance-id"`
+	Tags       map[string]string `json:"tags"`
+}
+
+// VolumeResourceTags holds the tags to set on a provider volume, and
+// the type of the storage provider managing the volume.
+type VolumeResourceTags struct {
+	VolumeId string            `json:"volume-id"`
+	Provider string            `json:"provider"`
+	Tags     map[string]string `json:"tags"`
+}
+
+// ResourceTags holds the tags to set on the provider resources of
+// a model: those shared by the model, such as security groups, and
+// its provisioned instances and volumes.
+type ResourceTags struct {
+	ModelTags map[string]string      `json:"model-tags"`
+	Instances []InstanceResourceTags `json:"instances"`
+	Volumes   []VolumeResourceTags   `json:"volumes"`
+}
+
+// ResourceTagsResult holds the tags to set on the provider resources
+// of a model, or an error.
+type ResourceTagsResult struct {
+	Result *ResourceTags `json:"result,omitempty"`
+	Error  *Error        `json:"error,omitempty"`
+}
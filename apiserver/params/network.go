@@ -415,6 +415,34 @@ type SetMachinesAddresses struct {
 	MachineAddresses []MachineAddresses `json:"machine-addresses"`
 }
 
+// ApplicationLoadBalancerAddresses holds an application tag and the
+// addresses of the provider load balancers in front of its exposed
+// endpoints, keyed by endpoint port range.
+type ApplicationLoadBalancerAddresses struct {
+	Tag       string              `json:"tag"`
+	Addresses map[string][]string `json:"addresses"`
+}
+
+// ApplicationLoadBalancerAddressesResult holds the addresses of the
+// provider load balancers in front of an application's exposed
+// endpoints, keyed by endpoint port range, or an error.
+type ApplicationLoadBalancerAddressesResult struct {
+	Addresses map[string][]string `json:"addresses,omitempty"`
+	Error     *Error              `json:"error,omitempty"`
+}
+
+// ApplicationLoadBalancerAddressesResults holds the results of an API
+// call to get the load balancer addresses of applications.
+type ApplicationLoadBalancerAddressesResults struct {
+	Results []ApplicationLoadBalancerAddressesResult `json:"results"`
+}
+
+// SetApplicationsLoadBalancerAddresses holds the parameters for making
+// an API call to record the load balancer addresses of applications.
+type SetApplicationsLoadBalancerAddresses struct {
+	Applications []ApplicationLoadBalancerAddresses `json:"applications"`
+}
+
 // SetMachineNetworkConfig holds the parameters for making an API call to update
 // machine network config.
 type SetMachineNetworkConfig struct {
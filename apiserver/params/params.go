@@ -120,9 +120,12 @@ type AddRelation struct {
 }
 
 // AddRelationResults holds the results of a AddRelation call. The Endpoints
-// field maps application names to the involved endpoints.
+// field maps application names to the involved endpoints. Warnings
+// describes any incompatibility between the relation data schemas
+// declared by the endpoints' charms.
 type AddRelationResults struct {
 	Endpoints map[string]CharmRelation `json:"endpoints"`
+	Warnings  []string                 `json:"warnings,omitempty"`
 }
 
 // DestroyRelation holds the parameters for making the DestroyRelation call.
@@ -572,6 +575,48 @@ type ApplicationUnitParams struct {
 	Data           map[string]interface{}     `json:"data,omitempty"`
 }
 
+// UnitProviderIdsResults holds the provider ids of the
+// units of a number of applications.
+type UnitProviderIdsResults struct {
+	Results []UnitProviderIdsResult `json:"results"`
+}
+
+// UnitProviderIdsResult holds the provider ids of the units
+// of an application, keyed by unit tag, or an error.
+type UnitProviderIdsResult struct {
+	ProviderIds map[string]string `json:"provider-ids,omitempty"`
+	Error       *Error            `json:"error,omitempty"`
+}
+
+// WorkloadExecArgs holds the commands to run in the
+// workload containers of a number of CAAS units.
+type WorkloadExecArgs struct {
+	Args []WorkloadExecArg `json:"args"`
+}
+
+// WorkloadExecArg holds the commands to run in the
+// workload container of a CAAS unit.
+type WorkloadExecArg struct {
+	Tag      string        `json:"tag"`
+	Commands string        `json:"commands"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+}
+
+// WorkloadExecResults holds the results of running commands
+// in the workload containers of a number of CAAS units.
+type WorkloadExecResults struct {
+	Results []WorkloadExecResult `json:"results"`
+}
+
+// WorkloadExecResult holds the exit code and output of commands
+// run in the workload container of a CAAS unit, or an error.
+type WorkloadExecResult struct {
+	Code   int    `json:"code"`
+	Stdout []byte `json:"stdout,omitempty"`
+	Stderr []byte `json:"stderr,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
 // UpdateApplicationServiceArgs holds the parameters for
 // updating application services.
 type UpdateApplicationServiceArgs struct {
@@ -581,9 +626,12 @@ type UpdateApplicationServiceArgs struct {
 // UpdateApplicationServiceArg holds parameters used to update
 // an application's service definition for the cloud.
 type UpdateApplicationServiceArg struct {
-	ApplicationTag string    `json:"application-tag"`
-	ProviderId     string    `json:"provider-id"`
-	Addresses      []Address `json:"addresses"`
+	ApplicationTag string                 `json:"application-tag"`
+	ProviderId     string                 `json:"provider-id"`
+	Addresses      []Address              `json:"addresses"`
+	Status         string                 `json:"status,omitempty"`
+	Info           string                 `json:"info,omitempty"`
+	Data           map[string]interface{} `json:"data,omitempty"`
 }
 
 // DestroyApplicationUnits holds parameters for the deprecated
@@ -769,6 +817,10 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// SecretsMasterKey is the base64 encoded key used to wrap
+	// the keys which encrypt model secrets. It is only ever held
+	// by controller agents, never in the database.
+	SecretsMasterKey string `json:"secrets-master-key,omitempty"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
@@ -0,0 +1,90 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// CreateSecretArgs holds the arguments for creating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the arguments for creating a secret owned by
+// the application of the unit with the given tag.
+type CreateSecretArg struct {
+	UnitTag        string            `json:"unit-tag"`
+	Description    string            `json:"description,omitempty"`
+	RotateInterval time.Duration     `json:"rotate-interval,omitempty"`
+	Data           map[string]string `json:"data"`
+}
+
+// GetSecretArgs holds the arguments for reading secret values.
+type GetSecretArgs struct {
+	Args []GetSecretArg `json:"args"`
+}
+
+// GetSecretArg identifies a secret revision to be read on behalf of
+// the unit with the given tag. A zero revision means the latest.
+type GetSecretArg struct {
+	UnitTag  string `json:"unit-tag"`
+	SecretId string `json:"secret-id"`
+	Revision int    `json:"revision,omitempty"`
+}
+
+// SecretValueResults holds the results of reading secret values.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult holds the values of a secret, or an error.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// RotateSecretArgs holds the arguments for rotating secrets.
+type RotateSecretArgs struct {
+	Args []RotateSecretArg `json:"args"`
+}
+
+// RotateSecretArg holds the new values of a secret, set on behalf of
+// the unit with the given tag.
+type RotateSecretArg struct {
+	UnitTag  string            `json:"unit-tag"`
+	SecretId string            `json:"secret-id"`
+	Data     map[string]string `json:"data"`
+}
+
+// GrantSecretArgs holds the arguments for granting or revoking
+// access to secrets.
+type GrantSecretArgs struct {
+	Args []GrantSecretArg `json:"args"`
+}
+
+// GrantSecretArg identifies a secret, and the relation whose remote
+// application is granted or denied access to it.
+type GrantSecretArg struct {
+	UnitTag    string `json:"unit-tag"`
+	SecretId   string `json:"secret-id"`
+	RelationId int    `json:"relation-id"`
+}
+
+// ListSecretResults holds the results of listing a model's secrets.
+type ListSecretResults struct {
+	Results []ListSecretResult `json:"results"`
+}
+
+// ListSecretResult describes a secret. Secret values are never
+// included.
+type ListSecretResult struct {
+	Id             string        `json:"id"`
+	Owner          string        `json:"owner"`
+	Description    string        `json:"description,omitempty"`
+	Revision       int           `json:"revision"`
+	RotateInterval time.Duration `json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time    `json:"next-rotate-time,omitempty"`
+	Grants         []string      `json:"grants,omitempty"`
+	CreateTime     time.Time     `json:"create-time"`
+	UpdateTime     time.Time     `json:"update-time"`
+}
@@ -132,9 +132,18 @@ type ApplicationStatus struct {
 	CharmVersion     string                 `json:"charm-verion"`
 	EndpointBindings map[string]string      `json:"endpoint-bindings"`
 
+	// LoadBalancerAddresses holds the addresses of the provider load
+	// balancers in front of the exposed application's endpoints, if
+	// any, keyed by endpoint port range.
+	LoadBalancerAddresses map[string][]string `json:"load-balancer-addresses,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId    string `json:"provider-id,omitempty"`
 	PublicAddress string `json:"public-address"`
+
+	// ServiceStatus reports the progress of any rolling
+	// update of the units of a CAAS application.
+	ServiceStatus *DetailedStatus `json:"service-status,omitempty"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
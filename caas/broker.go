@@ -5,8 +5,11 @@ package caas
 
 import (
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
 	"github.com/juju/version"
 
 	"github.com/juju/juju/constraints"
@@ -88,6 +91,35 @@ type ServiceParams struct {
 	Devices []devices.KubernetesDeviceParams
 }
 
+// NetworkPolicyParams defines the traffic which the pods of an
+// application are permitted to accept.
+type NetworkPolicyParams struct {
+	// RelatedApplications holds the names of the applications
+	// related to the application, whose pods may connect to
+	// the application's pods.
+	RelatedApplications []string
+
+	// IngressCIDRs holds the networks from which the consumers of
+	// the application's cross model relations, which have no pods
+	// in the model, may connect to the application's pods.
+	IngressCIDRs []string
+
+	// Exposed is true if the application is exposed, in which
+	// case traffic from any source is accepted.
+	Exposed bool
+}
+
+// ExecParams defines the commands to run in the
+// workload container of a unit.
+type ExecParams struct {
+	// Commands holds the shell commands to run.
+	Commands string
+
+	// Timeout, if non-zero, is how long to wait
+	// for the commands to complete.
+	Timeout time.Duration
+}
+
 // Broker instances interact with the CAAS substrate.
 type Broker interface {
 	// Provider returns the ContainerEnvironProvider that created this Broker.
@@ -121,6 +153,10 @@ type Broker interface {
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
 
+	// EnsureNetworkPolicy creates, updates or removes the network policy
+	// restricting the traffic accepted by pods of the specified application.
+	EnsureNetworkPolicy(appName string, params *NetworkPolicyParams, config application.ConfigAttributes) error
+
 	// WatchUnits returns a watcher which notifies when there
 	// are changes to units of the specified application.
 	WatchUnits(appName string) (watcher.NotifyWatcher, error)
@@ -130,6 +166,18 @@ type Broker interface {
 	// via volumes bound to the unit.
 	Units(appName string) ([]Unit, error)
 
+	// WorkloadLogs returns streams following the output of the
+	// workload containers of the specified unit, keyed by container
+	// name. Output written before since is omitted, unless since is
+	// zero. Each line of output is prefixed with the RFC3339 time at
+	// which it was written and a space. The streams must be closed
+	// by the caller.
+	WorkloadLogs(appName, unitID string, since time.Time) (map[string]io.ReadCloser, error)
+
+	// ExecInPod runs commands in the workload container of the
+	// specified unit, returning their output and exit code.
+	ExecInPod(appName, unitID string, params ExecParams) (*exec.ExecResponse, error)
+
 	// ProviderRegistry is an interface for obtaining storage providers.
 	storage.ProviderRegistry
 }
@@ -138,6 +186,11 @@ type Broker interface {
 type Service struct {
 	Id        string
 	Addresses []network.Address
+
+	// Status reports the progress of any rolling update of
+	// the service's units. It is empty if no update is in
+	// progress.
+	Status status.StatusInfo
 }
 
 // FilesystemInfo represents information about a filesystem
@@ -181,4 +234,9 @@ type OperatorConfig struct {
 
 	// AgentConf is the contents of the agent.conf file.
 	AgentConf []byte
+
+	// Replicas is the number of operator instances to run. Only one
+	// instance runs the charm at a time; any others are standbys
+	// which take over if it fails.
+	Replicas int
 }
@@ -18,6 +18,16 @@ const (
 
 	// JujuDefaultApplicationPath is the default value for juju-application-path.
 	JujuDefaultApplicationPath = "/"
+
+	// JujuOperatorReplicasKey specifies the number of operator pods to run
+	// for a CAAS application. Only one operator runs the charm at a time,
+	// the others are standbys ready to take over if it fails.
+	JujuOperatorReplicasKey = "juju-operator-replicas"
+
+	// JujuWorkloadLogsKey specifies whether the output of a CAAS
+	// application's workload containers is forwarded to the model's
+	// log, attributed to the units running them.
+	JujuWorkloadLogsKey = "juju-workload-logs"
 )
 
 var configFields = environschema.Fields{
@@ -31,6 +41,16 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	JujuOperatorReplicasKey: {
+		Description: "the number of operator pods to run, including standbys",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuWorkloadLogsKey: {
+		Description: "whether to forward workload container output to the model log",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 // ConfigSchema returns the valid fields for a CAAS application config.
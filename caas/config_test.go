@@ -24,6 +24,16 @@ var baseFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	caas.JujuOperatorReplicasKey: {
+		Description: "the number of operator pods to run, including standbys",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.JujuWorkloadLogsKey: {
+		Description: "whether to forward workload container output to the model log",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 var baseDefaults = schema.Defaults{
@@ -7,10 +7,12 @@ import (
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 
 	"github.com/juju/juju/caas"
@@ -40,6 +42,8 @@ type BaseSuite struct {
 	mockStorage                *mocks.MockStorageV1Interface
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
+	mockNetworking             *mocks.MockNetworkingV1Interface
+	mockNetworkPolicies        *mocks.MockNetworkPolicyInterface
 }
 
 const testNamespace = "test"
@@ -79,6 +83,17 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	mockCoreV1 := mocks.NewMockCoreV1Interface(ctrl)
 	s.k8sClient.EXPECT().CoreV1().AnyTimes().Return(mockCoreV1)
 
+	restClient, err := rest.RESTClientFor(&rest.Config{
+		Host:    "some-host",
+		APIPath: "/api",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion:         &core.SchemeGroupVersion,
+			NegotiatedSerializer: scheme.Codecs,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	mockCoreV1.EXPECT().RESTClient().AnyTimes().Return(restClient)
+
 	s.mockNamespaces = mocks.NewMockNamespaceInterface(ctrl)
 	mockCoreV1.EXPECT().Namespaces().AnyTimes().Return(s.mockNamespaces)
 
@@ -113,7 +128,11 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
 	s.mockStorage.EXPECT().StorageClasses().AnyTimes().Return(s.mockStorageClass)
 
-	var err error
+	s.mockNetworking = mocks.NewMockNetworkingV1Interface(ctrl)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(s.mockNetworking)
+	s.mockNetworking.EXPECT().NetworkPolicies(testNamespace).AnyTimes().Return(s.mockNetworkPolicies)
+
 	s.broker, err = provider.NewK8sBroker(cloudSpec, testNamespace, newClient)
 	c.Assert(err, jc.ErrorIsNil)
 	return ctrl
@@ -123,6 +142,10 @@ func (s *BaseSuite) k8sNotFoundError() *k8serrors.StatusError {
 	return k8serrors.NewNotFound(schema.GroupResource{}, "test")
 }
 
+func (s *BaseSuite) k8sInvalidError() *k8serrors.StatusError {
+	return k8serrors.NewInvalid(schema.GroupKind{}, "test", nil)
+}
+
 func (s *BaseSuite) deleteOptions(policy v1.DeletionPropagation) *v1.DeleteOptions {
 	return &v1.DeleteOptions{PropagationPolicy: &policy}
 }
@@ -27,6 +27,13 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	updateMaxUnavailableKey = "kubernetes-update-max-unavailable"
+	updateMaxSurgeKey       = "kubernetes-update-max-surge"
+	updatePartitionKey      = "kubernetes-update-partition"
+	updatePausedKey         = "kubernetes-update-paused"
+
+	networkPolicyKey = "kubernetes-network-policy"
 )
 
 var configFields = environschema.Fields{
@@ -80,6 +87,31 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	updateMaxUnavailableKey: {
+		Description: "maximum number or percentage of units which can be unavailable during a rolling update",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	updateMaxSurgeKey: {
+		Description: "maximum number or percentage of units which can be created above the desired number during a rolling update",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	updatePartitionKey: {
+		Description: "for applications with storage, only units with an ordinal greater or equal to the partition are updated",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	updatePausedKey: {
+		Description: "whether a rolling update of the application's units is paused",
+		Type:        environschema.Tbool,
+		Group:       environschema.ProviderGroup,
+	},
+	networkPolicyKey: {
+		Description: "whether to only accept traffic from related applications, and any source if exposed",
+		Type:        environschema.Tbool,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
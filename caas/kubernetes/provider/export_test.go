@@ -18,6 +18,8 @@ var (
 	ExtractRegistryURL     = extractRegistryURL
 	CreateDockerConfigJSON = createDockerConfigJSON
 	NewStorageConfig       = newStorageConfig
+	PodLogStream           = &podLogStream
+	PodExec                = &podExec
 )
 
 func PodSpec(u *unitSpec) core.PodSpec {
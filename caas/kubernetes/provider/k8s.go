@@ -6,29 +6,40 @@ package provider
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/retry"
 	"github.com/juju/utils/clock"
+	utilexec "github.com/juju/utils/exec"
 	"gopkg.in/juju/names.v2"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
+	networking "k8s.io/api/networking/v1"
 	k8sstorage "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+	k8sexec "k8s.io/client-go/util/exec"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/caas"
@@ -66,6 +77,11 @@ type kubernetesClient struct {
 	// namespace is the k8s namespace to use when
 	// creating k8s resources.
 	namespace string
+
+	// config is the configuration used to connect
+	// to the cluster, for requests which are not
+	// made through the client.
+	config *rest.Config
 }
 
 // To regenerate the mocks for the kubernetes Client used by this broker,
@@ -74,6 +90,7 @@ type kubernetesClient struct {
 //go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
 //go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
+//go:generate mockgen -package mocks -destination mocks/networkingv1_mock.go k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,NetworkPolicyInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
@@ -89,7 +106,7 @@ func NewK8sBroker(cloudSpec environs.CloudSpec, namespace string, newClient NewK
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &kubernetesClient{Interface: client, namespace: namespace}, nil
+	return &kubernetesClient{Interface: client, namespace: namespace, config: config}, nil
 }
 
 func newK8sConfig(cloudSpec environs.CloudSpec) (*rest.Config, error) {
@@ -211,6 +228,14 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 		return errors.Annotate(err, "creating or updating ConfigMap")
 	}
 
+	if config.Replicas > 1 {
+		return k.ensureOperatorStatefulSet(appName, agentPath, config)
+	}
+	// Standby operators may have been requested previously.
+	if err := k.deleteOperatorStatefulSet(appName); err != nil {
+		return errors.Annotate(err, "deleting operator stateful set")
+	}
+
 	// Attempt to get a persistent volume to store charm state etc.
 	// If there are none, that's ok, we'll just use ephemeral storage.
 	volStorageLabel := fmt.Sprintf("%s-operator-storage", appName)
@@ -270,6 +295,118 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 	return nil
 }
 
+// ensureOperatorStatefulSet runs the specified number of operator replicas
+// for an application using a stateful set. Each replica has its own storage
+// so a standby never waits on a volume attached to a failed replica, and
+// replicas are spread across nodes where possible. The replicas coordinate
+// using the application's operator lease so only one runs the charm.
+func (k *kubernetesClient) ensureOperatorStatefulSet(appName, agentPath string, config *caas.OperatorConfig) error {
+	pod := operatorPod(appName, agentPath, config.OperatorImagePath, config.Version.String())
+	podSpec := pod.Spec
+	podSpec.Affinity = operatorAffinity(appName)
+
+	replicas := int32(config.Replicas)
+	statefulset := &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   operatorPodName(appName),
+			Labels: map[string]string{labelOperator: appName}},
+		Spec: apps.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{labelOperator: appName},
+			},
+			// Replicas don't depend on each other, so there's
+			// no need to wait for one to start before the next.
+			PodManagementPolicy: apps.ParallelPodManagement,
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: pod.Labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	// Attempt to get a persistent volume for each replica to store charm
+	// state etc. If there are none, that's ok, we'll just use ephemeral storage.
+	params := volumeParams{
+		storageConfig:       &storageConfig{storageClass: operatorStorageClassName},
+		storageLabels:       []string{fmt.Sprintf("%s-operator-storage", appName), k.namespace, "default"},
+		pvcName:             operatorVolumeClaim(appName),
+		requestedVolumeSize: operatorStorageSize,
+		labels:              map[string]string{labelOperator: appName},
+	}
+	pvcSpec, _, err := k.maybeGetVolumeClaimSpec(params)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "finding operator volume claim")
+	} else if err == nil {
+		// Any existing claim is for the single operator pod;
+		// each replica needs a volume of its own.
+		pvcSpec.VolumeName = ""
+		statefulset.Spec.VolumeClaimTemplates = []core.PersistentVolumeClaim{{
+			ObjectMeta: v1.ObjectMeta{
+				Name:   params.pvcName,
+				Labels: params.labels},
+			Spec: *pvcSpec,
+		}}
+		podSpec := &statefulset.Spec.Template.Spec
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, core.VolumeMount{
+			Name:      params.pvcName,
+			MountPath: agent.BaseDir(agentPath),
+		})
+	}
+
+	// Remove any operator pod created before replicas were requested,
+	// along with its volume claim; each replica has a claim of its own.
+	if err := k.deletePod(operatorPodName(appName)); err != nil {
+		return errors.Annotate(err, "deleting operator pod")
+	}
+	if err := k.deleteVolumeClaim(operatorVolumeClaim(appName)); err != nil {
+		return errors.Annotate(err, "deleting operator volume claim")
+	}
+	return k.ensureStatefulSet(statefulset)
+}
+
+func (k *kubernetesClient) deleteVolumeClaim(name string) error {
+	pvClaims := k.CoreV1().PersistentVolumeClaims(k.namespace)
+	err := pvClaims.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// operatorAffinity returns the affinity used to schedule the operator
+// replicas of an application on different nodes, where possible.
+func operatorAffinity(appName string) *core.Affinity {
+	return &core.Affinity{
+		PodAntiAffinity: &core.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []core.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: core.PodAffinityTerm{
+					LabelSelector: &v1.LabelSelector{
+						MatchLabels: map[string]string{labelOperator: appName},
+					},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			}},
+		},
+	}
+}
+
+func (k *kubernetesClient) deleteOperatorStatefulSet(appName string) error {
+	statefulsets := k.AppsV1().StatefulSets(k.namespace)
+	err := statefulsets.Delete(operatorPodName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 // maybeGetStorageClass looks for a storage class to use when creating
 // a persistent volume, using the specified name (if supplied), or a class
 // matching the specified labels.
@@ -434,6 +571,19 @@ func (k *kubernetesClient) DeleteOperator(appName string) (err error) {
 		return nil
 	}
 
+	// Then any operator replicas and their volume claims.
+	if err := k.deleteOperatorStatefulSet(appName); err != nil {
+		return errors.Trace(err)
+	}
+	err = pvClaims.DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: operatorSelector(appName),
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+
 	// Finally the pod itself.
 	podName := operatorPodName(appName)
 	return k.deletePod(podName)
@@ -476,9 +626,32 @@ func (k *kubernetesClient) Service(appName string) (*caas.Service, error) {
 			Scope: network.ScopePublic,
 		})
 	}
+	if result.Status, err = k.rolloutStatus(appName); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &result, nil
 }
 
+// rolloutStatus returns the status of any rolling update in
+// progress for the pods of the specified application.
+func (k *kubernetesClient) rolloutStatus(appName string) (status.StatusInfo, error) {
+	deployment, err := k.AppsV1().Deployments(k.namespace).Get(deploymentName(appName), v1.GetOptions{})
+	if err == nil {
+		return deploymentRolloutStatus(deployment), nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	statefulSet, err := k.AppsV1().StatefulSets(k.namespace).Get(deploymentName(appName), v1.GetOptions{})
+	if err == nil {
+		return statefulSetRolloutStatus(statefulSet), nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	return status.StatusInfo{}, nil
+}
+
 // DeleteService deletes the specified service.
 func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	logger.Debugf("deleting application %s", appName)
@@ -501,7 +674,10 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 			return errors.Trace(err)
 		}
 	}
-	return errors.Trace(k.deleteDeployment(appName))
+	if err := k.deleteDeployment(appName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteNetworkPolicy(appName))
 }
 
 // EnsureService creates or updates a service for pods with the given params.
@@ -551,15 +727,20 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(appName, c.Name) })
 	}
 
+	updateStrategy, err := newUpdateStrategy(config)
+	if err != nil {
+		return errors.Annotatef(err, "parsing update strategy for %s", appName)
+	}
+
 	// Add a deployment controller configured to create the specified number of units/pods.
 	numPods := int32(numUnits)
 	if len(params.Filesystems) > 0 {
-		if err := k.configureStatefulSet(appName, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
+		if err := k.configureStatefulSet(appName, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems, updateStrategy); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	} else {
-		if err := k.configureDeployment(appName, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
+		if err := k.configureDeployment(appName, unitSpec, params.PodSpec.Containers, &numPods, updateStrategy); err != nil {
 			return errors.Annotate(err, "creating or updating DeploymentController")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
@@ -681,7 +862,9 @@ func (k *kubernetesClient) configurePodFiles(podSpec *core.PodSpec, containers [
 	return nil
 }
 
-func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpec, containers []caas.ContainerSpec, replicas *int32) error {
+func (k *kubernetesClient) configureDeployment(
+	appName string, unitSpec *unitSpec, containers []caas.ContainerSpec, replicas *int32, updateStrategy *updateStrategy,
+) error {
 	logger.Debugf("creating/updating deployment for %s", appName)
 
 	// Add the specified file to the pod spec.
@@ -712,6 +895,7 @@ func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpe
 			},
 		},
 	}
+	updateStrategy.configureDeployment(&deployment.Spec)
 	return k.ensureDeployment(deployment)
 }
 
@@ -736,7 +920,8 @@ func (k *kubernetesClient) deleteDeployment(appName string) error {
 }
 
 func (k *kubernetesClient) configureStatefulSet(
-	appName string, unitSpec *unitSpec, containers []caas.ContainerSpec, replicas *int32, filesystems []storage.KubernetesFilesystemParams,
+	appName string, unitSpec *unitSpec, containers []caas.ContainerSpec, replicas *int32,
+	filesystems []storage.KubernetesFilesystemParams, updateStrategy *updateStrategy,
 ) error {
 	logger.Debugf("creating/updating stateful set for %s", appName)
 
@@ -764,17 +949,17 @@ func (k *kubernetesClient) configureStatefulSet(
 	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
 		return errors.Trace(err)
 	}
-	existingPodSpec := podSpec
 
 	// Create a new stateful set with the necessary storage config.
 	if err := k.configureStorage(&podSpec, &statefulset.Spec, appName, filesystems); err != nil {
 		return errors.Annotatef(err, "configuring storage for %s", appName)
 	}
 	statefulset.Spec.Template.Spec = podSpec
-	return k.ensureStatefulSet(statefulset, existingPodSpec)
+	updateStrategy.configureStatefulSet(&statefulset.Spec)
+	return k.ensureStatefulSet(statefulset)
 }
 
-func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPodSpec core.PodSpec) error {
+func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet) error {
 	statefulsets := k.AppsV1().StatefulSets(k.namespace)
 	_, err := statefulsets.Update(spec)
 	if k8serrors.IsNotFound(err) {
@@ -784,20 +969,55 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 		return errors.Trace(err)
 	}
 
-	// The statefulset already exists so all we are allowed to update is replicas,
-	// template, update strategy. Juju may hand out info with a slightly different
-	// requested volume size due to trying to adapt the unit model to the k8s world.
+	// The statefulset already exists so all we are allowed to update is
+	// metadata, replicas, template and update strategy. Juju may hand out
+	// info with a slightly different requested volume size due to trying
+	// to adapt the unit model to the k8s world.
 	existing, err := statefulsets.Get(spec.Name, v1.GetOptions{IncludeUninitialized: true})
 	if err != nil {
 		return errors.Trace(err)
 	}
-	// TODO(caas) - allow extra storage to be added
+	existing.Labels = spec.Labels
+	existing.Annotations = spec.Annotations
 	existing.Spec.Replicas = spec.Spec.Replicas
-	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
+	existing.Spec.UpdateStrategy = spec.Spec.UpdateStrategy
+	existing.Spec.Template.Labels = spec.Spec.Template.Labels
+	existing.Spec.Template.Annotations = spec.Spec.Template.Annotations
+	existing.Spec.Template.Spec = templatePodSpec(spec.Spec.Template.Spec, existing.Spec.VolumeClaimTemplates)
 	_, err = statefulsets.Update(existing)
 	return errors.Trace(err)
 }
 
+// templatePodSpec returns the pod spec to use for an existing stateful
+// set, whose volume claim templates cannot be changed. Mounts of
+// volumes claimed by templates the stateful set does not have are
+// dropped.
+// TODO(caas) - allow extra storage to be added
+func templatePodSpec(podSpec core.PodSpec, claimTemplates []core.PersistentVolumeClaim) core.PodSpec {
+	volumeNames := set.NewStrings()
+	for _, v := range podSpec.Volumes {
+		volumeNames.Add(v.Name)
+	}
+	for _, pvc := range claimTemplates {
+		volumeNames.Add(pvc.Name)
+	}
+	containers := make([]core.Container, len(podSpec.Containers))
+	for i, container := range podSpec.Containers {
+		var mounts []core.VolumeMount
+		for _, m := range container.VolumeMounts {
+			if !volumeNames.Contains(m.Name) {
+				logger.Warningf("cannot add storage %q to existing stateful set", m.Name)
+				continue
+			}
+			mounts = append(mounts, m)
+		}
+		container.VolumeMounts = mounts
+		containers[i] = container
+	}
+	podSpec.Containers = containers
+	return podSpec
+}
+
 func (k *kubernetesClient) deleteStatefulSet(appName string) error {
 	deployments := k.AppsV1().StatefulSets(k.namespace)
 	err := deployments.Delete(deploymentName(appName), &v1.DeleteOptions{
@@ -989,6 +1209,270 @@ func (k *kubernetesClient) deleteIngress(appName string) error {
 	return errors.Trace(err)
 }
 
+// EnsureNetworkPolicy creates or updates the network policy restricting
+// the traffic accepted by pods of the specified application to that from
+// pods of the application itself and its related applications, and from
+// the ingress networks of its cross model relations, or from any source
+// if the application is exposed. If network policies are not
+// enabled in the application config, any existing policy is removed.
+func (k *kubernetesClient) EnsureNetworkPolicy(
+	appName string, params *caas.NetworkPolicyParams, config application.ConfigAttributes,
+) error {
+	if !config.GetBool(networkPolicyKey, false) {
+		return k.deleteNetworkPolicy(appName)
+	}
+	logger.Debugf("creating/updating network policy for %s", appName)
+
+	sources := set.NewStrings(params.RelatedApplications...)
+	sources.Add(appName)
+	sourceNames := sources.SortedValues()
+	rules := []networking.NetworkPolicyIngressRule{{
+		From: []networking.NetworkPolicyPeer{{
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key:      labelApplication,
+					Operator: v1.LabelSelectorOpIn,
+					Values:   sourceNames,
+				}},
+			},
+		}, {
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key:      labelOperator,
+					Operator: v1.LabelSelectorOpIn,
+					Values:   sourceNames,
+				}},
+			},
+		}},
+	}}
+	if len(params.IngressCIDRs) > 0 {
+		// Consumers of cross model relations have no pods
+		// here, so they are admitted by address instead.
+		var peers []networking.NetworkPolicyPeer
+		for _, cidr := range params.IngressCIDRs {
+			peers = append(peers, networking.NetworkPolicyPeer{
+				IPBlock: &networking.IPBlock{CIDR: cidr},
+			})
+		}
+		rules = append(rules, networking.NetworkPolicyIngressRule{From: peers})
+	}
+	if params.Exposed {
+		// A rule with no peers accepts traffic from any source.
+		rules = append(rules, networking.NetworkPolicyIngressRule{})
+	}
+	spec := &networking.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: map[string]string{labelApplication: appName},
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+	return k.ensureNetworkPolicy(spec)
+}
+
+func (k *kubernetesClient) ensureNetworkPolicy(spec *networking.NetworkPolicy) error {
+	policies := k.NetworkingV1().NetworkPolicies(k.namespace)
+	_, err := policies.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = policies.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNetworkPolicy(appName string) error {
+	policies := k.NetworkingV1().NetworkPolicies(k.namespace)
+	err := policies.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// WorkloadLogs returns streams following the output of the
+// containers in the pod of the specified unit.
+func (k *kubernetesClient) WorkloadLogs(appName, unitID string, since time.Time) (_ map[string]io.ReadCloser, err error) {
+	pod, err := k.unitPod(appName, unitID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	pods := k.CoreV1().Pods(k.namespace)
+	streams := make(map[string]io.ReadCloser)
+	defer func() {
+		if err != nil {
+			for _, stream := range streams {
+				stream.Close()
+			}
+		}
+	}()
+	for _, c := range pod.Spec.Containers {
+		opts := &core.PodLogOptions{
+			Container:  c.Name,
+			Follow:     true,
+			Timestamps: true,
+		}
+		if !since.IsZero() {
+			sinceTime := v1.NewTime(since)
+			opts.SinceTime = &sinceTime
+		}
+		stream, err := podLogStream(pods.GetLogs(pod.Name, opts))
+		if err != nil {
+			return nil, errors.Annotatef(err, "streaming logs of container %q", c.Name)
+		}
+		streams[c.Name] = stream
+	}
+	return streams, nil
+}
+
+// podLogStream opens the stream requested by a pod logs request.
+// It is a variable so that tests can avoid a real HTTP request.
+var podLogStream = func(req *rest.Request) (io.ReadCloser, error) {
+	return req.Stream()
+}
+
+// ExecInPod runs commands in the workload container of the specified unit.
+func (k *kubernetesClient) ExecInPod(appName, unitID string, params caas.ExecParams) (*utilexec.ExecResponse, error) {
+	pod, err := k.unitPod(appName, unitID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return nil, errors.NotFoundf("workload container for unit %q", unitID)
+	}
+	// The workload container is the first in the pod.
+	container := pod.Spec.Containers[0].Name
+	req := k.CoreV1().RESTClient().Post().
+		Namespace(k.namespace).
+		Resource("pods").
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&core.PodExecOptions{
+			Container: container,
+			Command:   []string{"/bin/sh", "-c", params.Commands},
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	var stdout, stderr bytes.Buffer
+	abort := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- podExec(k.config, req, &stdout, &stderr, abort)
+	}()
+	var timeout <-chan time.Time
+	if params.Timeout > 0 {
+		timeout = clock.WallClock.After(params.Timeout)
+	}
+	select {
+	case err = <-done:
+	case <-timeout:
+		// Closing the connection ends the exec session; wait for
+		// the stream to finish so nothing outlives this call.
+		close(abort)
+		<-done
+		return nil, errors.Timeoutf("running commands in pod %q", pod.Name)
+	}
+
+	response := &utilexec.ExecResponse{
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}
+	if exitErr, ok := err.(k8sexec.ExitError); ok {
+		response.Code = exitErr.ExitStatus()
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "running commands in pod %q", pod.Name)
+	}
+	return response, nil
+}
+
+// podExec streams the output of the commands requested by a pod exec
+// request, closing the connection if abort is closed first. It is a
+// variable so that tests can avoid a real connection.
+var podExec = func(config *rest.Config, req *rest.Request, stdout, stderr io.Writer, abort <-chan struct{}) error {
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conns := &abortableUpgrader{Upgrader: upgrader}
+	executor, err := remotecommand.NewSPDYExecutorForTransports(transport, conns, "POST", req.URL())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-abort:
+			conns.abort()
+		case <-finished:
+		}
+	}()
+	return executor.Stream(remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// abortableUpgrader records the streaming connection created for a
+// pod exec so that it can be closed before the commands complete.
+type abortableUpgrader struct {
+	spdy.Upgrader
+
+	mu      sync.Mutex
+	conn    httpstream.Connection
+	aborted bool
+}
+
+// NewConnection is part of the spdy.Upgrader interface.
+func (u *abortableUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.aborted {
+		conn.Close()
+		return nil, errors.New("pod exec aborted")
+	}
+	u.conn = conn
+	return conn, nil
+}
+
+func (u *abortableUpgrader) abort() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.aborted = true
+	if u.conn != nil {
+		u.conn.Close()
+	}
+}
+
+// unitPod returns the pod of the specified application
+// with the specified unit ID.
+func (k *kubernetesClient) unitPod(appName, unitID string) (*core.Pod, error) {
+	podsList, err := k.CoreV1().Pods(k.namespace).List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, p := range podsList.Items {
+		if string(p.UID) == unitID {
+			return &podsList.Items[i], nil
+		}
+	}
+	return nil, errors.NotFoundf("pod for unit %q", unitID)
+}
+
 func operatorSelector(appName string) string {
 	return fmt.Sprintf("%v==%v", labelOperator, appName)
 }
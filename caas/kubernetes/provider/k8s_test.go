@@ -4,20 +4,33 @@
 package provider_test
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	utilexec "github.com/juju/utils/exec"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
+	k8sexec "k8s.io/client-go/util/exec"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
@@ -159,6 +172,148 @@ func (s *K8sBrokerSuite) TestEnsureNamespace(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) operatorStatefulSetArg() *appsv1.StatefulSet {
+	pod := provider.OperatorPod("test", "/var/lib/juju", "jujusolutions/caas-jujud-operator", "2.99.0")
+	podSpec := pod.Spec
+	podSpec.Affinity = &core.Affinity{
+		PodAntiAffinity: &core.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []core.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: core.PodAffinityTerm{
+					LabelSelector: &v1.LabelSelector{
+						MatchLabels: map[string]string{"juju-operator": "test"},
+					},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			}},
+		},
+	}
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, core.VolumeMount{
+		Name:      "test-operator-volume",
+		MountPath: "/var/lib/juju",
+	})
+	replicas := int32(3)
+	scName := "juju-operator-storage"
+	return &appsv1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-operator-test",
+			Labels: map[string]string{"juju-operator": "test"}},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-operator": "test"},
+			},
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"juju-operator": "test", "juju-version": "2.99.0"},
+				},
+				Spec: podSpec,
+			},
+			VolumeClaimTemplates: []core.PersistentVolumeClaim{{
+				ObjectMeta: v1.ObjectMeta{
+					Name:   "test-operator-volume",
+					Labels: map[string]string{"juju-operator": "test"}},
+				Spec: core.PersistentVolumeClaimSpec{
+					StorageClassName: &scName,
+					AccessModes:      []core.PersistentVolumeAccessMode{core.ReadWriteOnce},
+					Resources: core.ResourceRequirements{
+						Requests: core.ResourceList{
+							core.ResourceStorage: resource.MustParse("10Mi"),
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureOperatorWithReplicas(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	config := &caas.OperatorConfig{
+		OperatorImagePath: "jujusolutions/caas-jujud-operator",
+		Version:           version.MustParse("2.99.0"),
+		AgentConf:         []byte("agent-conf-data"),
+		Replicas:          3,
+	}
+	configMapArg := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-operator-test-config"},
+		Data:       map[string]string{"agent.conf": "agent-conf-data"},
+	}
+	statefulSetArg := s.operatorStatefulSetArg()
+
+	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test"}}
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Update(ns).Times(1),
+		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1),
+		s.mockPersistentVolumeClaims.EXPECT().Get("test-operator-volume", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().Get("juju-operator-storage", v1.GetOptions{}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "juju-operator-storage"}}, nil),
+		s.mockPods.EXPECT().Delete("juju-operator-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockPersistentVolumeClaims.EXPECT().Delete("test-operator-volume", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
+			Return(nil, nil),
+	)
+
+	err := s.broker.EnsureOperator("test", "/var/lib/juju", config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureOperatorWithReplicasUpdatesExisting(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	config := &caas.OperatorConfig{
+		OperatorImagePath: "jujusolutions/caas-jujud-operator",
+		Version:           version.MustParse("2.99.0"),
+		AgentConf:         []byte("agent-conf-data"),
+		Replicas:          3,
+	}
+	configMapArg := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-operator-test-config"},
+		Data:       map[string]string{"agent.conf": "agent-conf-data"},
+	}
+	statefulSetArg := s.operatorStatefulSetArg()
+
+	// The existing stateful set was created for an older version with
+	// fewer replicas. Everything but its claim templates is updated.
+	existing := s.operatorStatefulSetArg()
+	replicas := int32(2)
+	existing.Spec.Replicas = &replicas
+	existing.Spec.Template.Labels["juju-version"] = "2.98.0"
+	existing.Spec.Template.Spec.Containers[0].Image = "jujusolutions/caas-jujud-operator:2.98.0"
+
+	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test"}}
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Update(ns).Times(1),
+		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1),
+		s.mockPersistentVolumeClaims.EXPECT().Get("test-operator-volume", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().Get("juju-operator-storage", v1.GetOptions{}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "juju-operator-storage"}}, nil),
+		s.mockPods.EXPECT().Delete("juju-operator-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockPersistentVolumeClaims.EXPECT().Delete("test-operator-volume", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sInvalidError()),
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(existing, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, nil),
+	)
+
+	err := s.broker.EnsureOperator("test", "/var/lib/juju", config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
@@ -173,6 +328,8 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 			Return(&core.PodList{Items: []core.Pod{}}, nil),
 		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
 	err := s.broker.DeleteService("test")
@@ -540,3 +697,412 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithUpdateStrategy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	maxUnavailable := intstr.FromString("25%")
+	maxSurge := intstr.FromInt(1)
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: podSpec,
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       &maxSurge,
+				},
+			},
+			Paused: true,
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "test"},
+			Type:     "ClusterIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":           "ClusterIP",
+		"kubernetes-update-max-unavailable": "25%",
+		"kubernetes-update-max-surge":       "1",
+		"kubernetes-update-paused":          true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceInvalidUpdateStrategy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err := s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+		"kubernetes-update-max-surge": "lots",
+	})
+	c.Assert(err, gc.ErrorMatches, `parsing update strategy for test: invalid kubernetes-update-max-surge: value "lots", expected a positive number or percentage not valid`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	sources := []string{"mariadb", "test", "wordpress"}
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{
+					PodSelector: &v1.LabelSelector{
+						MatchExpressions: []v1.LabelSelectorRequirement{{
+							Key:      "juju-application",
+							Operator: v1.LabelSelectorOpIn,
+							Values:   sources,
+						}},
+					},
+				}, {
+					PodSelector: &v1.LabelSelector{
+						MatchExpressions: []v1.LabelSelectorRequirement{{
+							Key:      "juju-operator",
+							Operator: v1.LabelSelectorOpIn,
+							Values:   sources,
+						}},
+					},
+				}},
+			}},
+		},
+	}
+	crossModelPolicy := *policy
+	crossModelPolicy.Spec.Ingress = append(policy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{{
+			IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/24"},
+		}, {
+			IPBlock: &networkingv1.IPBlock{CIDR: "192.168.1.0/24"},
+		}},
+	})
+	exposedPolicy := crossModelPolicy
+	exposedPolicy.Spec.Ingress = append(crossModelPolicy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{})
+	gomock.InOrder(
+		s.mockNetworkPolicies.EXPECT().Update(policy).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Create(policy).Times(1).
+			Return(nil, nil),
+		s.mockNetworkPolicies.EXPECT().Update(&crossModelPolicy).Times(1).
+			Return(nil, nil),
+		s.mockNetworkPolicies.EXPECT().Update(&exposedPolicy).Times(1).
+			Return(nil, nil),
+	)
+
+	config := application.ConfigAttributes{"kubernetes-network-policy": true}
+	params := &caas.NetworkPolicyParams{
+		RelatedApplications: []string{"wordpress", "mariadb"},
+	}
+	err := s.broker.EnsureNetworkPolicy("test", params, config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	params.IngressCIDRs = []string{"10.0.0.0/24", "192.168.1.0/24"}
+	err = s.broker.EnsureNetworkPolicy("test", params, config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	params.Exposed = true
+	err = s.broker.EnsureNetworkPolicy("test", params, config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyNotEnabled(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNetworkPolicies.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		Return(s.k8sNotFoundError())
+
+	params := &caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mariadb"},
+		Exposed:             true,
+	}
+	err := s.broker.EnsureNetworkPolicy("test", params, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestWorkloadLogs(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.PatchValue(provider.PodLogStream, func(*rest.Request) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader("2018-10-01T01:02:03Z hello\n")), nil
+	})
+
+	since := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	sinceTime := v1.NewTime(since)
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.PodList{Items: []core.Pod{{
+				ObjectMeta: v1.ObjectMeta{Name: "test-0", UID: "other"},
+			}, {
+				ObjectMeta: v1.ObjectMeta{Name: "test-1", UID: "uuid"},
+				Spec: core.PodSpec{
+					Containers: []core.Container{{Name: "gitlab"}, {Name: "sidecar"}},
+				},
+			}}}, nil),
+		s.mockPods.EXPECT().GetLogs("test-1", &core.PodLogOptions{
+			Container:  "gitlab",
+			Follow:     true,
+			Timestamps: true,
+			SinceTime:  &sinceTime,
+		}).Times(1).Return(&rest.Request{}),
+		s.mockPods.EXPECT().GetLogs("test-1", &core.PodLogOptions{
+			Container:  "sidecar",
+			Follow:     true,
+			Timestamps: true,
+			SinceTime:  &sinceTime,
+		}).Times(1).Return(&rest.Request{}),
+	)
+
+	streams, err := s.broker.WorkloadLogs("test", "uuid", since)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(streams, gc.HasLen, 2)
+	out, err := ioutil.ReadAll(streams["gitlab"])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, "2018-10-01T01:02:03Z hello\n")
+	c.Assert(streams["sidecar"], gc.NotNil)
+}
+
+func (s *K8sBrokerSuite) TestWorkloadLogsUnitNotFound(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{}, nil)
+
+	_, err := s.broker.WorkloadLogs("test", "uuid", time.Time{})
+	c.Assert(err, gc.ErrorMatches, `pod for unit "uuid" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestExecInPod(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.PatchValue(provider.PodExec, func(config *rest.Config, req *rest.Request, stdout, stderr io.Writer, abort <-chan struct{}) error {
+		c.Check(config.Host, gc.Equals, "some-host")
+		url := req.URL()
+		c.Check(url.Path, gc.Equals, "/api/v1/namespaces/test/pods/test-1/exec")
+		c.Check(url.Query()["container"], jc.DeepEquals, []string{"gitlab"})
+		c.Check(url.Query()["command"], jc.DeepEquals, []string{"/bin/sh", "-c", "echo hello"})
+		fmt.Fprint(stdout, "hello\n")
+		fmt.Fprint(stderr, "oops\n")
+		return k8sexec.CodeExitError{Err: errors.New("exit"), Code: 2}
+	})
+
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{{
+			ObjectMeta: v1.ObjectMeta{Name: "test-1", UID: "uuid"},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{Name: "gitlab"}, {Name: "sidecar"}},
+			},
+		}}}, nil)
+
+	result, err := s.broker.ExecInPod("test", "uuid", caas.ExecParams{Commands: "echo hello"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, &utilexec.ExecResponse{
+		Code:   2,
+		Stdout: []byte("hello\n"),
+		Stderr: []byte("oops\n"),
+	})
+}
+
+func (s *K8sBrokerSuite) TestExecInPodError(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.PatchValue(provider.PodExec, func(*rest.Config, *rest.Request, io.Writer, io.Writer, <-chan struct{}) error {
+		return errors.New("boom")
+	})
+
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{{
+			ObjectMeta: v1.ObjectMeta{Name: "test-1", UID: "uuid"},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{Name: "gitlab"}},
+			},
+		}}}, nil)
+
+	_, err := s.broker.ExecInPod("test", "uuid", caas.ExecParams{Commands: "echo hello"})
+	c.Assert(err, gc.ErrorMatches, `running commands in pod "test-1": boom`)
+}
+
+func (s *K8sBrokerSuite) TestExecInPodTimeoutAbortsStream(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	aborted := make(chan struct{})
+	s.PatchValue(provider.PodExec, func(_ *rest.Config, _ *rest.Request, _, _ io.Writer, abort <-chan struct{}) error {
+		<-abort
+		close(aborted)
+		return errors.New("connection closed")
+	})
+
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{{
+			ObjectMeta: v1.ObjectMeta{Name: "test-1", UID: "uuid"},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{Name: "gitlab"}},
+			},
+		}}}, nil)
+
+	_, err := s.broker.ExecInPod("test", "uuid", caas.ExecParams{
+		Commands: "sleep 100",
+		Timeout:  time.Millisecond,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsTimeout)
+	select {
+	case <-aborted:
+	default:
+		c.Fatal("exec stream not aborted")
+	}
+}
+
+func (s *K8sBrokerSuite) TestServiceRollingUpdateStatus(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	replicas := int32(3)
+	gomock.InOrder(
+		s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.ServiceList{Items: []core.Service{{
+				ObjectMeta: v1.ObjectMeta{UID: "uid"},
+				Spec:       core.ServiceSpec{ClusterIP: "10.0.0.1"},
+			}}}, nil),
+		s.mockDeployments.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(&appsv1.Deployment{
+				ObjectMeta: v1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           4,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  3,
+				},
+			}, nil),
+	)
+
+	svc, err := s.broker.Service("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Id, gc.Equals, "uid")
+	c.Assert(svc.Status.Status, gc.Equals, status.Maintenance)
+	c.Assert(svc.Status.Message, gc.Equals, "rolling update: 1 of 3 units updated")
+}
+
+func (s *K8sBrokerSuite) TestServiceUnavailablePodsNotRollingUpdate(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	replicas := int32(3)
+	gomock.InOrder(
+		s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.ServiceList{Items: []core.Service{{
+				ObjectMeta: v1.ObjectMeta{UID: "uid"},
+				Spec:       core.ServiceSpec{ClusterIP: "10.0.0.1"},
+			}}}, nil),
+		s.mockDeployments.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(&appsv1.Deployment{
+				ObjectMeta: v1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           3,
+					UpdatedReplicas:    3,
+					AvailableReplicas:  2,
+				},
+			}, nil),
+	)
+
+	svc, err := s.broker.Service("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Status, jc.DeepEquals, status.StatusInfo{})
+}
+
+func (s *K8sBrokerSuite) TestServiceStatefulSetPartitionedUpdateStatus(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	replicas := int32(3)
+	partition := int32(2)
+	gomock.InOrder(
+		s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.ServiceList{Items: []core.Service{{
+				ObjectMeta: v1.ObjectMeta{UID: "uid"},
+			}}}, nil),
+		s.mockDeployments.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(&appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: &replicas,
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						Type: appsv1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+							Partition: &partition,
+						},
+					},
+				},
+				Status: appsv1.StatefulSetStatus{
+					UpdatedReplicas: 1,
+					CurrentRevision: "rev-1",
+					UpdateRevision:  "rev-2",
+				},
+			}, nil),
+	)
+
+	svc, err := s.broker.Service("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Status.Status, gc.Equals, status.Waiting)
+	c.Assert(svc.Status.Message, gc.Equals, "rolling update paused: 1 of 3 units updated")
+}
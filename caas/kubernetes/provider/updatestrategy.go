@@ -0,0 +1,164 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/status"
+)
+
+// updateStrategy holds the rolling update policy used when
+// the pod spec of an application's workload changes.
+type updateStrategy struct {
+	maxUnavailable *intstr.IntOrString
+	maxSurge       *intstr.IntOrString
+	partition      *int32
+	paused         bool
+}
+
+// newUpdateStrategy returns the update strategy defined
+// by the specified application config.
+func newUpdateStrategy(config application.ConfigAttributes) (*updateStrategy, error) {
+	var (
+		result updateStrategy
+		err    error
+	)
+	if v := config.GetString(updateMaxUnavailableKey, ""); v != "" {
+		if result.maxUnavailable, err = parseIntOrPercent(v); err != nil {
+			return nil, errors.Annotatef(err, "invalid %s", updateMaxUnavailableKey)
+		}
+	}
+	if v := config.GetString(updateMaxSurgeKey, ""); v != "" {
+		if result.maxSurge, err = parseIntOrPercent(v); err != nil {
+			return nil, errors.Annotatef(err, "invalid %s", updateMaxSurgeKey)
+		}
+	}
+	if _, ok := config[updatePartitionKey]; ok {
+		partition := config.GetInt(updatePartitionKey, 0)
+		if partition < 0 {
+			return nil, errors.NotValidf("%s %d", updatePartitionKey, partition)
+		}
+		p := int32(partition)
+		result.partition = &p
+	}
+	result.paused = config.GetBool(updatePausedKey, false)
+	return &result, nil
+}
+
+// parseIntOrPercent parses a value such as "2" or "25%".
+func parseIntOrPercent(in string) (*intstr.IntOrString, error) {
+	value := strings.TrimSpace(in)
+	number := strings.TrimSuffix(value, "%")
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 0 {
+		return nil, errors.NotValidf("value %q, expected a positive number or percentage", in)
+	}
+	if number != value {
+		result := intstr.FromString(fmt.Sprintf("%d%%", n))
+		return &result, nil
+	}
+	result := intstr.FromInt(n)
+	return &result, nil
+}
+
+// configureDeployment sets the rollout policy on the deployment spec.
+// If no policy has been configured, the k8s defaults are used.
+func (s *updateStrategy) configureDeployment(spec *apps.DeploymentSpec) {
+	spec.Paused = s.paused
+	if s.maxUnavailable == nil && s.maxSurge == nil {
+		return
+	}
+	spec.Strategy = apps.DeploymentStrategy{
+		Type: apps.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &apps.RollingUpdateDeployment{
+			MaxUnavailable: s.maxUnavailable,
+			MaxSurge:       s.maxSurge,
+		},
+	}
+}
+
+// configureStatefulSet sets the rollout policy on the stateful set spec.
+// Stateful sets replace pods one at a time, in reverse ordinal order, so
+// only the partition is used. Pausing a rollout holds back all pods.
+func (s *updateStrategy) configureStatefulSet(spec *apps.StatefulSetSpec) {
+	partition := s.partition
+	if s.paused && spec.Replicas != nil {
+		partition = spec.Replicas
+	}
+	if partition == nil {
+		return
+	}
+	p := *partition
+	spec.UpdateStrategy = apps.StatefulSetUpdateStrategy{
+		Type: apps.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{
+			Partition: &p,
+		},
+	}
+}
+
+// deploymentRolloutStatus returns the status of any rollout in
+// progress for the deployment. The zero value is returned if the
+// deployment has finished rolling out. As with kubectl rollout
+// status, a rollout is in progress until the controller has observed
+// the latest spec and every replica has been updated; pods that are
+// updated but not available, such as those crash looping, do not
+// count as a rollout.
+func deploymentRolloutStatus(d *apps.Deployment) status.StatusInfo {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	updated := d.Status.UpdatedReplicas
+	inProgress := d.Status.ObservedGeneration < d.Generation ||
+		updated < desired ||
+		d.Status.Replicas > updated
+	if !inProgress {
+		return status.StatusInfo{}
+	}
+	return rolloutStatus(d.Spec.Paused, updated, desired)
+}
+
+// statefulSetRolloutStatus returns the status of any rollout in
+// progress for the stateful set. The zero value is returned if the
+// stateful set has finished rolling out.
+func statefulSetRolloutStatus(ss *apps.StatefulSet) status.StatusInfo {
+	desired := int32(1)
+	if ss.Spec.Replicas != nil {
+		desired = *ss.Spec.Replicas
+	}
+	updated := ss.Status.UpdatedReplicas
+	if ss.Status.UpdateRevision == "" || ss.Status.CurrentRevision == ss.Status.UpdateRevision {
+		return status.StatusInfo{}
+	}
+	var partition int32
+	if ru := ss.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		partition = *ru.Partition
+	}
+	// Once all pods above the partition have been
+	// updated, the rollout waits to be resumed.
+	paused := partition > 0 && updated >= desired-partition
+	return rolloutStatus(paused, updated, desired)
+}
+
+func rolloutStatus(paused bool, updated, desired int32) status.StatusInfo {
+	if paused {
+		return status.StatusInfo{
+			Status:  status.Waiting,
+			Message: fmt.Sprintf("rolling update paused: %d of %d units updated", updated, desired),
+		}
+	}
+	return status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: fmt.Sprintf("rolling update: %d of %d units updated", updated, desired),
+	}
+}
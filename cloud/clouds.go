@@ -339,6 +339,7 @@ var defaultCloudDescription = map[string]string{
 	"rackspace":   "Rackspace Cloud",
 	"joyent":      "Joyent Cloud",
 	"cloudsigma":  "CloudSigma Cloud",
+	"libvirt":     "libvirt KVM Hypervisor",
 	"lxd":         "LXD Container Hypervisor",
 	"maas":        "Metal As A Service",
 	"openstack":   "Openstack Cloud",
@@ -65,6 +65,20 @@ func (s *AddRelationSuite) TestAddRelationSuccess(c *gc.C) {
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
+func (s *AddRelationSuite) TestAddRelationSchemaWarnings(c *gc.C) {
+	s.mockAPI.addRelationFunc = func(endpoints, viaCIDRs []string) (*params.AddRelationResults, error) {
+		return &params.AddRelationResults{
+			Warnings: []string{`application2:db expects "host", which application1:db does not publish`},
+		}, nil
+	}
+	cmd := application.NewAddRelationCommandForTest(s.mockAPI, s.mockAPI)
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "application1", "application2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains,
+		`application2:db expects "host", which application1:db does not publish`)
+}
+
 func (s *AddRelationSuite) TestAddRelationFail(c *gc.C) {
 	msg := "fail add-relation call at API"
 	s.mockAPI.SetErrors(errors.New(msg))
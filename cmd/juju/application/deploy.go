@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/api/application"
 	apicharms "github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
 	app "github.com/juju/juju/apiserver/facades/client/application"
 	apiparams "github.com/juju/juju/apiserver/params"
@@ -39,6 +40,7 @@ import (
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
 )
@@ -100,6 +102,8 @@ type DeployAPI interface {
 
 	GetBundle(*charm.URL) (charm.Bundle, error)
 
+	PreviewInstances([]apiparams.PreviewInstanceArg) ([]apiparams.PreviewInstanceResult, error)
+
 	WatchAll() (*api.AllWatcher, error)
 }
 
@@ -185,6 +189,10 @@ func (a *deployAPIAdapter) Get(url *charm.URL) (charm.Charm, error) {
 	return a.charmRepoClient.Get(url)
 }
 
+func (a *deployAPIAdapter) PreviewInstances(args []apiparams.PreviewInstanceArg) ([]apiparams.PreviewInstanceResult, error) {
+	return machinemanager.NewClient(a.Connection).PreviewInstances(args)
+}
+
 func (a *deployAPIAdapter) SetAnnotation(annotations map[string]map[string]string) ([]apiparams.ErrorResult, error) {
 	return a.annotationsClient.Set(annotations)
 }
@@ -494,6 +502,12 @@ for the bundle machine 3.
 Only top level machines can be mapped in this way, just as only top level
 machines can be defined in the machines section of the bundle.
 
+The --dry-run option shows what a deployment would do without changing the
+model. For a bundle, the changes the bundle would make are listed. For a charm,
+the instance type, image and availability zone the cloud would use for each
+new machine are shown, along with any constraints that conflict or would be
+ignored, so the effect of --constraints and --to can be checked beforehand.
+
 
 Examples:
     juju deploy mysql               (deploy to a new machine)
@@ -511,6 +525,9 @@ Examples:
     juju deploy mysql -n 5 --constraints mem=8G
     (deploy 5 units to machines with at least 8 GB of memory)
 
+    juju deploy mysql -n 3 --constraints mem=8G --dry-run
+    (show the instances that would be started for 3 units, without deploying)
+
     juju deploy mysql --to zone=us-east-1a
     (provider-dependent; deploy to a specific AZ)
 
@@ -572,9 +589,8 @@ func (c *DeployCommand) Info() *cmd.Info {
 }
 
 var (
-	// TODO(thumper): support dry-run for apps as well as bundles.
 	bundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "map-machines",
 	}
 )
 
@@ -606,7 +622,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
-	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the deploy would do, without deploying")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed to a machine running an unsupported series")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
@@ -941,6 +957,60 @@ func (c *DeployCommand) deployCharm(
 	return errors.Trace(apiRoot.Deploy(args))
 }
 
+// previewDeploy reports the instances the provider would start for the
+// units of the application being deployed, without deploying it. Units
+// placed on existing machines, or in containers on existing machines, do
+// not start new instances and are not previewed.
+func (c *DeployCommand) previewDeploy(ctx *cmd.Context, apiRoot DeployAPI, series string) error {
+	modelType, err := c.ModelType()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelType == model.CAAS {
+		return errors.New("--dry-run is not supported when deploying a charm to a kubernetes model")
+	}
+	uuid, ok := apiRoot.ModelUUID()
+	if !ok {
+		return errors.New("API connection is controller-only (should never happen)")
+	}
+
+	var args []apiparams.PreviewInstanceArg
+	for i := 0; i < c.NumUnits; i++ {
+		var placement *instance.Placement
+		if i < len(c.Placement) {
+			placement = c.Placement[i]
+		}
+		if placement != nil {
+			if _, err := instance.ParseContainerType(placement.Scope); err == nil && placement.Directive == "" {
+				// A container on a new machine; the new machine is previewed.
+				placement = nil
+			} else if placement.Scope != "model-uuid" {
+				ctx.Infof("Unit %d would be placed using %q; no new machine would be started for it.", i, placement)
+				continue
+			} else {
+				placement = &instance.Placement{Scope: uuid, Directive: placement.Directive}
+			}
+		}
+		args = append(args, apiparams.PreviewInstanceArg{
+			Series:      series,
+			Constraints: c.Constraints,
+			Placement:   placement,
+		})
+	}
+	if len(args) == 0 {
+		ctx.Infof("No new machines would be started.")
+		return nil
+	}
+
+	results, err := apiRoot.PreviewInstances(args)
+	if errors.IsNotSupported(err) {
+		return errors.New("--dry-run is not supported by this controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return common.WriteInstancePreviews(ctx.Stdout, results)
+}
+
 const parseBindErrorPrefix = "--bind must be in the form '[<default-space>] [<endpoint-name>=<space> ...]'. "
 
 // parseBind parses the --bind option. Valid forms are:
@@ -1070,6 +1140,9 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 		}
 		formattedCharmURL := userCharmURL.String()
 		ctx.Infof("Located charm %q.", formattedCharmURL)
+		if c.DryRun {
+			return errors.Trace(c.previewDeploy(ctx, api, userCharmURL.Series))
+		}
 		ctx.Infof("Deploying charm %q.", formattedCharmURL)
 		return errors.Trace(c.deployCharm(
 			charmstore.CharmID{URL: userCharmURL},
@@ -1222,6 +1295,14 @@ func (c *DeployCommand) maybeReadLocalCharm(apiRoot DeployAPI) (deployFn, error)
 			return errors.Trace(err)
 		}
 
+		if c.DryRun {
+			if ch.Meta().Subordinate {
+				ctx.Infof("Subordinate applications do not start new machines.")
+				return nil
+			}
+			return errors.Trace(c.previewDeploy(ctx, apiRoot, curl.Series))
+		}
+
 		if curl, err = apiRoot.AddLocalCharm(curl, ch); err != nil {
 			return errors.Trace(err)
 		}
@@ -1355,6 +1436,11 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 			return errors.Errorf("%v. Use --force to deploy the charm anyway.", err)
 		}
 
+		if c.DryRun {
+			ctx.Infof("Located charm %q.", storeCharmOrBundleURL.String())
+			return errors.Trace(c.previewDeploy(ctx, apiRoot, series))
+		}
+
 		// Store the charm in the controller
 		curl, csMac, err := addCharmFromURL(apiRoot, storeCharmOrBundleURL, channel)
 		if err != nil {
@@ -1579,6 +1579,55 @@ func (s *DeployUnitTestSuite) TestDeployLocalWithBundleOverlay(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "flags provided but not supported when deploying a charm: --overlay")
 }
 
+func (s *DeployUnitTestSuite) TestDeployLocalDryRun(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+
+	// Neither AddLocalCharm nor Deploy is mocked, so the dry run
+	// fails if it tries to deploy anything.
+	fakeAPI.Call("PreviewInstances", []params.PreviewInstanceArg{{
+		Series:      "trusty",
+		Constraints: constraints.MustParse("mem=4G"),
+	}, {
+		Series:      "trusty",
+		Constraints: constraints.MustParse("mem=4G"),
+		Placement:   &instance.Placement{Scope: "deadbeef-0bad-400d-8000-4b1d0d06f00d", Directive: "zone=z2"},
+	}}).Returns([]params.PreviewInstanceResult{{
+		Series:           "trusty",
+		Constraints:      constraints.MustParse("mem=4G"),
+		InstanceType:     &params.InstanceType{Name: "m3.medium"},
+		ImageId:          "ami-1",
+		AvailabilityZone: "z1",
+	}, {
+		Series:           "trusty",
+		Constraints:      constraints.MustParse("mem=4G"),
+		InstanceType:     &params.InstanceType{Name: "m3.medium"},
+		ImageId:          "ami-1",
+		AvailabilityZone: "z2",
+	}}, error(nil))
+
+	ctx, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty", "-n", "3",
+		"--constraints", "mem=4G", "--to", "lxd,zone=z2,0", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Machine  Series  Instance type  Image  Zone  Constraints  Notes\n"+
+		"new-1    trusty  m3.medium      ami-1  z1    mem=4096M    \n"+
+		"new-2    trusty  m3.medium      ami-1  z2    mem=4096M    \n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		`Unit 2 would be placed using "#:0"; no new machine would be started for it.`+"\n")
+}
+
+func (s *DeployUnitTestSuite) TestDeployLocalDryRunNotSupported(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+	fakeAPI.Call("PreviewInstances", []params.PreviewInstanceArg{{
+		Series: "trusty",
+	}}).Returns([]params.PreviewInstanceResult(nil), errors.NotSupportedf("previewing instances"))
+
+	_, err := s.runDeploy(c, fakeAPI, charmDir.Path, "--series", "trusty", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported by this controller")
+}
+
 func (s *DeployUnitTestSuite) TestDeployLocalCharmGivesCorrectUserMessage(c *gc.C) {
 	// Copy multi-series charm to path where we can deploy it from
 	charmDir := s.makeCharmDir(c, "multi-series")
@@ -1866,6 +1915,11 @@ func (f *fakeDeployAPI) GetBundle(url *charm.URL) (charm.Bundle, error) {
 	return results[0].(charm.Bundle), jujutesting.TypeAssertError(results[1])
 }
 
+func (f *fakeDeployAPI) PreviewInstances(args []params.PreviewInstanceArg) ([]params.PreviewInstanceResult, error) {
+	results := f.MethodCall(f, "PreviewInstances", args)
+	return results[0].([]params.PreviewInstanceResult), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) Status(patterns []string) (*params.FullStatus, error) {
 	results := f.MethodCall(f, "Status", patterns)
 	return results[0].(*params.FullStatus), jujutesting.TypeAssertError(results[1])
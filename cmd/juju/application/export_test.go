@@ -5,6 +5,7 @@ package application
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -22,7 +23,9 @@ func NewUpgradeCharmCommandForTest(
 	newCharmUpgradeClient func(api.Connection) CharmUpgradeClient,
 	newModelConfigGetter func(api.Connection) ModelConfigGetter,
 	newResourceLister func(api.Connection) (ResourceLister, error),
+	newHookHistoryClient func(api.Connection) HookHistoryClient,
 	charmStoreURLGetter func(api.Connection) (string, error),
+	clock clock.Clock,
 ) cmd.Command {
 	cmd := &upgradeCharmCommand{
 		DeployResources:       deployResources,
@@ -32,7 +35,9 @@ func NewUpgradeCharmCommandForTest(
 		NewCharmUpgradeClient: newCharmUpgradeClient,
 		NewModelConfigGetter:  newModelConfigGetter,
 		NewResourceLister:     newResourceLister,
+		NewHookHistoryClient:  newHookHistoryClient,
 		CharmStoreURLGetter:   charmStoreURLGetter,
+		Clock:                 clock,
 	}
 	cmd.SetClientStore(store)
 	cmd.SetAPIOpen(apiOpen)
@@ -104,6 +109,16 @@ func NewSetSeriesCommandForTest(
 	return modelcmd.Wrap(cmd)
 }
 
+// NewPauseUnitCommandForTest returns a pause-unit or resume-unit command
+// with the api provided as specified.
+func NewPauseUnitCommandForTest(api SetUnitsPausedAPI, paused bool, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &pauseUnitCommand{paused: paused, newAPIFunc: func() (SetUnitsPausedAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewSuspendRelationCommandForTest returns a SuspendRelationCommand with the api provided as specified.
 func NewSuspendRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &suspendRelationCommand{newAPIFunc: func() (SetRelationSuspendedAPI, error) {
@@ -19,6 +19,13 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+If the model's expose-load-balancers setting is true and the cloud
+supports it (currently Amazon EC2, Google Compute Engine, and OpenStack
+with Octavia), a load balancer is also created in front of the units of
+the exposed application for each port range they open. Load balancers
+are removed when the application is unexposed or the model is destroyed,
+and their addresses are shown by "juju status".
+
 Examples:
     juju expose wordpress
 
@@ -0,0 +1,122 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var pauseUnitHelpSummary = `
+Stops units from running hooks and actions.`[1:]
+
+var pauseUnitHelpDetails = `
+The agents of the specified units will finish any hook or action they are
+running, and then run no more until the units are resumed. Events that occur
+while a unit is paused are queued, and are acted on when it is resumed. The
+agent stays connected to the controller, and its status is shown as paused.
+
+Examples:
+    juju pause-unit mysql/0
+    juju pause-unit mysql/0 mysql/1
+
+See also:
+    resume-unit
+    resolved`
+
+var resumeUnitHelpSummary = `
+Resumes hook and action execution for paused units.`[1:]
+
+var resumeUnitHelpDetails = `
+The agents of the specified units will run hooks for any events that were
+queued while they were paused, and continue as normal.
+
+Examples:
+    juju resume-unit mysql/0
+    juju resume-unit mysql/0 mysql/1
+
+See also:
+    pause-unit`
+
+// NewPauseUnitCommand returns a command to pause units.
+func NewPauseUnitCommand() cmd.Command {
+	cmd := &pauseUnitCommand{paused: true}
+	cmd.newAPIFunc = cmd.newAPI
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResumeUnitCommand returns a command to resume paused units.
+func NewResumeUnitCommand() cmd.Command {
+	cmd := &pauseUnitCommand{paused: false}
+	cmd.newAPIFunc = cmd.newAPI
+	return modelcmd.Wrap(cmd)
+}
+
+// SetUnitsPausedAPI defines the API methods that the pause/resume unit
+// commands use.
+type SetUnitsPausedAPI interface {
+	Close() error
+	SetUnitsPaused(units []string, paused bool) error
+}
+
+// pauseUnitCommand implements both pause-unit and resume-unit.
+type pauseUnitCommand struct {
+	modelcmd.ModelCommandBase
+	paused     bool
+	unitNames  []string
+	newAPIFunc func() (SetUnitsPausedAPI, error)
+}
+
+func (c *pauseUnitCommand) Info() *cmd.Info {
+	if c.paused {
+		return &cmd.Info{
+			Name:    "pause-unit",
+			Args:    "<unit> [<unit> ...]",
+			Purpose: pauseUnitHelpSummary,
+			Doc:     pauseUnitHelpDetails,
+		}
+	}
+	return &cmd.Info{
+		Name:    "resume-unit",
+		Args:    "<unit> [<unit> ...]",
+		Purpose: resumeUnitHelpSummary,
+		Doc:     resumeUnitHelpDetails,
+	}
+}
+
+func (c *pauseUnitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit specified")
+	}
+	for _, u := range args {
+		if !names.IsValidUnit(u) {
+			return errors.NotValidf("unit name %q", u)
+		}
+	}
+	c.unitNames = args
+	return nil
+}
+
+func (c *pauseUnitCommand) newAPI() (SetUnitsPausedAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+func (c *pauseUnitCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetUnitsPaused(c.unitNames, c.paused)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PauseUnitSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockPauseUnitAPI
+}
+
+func (s *PauseUnitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockPauseUnitAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&PauseUnitSuite{})
+
+func (s *PauseUnitSuite) run(c *gc.C, paused bool, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, application.NewPauseUnitCommandForTest(s.mockAPI, paused, store), args...)
+	return err
+}
+
+func (s *PauseUnitSuite) TestInvalidArguments(c *gc.C) {
+	err := s.run(c, true)
+	c.Assert(err, gc.ErrorMatches, "no unit specified")
+
+	err = s.run(c, false, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *PauseUnitSuite) TestPauseUnit(c *gc.C) {
+	err := s.run(c, true, "mysql/0", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetUnitsPaused", []interface{}{[]string{"mysql/0", "mysql/1"}, true}},
+		{"Close", nil},
+	})
+}
+
+func (s *PauseUnitSuite) TestResumeUnit(c *gc.C) {
+	err := s.run(c, false, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetUnitsPaused", []interface{}{[]string{"mysql/0"}, false}},
+		{"Close", nil},
+	})
+}
+
+func (s *PauseUnitSuite) TestPauseUnitFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.run(c, true, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *PauseUnitSuite) TestPauseUnitBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestPauseUnitBlocked"))
+	err := s.run(c, true, "mysql/0")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestPauseUnitBlocked.*")
+}
+
+type mockPauseUnitAPI struct {
+	*testing.Stub
+}
+
+func (s mockPauseUnitAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockPauseUnitAPI) SetUnitsPaused(units []string, paused bool) error {
+	s.MethodCall(s, "SetUnitsPaused", units, paused)
+	return s.NextErr()
+}
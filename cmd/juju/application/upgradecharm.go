@@ -10,6 +10,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6"
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/juju/charmrepo.v3"
@@ -57,7 +58,11 @@ func NewUpgradeCharmCommand() cmd.Command {
 			}
 			return resclient, nil
 		},
+		NewHookHistoryClient: func(conn api.Connection) HookHistoryClient {
+			return conn.Client()
+		},
 		CharmStoreURLGetter: getCharmStoreAPIURL,
+		Clock:               clock.WallClock,
 	}
 	return modelcmd.Wrap(cmd)
 }
@@ -102,7 +107,9 @@ type upgradeCharmCommand struct {
 	NewCharmUpgradeClient func(api.Connection) CharmUpgradeClient
 	NewModelConfigGetter  func(api.Connection) ModelConfigGetter
 	NewResourceLister     func(api.Connection) (ResourceLister, error)
+	NewHookHistoryClient  func(api.Connection) HookHistoryClient
 	CharmStoreURLGetter   func(api.Connection) (string, error)
+	Clock                 clock.Clock
 
 	ApplicationName string
 	ForceUnits      bool
@@ -111,6 +118,11 @@ type upgradeCharmCommand struct {
 	CharmPath       string
 	Revision        int // defaults to -1 (latest)
 
+	// Watch, if true, causes the command to keep running after the
+	// upgrade, re-uploading the charm at CharmPath and upgrading the
+	// application each time it changes.
+	Watch bool
+
 	// Resources is a map of resource name to filename to be uploaded on upgrade.
 	Resources map[string]string
 
@@ -195,6 +207,15 @@ is determined by the contents of the charm at the specified path.
 number with --switch, give it in the charm URL, for instance "cs:wordpress-5"
 would specify revision number 5 of the wordpress charm.
 
+When developing a local charm, the --watch flag may be used with --path to keep
+the command running after the upgrade. Each time the charm directory changes,
+the charm is uploaded again and the application is upgraded to it, as if
+--force-units had been specified, so that units in an error state are also
+upgraded. The outcome of each hook run by the application's units is printed
+as it completes. Press Ctrl-C to stop watching.
+
+  juju upgrade-charm foo --path ./foo --watch
+
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
@@ -220,6 +241,7 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.BoolVar(&c.Watch, "watch", false, "Keep upgrading to the charm at --path each time it changes")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -243,6 +265,9 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.Watch && c.CharmPath == "" {
+		return errors.Errorf("--watch requires --path")
+	}
 	return nil
 }
 
@@ -355,7 +380,46 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
-	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
+	err = block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
+	if err != nil || !c.Watch {
+		return err
+	}
+
+	// Keep uploading the charm and upgrading the application to it
+	// each time the charm directory changes. Config and storage
+	// constraints were applied above, so they are not passed again.
+	reload := func() (*charm.URL, error) {
+		chID, csMac, err := c.addCharm(charmAdder, charmRepo, modelConfig, oldURL, c.CharmPath, deployedSeries)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids, err := c.upgradeResources(apiRoot, charmsClient, resourceLister, chID, csMac)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		err = charmUpgradeClient.SetCharm(application.SetCharmConfig{
+			ApplicationName: c.ApplicationName,
+			CharmID:         chID,
+			ForceSeries:     c.ForceSeries,
+			ForceUnits:      true,
+			ResourceIDs:     ids,
+		})
+		if err != nil {
+			return nil, block.ProcessBlockedError(err, block.BlockChange)
+		}
+		return chID.URL, nil
+	}
+	watcher := &localCharmWatcher{
+		clock:       c.Clock,
+		path:        c.CharmPath,
+		application: c.ApplicationName,
+		client:      c.NewHookHistoryClient(apiRoot),
+		reload:      reload,
+	}
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	return watcher.run(ctx, interrupted)
 }
 
 // upgradeResources pushes metadata up to the server for each resource defined
@@ -11,6 +11,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -59,6 +60,8 @@ type UpgradeCharmSuite struct {
 	charmUpgradeClient mockCharmUpgradeClient
 	modelConfigGetter  mockModelConfigGetter
 	resourceLister     mockResourceLister
+	hookHistoryClient  mockHookHistoryClient
+	clock              *testing.Clock
 	cmd                cmd.Command
 }
 
@@ -117,6 +120,8 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 	s.charmUpgradeClient = mockCharmUpgradeClient{charmURL: currentCharmURL}
 	s.modelConfigGetter = mockModelConfigGetter{}
 	s.resourceLister = mockResourceLister{}
+	s.hookHistoryClient = mockHookHistoryClient{}
+	s.clock = testing.NewClock(time.Time{})
 
 	store := jujuclient.NewMemStore()
 	store.CurrentControllerName = "foo"
@@ -160,10 +165,15 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 			s.AddCall("NewResourceLister", conn)
 			return &s.resourceLister, s.NextErr()
 		},
+		func(conn api.Connection) HookHistoryClient {
+			s.AddCall("NewHookHistoryClient", conn)
+			return &s.hookHistoryClient
+		},
 		func(conn api.Connection) (string, error) {
 			s.AddCall("CharmStoreURLGetter", conn)
 			return "testing.api.charmstore", s.NextErr()
 		},
+		s.clock,
 	)
 }
 
@@ -336,6 +346,12 @@ func (s *UpgradeCharmErrorsStateSuite) TestSwitchAndPathFails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "--switch and --path are mutually exclusive")
 }
 
+func (s *UpgradeCharmErrorsStateSuite) TestWatchWithoutPathFails(c *gc.C) {
+	s.deployApplication(c)
+	err := runUpgradeCharm(c, "riak", "--watch")
+	c.Assert(err, gc.ErrorMatches, "--watch requires --path")
+}
+
 func (s *UpgradeCharmErrorsStateSuite) TestInvalidRevision(c *gc.C) {
 	s.deployApplication(c)
 	err := runUpgradeCharm(c, "riak", "--revision=blah")
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+)
+
+const (
+	// watchPollInterval is how often the charm directory, and the
+	// hook history of the application's units, are checked.
+	watchPollInterval = 500 * time.Millisecond
+
+	// watchDebounce is how long the charm directory must remain
+	// unchanged after an edit before the charm is uploaded, so that
+	// a burst of saves results in a single upgrade.
+	watchDebounce = time.Second
+)
+
+// HookHistoryClient defines the API methods that upgrade-charm --watch
+// uses to report the outcome of hooks run by the application's units.
+type HookHistoryClient interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+}
+
+// localCharmWatcher watches a local charm directory, calling reload
+// each time its contents change, and reports the hooks run by the
+// units of the application as they complete.
+type localCharmWatcher struct {
+	clock       clock.Clock
+	path        string
+	application string
+	client      HookHistoryClient
+	reload      func() (*charm.URL, error)
+
+	// since records, for each unit, the start time of the most
+	// recent hook that has been reported.
+	since map[string]time.Time
+	start time.Time
+}
+
+// run watches the charm directory until a value is received on stop.
+func (w *localCharmWatcher) run(ctx *cmd.Context, stop <-chan os.Signal) error {
+	fingerprint, err := charmDirFingerprint(w.path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.start = w.clock.Now()
+	w.since = make(map[string]time.Time)
+	ctx.Infof("Watching %q for changes, press Ctrl-C to stop.", w.path)
+
+	var changed time.Time
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-w.clock.After(watchPollInterval):
+		}
+		if err := w.reportHooks(ctx); err != nil {
+			logger.Warningf("cannot report hooks: %v", err)
+		}
+
+		current, err := charmDirFingerprint(w.path)
+		if err != nil {
+			logger.Warningf("cannot read charm directory: %v", err)
+			continue
+		}
+		now := w.clock.Now()
+		if current != fingerprint {
+			fingerprint = current
+			changed = now
+			continue
+		}
+		if changed.IsZero() || now.Sub(changed) < watchDebounce {
+			continue
+		}
+		changed = time.Time{}
+
+		curl, err := w.reload()
+		if err != nil {
+			ctx.Infof("ERROR cannot upgrade charm: %v", err)
+			continue
+		}
+		ctx.Infof("Upgraded %q to charm %q.", w.application, curl)
+	}
+}
+
+// reportHooks prints the hooks that the application's units have
+// completed since they were last reported.
+func (w *localCharmWatcher) reportHooks(ctx *cmd.Context) error {
+	fullStatus, err := w.client.Status([]string{w.application})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appStatus, ok := fullStatus.Applications[w.application]
+	if !ok {
+		return errors.NotFoundf("application %q", w.application)
+	}
+	unitNames := make([]string, 0, len(appStatus.Units))
+	for name := range appStatus.Units {
+		unitNames = append(unitNames, name)
+	}
+	sort.Strings(unitNames)
+
+	for _, name := range unitNames {
+		from, ok := w.since[name]
+		if !ok {
+			from = w.start
+		}
+		history, err := w.client.StatusHistory(
+			status.KindHook,
+			names.NewUnitTag(name),
+			status.StatusHistoryFilter{FromDate: &from},
+		)
+		if err != nil {
+			return errors.Annotatef(err, "getting hook history for %q", name)
+		}
+		// History is returned newest first.
+		for i := len(history) - 1; i >= 0; i-- {
+			h := history[i]
+			if h.Since == nil || !h.Since.After(from) {
+				continue
+			}
+			from = *h.Since
+			fmt.Fprintf(ctx.Stdout, "%s %s: %s\n", from.Local().Format("15:04:05"), name, h.Info)
+		}
+		w.since[name] = from
+	}
+	return nil
+}
+
+// charmDirFingerprint returns a value that changes whenever a file in
+// the charm directory is added, removed or modified. Files in version
+// control directories are ignored.
+func charmDirFingerprint(path string) (string, error) {
+	hash := sha256.New()
+	err := filepath.Walk(path, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case ".git", ".bzr", ".hg":
+				return filepath.SkipDir
+			}
+			// A directory's modification time changes with its
+			// entries, which are fingerprinted themselves.
+			return nil
+		}
+		fmt.Fprintf(hash, "%s %v %d %d\n", name, info.Mode(), info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
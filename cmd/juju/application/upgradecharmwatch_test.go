@@ -0,0 +1,221 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LocalCharmWatcherSuite struct {
+	testing.IsolationSuite
+
+	clock   *testing.Clock
+	dir     string
+	client  mockHookHistoryClient
+	reloads chan struct{}
+	watcher *localCharmWatcher
+}
+
+var _ = gc.Suite(&LocalCharmWatcherSuite{})
+
+func (s *LocalCharmWatcherSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.dir = c.MkDir()
+	s.writeFile(c, "metadata.yaml", "name: foo")
+	s.client = mockHookHistoryClient{}
+	s.reloads = make(chan struct{}, 10)
+	s.watcher = &localCharmWatcher{
+		clock:       s.clock,
+		path:        s.dir,
+		application: "foo",
+		client:      &s.client,
+		reload: func() (*charm.URL, error) {
+			s.reloads <- struct{}{}
+			return charm.MustParseURL("local:quantal/foo-2"), nil
+		},
+	}
+}
+
+func (s *LocalCharmWatcherSuite) writeFile(c *gc.C, name, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.dir, name), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// start runs the watcher in the background, returning a function
+// that stops it and waits for it to finish.
+func (s *LocalCharmWatcherSuite) start(c *gc.C) (*cmd.Context, func()) {
+	ctx := cmdtesting.Context(c)
+	stop := make(chan os.Signal)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.watcher.run(ctx, stop)
+	}()
+	return ctx, func() {
+		close(stop)
+		select {
+		case err := <-done:
+			c.Assert(err, jc.ErrorIsNil)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for watcher to stop")
+		}
+	}
+}
+
+func (s *LocalCharmWatcherSuite) poll(c *gc.C) {
+	err := s.clock.WaitAdvance(watchPollInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LocalCharmWatcherSuite) assertReloads(c *gc.C, expect int) {
+	for i := 0; i < expect; i++ {
+		select {
+		case <-s.reloads:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for reload")
+		}
+	}
+	select {
+	case <-s.reloads:
+		c.Fatalf("unexpected reload")
+	default:
+	}
+}
+
+func (s *LocalCharmWatcherSuite) TestReloadsAfterChangeSettles(c *gc.C) {
+	ctx, stop := s.start(c)
+
+	s.writeFile(c, "hooks-install", "#!/bin/sh")
+	s.poll(c) // change seen
+	s.writeFile(c, "hooks-start", "#!/bin/sh")
+	s.poll(c) // another change seen
+	s.poll(c) // unchanged, but not yet for long enough
+	s.poll(c) // unchanged for the debounce period; reload
+	s.poll(c) // make sure the previous poll completed
+	stop()
+
+	s.assertReloads(c, 1)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Upgraded "foo" to charm "local:quantal/foo-2".`)
+}
+
+func (s *LocalCharmWatcherSuite) TestNoReloadWithoutChange(c *gc.C) {
+	_, stop := s.start(c)
+	for i := 0; i < 5; i++ {
+		s.poll(c)
+	}
+	stop()
+	s.assertReloads(c, 0)
+}
+
+func (s *LocalCharmWatcherSuite) TestReloadErrorKeepsWatching(c *gc.C) {
+	s.watcher.reload = func() (*charm.URL, error) {
+		s.reloads <- struct{}{}
+		return nil, errors.New("boom")
+	}
+	ctx, stop := s.start(c)
+
+	s.writeFile(c, "hooks-install", "#!/bin/sh")
+	for i := 0; i < 4; i++ {
+		s.poll(c)
+	}
+	s.writeFile(c, "hooks-install", "#!/bin/bash")
+	for i := 0; i < 4; i++ {
+		s.poll(c)
+	}
+	stop()
+
+	s.assertReloads(c, 2)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "ERROR cannot upgrade charm: boom")
+}
+
+func (s *LocalCharmWatcherSuite) TestReportsHooks(c *gc.C) {
+	start := s.clock.Now()
+	ran := start.Add(time.Second)
+	failed := ran.Add(time.Second)
+	s.client.units = []string{"foo/1", "foo/0"}
+	s.client.history = status.History{{
+		Status: status.Error,
+		Info:   `"config-changed" hook failed with exit code 1 (took 1s)`,
+		Since:  &failed,
+	}, {
+		Status: status.Idle,
+		Info:   `ran "upgrade-charm" hook (took 2s)`,
+		Since:  &ran,
+	}, {
+		Status: status.Idle,
+		Info:   `ran "install" hook (took 3s)`,
+		Since:  &start,
+	}}
+	ctx, stop := s.start(c)
+	s.poll(c)
+	s.poll(c)
+	stop()
+
+	ranAt := ran.Local().Format("15:04:05")
+	failedAt := failed.Local().Format("15:04:05")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		ranAt+` foo/0: ran "upgrade-charm" hook (took 2s)`+"\n"+
+		failedAt+` foo/0: "config-changed" hook failed with exit code 1 (took 1s)`+"\n"+
+		ranAt+` foo/1: ran "upgrade-charm" hook (took 2s)`+"\n"+
+		failedAt+` foo/1: "config-changed" hook failed with exit code 1 (took 1s)`+"\n",
+	)
+	s.client.CheckCall(c, 1, "StatusHistory", status.KindHook, names.NewUnitTag("foo/0"), start)
+	s.client.CheckCall(c, 4, "StatusHistory", status.KindHook, names.NewUnitTag("foo/0"), failed)
+}
+
+func (s *LocalCharmWatcherSuite) TestFingerprintIgnoresVCS(c *gc.C) {
+	before, err := charmDirFingerprint(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = os.Mkdir(filepath.Join(s.dir, ".git"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	after, err := charmDirFingerprint(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(after, gc.Equals, before)
+
+	s.writeFile(c, "config.yaml", "options: {}")
+	after, err = charmDirFingerprint(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(after, gc.Not(gc.Equals), before)
+}
+
+type mockHookHistoryClient struct {
+	testing.Stub
+	units   []string
+	history status.History
+}
+
+func (m *mockHookHistoryClient) Status(patterns []string) (*params.FullStatus, error) {
+	m.MethodCall(m, "Status", patterns)
+	units := make(map[string]params.UnitStatus)
+	for _, name := range m.units {
+		units[name] = params.UnitStatus{}
+	}
+	return &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {Units: units},
+		},
+	}, m.NextErr()
+}
+
+func (m *mockHookHistoryClient) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	m.MethodCall(m, "StatusHistory", kind, tag, *filter.FromDate)
+	return m.history, m.NextErr()
+}
@@ -46,8 +46,8 @@ clouds:
 
 If the named cloud already exists, the `[1:] + "`--replace`" + ` option is required to 
 overwrite its configuration.
-Known cloud types: azure, cloudsigma, ec2, gce, joyent, lxd, maas, manual,
-openstack, rackspace
+Known cloud types: azure, cloudsigma, ec2, gce, joyent, libvirt, lxd, maas,
+manual, openstack, rackspace
 
 Examples:
     juju add-cloud mycloud ~/mycloud.yaml
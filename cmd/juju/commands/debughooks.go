@@ -10,6 +10,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v2"
 
@@ -31,16 +32,48 @@ func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 // debugHooksCommand is responsible for launching a ssh shell on a given unit or machine.
 type debugHooksCommand struct {
 	sshCommand
+	modelcmd.IAASOnlyCommand
 	hooks []string
 
+	debugPort int
+	debugAt   string
+	wrapper   string
+
 	getActionAPI func() (ActionsAPI, error)
 }
 
 const debugHooksDoc = `
 Interactively debug hooks or actions remotely on an application unit.
 
+By default, a tmux session is started on the unit, and a new window is
+opened in it for each matching hook or action, from which it must be
+run manually.
+
+If any of --debug-port, --debug-at or --wrapper are specified, no tmux
+session is started. Instead, matching hooks and actions are run by the
+unit agent as usual, so that a charm can wait for a remote debugger to
+attach, such as one in an IDE. The session lasts until debug-hooks is
+interrupted.
+
+  --debug-port is passed to hooks as $JUJU_DEBUG_PORT, and is forwarded
+  from the local machine to the unit over ssh, so that a debugger can
+  attach to localhost.
+
+  --debug-at is passed to hooks as $JUJU_DEBUG_AT, for charms to decide
+  where to stop.
+
+  --wrapper is a command that hooks are run under, with the path of the
+  hook appended to it.
+
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
+
+Examples:
+
+    juju debug-hooks mysql/0 config-changed
+
+    juju debug-hooks mysql/0 install --debug-port 5678 \
+        --wrapper "python3 -m debugpy --listen 5678 --wait-for-client"
 `
 
 func (c *debugHooksCommand) Info() *cmd.Info {
@@ -52,7 +85,17 @@ func (c *debugHooksCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.IntVar(&c.debugPort, "debug-port", 0, "Port to forward to the unit and pass to hooks as $JUJU_DEBUG_PORT")
+	f.StringVar(&c.debugAt, "debug-at", "", "Breakpoints to pass to hooks as $JUJU_DEBUG_AT")
+	f.StringVar(&c.wrapper, "wrapper", "", "Command to run hooks under")
+}
+
 func (c *debugHooksCommand) Init(args []string) error {
+	if c.debugPort < 0 || c.debugPort > 65535 {
+		return errors.Errorf("invalid debug port %d", c.debugPort)
+	}
 	if len(args) < 1 {
 		return errors.Errorf("no unit name specified")
 	}
@@ -189,9 +232,25 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	debugctx := unitdebug.NewHooksContext(c.Target)
-	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks)))
+	options := unitdebug.DebugOptions{
+		Port:    c.debugPort,
+		At:      c.debugAt,
+		Wrapper: c.wrapper,
+	}
+	var clientScript string
+	if options.IsZero() {
+		clientScript = unitdebug.ClientScript(debugctx, c.hooks)
+	} else {
+		clientScript = unitdebug.DebugClientScript(debugctx, c.hooks, options)
+	}
+	script := base64.StdEncoding.EncodeToString([]byte(clientScript))
 	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, script)
-	args := []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
+	var args []string
+	if c.debugPort != 0 {
+		// Forward the port so that a local debugger can attach.
+		args = append(args, "-L", fmt.Sprintf("%d:localhost:%d", c.debugPort, c.debugPort))
+	}
+	args = append(args, fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd))
 	c.Args = args
 	return c.sshCommand.Run(ctx)
 }
@@ -110,6 +110,19 @@ var debugHooksTests = []struct {
 	args:        []string{"mysql/0", "juju-info-relation-joined"},
 	hostChecker: validAddresses("0.public"),
 	expected:    nil,
+}, {
+	info:        `debug port is forwarded to the unit`,
+	args:        []string{"mysql/0", "install", "--debug-port", "5678", "--wrapper", "python3 -m debugpy"},
+	hostChecker: validAddresses("0.public"),
+	expected: &argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		argsMatch:       `ubuntu@0\.public -L 5678:localhost:5678 sudo /bin/bash .+`,
+	},
+}, {
+	info:  `invalid debug port`,
+	args:  []string{"mysql/0", "--debug-port", "-1"},
+	error: `invalid debug port -1`,
 }, {
 	info:  `invalid unit syntax`,
 	args:  []string{"mysql"},
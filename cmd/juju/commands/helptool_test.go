@@ -53,6 +53,14 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    secret-add               add a new secret
+    secret-get               print the value of a secret
+    secret-grant             grant access to a secret
+    secret-revoke            revoke access to a secret
+    secret-rotate            set a new value for a secret
+    state-delete             delete charm state stored by the controller
+    state-get                print charm state stored by the controller
+    state-set                set charm state stored by the controller
     status-get               print status information
     status-set               set status information
     storage-add              add storage instances
@@ -97,6 +105,14 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-add",
+	"secret-get",
+	"secret-grant",
+	"secret-revoke",
+	"secret-rotate",
+	"state-delete",
+	"state-get",
+	"state-set",
 	"status-get",
 	"status-set",
 	"storage-add",
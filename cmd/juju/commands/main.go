@@ -40,6 +40,7 @@ import (
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/cmd/juju/resource"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
@@ -288,6 +289,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(firewall.NewSetFirewallRuleCommand())
 	r.Register(firewall.NewListFirewallRulesCommand())
 
+	// Secret commands.
+	r.Register(secrets.NewListSecretsCommand())
+
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
 	r.Register(application.NewRemoveApplicationCommand())
@@ -304,6 +308,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
+	r.Register(application.NewPauseUnitCommand())
+	r.Register(application.NewResumeUnitCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 
@@ -358,6 +364,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewInstanceTypesCommand())
 
 	if featureflag.Enabled(feature.UpgradeSeries) {
 		r.Register(machine.NewUpgradeSeriesCommand())
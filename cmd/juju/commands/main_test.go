@@ -453,6 +453,7 @@ var commandNames = []string{
 	"hook-tools",
 	"import-filesystem",
 	"import-ssh-key",
+	"instance-types",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -471,6 +472,7 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -489,6 +491,7 @@ var commandNames = []string{
 	"models",
 	"offer",
 	"offers",
+	"pause-unit",
 	"payloads",
 	"plans",
 	"regions",
@@ -515,11 +518,13 @@ var commandNames = []string{
 	"resources",
 	"restore-backup",
 	"resume-relation",
+	"resume-unit",
 	"retry-provisioning",
 	"revoke",
 	"run",
 	"run-action",
 	"scp",
+	"secrets",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
@@ -6,6 +6,7 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -21,6 +22,8 @@ import (
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -39,7 +42,6 @@ func newRunCommand(store jujuclient.ClientStore, timeAfter func(time.Duration) <
 // runCommand is responsible for running arbitrary commands on remote machines.
 type runCommand struct {
 	modelcmd.ModelCommandBase
-	modelcmd.IAASOnlyCommand
 	out          cmd.Output
 	all          bool
 	timeout      time.Duration
@@ -47,6 +49,8 @@ type runCommand struct {
 	applications []string
 	units        []string
 	commands     string
+	stream       bool
+	failFast     bool
 	timeAfter    func(time.Duration) <-chan time.Time
 }
 
@@ -77,6 +81,10 @@ is equivalent to
 Commands run for applications or units are executed in a 'hook context' for
 the unit.
 
+On kubernetes models, only applications and units may be targeted. The
+commands are run in the workload container of each unit, outside of a hook
+context.
+
 --all is provided as a simple way to run the command on all the machines
 in the model.  If you specify --all you cannot provide additional
 targets.
@@ -84,6 +92,18 @@ targets.
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
+--stream writes the result of each target as soon as that target
+finishes, rather than waiting for all of them. With the default output
+format, the output of each target is written under a heading naming it,
+and a summary of the exit code of every target is printed once all have
+completed; with other formats, each result is written as a separate
+document. When streaming, the command fails if any of the targets
+failed, whatever the output format.
+
+--fail-fast cancels any runs still pending as soon as one target fails.
+Runs that have already started cannot be cancelled, but juju run stops
+waiting for them.
+
 If you need to pass flags to the command being run, you must precede the
 command and its arguments with "--", to tell "juju run" to stop processing
 those arguments. For example:
@@ -110,6 +130,8 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 	f.BoolVar(&c.all, "all", false, "Run the commands on all the machines")
 	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait before the remote command is considered to have failed")
+	f.BoolVar(&c.stream, "stream", false, "Write the result of each target as soon as it finishes")
+	f.BoolVar(&c.failFast, "fail-fast", false, "Cancel pending runs as soon as one target fails")
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.applications), "a", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.applications), "app", "")
@@ -222,6 +244,14 @@ func ConvertActionResults(result params.ActionResult, query actionQuery) map[str
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
+	modelType, err := c.ModelType()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelType == model.CAAS && (c.all || len(c.machines) > 0) {
+		return errors.Errorf("kubernetes models have no machines; specify --application or --unit")
+	}
+
 	client, err := getRunAPIClient(c)
 	if err != nil {
 		return err
@@ -283,8 +313,27 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.New("no actions were successfully enqueued, aborting")
 	}
 
+	// When streaming, each result is written as soon as it is
+	// available. With the default format, a summary of exit codes
+	// follows once all of the runs have finished.
+	humanOutput := c.out.Name() == "default"
+	var summary []runSummary
+	var writeErr error
+	record := func(query actionQuery, status string, value map[string]interface{}) {
+		if !c.stream {
+			return
+		}
+		summary = append(summary, newRunSummary(query, status, value))
+		if humanOutput {
+			writeStreamedResult(ctx, query, value)
+		} else if err := c.out.Write(ctx, value); err != nil && writeErr == nil {
+			writeErr = err
+		}
+	}
+
 	timeout := c.timeAfter(c.timeout)
 	values := []interface{}{}
+	failed := 0
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
@@ -301,10 +350,25 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			value := ConvertActionResults(result, actionsToQuery[i])
+			values = append(values, value)
+			if runFailed(result.Status, value) {
+				failed++
+			}
+			record(actionsToQuery[i], result.Status, value)
 		}
 		actionsToQuery = newActionsToQuery
 
+		if failed > 0 && c.failFast && len(actionsToQuery) > 0 {
+			for i, value := range cancelActions(client, actionsToQuery) {
+				values = append(values, value)
+				failed++
+				record(actionsToQuery[i], params.ActionCancelled, value)
+			}
+			actionsToQuery = nil
+			break
+		}
+
 		if len(actionsToQuery) > 0 {
 			var timedOut bool
 			select {
@@ -322,30 +386,33 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	if writeErr != nil {
+		return errors.Trace(writeErr)
+	}
+
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
-	if len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" {
+	if !c.stream && len(actionsToQuery) == 0 && len(values) == 1 && humanOutput {
 		result, ok := values[0].(map[string]interface{})
 		if !ok {
 			return errors.New("couldn't read action output")
 		}
-		if res, ok := result["Error"].(string); ok {
-			return errors.New(res)
-		}
-		ctx.Stdout.Write(formatOutput(result, "Stdout"))
-		ctx.Stderr.Write(formatOutput(result, "Stderr"))
-		if code, ok := result["ReturnCode"].(int); ok && code != 0 {
-			return cmd.NewRcPassthroughError(code)
-		}
-		// Message should always contain only errors.
-		if res, ok := result["Message"].(string); ok && res != "" {
-			ctx.Stderr.Write([]byte(res))
-		}
-
-		return nil
+		return writeSingleResult(ctx, result)
 	}
 
-	if len(values) > 0 {
+	if c.stream {
+		if humanOutput {
+			for _, query := range actionsToQuery {
+				summary = append(summary, runSummary{
+					target: names.ReadableString(query.receiver.tag),
+					status: "timed out",
+				})
+			}
+			if err := writeRunSummary(ctx.Stdout, summary); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	} else if len(values) > 0 {
 		if err := c.out.Write(ctx, values); err != nil {
 			return err
 		}
@@ -366,6 +433,131 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			suffix, strings.Join(receivers, ", "),
 		)
 	}
+	if c.stream && failed > 0 {
+		return errors.Errorf("%d of %d targets failed", failed, len(values))
+	}
+	return nil
+}
+
+// runFailed reports whether a finished run, described by its action
+// status and converted result, should be treated as a failure.
+func runFailed(status string, value map[string]interface{}) bool {
+	if _, ok := value["Error"]; ok {
+		return true
+	}
+	if code, ok := value["ReturnCode"].(int); ok && code != 0 {
+		return true
+	}
+	return status == params.ActionFailed
+}
+
+// cancelActions cancels the given pending actions, returning a
+// converted result for each of them in the same order.
+func cancelActions(client RunClient, queries []actionQuery) []map[string]interface{} {
+	results, err := client.Cancel(entities(queries))
+	values := make([]map[string]interface{}, len(queries))
+	for i, query := range queries {
+		values[i] = map[string]interface{}{
+			query.receiver.receiverType: query.receiver.tag.Id(),
+			"Action":                    query.actionTag.Id(),
+		}
+		switch {
+		case err != nil:
+			values[i]["Error"] = fmt.Sprintf("cancelling run: %v", err)
+		case i >= len(results.Results):
+			values[i]["Error"] = "cancelling run: no result returned"
+		case results.Results[i].Error != nil:
+			values[i]["Error"] = fmt.Sprintf("cancelling run: %v", results.Results[i].Error)
+		default:
+			values[i]["Error"] = "cancelled after an earlier run failed"
+		}
+	}
+	return values
+}
+
+// writeStreamedResult writes the output of a single converted action
+// result, under a heading naming the target it was run on.
+func writeStreamedResult(ctx *cmd.Context, query actionQuery, result map[string]interface{}) {
+	heading := names.ReadableString(query.receiver.tag) + ":\n"
+	if stdout := formatOutput(result, "Stdout"); len(stdout) > 0 {
+		writeWithNewline(ctx.Stdout, heading, stdout)
+	}
+	var stderr []byte
+	stderr = append(stderr, formatOutput(result, "Stderr")...)
+	for _, key := range []string{"Message", "Error"} {
+		if res, ok := result[key].(string); ok && res != "" {
+			if len(stderr) > 0 && stderr[len(stderr)-1] != '\n' {
+				stderr = append(stderr, '\n')
+			}
+			stderr = append(stderr, res...)
+		}
+	}
+	if len(stderr) > 0 {
+		writeWithNewline(ctx.Stderr, heading, stderr)
+	}
+}
+
+// writeWithNewline writes the heading and data, making sure that
+// the data is terminated by a newline.
+func writeWithNewline(w io.Writer, heading string, data []byte) {
+	io.WriteString(w, heading)
+	w.Write(data)
+	if data[len(data)-1] != '\n' {
+		io.WriteString(w, "\n")
+	}
+}
+
+// runSummary holds the outcome of running the commands on one target.
+type runSummary struct {
+	target string
+	status string
+	code   string
+}
+
+func newRunSummary(query actionQuery, status string, value map[string]interface{}) runSummary {
+	summary := runSummary{
+		target: names.ReadableString(query.receiver.tag),
+		status: status,
+		code:   "-",
+	}
+	if _, ok := value["Error"]; ok {
+		if status != params.ActionCancelled {
+			summary.status = "error"
+		}
+		return summary
+	}
+	summary.code = "0"
+	if code, ok := value["ReturnCode"].(int); ok {
+		summary.code = strconv.Itoa(code)
+	}
+	return summary
+}
+
+// writeRunSummary writes a table of the exit code of each target.
+func writeRunSummary(writer io.Writer, summary []runSummary) error {
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\n", "Target", "Status", "Exit code")
+	for _, s := range summary {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.target, s.status, s.code)
+	}
+	return tw.Flush()
+}
+
+// writeSingleResult writes the output of a single converted action
+// result as if the commands had been run locally.
+func writeSingleResult(ctx *cmd.Context, result map[string]interface{}) error {
+	if res, ok := result["Error"].(string); ok {
+		return errors.New(res)
+	}
+	ctx.Stdout.Write(formatOutput(result, "Stdout"))
+	ctx.Stderr.Write(formatOutput(result, "Stderr"))
+	if code, ok := result["ReturnCode"].(int); ok && code != 0 {
+		return cmd.NewRcPassthroughError(code)
+	}
+	// Message should always contain only errors.
+	if res, ok := result["Message"].(string); ok && res != "" {
+		ctx.Stderr.Write([]byte(res))
+	}
 	return nil
 }
 
@@ -23,6 +23,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
 )
@@ -297,6 +299,182 @@ func (s *RunSuite) TestRunForMachineAndUnit(c *gc.C) {
 	c.Check(cmdtesting.Stdout(context), gc.Equals, buff.String())
 }
 
+func (s *RunSuite) TestStreamedOutputAndSummary(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{
+		stdout:     "megatron\n",
+		code:       "0",
+		machineTag: "machine-0",
+		status:     params.ActionCompleted,
+	})
+	mock.setResponse("unit/0", mockResponse{
+		stdout:  "bumblebee",
+		stderr:  "oops",
+		code:    "1",
+		unitTag: "unit-unit-0",
+		status:  params.ActionFailed,
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.runResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.runResponses["unit/0"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--stream", "--machine=0", "--unit=unit/0", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "1 of 2 targets failed")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, ""+
+		"machine 0:\n"+
+		"megatron\n"+
+		"unit unit/0:\n"+
+		"bumblebee\n"+
+		"Target       Status     Exit code\n"+
+		"machine 0    completed  0\n"+
+		"unit unit/0  failed     1\n",
+	)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "unit unit/0:\noops\n")
+}
+
+func (s *RunSuite) TestStreamedOutputStructuredFormat(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{
+		stdout:     "megatron\n",
+		code:       "0",
+		machineTag: "machine-0",
+		status:     params.ActionCompleted,
+	})
+	mock.setResponse("unit/0", mockResponse{
+		stderr:  "oops",
+		code:    "1",
+		unitTag: "unit-unit-0",
+		status:  params.ActionFailed,
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.runResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.runResponses["unit/0"],
+	}
+
+	// Each result is written as its own document, and the exit
+	// code follows the same rules as the default format.
+	var buf bytes.Buffer
+	machine0Query := makeActionQuery(mock.receiverIdMap["0"], "MachineId", names.NewMachineTag("0"))
+	err := cmd.FormatJson(&buf, ConvertActionResults(mock.runResponses["0"], machine0Query))
+	c.Assert(err, jc.ErrorIsNil)
+	unitQuery := makeActionQuery(mock.receiverIdMap["unit/0"], "UnitId", names.NewUnitTag("unit/0"))
+	err = cmd.FormatJson(&buf, ConvertActionResults(mock.runResponses["unit/0"], unitQuery))
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--stream", "--format=json", "--machine=0", "--unit=unit/0", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "1 of 2 targets failed")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
+}
+
+func (s *RunSuite) TestNotStreamedByDefault(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{
+		stdout:     "megatron\n",
+		code:       "0",
+		machineTag: "machine-0",
+		status:     params.ActionCompleted,
+	})
+	mock.setResponse("unit/0", mockResponse{
+		stderr:  "oops",
+		code:    "1",
+		unitTag: "unit-unit-0",
+		status:  params.ActionFailed,
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.runResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.runResponses["unit/0"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--machine=0", "--unit=unit/0", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Not(gc.Matches), "(?s).*Exit code.*")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
+func (s *RunSuite) TestFailFastCancelsPendingRuns(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1")
+	mock.setResponse("0", mockResponse{
+		stderr:     "boom\n",
+		code:       "2",
+		machineTag: "machine-0",
+		status:     params.ActionFailed,
+	})
+	mock.setResponse("1", mockResponse{
+		machineTag: "machine-1",
+		status:     params.ActionPending,
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.runResponses["0"],
+		mock.receiverIdMap["1"]: mock.runResponses["1"],
+	}
+
+	machine0Query := makeActionQuery(mock.receiverIdMap["0"], "MachineId", names.NewMachineTag("0"))
+	var buf bytes.Buffer
+	err := cmd.FormatJson(&buf, []interface{}{
+		ConvertActionResults(mock.runResponses["0"], machine0Query),
+		map[string]interface{}{
+			"Action":    mock.receiverIdMap["1"],
+			"MachineId": "1",
+			"Error":     "cancelled after an earlier run failed",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var clock mockClock
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&clock),
+		"--format=json", "--all", "--fail-fast", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
+	c.Check(mock.cancelled, jc.DeepEquals, []string{
+		names.NewActionTag(mock.receiverIdMap["1"]).String(),
+	})
+	// We never wait for the pending run.
+	clock.CheckCalls(c, []gitjujutesting.StubCall{
+		{"After", []interface{}{5 * time.Minute}},
+	})
+}
+
+func (s *RunSuite) TestFailFastStreamsCancelledRuns(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1")
+	mock.setResponse("0", mockResponse{
+		code:       "2",
+		machineTag: "machine-0",
+		status:     params.ActionFailed,
+	})
+	mock.setResponse("1", mockResponse{
+		machineTag: "machine-1",
+		status:     params.ActionPending,
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.runResponses["0"],
+		mock.receiverIdMap["1"]: mock.runResponses["1"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--stream", "--all", "--fail-fast", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "2 of 2 targets failed")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Target     Status     Exit code\n"+
+		"machine 0  failed     2\n"+
+		"machine 1  cancelled  -\n",
+	)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		"machine 1:\n"+
+		"cancelled after an earlier run failed\n",
+	)
+}
+
 func (s *RunSuite) TestBlockRunForMachineAndUnit(c *gc.C) {
 	mock := s.setupMockAPI()
 	// Block operation
@@ -514,6 +692,43 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func caasStore() *jujuclient.MemStore {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"].Models["king/sword"] = jujuclient.ModelDetails{
+		ModelType: model.CAAS,
+	}
+	return store
+}
+
+func (s *RunSuite) TestCAASRejectsMachineTargets(c *gc.C) {
+	s.setupMockAPI()
+	for i, args := range [][]string{
+		{"--all", "hostname"},
+		{"--machine", "0", "hostname"},
+	} {
+		c.Logf("%d: %v", i, args)
+		_, err := cmdtesting.RunCommand(c, newRunCommand(caasStore(), (&mockClock{}).After), args...)
+		c.Check(err, gc.ErrorMatches, "kubernetes models have no machines; specify --application or --unit")
+	}
+}
+
+func (s *RunSuite) TestCAASUnit(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("gitlab/0", mockResponse{
+		stdout:  "hello\n",
+		code:    "0",
+		unitTag: "unit-gitlab-0",
+		status:  params.ActionCompleted,
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["gitlab/0"]: mock.runResponses["gitlab/0"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, newRunCommand(caasStore(), (&mockClock{}).After), "--unit", "gitlab/0", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "hello\n")
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *runCommand) (RunClient, error) {
@@ -533,6 +748,7 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	cancelled       []string
 }
 
 type mockResponse struct {
@@ -681,5 +897,17 @@ func (m *mockRunAPI) Actions(actionTags params.Entities) (params.ActionResults,
 	return results, nil
 }
 
+func (m *mockRunAPI) Cancel(actionTags params.Entities) (params.ActionResults, error) {
+	results := params.ActionResults{Results: make([]params.ActionResult, len(actionTags.Entities))}
+	for i, entity := range actionTags.Entities {
+		m.cancelled = append(m.cancelled, entity.Tag)
+		results.Results[i] = params.ActionResult{
+			Action: &params.Action{Tag: entity.Tag},
+			Status: params.ActionCancelled,
+		}
+	}
+	return results, nil
+}
+
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
@@ -82,6 +82,7 @@ func newSCPCommand(hostChecker jujussh.ReachableChecker) cmd.Command {
 // scpCommand is responsible for launching a scp command to copy files to/from remote machine(s)
 type scpCommand struct {
 	SSHCommon
+	modelcmd.IAASOnlyCommand
 }
 
 func (c *scpCommand) Info() *cmd.Info {
@@ -6,13 +6,20 @@ package commands
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
 	"github.com/juju/utils/ssh"
+	"gopkg.in/juju/names.v2"
 
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 	jujussh "github.com/juju/juju/network/ssh"
 )
 
@@ -35,6 +42,10 @@ a pseudo-terminal (pty) for the ssh session; otherwise a pty is allocated. This
 behavior can be overridden by explicitly specifying the behavior with
 "--pty=true" or "--pty=false".
 
+On kubernetes models the target must be a unit, and a command must be given.
+The command is run in the unit's workload container using the kubernetes API
+rather than ssh, and its output is sent back to the user.
+
 The SSH host keys of the target are verified. The --no-host-key-checks option
 can be used to disable these checks. Use of this option is not recommended as
 it opens up the possibility of a man-in-the-middle attack.
@@ -67,6 +78,10 @@ Connect to a mysql unit with an identity not known to juju (ssh option -i):
 
     juju ssh mysql/0 -i ~/.ssh/my_private_key echo hello
 
+Run 'ps aux' in the workload container of a unit in a kubernetes model:
+
+    juju ssh gitlab/0 ps aux
+
 See also: 
     scp`
 
@@ -112,7 +127,15 @@ func (c *sshCommand) Init(args []string) error {
 // Run resolves c.Target to a machine, to the address of a i
 // machine or unit forks ssh passing any arguments provided.
 func (c *sshCommand) Run(ctx *cmd.Context) error {
-	err := c.initRun()
+	modelType, err := c.ModelType()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelType == model.CAAS {
+		return c.runInWorkload(ctx)
+	}
+
+	err = c.initRun()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -149,6 +172,72 @@ func (c *sshCommand) Run(ctx *cmd.Context) error {
 	return cmd.Run()
 }
 
+// workloadCommandTimeout is how long to wait for a command
+// run in the workload container of a kubernetes unit.
+const workloadCommandTimeout = 5 * time.Minute
+
+// runInWorkload runs the command in the workload container of the
+// target unit of a kubernetes model, using a juju-run action.
+func (c *sshCommand) runInWorkload(ctx *cmd.Context) error {
+	if !names.IsValidUnit(c.Target) {
+		return errors.Errorf("%q is not a unit; only units may be targeted on kubernetes models", c.Target)
+	}
+	if len(c.Args) == 0 {
+		return errors.New("interactive sessions are not supported on kubernetes models; specify a command to run")
+	}
+
+	client, err := getSSHRunAPIClient(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.Run(params.RunParams{
+		Commands: utils.CommandString(c.Args...),
+		Timeout:  workloadCommandTimeout,
+		Units:    []string{c.Target},
+	})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if n := len(results); n != 1 {
+		return errors.Errorf("expected 1 result, got %d", n)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	actionTag, err := names.ParseActionTag(results[0].Action.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	result, err := getActionResult(client, actionTag.Id(), time.NewTimer(workloadCommandTimeout))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch result.Status {
+	case params.ActionRunning, params.ActionPending:
+		return errors.Errorf("timed out waiting for result from: %s", c.Target)
+	}
+	return writeSingleResult(ctx, ConvertActionResults(result, actionQuery{
+		actionTag: actionTag,
+		receiver: actionReceiver{
+			receiverType: "UnitId",
+			tag:          names.NewUnitTag(c.Target),
+		},
+	}))
+}
+
+// In order to be able to easily mock out the API side for testing,
+// the API client is retrieved using a function.
+var getSSHRunAPIClient = func(c *sshCommand) (RunClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return actionapi.NewClient(root), nil
+}
+
 // autoBoolValue is like gnuflag.boolValue, but remembers
 // whether or not a value has been set, so its behaviour
 // can be determined dynamically, during command execution.
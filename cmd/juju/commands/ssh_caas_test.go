@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/testing"
+)
+
+type SSHCAASSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	mock *mockRunAPI
+}
+
+var _ = gc.Suite(&SSHCAASSuite{})
+
+func (s *SSHCAASSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.mock = &mockRunAPI{}
+	s.PatchValue(&getSSHRunAPIClient, func(_ *sshCommand) (RunClient, error) {
+		return s.mock, nil
+	})
+}
+
+func (s *SSHCAASSuite) newCommand() modelcmd.ModelCommand {
+	cmd := newSSHCommand(nil, nil).(modelcmd.ModelCommand)
+	cmd.SetClientStore(caasStore())
+	return cmd
+}
+
+func (s *SSHCAASSuite) TestRunsInWorkload(c *gc.C) {
+	s.mock.setResponse("gitlab/0", mockResponse{
+		stdout:  "hello\n",
+		code:    "0",
+		unitTag: "unit-gitlab-0",
+		status:  params.ActionCompleted,
+	})
+	s.mock.actionResponses = map[string]params.ActionResult{
+		s.mock.receiverIdMap["gitlab/0"]: s.mock.runResponses["gitlab/0"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, s.newCommand(), "gitlab/0", "echo", "hello")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "hello\n")
+}
+
+func (s *SSHCAASSuite) TestMachineTarget(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "0", "hostname")
+	c.Assert(err, gc.ErrorMatches, `"0" is not a unit; only units may be targeted on kubernetes models`)
+}
+
+func (s *SSHCAASSuite) TestInteractive(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "gitlab/0")
+	c.Assert(err, gc.ErrorMatches, "interactive sessions are not supported on kubernetes models; specify a command to run")
+}
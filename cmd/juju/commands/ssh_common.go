@@ -31,7 +31,6 @@ import (
 // and DebugHooksCommand.
 type SSHCommon struct {
 	modelcmd.ModelCommandBase
-	modelcmd.IAASOnlyCommand
 	proxy           bool
 	noHostKeyChecks bool
 	Target          string
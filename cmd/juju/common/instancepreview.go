@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// WriteInstancePreviews writes a table describing the instances that
+// would be started for new machines, as returned by the MachineManager
+// PreviewInstances API. Nothing is started. An error is returned if any
+// of the machines could not be started as requested.
+func WriteInstancePreviews(writer io.Writer, results []params.PreviewInstanceResult) error {
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("Machine", "Series", "Instance type", "Image", "Zone", "Constraints", "Notes")
+	var failed int
+	for i, result := range results {
+		var instanceType, notes string
+		if result.InstanceType != nil {
+			instanceType = result.InstanceType.Name
+		}
+		switch {
+		case result.Error != nil:
+			failed++
+			notes = result.Error.Error()
+		case len(result.Unsupported) > 0:
+			notes = "unsupported constraints ignored: " + strings.Join(result.Unsupported, ",")
+		}
+		print(
+			"new-"+strconv.Itoa(i+1),
+			result.Series,
+			instanceType,
+			result.ImageId,
+			result.AvailabilityZone,
+			result.Constraints.String(),
+			notes,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d machines could not be started as requested", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"bytes"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/constraints"
+)
+
+type InstancePreviewSuite struct{}
+
+var _ = gc.Suite(&InstancePreviewSuite{})
+
+func (s *InstancePreviewSuite) TestWriteInstancePreviews(c *gc.C) {
+	var buf bytes.Buffer
+	err := common.WriteInstancePreviews(&buf, []params.PreviewInstanceResult{{
+		Series:           "bionic",
+		Constraints:      constraints.MustParse("mem=4G tags=foo"),
+		Unsupported:      []string{"tags"},
+		InstanceType:     &params.InstanceType{Name: "m3.medium"},
+		ImageId:          "ami-1",
+		AvailabilityZone: "us-east-1a",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, ""+
+		"Machine  Series  Instance type  Image  Zone        Constraints      Notes\n"+
+		"new-1    bionic  m3.medium      ami-1  us-east-1a  mem=4G tags=foo  unsupported constraints ignored: tags\n")
+}
+
+func (s *InstancePreviewSuite) TestWriteInstancePreviewsFailures(c *gc.C) {
+	var buf bytes.Buffer
+	err := common.WriteInstancePreviews(&buf, []params.PreviewInstanceResult{{
+		Series:           "bionic",
+		Constraints:      constraints.MustParse("mem=4G tags=foo"),
+		Unsupported:      []string{"tags"},
+		InstanceType:     &params.InstanceType{Name: "m3.medium"},
+		ImageId:          "ami-1",
+		AvailabilityZone: "us-east-1a",
+	}, {
+		Series: "bionic",
+		Error:  &params.Error{Message: "no capacity"},
+	}})
+	c.Assert(err, gc.ErrorMatches, "1 of 2 machines could not be started as requested")
+	c.Assert(buf.String(), gc.Equals, ""+
+		"Machine  Series  Instance type  Image  Zone        Constraints      Notes\n"+
+		"new-1    bionic  m3.medium      ami-1  us-east-1a  mem=4G tags=foo  unsupported constraints ignored: tags\n"+
+		"new-2    bionic                                                     no capacity\n")
+}
@@ -4,7 +4,10 @@
 package machine
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -57,6 +60,14 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+Several machines may be provisioned at once, either by listing their hosts,
+separated by commas, after "ssh:", or by naming a file with "--hosts-file"
+that lists one [user@]host per line. Blank lines and lines starting with
+"#" in the file are ignored. The machines are provisioned in parallel,
+without prompting, so each must either already have an "ubuntu" user with
+passwordless sudo reachable with your SSH keys, or allow the given user to
+use sudo without a password.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
@@ -70,9 +81,15 @@ Examples:
    juju add-machine lxd:4                (starts a new lxd container on machine 4)
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions machine with ssh)
+   juju add-machine ssh:10.10.0.3,10.10.0.4
+                                         (manually provisions 2 machines with ssh)
+   juju add-machine --hosts-file hosts.txt
+                                         (manually provisions the listed machines with ssh)
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
+   juju add-machine --constraints mem=8G --dry-run
+                                         (show the instance that would be started)
 
 See also:
     remove-machine
@@ -114,12 +131,18 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// DryRun reports the instances that would be started, without
+	// starting them.
+	DryRun bool
+	// HostsFile names a file listing the hosts of machines to be
+	// manually provisioned with SSH.
+	HostsFile string
 }
 
 func (c *addCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-machine",
-		Args:    "[<container>:machine | <container> | ssh:[user@]host | winrm:[user@]host | placement]",
+		Args:    "[<container>:machine | <container> | ssh:[user@]host[,[user@]host...] | winrm:[user@]host | placement]",
 		Purpose: "Start a new, empty machine and optionally a container, or add a container to a machine.",
 		Doc:     addMachineDoc,
 	}
@@ -131,6 +154,8 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show the instances that would be started, without starting them")
+	f.StringVar(&c.HostsFile, "hosts-file", "", "A file listing the hosts of machines to provision with ssh")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -152,9 +177,44 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.HostsFile != "" {
+		if c.Placement != nil {
+			return errors.New("cannot use --hosts-file when specifying a placement directive")
+		}
+		if c.NumMachines > 1 {
+			return errors.New("cannot use -n with --hosts-file")
+		}
+		c.Placement = &instance.Placement{Scope: sshScope}
+	} else if c.Placement != nil && c.Placement.Scope == sshScope {
+		if _, err := parseHosts(strings.Split(c.Placement.Directive, ",")); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if c.DryRun {
+		return c.validateDryRun()
+	}
 	return nil
 }
 
+// validateDryRun checks that the machines being added would be started
+// by the provider, since only those can be previewed.
+func (c *addCommand) validateDryRun() error {
+	if len(c.Disks) > 0 {
+		return errors.New("--dry-run cannot be used with --disks")
+	}
+	if c.HostsFile != "" {
+		return errors.New("--dry-run cannot be used with --hosts-file")
+	}
+	if c.Placement == nil || c.Placement.Scope == "model-uuid" {
+		return nil
+	}
+	if _, err := instance.ParseContainerType(c.Placement.Scope); err == nil && c.Placement.Directive == "" {
+		// A container on a new machine; the new machine is previewed.
+		return nil
+	}
+	return errors.Errorf("--dry-run cannot be used with placement %q", c.Placement)
+}
+
 type AddMachineAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	Close() error
@@ -171,6 +231,7 @@ type ModelConfigAPI interface {
 
 type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
+	PreviewInstances([]params.PreviewInstanceArg) ([]params.PreviewInstanceResult, error)
 	BestAPIVersion() int
 	Close() error
 }
@@ -230,6 +291,10 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.DryRun {
+		return c.previewMachines(ctx, client)
+	}
+
 	var machineManager MachineManagerAPI
 	if len(c.Disks) > 0 {
 		machineManager, err = c.getMachineManagerAPI()
@@ -338,6 +403,40 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// previewMachines reports the instances that the provider would start for
+// the machines being added, without adding them.
+func (c *addCommand) previewMachines(ctx *cmd.Context, client AddMachineAPI) error {
+	machineManager, err := c.getMachineManagerAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer machineManager.Close()
+
+	var placement *instance.Placement
+	if c.Placement != nil && c.Placement.Scope == "model-uuid" {
+		uuid, ok := client.ModelUUID()
+		if !ok {
+			return errors.New("API connection is controller-only (should never happen)")
+		}
+		placement = &instance.Placement{Scope: uuid, Directive: c.Placement.Directive}
+	}
+	args := make([]params.PreviewInstanceArg, c.NumMachines)
+	for i := range args {
+		args[i] = params.PreviewInstanceArg{
+			Series:      c.Series,
+			Constraints: c.Constraints,
+			Placement:   placement,
+		}
+	}
+	results, err := machineManager.PreviewInstances(args)
+	if errors.IsNotSupported(err) {
+		return errors.New("--dry-run is not supported by this controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return common.WriteInstancePreviews(ctx.Stdout, results)
+}
+
 var (
 	sshProvisioner    = sshprovisioner.ProvisionMachine
 	winrmProvisioner  = winrmprovisioner.ProvisionMachine
@@ -363,10 +462,7 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 		return errors.Annotatef(err, "cannot reading authorized-keys")
 	}
 
-	user, host := splitUserHost(c.Placement.Directive)
 	args := manual.ProvisionMachineArgs{
-		Host:           host,
-		User:           user,
 		Client:         client,
 		Stdin:          ctx.Stdin,
 		Stdout:         ctx.Stdout,
@@ -378,6 +474,17 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 		},
 	}
 
+	if c.Placement.Scope == sshScope {
+		hosts, err := c.sshHosts(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(hosts) > 1 || c.HostsFile != "" {
+			return provisionHosts(ctx, provisionMachine, hosts, args)
+		}
+	}
+
+	args.User, args.Host = splitUserHost(c.Placement.Directive)
 	machineId, err := provisionMachine(args)
 	if err != nil {
 		return errors.Trace(err)
@@ -386,6 +493,117 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 	return nil
 }
 
+// sshHosts returns the [user@]host strings of the machines to be
+// provisioned with SSH, read from the hosts file if there is one.
+func (c *addCommand) sshHosts(ctx *cmd.Context) ([]string, error) {
+	if c.HostsFile == "" {
+		return parseHosts(strings.Split(c.Placement.Directive, ","))
+	}
+	f, err := os.Open(ctx.AbsPath(c.HostsFile))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading hosts file")
+	}
+	defer f.Close()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Annotate(err, "reading hosts file")
+	}
+	if len(lines) == 0 {
+		return nil, errors.Errorf("no hosts found in %q", c.HostsFile)
+	}
+	return parseHosts(lines)
+}
+
+// parseHosts checks that the given [user@]host strings each name a
+// host, and that no host is given more than once.
+func parseHosts(userHosts []string) ([]string, error) {
+	seen := make(map[string]bool)
+	for i, userHost := range userHosts {
+		userHost = strings.TrimSpace(userHost)
+		_, host := splitUserHost(userHost)
+		if host == "" {
+			return nil, errors.NotValidf("empty host in %q", strings.Join(userHosts, ","))
+		}
+		if seen[host] {
+			return nil, errors.Errorf("host %q specified more than once", host)
+		}
+		seen[host] = true
+		userHosts[i] = userHost
+	}
+	return userHosts, nil
+}
+
+// maxParallelProvisioning is the maximum number of machines that are
+// manually provisioned at the same time.
+const maxParallelProvisioning = 10
+
+type provisionResult struct {
+	host      string
+	machineId string
+	output    string
+	err       error
+}
+
+// provisionHosts manually provisions a machine for each of the given
+// [user@]host strings in parallel, reporting progress as each finishes.
+// As the machines are provisioned together, there is no prompting: the
+// output of each provisioning is only shown if it fails.
+func provisionHosts(
+	ctx *cmd.Context,
+	provisionMachine manual.ProvisionMachineFunc,
+	hosts []string,
+	args manual.ProvisionMachineArgs,
+) error {
+	ctx.Infof("provisioning %d machines", len(hosts))
+	results := make(chan provisionResult, len(hosts))
+	sem := make(chan struct{}, maxParallelProvisioning)
+	for _, userHost := range hosts {
+		go func(userHost string) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			var output bytes.Buffer
+			hostArgs := args
+			hostArgs.User, hostArgs.Host = splitUserHost(userHost)
+			hostArgs.Stdin = strings.NewReader("")
+			hostArgs.Stdout = &output
+			hostArgs.Stderr = &output
+			machineId, err := provisionMachine(hostArgs)
+			results <- provisionResult{
+				host:      hostArgs.Host,
+				machineId: machineId,
+				output:    output.String(),
+				err:       err,
+			}
+		}(userHost)
+	}
+
+	var failed int
+	for i := range hosts {
+		result := <-results
+		if result.err != nil {
+			failed++
+			ctx.Infof("(%d/%d) failed to provision %s: %v", i+1, len(hosts), result.host, result.err)
+			if output := strings.TrimSpace(result.output); output != "" {
+				ctx.Verbosef("%s", output)
+			}
+			continue
+		}
+		ctx.Infof("(%d/%d) created machine %v on %s", i+1, len(hosts), result.machineId, result.host)
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to provision %d of %d machines", failed, len(hosts))
+	}
+	return nil
+}
+
 func (c *addCommand) provisionWinRM(args manual.ProvisionMachineArgs) (string, error) {
 	base := osenv.JujuXDGDataHomePath("x509")
 	keyPath := filepath.Join(base, "winrmkey.pem")
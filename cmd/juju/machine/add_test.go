@@ -4,8 +4,12 @@
 package machine_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -16,7 +20,9 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -71,6 +77,26 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"ssh:user@10.10.0.3"},
 			count:     1,
 			placement: "ssh:user@10.10.0.3",
+		}, {
+			args:      []string{"ssh:10.10.0.3,user@10.10.0.4"},
+			count:     1,
+			placement: "ssh:10.10.0.3,user@10.10.0.4",
+		}, {
+			args:        []string{"ssh:10.10.0.3,user@10.10.0.3"},
+			errorString: `host "10.10.0.3" specified more than once`,
+		}, {
+			args:        []string{"ssh:10.10.0.3,"},
+			errorString: `empty host in "10.10.0.3," not valid`,
+		}, {
+			args:      []string{"--hosts-file", "hosts.txt"},
+			count:     1,
+			placement: "ssh:",
+		}, {
+			args:        []string{"--hosts-file", "hosts.txt", "lxd"},
+			errorString: "cannot use --hosts-file when specifying a placement directive",
+		}, {
+			args:        []string{"--hosts-file", "hosts.txt", "-n", "2"},
+			errorString: "cannot use -n with --hosts-file",
 		}, {
 			args:      []string{"winrm:user@10.10.0.3"},
 			count:     1,
@@ -156,6 +182,70 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) patchSSHProvisioner(fail string) *[]string {
+	var mu sync.Mutex
+	var provisioned []string
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		if args.Host == fail {
+			fmt.Fprintln(args.Stderr, "provisioning output")
+			return "", errors.New("failed to initialize warp core")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		provisioned = append(provisioned, args.User+"@"+args.Host)
+		return strings.TrimPrefix(args.Host, "10.1.2."), nil
+	})
+	return &provisioned
+}
+
+func (s *AddMachineSuite) TestSSHPlacementMultipleHosts(c *gc.C) {
+	provisioned := s.patchSSHProvisioner("")
+	context, err := s.run(c, "ssh:10.1.2.3,user@10.1.2.4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*provisioned, jc.SameContents, []string{"@10.1.2.3", "user@10.1.2.4"})
+	lines := strings.Split(strings.TrimSpace(cmdtesting.Stderr(context)), "\n")
+	c.Assert(lines, gc.HasLen, 3)
+	c.Assert(lines[0], gc.Equals, "provisioning 2 machines")
+	// The machines are provisioned in parallel, so may finish in
+	// either order.
+	c.Assert(lines[1], gc.Matches, `\(1/2\) created machine [34] on 10\.1\.2\.[34]`)
+	c.Assert(lines[2], gc.Matches, `\(2/2\) created machine [34] on 10\.1\.2\.[34]`)
+	c.Assert(lines[1][6:], gc.Not(gc.Equals), lines[2][6:])
+}
+
+func (s *AddMachineSuite) TestSSHPlacementMultipleHostsError(c *gc.C) {
+	provisioned := s.patchSSHProvisioner("10.1.2.4")
+	context, err := s.run(c, "ssh:10.1.2.3,10.1.2.4")
+	c.Assert(err, gc.ErrorMatches, "failed to provision 1 of 2 machines")
+	c.Assert(*provisioned, jc.DeepEquals, []string{"@10.1.2.3"})
+	c.Assert(cmdtesting.Stderr(context), jc.Contains, "failed to provision 10.1.2.4: failed to initialize warp core\n")
+}
+
+func (s *AddMachineSuite) TestHostsFile(c *gc.C) {
+	provisioned := s.patchSSHProvisioner("")
+	hostsFile := filepath.Join(c.MkDir(), "hosts.txt")
+	err := ioutil.WriteFile(hostsFile, []byte(`
+# web servers
+10.1.2.3
+user@10.1.2.4
+
+10.1.2.5
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	context, err := s.run(c, "--hosts-file", hostsFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*provisioned, jc.SameContents, []string{"@10.1.2.3", "user@10.1.2.4", "@10.1.2.5"})
+	c.Assert(cmdtesting.Stderr(context), jc.HasPrefix, "provisioning 3 machines\n")
+}
+
+func (s *AddMachineSuite) TestHostsFileEmpty(c *gc.C) {
+	hostsFile := filepath.Join(c.MkDir(), "hosts.txt")
+	err := ioutil.WriteFile(hostsFile, []byte("# nothing here\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, "--hosts-file", hostsFile)
+	c.Assert(err, gc.ErrorMatches, `no hosts found in ".*hosts.txt"`)
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
@@ -206,6 +296,60 @@ func (s *AddMachineSuite) TestAddMachineWithDisks(c *gc.C) {
 	})
 }
 
+func (s *AddMachineSuite) TestAddMachineDryRun(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 6
+	s.fakeMachineManager.previewResults = []params.PreviewInstanceResult{{
+		Series:           "bionic",
+		Constraints:      constraints.MustParse("mem=4G"),
+		InstanceType:     &params.InstanceType{Name: "m3.medium"},
+		ImageId:          "ami-1",
+		AvailabilityZone: "zone-a",
+	}, {
+		Series:           "bionic",
+		Constraints:      constraints.MustParse("mem=4G"),
+		InstanceType:     &params.InstanceType{Name: "m3.medium"},
+		ImageId:          "ami-1",
+		AvailabilityZone: "zone-b",
+	}}
+	context, err := s.run(c, "--dry-run", "-n", "2", "--constraints", "mem=4G", "--series", "bionic")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+	c.Assert(s.fakeMachineManager.args, gc.HasLen, 0)
+	expectedArg := params.PreviewInstanceArg{
+		Series:      "bionic",
+		Constraints: constraints.MustParse("mem=4G"),
+	}
+	c.Assert(s.fakeMachineManager.previewArgs, jc.DeepEquals, []params.PreviewInstanceArg{expectedArg, expectedArg})
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  Series  Instance type  Image  Zone    Constraints  Notes\n"+
+		"new-1    bionic  m3.medium      ami-1  zone-a  mem=4096M    \n"+
+		"new-2    bionic  m3.medium      ami-1  zone-b  mem=4096M    \n")
+}
+
+func (s *AddMachineSuite) TestAddMachineDryRunPlacement(c *gc.C) {
+	s.fakeMachineManager.apiVersion = 6
+	s.fakeMachineManager.previewResults = []params.PreviewInstanceResult{{}}
+	_, err := s.run(c, "--dry-run", "zone=zone-a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeMachineManager.previewArgs, gc.HasLen, 1)
+	c.Assert(s.fakeMachineManager.previewArgs[0].Placement, jc.DeepEquals, &instance.Placement{
+		Scope:     "fake-uuid",
+		Directive: "zone=zone-a",
+	})
+}
+
+func (s *AddMachineSuite) TestAddMachineDryRunUnsupportedPlacement(c *gc.C) {
+	for _, placement := range []string{"lxd:4", "ssh:user@10.10.0.3"} {
+		_, err := s.run(c, "--dry-run", placement)
+		c.Check(err, gc.ErrorMatches, `--dry-run cannot be used with placement ".*"`)
+	}
+}
+
+func (s *AddMachineSuite) TestAddMachineDryRunUnsupportedByController(c *gc.C) {
+	_, err := s.run(c, "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported by this controller")
+}
+
 func (s *AddMachineSuite) TestAddMachineWithDisksUnsupported(c *gc.C) {
 	_, err := s.run(c, "--disks", "2,1G", "--disks", "2G")
 	c.Assert(err, gc.ErrorMatches, "cannot add machines with disks: not supported by the API server")
@@ -279,8 +423,18 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 type fakeMachineManagerAPI struct {
 	apiVersion int
 	fakeAddMachineAPI
+	previewArgs    []params.PreviewInstanceArg
+	previewResults []params.PreviewInstanceResult
 }
 
 func (f *fakeMachineManagerAPI) BestAPIVersion() int {
 	return f.apiVersion
 }
+
+func (f *fakeMachineManagerAPI) PreviewInstances(args []params.PreviewInstanceArg) ([]params.PreviewInstanceResult, error) {
+	if f.apiVersion < 6 {
+		return nil, errors.NotSupportedf("previewing instances")
+	}
+	f.previewArgs = args
+	return f.previewResults, nil
+}
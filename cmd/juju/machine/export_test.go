@@ -13,7 +13,8 @@ import (
 )
 
 var (
-	SSHProvisioner = &sshProvisioner
+	SSHProvisioner         = &sshProvisioner
+	UninstallManualMachine = &uninstallManualMachine
 )
 
 type AddCommand struct {
@@ -45,15 +46,24 @@ func NewShowCommandForTest(api statusAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewInstanceTypesCommandForTest returns an instanceTypesCommand with the
+// api provided as specified.
+func NewInstanceTypesCommandForTest(api InstanceTypesAPI) cmd.Command {
+	cmd := &instanceTypesCommand{api: api}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
 type RemoveCommand struct {
 	*removeCommand
 }
 
 // NewRemoveCommand returns an RemoveCommand with the api provided as specified.
-func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI) (cmd.Command, *RemoveCommand) {
+func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI, statusAPI MachineStatusAPI) (cmd.Command, *RemoveCommand) {
 	cmd := &removeCommand{
 		apiRoot:    apiRoot,
 		machineAPI: machineAPI,
+		statusAPI:  statusAPI,
 	}
 	cmd.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(cmd), &RemoveCommand{cmd}
@@ -0,0 +1,213 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+)
+
+// NewInstanceTypesCommand returns a command that lists the instance types
+// available in the model's cloud region.
+func NewInstanceTypesCommand() cmd.Command {
+	return modelcmd.Wrap(&instanceTypesCommand{})
+}
+
+// InstanceTypesAPI defines the API methods used by the instance-types
+// command.
+type InstanceTypesAPI interface {
+	InstanceTypes(constraints.Value) (params.InstanceTypesResult, error)
+	Close() error
+}
+
+// instanceTypesCommand lists the instance types matching a set of
+// constraints.
+type instanceTypesCommand struct {
+	baseMachinesCommand
+	api            InstanceTypesAPI
+	out            cmd.Output
+	constraintsStr string
+}
+
+const instanceTypesDoc = `
+List the instance types offered by the cloud region hosting the current
+model, along with their cores, memory, architectures, root disk size and
+cost, where the cloud provides them.
+
+Use --constraints to see which instance types satisfy a set of constraints
+before deploying an application or adding a machine with them.
+
+Examples:
+
+    juju instance-types
+    juju instance-types --constraints "cores=4 mem=8G"
+    juju instance-types --constraints arch=arm64 --format json
+
+See also:
+    add-machine
+    deploy
+    set-constraints
+`
+
+// Info implements Command.Info.
+func (c *instanceTypesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "instance-types",
+		Purpose: "Lists the instance types available in the model's cloud region.",
+		Doc:     instanceTypesDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *instanceTypesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baseMachinesCommand.SetFlags(f)
+	f.StringVar(&c.constraintsStr, "constraints", "", "Only list instance types matching these constraints")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatInstanceTypesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *instanceTypesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *instanceTypesCommand) getAPI() (InstanceTypesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *instanceTypesCommand) Run(ctx *cmd.Context) error {
+	cons, err := common.ParseConstraints(ctx, c.constraintsStr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.InstanceTypes(cons)
+	if err != nil {
+		return errors.Annotate(err, "cannot list instance types")
+	}
+	if len(result.InstanceTypes) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No instance types match the constraints.")
+		return nil
+	}
+	return c.out.Write(ctx, newInstanceTypesInfo(result))
+}
+
+// instanceTypesInfo is the serialisable form of the instance types
+// returned by the controller.
+type instanceTypesInfo struct {
+	InstanceTypes []instanceTypeInfo `yaml:"instance-types" json:"instance-types"`
+	CostUnit      string             `yaml:"cost-unit,omitempty" json:"cost-unit,omitempty"`
+	CostCurrency  string             `yaml:"cost-currency,omitempty" json:"cost-currency,omitempty"`
+}
+
+// instanceTypeInfo describes a single instance type. Memory and root disk
+// sizes are expressed in MiB, and cost in the cost unit.
+type instanceTypeInfo struct {
+	Name     string   `yaml:"name" json:"name"`
+	Arches   []string `yaml:"arches" json:"arches"`
+	Cores    int      `yaml:"cores" json:"cores"`
+	Memory   int      `yaml:"memory" json:"memory"`
+	RootDisk int      `yaml:"root-disk,omitempty" json:"root-disk,omitempty"`
+	VirtType string   `yaml:"virt-type,omitempty" json:"virt-type,omitempty"`
+	Cost     float64  `yaml:"cost,omitempty" json:"cost,omitempty"`
+}
+
+func newInstanceTypesInfo(result params.InstanceTypesResult) instanceTypesInfo {
+	info := instanceTypesInfo{
+		InstanceTypes: make([]instanceTypeInfo, 0, len(result.InstanceTypes)),
+		CostUnit:      result.CostUnit,
+		CostCurrency:  result.CostCurrency,
+	}
+	for _, it := range result.InstanceTypes {
+		cost := float64(it.Cost)
+		if result.CostDivisor > 0 {
+			cost /= float64(result.CostDivisor)
+		}
+		info.InstanceTypes = append(info.InstanceTypes, instanceTypeInfo{
+			Name:     it.Name,
+			Arches:   it.Arches,
+			Cores:    it.CPUCores,
+			Memory:   it.Memory,
+			RootDisk: it.RootDiskSize,
+			VirtType: it.VirtType,
+			Cost:     cost,
+		})
+	}
+	return info
+}
+
+// formatInstanceTypesTabular writes a tabular summary of instance types.
+func formatInstanceTypesTabular(writer io.Writer, value interface{}) error {
+	info, ok := value.(instanceTypesInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", info, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	costHeading := "Cost"
+	if info.CostUnit != "" {
+		costHeading = fmt.Sprintf("Cost (%s)", info.CostUnit)
+	}
+	print("Name", "Arches", "Cores", "Memory", "Root disk", costHeading)
+	for _, it := range info.InstanceTypes {
+		cost := ""
+		if it.Cost > 0 {
+			cost = strconv.FormatFloat(it.Cost, 'f', -1, 64)
+		}
+		print(
+			it.Name,
+			strings.Join(it.Arches, ","),
+			strconv.Itoa(it.Cores),
+			formatMegabytes(it.Memory),
+			formatMegabytes(it.RootDisk),
+			cost,
+		)
+	}
+	return tw.Flush()
+}
+
+// formatMegabytes formats a size in MiB using the same units as
+// constraints, leaving unknown (zero) sizes blank.
+func formatMegabytes(size int) string {
+	switch {
+	case size <= 0:
+		return ""
+	case size%1024 == 0:
+		return fmt.Sprintf("%dG", size/1024)
+	default:
+		return fmt.Sprintf("%dM", size)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type InstanceTypesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeInstanceTypesAPI
+}
+
+var _ = gc.Suite(&InstanceTypesSuite{})
+
+func (s *InstanceTypesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeInstanceTypesAPI{
+		result: params.InstanceTypesResult{
+			InstanceTypes: []params.InstanceType{{
+				Name:         "m3.medium",
+				Arches:       []string{"amd64"},
+				CPUCores:     1,
+				Memory:       3840,
+				RootDiskSize: 4096,
+				Cost:         67,
+			}, {
+				Name:         "m3.large",
+				Arches:       []string{"amd64", "i386"},
+				CPUCores:     2,
+				Memory:       7680,
+				RootDiskSize: 32768,
+				Cost:         133,
+			}},
+			CostUnit:     "$USD/hour",
+			CostCurrency: "USD",
+			CostDivisor:  1000,
+		},
+	}
+}
+
+func (s *InstanceTypesSuite) TestInitRejectsArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewInstanceTypesCommandForTest(s.api), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *InstanceTypesSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewInstanceTypesCommandForTest(s.api), "--constraints", "cores=1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Name       Arches      Cores  Memory  Root disk  Cost ($USD/hour)\n"+
+		"m3.medium  amd64       1      3840M   4G         0.067\n"+
+		"m3.large   amd64,i386  2      7680M   32G        0.133\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "InstanceTypes", Args: []interface{}{constraints.MustParse("cores=1")}},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *InstanceTypesSuite) TestJSON(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewInstanceTypesCommandForTest(s.api), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"instance-types":[`+
+		`{"name":"m3.medium","arches":["amd64"],"cores":1,"memory":3840,"root-disk":4096,"cost":0.067},`+
+		`{"name":"m3.large","arches":["amd64","i386"],"cores":2,"memory":7680,"root-disk":32768,"cost":0.133}],`+
+		`"cost-unit":"$USD/hour","cost-currency":"USD"}`+"\n")
+	s.api.CheckCall(c, 0, "InstanceTypes", constraints.Value{})
+}
+
+func (s *InstanceTypesSuite) TestNoMatches(c *gc.C) {
+	s.api.result = params.InstanceTypesResult{}
+	ctx, err := cmdtesting.RunCommand(c, machine.NewInstanceTypesCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No instance types match the constraints.\n")
+}
+
+func (s *InstanceTypesSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.NotImplementedf("instance types"))
+	_, err := cmdtesting.RunCommand(c, machine.NewInstanceTypesCommandForTest(s.api))
+	c.Assert(err, gc.ErrorMatches, "cannot list instance types: instance types not implemented")
+}
+
+type fakeInstanceTypesAPI struct {
+	jujutesting.Stub
+	result params.InstanceTypesResult
+}
+
+func (f *fakeInstanceTypesAPI) InstanceTypes(cons constraints.Value) (params.InstanceTypesResult, error) {
+	f.MethodCall(f, "InstanceTypes", cons)
+	if err := f.NextErr(); err != nil {
+		return params.InstanceTypesResult{}, err
+	}
+	return f.result, nil
+}
+
+func (f *fakeInstanceTypesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
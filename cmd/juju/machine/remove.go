@@ -4,6 +4,8 @@
 package machine
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -14,6 +16,9 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+	"github.com/juju/juju/status"
 )
 
 // NewRemoveCommand returns a command used to remove a specified machine.
@@ -26,9 +31,11 @@ type removeCommand struct {
 	baseMachinesCommand
 	apiRoot      api.Connection
 	machineAPI   RemoveMachineAPI
+	statusAPI    MachineStatusAPI
 	MachineIds   []string
 	Force        bool
 	KeepInstance bool
+	SSHUser      string
 }
 
 const destroyMachineDoc = `
@@ -54,6 +61,19 @@ the corresponding cloud instance:
 
     juju remove-machine 7 --keep-instance
 
+A manually provisioned machine normally uninstalls its agents when it is
+removed. If its machine agent is down and '--force' is specified, the
+agents are instead uninstalled over SSH: the agent services, the data and
+log directories, and the settings written by Juju are removed from the
+machine. The SSH user, which must be able to run sudo without a password,
+is "ubuntu" unless '--ssh-user' is specified. This does not happen when
+'--keep-instance' is specified.
+
+Remove manual machine 8, whose machine agent is down, uninstalling its
+agents as the admin user:
+
+    juju remove-machine 8 --force --ssh-user admin
+
 See also:
     add-machine
 `
@@ -73,6 +93,7 @@ func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.Force, "force", false, "Completely remove a machine and all its dependencies")
 	f.BoolVar(&c.KeepInstance, "keep-instance", false, "Do not stop the running cloud instance")
+	f.StringVar(&c.SSHUser, "ssh-user", "ubuntu", "The user to uninstall the agents of manual machines as, with --force")
 }
 
 func (c *removeCommand) Init(args []string) error {
@@ -95,6 +116,11 @@ type RemoveMachineAPI interface {
 	Close() error
 }
 
+// MachineStatusAPI provides the status of the machines being removed.
+type MachineStatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
 // TODO(axw) 2017-03-16 #1673323
 // Drop this in Juju 3.0.
 type removeMachineAdapter struct {
@@ -148,6 +174,53 @@ func (c *removeCommand) getRemoveMachineAPI() (RemoveMachineAPI, error) {
 	return removeMachineAdapter{root.Client()}, nil
 }
 
+func (c *removeCommand) getStatusAPI() (MachineStatusAPI, error) {
+	if c.statusAPI != nil {
+		return c.statusAPI, nil
+	}
+	root, err := c.getAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return root.Client(), nil
+}
+
+// unreachableManualMachines returns the manually provisioned machines,
+// among those being removed, whose machine agents are down. The agents
+// on these machines cannot uninstall themselves.
+func (c *removeCommand) unreachableManualMachines() (map[string]params.MachineStatus, error) {
+	var ids []string
+	for _, id := range c.MachineIds {
+		if !names.IsContainerMachine(id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	statusAPI, err := c.getStatusAPI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fullStatus, err := statusAPI.Status(ids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines := make(map[string]params.MachineStatus)
+	for id, m := range fullStatus.Machines {
+		if !strings.HasPrefix(string(m.InstanceId), manual.ManualInstancePrefix) {
+			continue
+		}
+		if m.AgentStatus.Status != status.Down.String() {
+			continue
+		}
+		machines[id] = m
+	}
+	return machines, nil
+}
+
+var uninstallManualMachine = sshprovisioner.UninstallMachine
+
 // Run implements Command.Run.
 func (c *removeCommand) Run(ctx *cmd.Context) error {
 	client, err := c.getRemoveMachineAPI()
@@ -156,6 +229,16 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	var unreachable map[string]params.MachineStatus
+	if !c.KeepInstance {
+		// The status must be gathered before the machines are
+		// removed, at which point it is no longer available.
+		unreachable, err = c.unreachableManualMachines()
+		if err != nil {
+			logger.Warningf("cannot determine manual machines to uninstall: %v", err)
+		}
+	}
+
 	var results []params.DestroyMachineResult
 	if c.KeepInstance {
 		results, err = client.DestroyMachinesWithParams(c.Force, c.KeepInstance, c.MachineIds...)
@@ -207,6 +290,18 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 			}
 			ctx.Infof("- will detach %s", names.ReadableString(storageTag))
 		}
+		if m, ok := unreachable[id]; ok {
+			host := strings.TrimPrefix(string(m.InstanceId), manual.ManualInstancePrefix)
+			if !c.Force {
+				ctx.Infof("- machine agent is down, use --force to uninstall agents from %s over SSH", host)
+				continue
+			}
+			ctx.Infof("- machine agent is down, uninstalling agents from %s over SSH", host)
+			if err := uninstallManualMachine(c.SSHUser, host, m.Series); err != nil {
+				anyFailed = true
+				ctx.Infof("uninstalling agents from machine %s failed: %s", id, err)
+			}
+		}
 	}
 
 	if anyFailed {
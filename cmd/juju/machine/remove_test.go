@@ -4,6 +4,8 @@
 package machine_test
 
 import (
+	"errors"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
@@ -13,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/testing"
 )
 
@@ -33,7 +36,7 @@ func (s *RemoveMachineSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *RemoveMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.fake)
 	return cmdtesting.RunCommand(c, remove, args...)
 }
 
@@ -74,7 +77,7 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.fake)
 		err := cmdtesting.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -158,6 +161,108 @@ func (s *RemoveMachineSuite) TestForceBlockedError(c *gc.C) {
 	testing.AssertOperationWasBlocked(c, err, ".*TestForceBlockedError.*")
 }
 
+func (s *RemoveMachineSuite) manualMachinesStatus() *params.FullStatus {
+	return &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"1": {
+				Id:          "1",
+				InstanceId:  instance.Id("manual:10.0.0.1"),
+				Series:      "xenial",
+				AgentStatus: params.DetailedStatus{Status: "down"},
+			},
+			"2": {
+				Id:          "2",
+				InstanceId:  instance.Id("manual:10.0.0.2"),
+				Series:      "xenial",
+				AgentStatus: params.DetailedStatus{Status: "started"},
+			},
+			"3": {
+				Id:          "3",
+				InstanceId:  instance.Id("i-3"),
+				Series:      "xenial",
+				AgentStatus: params.DetailedStatus{Status: "down"},
+			},
+		},
+	}
+}
+
+func (s *RemoveMachineSuite) TestRemoveUninstallsUnreachableManualMachines(c *gc.C) {
+	s.fake.status = s.manualMachinesStatus()
+	var uninstalled []string
+	s.PatchValue(machine.UninstallManualMachine, func(user, host, series string) error {
+		uninstalled = append(uninstalled, user+"@"+host+" "+series)
+		return nil
+	})
+	ctx, err := s.run(c, "--force", "1", "2", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.statusPatterns, jc.DeepEquals, []string{"1", "2", "3"})
+	c.Assert(uninstalled, jc.DeepEquals, []string{"ubuntu@10.0.0.1 xenial"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+- machine agent is down, uninstalling agents from 10.0.0.1 over SSH
+removing machine 2
+removing machine 3
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveUninstallSSHUser(c *gc.C) {
+	s.fake.status = s.manualMachinesStatus()
+	var uninstalled []string
+	s.PatchValue(machine.UninstallManualMachine, func(user, host, series string) error {
+		uninstalled = append(uninstalled, user+"@"+host)
+		return nil
+	})
+	_, err := s.run(c, "--force", "--ssh-user", "admin", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uninstalled, jc.DeepEquals, []string{"admin@10.0.0.1"})
+}
+
+func (s *RemoveMachineSuite) TestRemoveWithoutForceDoesNotUninstall(c *gc.C) {
+	s.fake.status = s.manualMachinesStatus()
+	s.PatchValue(machine.UninstallManualMachine, func(user, host, series string) error {
+		c.Fatalf("unexpected uninstall from %s", host)
+		return nil
+	})
+	ctx, err := s.run(c, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+- machine agent is down, use --force to uninstall agents from 10.0.0.1 over SSH
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveUninstallFailure(c *gc.C) {
+	s.fake.status = s.manualMachinesStatus()
+	s.PatchValue(machine.UninstallManualMachine, func(user, host, series string) error {
+		return errors.New("connection refused")
+	})
+	ctx, err := s.run(c, "--force", "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+- machine agent is down, uninstalling agents from 10.0.0.1 over SSH
+uninstalling agents from machine 1 failed: connection refused
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveKeepDoesNotUninstall(c *gc.C) {
+	s.fake.status = s.manualMachinesStatus()
+	s.PatchValue(machine.UninstallManualMachine, func(user, host, series string) error {
+		c.Fatalf("unexpected uninstall from %s", host)
+		return nil
+	})
+	_, err := s.run(c, "--force", "--keep-instance", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.statusPatterns, gc.IsNil)
+}
+
+func (s *RemoveMachineSuite) TestRemoveStatusError(c *gc.C) {
+	s.fake.statusError = errors.New("boom")
+	_, err := s.run(c, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1"})
+}
+
 func (s *RemoveMachineSuite) TestOldFacadeRemoveKeep(c *gc.C) {
 	s.apiConnection.bestFacadeVersion = 3
 	_, err := s.run(c, "--keep-instance", "1")
@@ -165,11 +270,25 @@ func (s *RemoveMachineSuite) TestOldFacadeRemoveKeep(c *gc.C) {
 }
 
 type fakeRemoveMachineAPI struct {
-	forced      bool
-	keep        bool
-	machines    []string
-	removeError error
-	results     []params.DestroyMachineResult
+	forced         bool
+	keep           bool
+	machines       []string
+	removeError    error
+	results        []params.DestroyMachineResult
+	status         *params.FullStatus
+	statusPatterns []string
+	statusError    error
+}
+
+func (f *fakeRemoveMachineAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.statusPatterns = patterns
+	if f.statusError != nil {
+		return nil, f.statusError
+	}
+	if f.status == nil {
+		return &params.FullStatus{}, nil
+	}
+	return f.status, nil
 }
 
 func (f *fakeRemoveMachineAPI) Close() error {
@@ -0,0 +1,21 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+func NewListSecretsCommandForTest(api ListSecretsAPI) cmd.Command {
+	aCmd := &listSecretsCommand{
+		newAPIFunc: func() (ListSecretsAPI, error) {
+			return api, nil
+		},
+	}
+	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(aCmd)
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var listSecretsHelpSummary = `
+Lists the secrets created by charms in the model.`[1:]
+
+var listSecretsHelpDetails = `
+Lists the secrets created by charms using the secret-add hook tool,
+showing the application that owns each secret, its current revision,
+how often it is rotated, and the applications it has been shared with.
+Secret values are never shown.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml`
+
+// NewListSecretsCommand returns a command to list secrets.
+func NewListSecretsCommand() cmd.Command {
+	cmd := &listSecretsCommand{}
+	cmd.newAPIFunc = func() (ListSecretsAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return secrets.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type listSecretsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	newAPIFunc func() (ListSecretsAPI, error)
+}
+
+// ListSecretsAPI defines the API methods that the list secrets command uses.
+type ListSecretsAPI interface {
+	Close() error
+	ListSecrets() ([]params.ListSecretResult, error)
+}
+
+// Info implements cmd.Command.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-secrets",
+		Purpose: listSecretsHelpSummary,
+		Doc:     listSecretsHelpDetails,
+		Aliases: []string{"secrets"},
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatListTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listSecretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	results, err := client.ListSecrets()
+	if err != nil {
+		return err
+	}
+
+	secrets := make([]secretInfo, len(results))
+	for i, r := range results {
+		secrets[i] = secretInfo{
+			Id:          r.Id,
+			Owner:       r.Owner,
+			Description: r.Description,
+			Revision:    r.Revision,
+			Grants:      r.Grants,
+			Created:     r.CreateTime,
+			Updated:     r.UpdateTime,
+		}
+		if r.RotateInterval > 0 {
+			secrets[i].RotateInterval = r.RotateInterval.String()
+			secrets[i].NextRotate = r.NextRotateTime
+		}
+	}
+	return c.out.Write(ctx, secrets)
+}
+
+type secretInfo struct {
+	Id             string     `yaml:"id" json:"id"`
+	Owner          string     `yaml:"owner" json:"owner"`
+	Description    string     `yaml:"description,omitempty" json:"description,omitempty"`
+	Revision       int        `yaml:"revision" json:"revision"`
+	RotateInterval string     `yaml:"rotate-interval,omitempty" json:"rotate-interval,omitempty"`
+	NextRotate     *time.Time `yaml:"next-rotate,omitempty" json:"next-rotate,omitempty"`
+	Grants         []string   `yaml:"grants,omitempty" json:"grants,omitempty"`
+	Created        time.Time  `yaml:"created" json:"created"`
+	Updated        time.Time  `yaml:"updated" json:"updated"`
+}
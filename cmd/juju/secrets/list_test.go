@@ -0,0 +1,116 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockListAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	created := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	next := created.Add(24 * time.Hour)
+	s.mockAPI = &mockListAPI{
+		secrets: []params.ListSecretResult{{
+			Id:             "5a7e0c6b-0b1d-4c4e-8b57-2c8a3a6f1f10",
+			Owner:          "wordpress",
+			Description:    "admin password",
+			Revision:       3,
+			RotateInterval: 24 * time.Hour,
+			NextRotateTime: &next,
+			CreateTime:     created,
+			UpdateTime:     created,
+		}, {
+			Id:         "0e6f7b1a-7d38-4a43-9e56-1f0d2f6b8a22",
+			Owner:      "mysql",
+			Revision:   1,
+			Grants:     []string{"wordpress", "mediawiki"},
+			CreateTime: created,
+			UpdateTime: created,
+		}},
+	}
+}
+
+func (s *ListSuite) runList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.mockAPI), args...)
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runList(c)
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID                                    Owner      Revision  Rotate   Grants               Description
+0e6f7b1a-7d38-4a43-9e56-1f0d2f6b8a22  mysql      1                  wordpress,mediawiki  
+5a7e0c6b-0b1d-4c4e-8b57-2c8a3a6f1f10  wordpress  3         24h0m0s                       admin password
+
+`[1:])
+}
+
+func (s *ListSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.runList(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: 5a7e0c6b-0b1d-4c4e-8b57-2c8a3a6f1f10
+  owner: wordpress
+  description: admin password
+  revision: 3
+  rotate-interval: 24h0m0s
+  next-rotate: 2018-05-02T10:00:00Z
+  created: 2018-05-01T10:00:00Z
+  updated: 2018-05-01T10:00:00Z
+- id: 0e6f7b1a-7d38-4a43-9e56-1f0d2f6b8a22
+  owner: mysql
+  revision: 1
+  grants:
+  - wordpress
+  - mediawiki
+  created: 2018-05-01T10:00:00Z
+  updated: 2018-05-01T10:00:00Z
+`[1:])
+}
+
+func (s *ListSuite) TestListRejectsArgs(c *gc.C) {
+	_, err := s.runList(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+type mockListAPI struct {
+	secrets []params.ListSecretResult
+	err     error
+}
+
+func (s *mockListAPI) Close() error {
+	return nil
+}
+
+func (s *mockListAPI) ListSecrets() ([]params.ListSecretResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.secrets, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/output"
+)
+
+func formatListTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]secretInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	formatSecretsTabular(writer, secrets)
+	return nil
+}
+
+// formatSecretsTabular writes a tabular summary of secrets.
+func formatSecretsTabular(writer io.Writer, secrets []secretInfo) {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Owner != secrets[j].Owner {
+			return secrets[i].Owner < secrets[j].Owner
+		}
+		return secrets[i].Id < secrets[j].Id
+	})
+
+	w.Println("ID", "Owner", "Revision", "Rotate", "Grants", "Description")
+	for _, s := range secrets {
+		w.Println(s.Id, s.Owner, s.Revision, s.RotateInterval, strings.Join(s.Grants, ","), s.Description)
+	}
+	tw.Flush()
+}
@@ -105,8 +105,10 @@ type applicationStatus struct {
 	ProviderId       string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Address          string                `json:"address,omitempty" yaml:"address,omitempty"`
 	Exposed          bool                  `json:"exposed" yaml:"exposed"`
+	LoadBalancer     map[string][]string   `json:"load-balancer-addresses,omitempty" yaml:"load-balancer-addresses,omitempty"`
 	Life             string                `json:"life,omitempty" yaml:"life,omitempty"`
 	StatusInfo       statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
+	ServiceStatus    *statusInfoContents   `json:"service-status,omitempty" yaml:"service-status,omitempty"`
 	Relations        map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
 	SubordinateTo    []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
 	Units            map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
@@ -209,6 +209,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		CharmRev:         charmRev,
 		CharmVersion:     application.CharmVersion,
 		Exposed:          application.Exposed,
+		LoadBalancer:     application.LoadBalancerAddresses,
 		Life:             application.Life,
 		ProviderId:       application.ProviderId,
 		Address:          application.PublicAddress,
@@ -220,6 +221,10 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		Version:          application.WorkloadVersion,
 		EndpointBindings: application.EndpointBindings,
 	}
+	if application.ServiceStatus != nil {
+		serviceStatus := sf.getStatusInfoContents(*application.ServiceStatus)
+		out.ServiceStatus = &serviceStatus
+	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
@@ -109,9 +109,41 @@ func (s *StatusHistorySuite) TestResults(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
 }
 
+func (s *StatusHistorySuite) TestHookResults(c *gc.C) {
+	api := &fakeHistoryAPI{
+		history: status.History{
+			{
+				Kind:   status.KindHook,
+				Status: status.Idle,
+				Info:   `ran "install" hook (took 1m30s)`,
+				Since:  s.next(),
+			}, {
+				Kind:   status.KindHook,
+				Status: status.Error,
+				Info:   `"config-changed" hook failed with exit code 1 (took 2s)`,
+				Since:  s.next(),
+			},
+		},
+	}
+	s.api = api
+	expected := "" +
+		"Time                  Type  Status  Message\n" +
+		"2017-11-28 12:34:56Z  hook  idle    ran \"install\" hook (took 1m30s)\n" +
+		"2017-11-28 12:35:56Z  hook  error   \"config-changed\" hook failed with exit code 1 (took 2s)\n"
+
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--type", "hook", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+	c.Check(api.kind, gc.Equals, status.KindHook)
+	c.Check(api.tag, gc.Equals, names.NewUnitTag("mysql/0"))
+}
+
 type fakeHistoryAPI struct {
 	err     error
 	history status.History
+	kind    status.HistoryKind
+	tag     names.Tag
 }
 
 func (*fakeHistoryAPI) Close() error {
@@ -119,5 +151,7 @@ func (*fakeHistoryAPI) Close() error {
 }
 
 func (f *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	f.kind = kind
+	f.tag = tag
 	return f.history, f.err
 }
@@ -6,6 +6,7 @@ package status
 import (
 	"fmt"
 	"io"
+	"net"
 	"regexp"
 	"sort"
 	"strings"
@@ -111,6 +112,24 @@ func endSection(tw *ansiterm.TabWriter) {
 	tw.Flush()
 }
 
+// loadBalancerAddresses returns the addresses of the load balancers of
+// an application's endpoints as address:endpoint strings, ordered by
+// endpoint.
+func loadBalancerAddresses(endpoints map[string][]string) []string {
+	var names []string
+	for endpoint := range endpoints {
+		names = append(names, endpoint)
+	}
+	naturalsort.Sort(names)
+	var result []string
+	for _, endpoint := range names {
+		for _, addr := range endpoints[endpoint] {
+			result = append(result, net.JoinHostPort(addr, endpoint))
+		}
+	}
+	return result
+}
+
 func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 	maxVersionWidth := iaasMaxVersionWidth
 	if fs.Model.Type == caasModelType {
@@ -146,6 +165,16 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 		notes := ""
 		if app.Exposed {
 			notes = "exposed"
+			if len(app.LoadBalancer) > 0 {
+				notes += " at " + strings.Join(loadBalancerAddresses(app.LoadBalancer), ",")
+			}
+		}
+		if app.ServiceStatus != nil && app.ServiceStatus.Current != status.Active && app.ServiceStatus.Message != "" {
+			// Report the progress of any rolling update.
+			if notes != "" {
+				notes += ", "
+			}
+			notes += app.ServiceStatus.Message
 		}
 		w.Print(appName, version)
 		w.PrintStatus(app.StatusInfo.Current)
@@ -4777,6 +4777,24 @@ foo/1  maintenance  executing                                  (backup database)
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularLoadBalancer(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Exposed: true,
+				LoadBalancer: map[string][]string{
+					"80/tcp":  {"203.0.113.1", "2001:db8::1"},
+					"443/tcp": {"203.0.113.2"},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), jc.Contains, "  exposed at 203.0.113.1:80/tcp,[2001:db8::1]:80/tcp,203.0.113.2:443/tcp\n")
+}
+
 func (s *StatusSuite) TestFormatTabularHookActionName(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
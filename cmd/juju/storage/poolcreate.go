@@ -38,6 +38,11 @@ into named resources that can be used during deployment.
 Pools defined at the model level are easily reused across applications.
 Pool creation requires a pool name, the provider type and attributes for
 configuration as space-separated pairs, e.g. tags, size, path, etc.
+
+The "encrypted" and "kms-key" attributes are common to all providers:
+"encrypted=true" requests that volumes be encrypted at rest, and
+"kms-key" names the key management service key to encrypt them with.
+Pool creation fails if the provider cannot honour them.
 `
 
 // NewPoolCreateCommand returns a command that creates or defines a storage pool
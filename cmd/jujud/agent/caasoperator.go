@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"os"
 	"runtime"
 	"time"
 
@@ -139,14 +140,22 @@ func (op *CaasOperatorAgent) Run(ctx *cmd.Context) (err error) {
 
 // Workers returns a dependency.Engine running the operator's responsibilities.
 func (op *CaasOperatorAgent) Workers() (worker.Worker, error) {
+	// The host name identifies this operator among any standby
+	// operators for the application; for a pod, it is the pod name.
+	operatorName, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting operator name")
+	}
 	manifolds := CaasOperatorManifolds(caasoperator.ManifoldsConfig{
-		Agent:                op,
-		Clock:                clock.WallClock,
-		LogSource:            op.bufferedLogger.Logs(),
-		PrometheusRegisterer: op.prometheusRegistry,
-		LeadershipGuarantee:  30 * time.Second,
-		UpgradeStepsLock:     op.upgradeComplete,
-		ValidateMigration:    op.validateMigration,
+		Agent:                 op,
+		Clock:                 clock.WallClock,
+		LogSource:             op.bufferedLogger.Logs(),
+		PrometheusRegisterer:  op.prometheusRegistry,
+		LeadershipGuarantee:   30 * time.Second,
+		OperatorName:          operatorName,
+		OperatorLeaseDuration: 30 * time.Second,
+		UpgradeStepsLock:      op.upgradeComplete,
+		ValidateMigration:     op.validateMigration,
 	})
 
 	config := dependency.EngineConfig{
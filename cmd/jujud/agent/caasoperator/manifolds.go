@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/caasoperator"
+	"github.com/juju/juju/worker/caasoperatorflag"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/juju/worker/retrystrategy"
+	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/uniter"
 )
 
@@ -48,6 +50,16 @@ type ManifoldsConfig struct {
 	// LeadershipGuarantee controls the behaviour of the leadership tracker.
 	LeadershipGuarantee time.Duration
 
+	// OperatorName identifies this operator instance among the
+	// instances running for the application, when claiming the
+	// right to run the application's charm.
+	OperatorName string
+
+	// OperatorLeaseDuration controls how long this operator instance
+	// holds the right to run the application's charm between claims,
+	// and so how quickly a standby instance takes over if it fails.
+	OperatorLeaseDuration time.Duration
+
 	// ValidateMigration is called by the migrationminion during the
 	// migration process to check that the agent will be ok when
 	// connected to the new target controller.
@@ -132,12 +144,25 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     retrystrategy.NewRetryStrategyWorker,
 		})),
 
+		// The operator lease flag is set while this operator instance
+		// holds the right to run the application's charm. Any other
+		// instances are standbys, waiting for the lease to expire.
+		operatorLeaseFlagName: caasoperatorflag.Manifold(caasoperatorflag.ManifoldConfig{
+			AgentName:     agentName,
+			ClockName:     clockName,
+			APICallerName: apiCallerName,
+			Holder:        config.OperatorName,
+			Duration:      config.OperatorLeaseDuration,
+			NewFacade:     caasoperatorflag.NewFacade,
+			NewWorker:     singular.NewWorker,
+		}),
+
 		// The operator installs and deploys charm containers;
 		// manages the unit's presence in its relations;
 		// creates suboordinate units; runs all the hooks;
 		// sends metrics; etc etc etc.
 
-		operatorName: ifNotMigrating(caasoperator.Manifold(caasoperator.ManifoldConfig{
+		operatorName: ifOperatorLeaseHolder(caasoperator.Manifold(caasoperator.ManifoldConfig{
 			AgentName:             agentName,
 			APICallerName:         apiCallerName,
 			ClockName:             clockName,
@@ -180,6 +205,14 @@ var ifNotMigrating = engine.Housing{
 	Occupy: migrationFortressName,
 }.Decorate
 
+var ifOperatorLeaseHolder = engine.Housing{
+	Flags: []string{
+		migrationInactiveFlagName,
+		operatorLeaseFlagName,
+	},
+	Occupy: migrationFortressName,
+}.Decorate
+
 const (
 	agentName     = "agent"
 	apiCallerName = "api-caller"
@@ -188,6 +221,7 @@ const (
 
 	charmDirName          = "charm-dir"
 	hookRetryStrategyName = "hook-retry-strategy"
+	operatorLeaseFlagName = "operator-lease-flag"
 
 	upgradeStepsGateName = "upgrade-steps-gate"
 	upgradeStepsFlagName = "upgrade-steps-flag"
@@ -39,6 +39,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"clock",
 		"hook-retry-strategy",
 		"operator",
+		"operator-lease-flag",
 		"migration-fortress",
 		"migration-minion",
 		"migration-inactive-flag",
@@ -45,6 +45,7 @@ var (
 		"migration-master",        // secondary dependency: will be inactive because depends on model-upgrader
 		"model-upgrader",
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
+		"resource-tagger",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"status-history-pruner", // tertiary dependency: will be inactive because migration workers will be inactive
 		"storage-provisioner",   // tertiary dependency: will be inactive because migration workers will be inactive
@@ -69,6 +70,7 @@ var (
 		"storage-provisioner",
 		"unit-assigner",
 		"remote-relations",
+		"resource-tagger",
 		"log-forwarder",
 	}
 	migratingModelWorkers = []string{
@@ -925,6 +925,10 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 	}
 	defer session.Close()
 
+	secretsMasterKey, err := agent.SecretsMasterKey(agentConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	ctlr, err := state.OpenController(state.OpenParams{
 		Clock:                  clock.WallClock,
 		ControllerTag:          agentConfig.Controller(),
@@ -932,6 +936,7 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		SecretsMasterKey:       secretsMasterKey,
 	})
 	return ctlr, nil
 }
@@ -1071,6 +1076,10 @@ func openState(
 	}
 	defer session.Close()
 
+	secretsMasterKey, err := agent.SecretsMasterKey(agentConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	st, err := state.Open(state.OpenParams{
 		Clock:                  clock.WallClock,
 		ControllerTag:          agentConfig.Controller(),
@@ -1078,6 +1087,7 @@ func openState(
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: runTransactionObserver,
+		SecretsMasterKey:       secretsMasterKey,
 	})
 	if err != nil {
 		return nil, nil, err
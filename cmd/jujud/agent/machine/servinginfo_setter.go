@@ -79,6 +79,11 @@ func ServingInfoSetterManifold(config ServingInfoSetterConfig) dependency.Manifo
 					// apiState.
 					info.Cert = existing.Cert
 					info.PrivateKey = existing.PrivateKey
+					// Never drop a secrets master key we already
+					// hold; without it model secrets are unreadable.
+					if info.SecretsMasterKey == "" {
+						info.SecretsMasterKey = existing.SecretsMasterKey
+					}
 				}
 				config.SetStateServingInfo(info)
 				return nil
@@ -168,6 +168,20 @@ func (s *ServingInfoSetterSuite) TestJobManageEnvironNotOverwriteCert(c *gc.C) {
 	c.Assert(a.conf.ssi.PrivateKey, gc.Equals, existingKey)
 }
 
+func (s *ServingInfoSetterSuite) TestJobManageEnvironNotDropSecretsMasterKey(c *gc.C) {
+	const mockAPIPort = 1234
+
+	a := &mockAgent{}
+	a.conf.SetStateServingInfo(params.StateServingInfo{
+		SecretsMasterKey: "existing key",
+	})
+
+	s.startManifold(c, a, mockAPIPort)
+
+	c.Assert(a.conf.ssiSet, jc.IsTrue)
+	c.Assert(a.conf.ssi.SecretsMasterKey, gc.Equals, "existing key")
+}
+
 func (s *ServingInfoSetterSuite) TestJobHostUnits(c *gc.C) {
 	// State serving info should not be set for JobHostUnits.
 	s.checkNotController(c, multiwatcher.JobHostUnits)
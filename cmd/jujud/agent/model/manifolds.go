@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/base"
 	caasfirewallerapi "github.com/juju/juju/api/caasfirewaller"
 	caasunitprovisionerapi "github.com/juju/juju/api/caasunitprovisioner"
+	"github.com/juju/juju/api/logsender"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
@@ -31,6 +32,7 @@ import (
 	"github.com/juju/juju/worker/caasmodelupgrader"
 	"github.com/juju/juju/worker/caasoperatorprovisioner"
 	"github.com/juju/juju/worker/caasunitprovisioner"
+	"github.com/juju/juju/worker/caasworkloadlogs"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
 	"github.com/juju/juju/worker/cleaner"
@@ -53,6 +55,7 @@ import (
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/resourcetagger"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -387,6 +390,12 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:                    machineundertaker.NewWorker,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 		}))),
+		resourceTaggerName: ifNotMigrating(ifCredentialValid(resourcetagger.Manifold(resourcetagger.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			NewWorker:                    resourcetagger.NewWorker,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
 		modelUpgraderName: ifCredentialValid(modelupgrader.Manifold(modelupgrader.ManifoldConfig{
 			APICallerName:                apiCallerName,
 			EnvironName:                  environTrackerName,
@@ -454,6 +463,20 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 				NewWorker: caasunitprovisioner.NewWorker,
 			},
 		)),
+		caasWorkloadLogsName: ifNotMigrating(caasworkloadlogs.Manifold(
+			caasworkloadlogs.ManifoldConfig{
+				APICallerName: apiCallerName,
+				BrokerName:    caasBrokerTrackerName,
+				ClockName:     clockName,
+				NewClient: func(caller base.APICaller) caasworkloadlogs.Client {
+					return caasunitprovisionerapi.NewClient(caller)
+				},
+				NewLogWriter: func(caller base.APICaller) (logsender.LogWriter, error) {
+					return logsender.NewAPI(caller).LogWriter()
+				},
+				NewWorker: caasworkloadlogs.NewWorker,
+			},
+		)),
 		modelUpgraderName: caasmodelupgrader.Manifold(caasmodelupgrader.ManifoldConfig{
 			APICallerName: apiCallerName,
 			GateName:      modelUpgradeGateName,
@@ -579,11 +602,13 @@ const (
 	actionPrunerName         = "action-pruner"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
+	resourceTaggerName       = "resource-tagger"
 	logForwarderName         = "log-forwarder"
 
 	caasFirewallerName          = "caas-firewaller"
 	caasOperatorProvisionerName = "caas-operator-provisioner"
 	caasUnitProvisionerName     = "caas-unit-provisioner"
+	caasWorkloadLogsName        = "caas-workload-logs"
 	caasBrokerTrackerName       = "caas-broker-tracker"
 
 	validCredentialFlagName = "valid-credential-flag"
@@ -56,6 +56,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"resource-tagger",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
@@ -84,6 +85,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"caas-firewaller",
 		"caas-operator-provisioner",
 		"caas-unit-provisioner",
+		"caas-workload-logs",
 		"charm-revision-updater",
 		"clock",
 		"is-responsible-flag",
@@ -243,6 +245,18 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"caas-workload-logs": {
+		"agent",
+		"api-caller",
+		"caas-broker-tracker",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"charm-revision-updater": {
 		"agent",
 		"api-caller",
@@ -531,6 +545,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"resource-tagger": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"state-cleaner": {
 		"agent",
 		"api-caller",
@@ -226,6 +226,14 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	}
 	info.SharedSecret = sharedSecret
 	info.SystemIdentity = privateKey
+	if info.SecretsMasterKey == "" {
+		// Generate the key used to wrap model secret keys. It is
+		// only ever held by controller agents, never in mongo.
+		info.SecretsMasterKey, err = agent.GenerateSecretsMasterKey()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
 		mmprof, err := mongo.NewMemoryProfile(args.ControllerConfig.MongoMemoryProfile())
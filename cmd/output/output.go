@@ -104,6 +104,7 @@ var statusColors = map[status.Status]*ansiterm.Context{
 	status.Allocating:  WarningHighlight,
 	status.Lost:        WarningHighlight,
 	status.Maintenance: WarningHighlight,
+	status.Paused:      WarningHighlight,
 	status.Pending:     WarningHighlight,
 	status.Rebooting:   WarningHighlight,
 	status.Stopped:     WarningHighlight,
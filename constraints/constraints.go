@@ -32,6 +32,19 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+	Zones        = "zones"
+
+	InstanceLifecycle = "instance-lifecycle"
+	MaxPrice          = "max-price"
+)
+
+// The following constants list the instance lifecycles which may be
+// requested with the instance-lifecycle constraint. Providers declare
+// which of them they support through their constraints validator.
+const (
+	OnDemand    = "on-demand"
+	Spot        = "spot"
+	Preemptible = "preemptible"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +98,21 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// Zones, if not nil, holds a list of availability zones limiting
+	// where the machine can be located.
+	Zones *[]string `json:"zones,omitempty" yaml:"zones,omitempty"`
+
+	// InstanceLifecycle, if not nil or empty, indicates the kind of
+	// capacity the machine should be started on, such as "spot" or
+	// "preemptible" instances, which are cheaper but may be terminated
+	// by the provider at any time.
+	InstanceLifecycle *string `json:"instance-lifecycle,omitempty" yaml:"instance-lifecycle,omitempty"`
+
+	// MaxPrice, if not nil or empty, holds the maximum hourly price,
+	// as a decimal number in the cloud's currency, that should be paid
+	// for spot capacity.
+	MaxPrice *string `json:"max-price,omitempty" yaml:"max-price,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -180,6 +208,23 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasZones returns whether any zone constraints were specified.
+func (v *Value) HasZones() bool {
+	return v.Zones != nil && len(*v.Zones) > 0
+}
+
+// HasInstanceLifecycle returns true if the constraints.Value specifies
+// an instance lifecycle.
+func (v *Value) HasInstanceLifecycle() bool {
+	return v.InstanceLifecycle != nil && *v.InstanceLifecycle != ""
+}
+
+// HasMaxPrice returns true if the constraints.Value specifies a maximum
+// price for spot capacity.
+func (v *Value) HasMaxPrice() bool {
+	return v.MaxPrice != nil && *v.MaxPrice != ""
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -198,6 +243,12 @@ func (v Value) String() string {
 	if v.InstanceType != nil {
 		strs = append(strs, "instance-type="+(*v.InstanceType))
 	}
+	if v.InstanceLifecycle != nil {
+		strs = append(strs, "instance-lifecycle="+(*v.InstanceLifecycle))
+	}
+	if v.MaxPrice != nil {
+		strs = append(strs, "max-price="+(*v.MaxPrice))
+	}
 	if v.Mem != nil {
 		s := uintStr(*v.Mem)
 		if s != "" {
@@ -223,6 +274,10 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+(*v.VirtType))
 	}
+	if v.Zones != nil {
+		s := strings.Join(*v.Zones, ",")
+		strs = append(strs, "zones="+s)
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -248,6 +303,12 @@ func (v Value) GoString() string {
 	if v.InstanceType != nil {
 		values = append(values, fmt.Sprintf("InstanceType: %q", *v.InstanceType))
 	}
+	if v.InstanceLifecycle != nil {
+		values = append(values, fmt.Sprintf("InstanceLifecycle: %q", *v.InstanceLifecycle))
+	}
+	if v.MaxPrice != nil {
+		values = append(values, fmt.Sprintf("MaxPrice: %q", *v.MaxPrice))
+	}
 	if v.Container != nil {
 		values = append(values, fmt.Sprintf("Container: %q", *v.Container))
 	}
@@ -264,6 +325,11 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.Zones != nil && *v.Zones != nil {
+		values = append(values, fmt.Sprintf("Zones: %q", *v.Zones))
+	} else if v.Zones != nil {
+		values = append(values, "Zones: (*[]string)(nil)")
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -420,6 +486,12 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case Zones:
+		err = v.setZones(str)
+	case InstanceLifecycle:
+		err = v.setInstanceLifecycle(str)
+	case MaxPrice:
+		err = v.setMaxPrice(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -483,6 +555,14 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case Zones:
+			v.Zones, err = parseYamlStrings("zones", val)
+		case InstanceLifecycle:
+			v.InstanceLifecycle = &vstr
+		case MaxPrice:
+			if err = validateMaxPrice(vstr); err == nil {
+				v.MaxPrice = &vstr
+			}
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -607,6 +687,44 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setZones(str string) error {
+	if v.Zones != nil {
+		return errors.Errorf("already set")
+	}
+	v.Zones = parseCommaDelimited(str)
+	return nil
+}
+
+func (v *Value) setInstanceLifecycle(str string) error {
+	if v.InstanceLifecycle != nil {
+		return errors.Errorf("already set")
+	}
+	v.InstanceLifecycle = &str
+	return nil
+}
+
+func (v *Value) setMaxPrice(str string) error {
+	if v.MaxPrice != nil {
+		return errors.Errorf("already set")
+	}
+	if err := validateMaxPrice(str); err != nil {
+		return err
+	}
+	v.MaxPrice = &str
+	return nil
+}
+
+func validateMaxPrice(str string) error {
+	if str == "" {
+		return nil
+	}
+	val, err := strconv.ParseFloat(str, 64)
+	if err != nil || val <= 0 {
+		return errors.Errorf("must be a positive decimal number")
+	}
+	return nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
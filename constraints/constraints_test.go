@@ -313,19 +313,70 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// "zones" in detail.
+	{
+		summary: "single zone",
+		args:    []string{"zones=us-east-1a"},
+	}, {
+		summary: "multiple zones",
+		args:    []string{"zones=us-east-1a,us-east-1b"},
+	}, {
+		summary: "no zones",
+		args:    []string{"zones="},
+	}, {
+		summary: "double set zones",
+		args:    []string{"zones=us-east-1a", "zones=us-east-1b"},
+		err:     `bad "zones" constraint: already set`,
+	},
+
+	// "instance-lifecycle" and "max-price" in detail.
+	{
+		summary: "set instance-lifecycle spot",
+		args:    []string{"instance-lifecycle=spot"},
+	}, {
+		summary: "set instance-lifecycle empty",
+		args:    []string{"instance-lifecycle="},
+	}, {
+		summary: "double set instance-lifecycle",
+		args:    []string{"instance-lifecycle=spot", "instance-lifecycle=preemptible"},
+		err:     `bad "instance-lifecycle" constraint: already set`,
+	}, {
+		summary: "set max-price",
+		args:    []string{"instance-lifecycle=spot max-price=0.05"},
+	}, {
+		summary: "set max-price empty",
+		args:    []string{"max-price="},
+	}, {
+		summary: "set max-price zero",
+		args:    []string{"max-price=0"},
+		err:     `bad "max-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "set max-price negative",
+		args:    []string{"max-price=-1"},
+		err:     `bad "max-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "set max-price non-numeric",
+		args:    []string{"max-price=cheap"},
+		err:     `bad "max-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "double set max-price",
+		args:    []string{"max-price=1", "max-price=2"},
+		err:     `bad "max-price" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
 		args: []string{
 			"root-disk=8G mem=2T  arch=i386  cores=4096 cpu-power=9001 container=lxd " +
 				"tags=foo,bar spaces=space1,^space2 instance-type=foo",
-			"virt-type=kvm"},
+			"virt-type=kvm zones=az1,az2"},
 	}, {
 		summary: "kitchen sink separately",
 		args: []string{
 			"root-disk=8G", "mem=2T", "cores=4096", "cpu-power=9001", "arch=armhf",
 			"container=lxd", "tags=foo,bar", "spaces=space1,^space2",
-			"instance-type=foo", "virt-type=kvm"},
+			"instance-type=foo", "virt-type=kvm", "zones=az1,az2"},
 	},
 }
 
@@ -510,16 +561,26 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"Zones1", constraints.Value{Zones: nil}},
+	{"Zones2", constraints.Value{Zones: &[]string{}}},
+	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
+	{"InstanceLifecycle1", constraints.Value{InstanceLifecycle: strp("")}},
+	{"InstanceLifecycle2", constraints.Value{InstanceLifecycle: strp("spot")}},
+	{"MaxPrice1", constraints.Value{MaxPrice: strp("")}},
+	{"MaxPrice2", constraints.Value{MaxPrice: strp("0.05")}},
 	{"All", constraints.Value{
-		Arch:         strp("i386"),
-		Container:    ctypep("lxd"),
-		CpuCores:     uint64p(4096),
-		CpuPower:     uint64p(9001),
-		Mem:          uint64p(18000000000),
-		RootDisk:     uint64p(24000000000),
-		Tags:         &[]string{"foo", "bar"},
-		Spaces:       &[]string{"space1", "^space2"},
-		InstanceType: strp("foo"),
+		Arch:              strp("i386"),
+		Container:         ctypep("lxd"),
+		CpuCores:          uint64p(4096),
+		CpuPower:          uint64p(9001),
+		Mem:               uint64p(18000000000),
+		RootDisk:          uint64p(24000000000),
+		Tags:              &[]string{"foo", "bar"},
+		Spaces:            &[]string{"space1", "^space2"},
+		InstanceType:      strp("foo"),
+		Zones:             &[]string{"az1", "az2"},
+		InstanceLifecycle: strp("spot"),
+		MaxPrice:          strp("1.5"),
 	}},
 }
 
@@ -597,6 +658,24 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasZones(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasZones(), jc.IsFalse)
+	cons = constraints.MustParse("zones=")
+	c.Check(cons.HasZones(), jc.IsFalse)
+	cons = constraints.MustParse("zones=az1,az2")
+	c.Check(cons.HasZones(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasInstanceLifecycleAndMaxPrice(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasInstanceLifecycle(), jc.IsFalse)
+	c.Check(cons.HasMaxPrice(), jc.IsFalse)
+	cons = constraints.MustParse("instance-lifecycle=spot max-price=0.25")
+	c.Check(cons.HasInstanceLifecycle(), jc.IsTrue)
+	c.Check(cons.HasMaxPrice(), jc.IsTrue)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
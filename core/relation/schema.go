@@ -0,0 +1,322 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/gojsonschema"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
+)
+
+// schemasKey is the charm metadata key under which relation data
+// schemas are declared. For example:
+//
+//     relation-schemas:
+//       db:
+//         publishes:
+//           type: object
+//           required: [host, port]
+//           properties:
+//             host: {type: string}
+//             port: {type: string, pattern: "^[0-9]+$"}
+//         expects:
+//           type: object
+//           required: [database]
+//
+// Relation settings values are always strings, so schemas should
+// describe them as such.
+const schemasKey = "relation-schemas"
+
+// Schemas holds the relation data schemas declared by a charm, keyed
+// by endpoint name.
+type Schemas map[string]EndpointSchema
+
+// EndpointSchema holds the JSON schemas for the relation data of a
+// single charm endpoint. Either may be nil, if the charm does not
+// declare it.
+type EndpointSchema struct {
+	// Publishes is the schema for the settings that the charm's units
+	// set on the relation.
+	Publishes map[string]interface{} `yaml:"publishes,omitempty" json:"publishes,omitempty"`
+
+	// Expects is the schema for the settings that the charm's units
+	// expect to read from the remote units.
+	Expects map[string]interface{} `yaml:"expects,omitempty" json:"expects,omitempty"`
+}
+
+// ParseSchemas returns the relation data schemas declared in the given
+// charm metadata. It returns an error if any schema is not valid.
+func ParseSchemas(metadata []byte) (Schemas, error) {
+	var meta struct {
+		Schemas map[string]struct {
+			Publishes interface{} `yaml:"publishes"`
+			Expects   interface{} `yaml:"expects"`
+		} `yaml:"relation-schemas"`
+	}
+	if err := yaml.Unmarshal(metadata, &meta); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse %s", schemasKey)
+	}
+	if len(meta.Schemas) == 0 {
+		return nil, nil
+	}
+	schemas := make(Schemas)
+	for endpoint, s := range meta.Schemas {
+		publishes, err := checkSchema(s.Publishes)
+		if err != nil {
+			return nil, errors.Annotatef(err, "endpoint %q publishes schema", endpoint)
+		}
+		expects, err := checkSchema(s.Expects)
+		if err != nil {
+			return nil, errors.Annotatef(err, "endpoint %q expects schema", endpoint)
+		}
+		schemas[endpoint] = EndpointSchema{
+			Publishes: publishes,
+			Expects:   expects,
+		}
+	}
+	return schemas, nil
+}
+
+// ReadCharmSchemas returns the relation data schemas declared in the
+// metadata of the given charm directory or archive. Other kinds of
+// charm are assumed to declare no schemas.
+func ReadCharmSchemas(ch charm.Charm) (Schemas, error) {
+	var metadata []byte
+	var err error
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		metadata, err = ioutil.ReadFile(filepath.Join(ch.Path, "metadata.yaml"))
+	case *charm.CharmArchive:
+		if ch.Path == "" {
+			// The archive was read from memory.
+			return nil, nil
+		}
+		metadata, err = readArchiveMetadata(ch.Path)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseSchemas(metadata)
+}
+
+func readArchiveMetadata(archivePath string) ([]byte, error) {
+	zipReader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm archive")
+	}
+	defer zipReader.Close()
+	for _, file := range zipReader.File {
+		if path.Clean(file.Name) != "metadata.yaml" {
+			continue
+		}
+		r, err := file.Open()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read charm metadata")
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return nil, errors.NotFoundf("charm metadata")
+}
+
+// checkSchema converts a schema read from YAML into the form expected
+// by the JSON schema validator, and checks that it is a valid schema.
+func checkSchema(in interface{}) (map[string]interface{}, error) {
+	if in == nil {
+		return nil, nil
+	}
+	schema, ok := toJSON(in).(map[string]interface{})
+	if !ok {
+		return nil, errors.NotValidf("schema of type %T", in)
+	}
+	// Validating an empty document reports any problem with the schema
+	// itself as an error, rather than as a validation failure.
+	if _, err := validate(schema, map[string]interface{}{}); err != nil {
+		return nil, errors.NewNotValid(err, "invalid schema")
+	}
+	return schema, nil
+}
+
+// toJSON converts the maps produced by the YAML decoder, which may have
+// non-string keys, into JSON-compatible maps.
+func toJSON(in interface{}) interface{} {
+	switch in := in.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(in))
+		for k, v := range in {
+			out[fmt.Sprint(k)] = toJSON(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(in))
+		for i, v := range in {
+			out[i] = toJSON(v)
+		}
+		return out
+	}
+	return in
+}
+
+// validate returns a description of each way in which the document
+// fails to conform to the schema.
+func validate(schema map[string]interface{}, doc interface{}) ([]string, error) {
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewGoLoader(schema),
+		gojsonschema.NewGoLoader(doc),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var problems []string
+	for _, resultErr := range result.Errors() {
+		problems = append(problems, resultErr.Description)
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// jujuManagedKeys holds the relation settings keys that Juju itself
+// sets on behalf of every unit. They are not part of any interface, so
+// schemas never see them.
+var jujuManagedKeys = map[string]bool{
+	"private-address": true,
+	"ingress-address": true,
+	"egress-subnets":  true,
+}
+
+// ValidatePublished returns an error if the given relation settings do
+// not conform to the schema for the data the endpoint publishes. The
+// settings Juju manages itself are ignored.
+func (s EndpointSchema) ValidatePublished(settings map[string]string) error {
+	if s.Publishes == nil {
+		return nil
+	}
+	doc := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		if !jujuManagedKeys[k] {
+			doc[k] = v
+		}
+	}
+	problems, err := validate(s.Publishes, doc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(problems) > 0 {
+		return errors.NotValidf("relation settings (%s)", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Incompatibilities returns a description of each way in which the data
+// published by one endpoint fails to meet the expectations of the other,
+// in either direction. The names identify the endpoints in the
+// descriptions.
+//
+// Only the top level properties of the schemas are compared: a property
+// that one endpoint requires must be declared by the other, and a
+// property declared by both must have the same type.
+func Incompatibilities(name1 string, s1 EndpointSchema, name2 string, s2 EndpointSchema) []string {
+	problems := incompatibilities(name1, s1.Expects, name2, s2.Publishes)
+	return append(problems, incompatibilities(name2, s2.Expects, name1, s1.Publishes)...)
+}
+
+func incompatibilities(
+	expectsName string, expects map[string]interface{},
+	publishesName string, publishes map[string]interface{},
+) []string {
+	if expects == nil || publishes == nil {
+		// Without both schemas there is nothing to compare.
+		return nil
+	}
+	expectProps := schemaProperties(expects)
+	publishProps := schemaProperties(publishes)
+	var problems []string
+	for _, key := range schemaRequired(expects) {
+		if _, ok := publishProps[key]; !ok {
+			problems = append(problems, fmt.Sprintf(
+				"%s expects %q, which %s does not publish", expectsName, key, publishesName,
+			))
+		}
+	}
+	keys := make([]string, 0, len(expectProps))
+	for key := range expectProps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		expectType := propertyType(expectProps[key])
+		publishType := propertyType(publishProps[key])
+		if expectType != "" && publishType != "" && expectType != publishType {
+			problems = append(problems, fmt.Sprintf(
+				"%s expects %q of type %s, but %s publishes type %s",
+				expectsName, key, expectType, publishesName, publishType,
+			))
+		}
+	}
+	return problems
+}
+
+func schemaProperties(schema map[string]interface{}) map[string]interface{} {
+	props, _ := schema["properties"].(map[string]interface{})
+	return props
+}
+
+func schemaRequired(schema map[string]interface{}) []string {
+	required, _ := schema["required"].([]interface{})
+	var keys []string
+	for _, key := range required {
+		if key, ok := key.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func propertyType(prop interface{}) string {
+	p, ok := prop.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	t, _ := p["type"].(string)
+	return t
+}
+
+// Marshal returns the JSON encoding of the schemas, suitable for
+// storage. JSON schemas commonly use keys, such as "$schema", that
+// cannot be stored directly in mongo.
+func (s Schemas) Marshal() (string, error) {
+	if len(s) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// UnmarshalSchemas returns the schemas encoded by Schemas.Marshal.
+func UnmarshalSchemas(data string) (Schemas, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var s Schemas
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
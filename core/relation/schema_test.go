@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/relation"
+)
+
+type SchemaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SchemaSuite{})
+
+const serverMetadata = `
+name: mysql
+summary: database
+description: database
+provides:
+  server:
+    interface: mysql
+relation-schemas:
+  server:
+    publishes:
+      $schema: "http://json-schema.org/draft-04/schema#"
+      type: object
+      required: [host, port]
+      properties:
+        host: {type: string}
+        port: {type: string, pattern: "^[0-9]+$"}
+    expects:
+      type: object
+      required: [database]
+      properties:
+        database: {type: string}
+`
+
+func (s *SchemaSuite) parse(c *gc.C, metadata string) relation.Schemas {
+	schemas, err := relation.ParseSchemas([]byte(metadata))
+	c.Assert(err, jc.ErrorIsNil)
+	return schemas
+}
+
+func (s *SchemaSuite) TestParseSchemas(c *gc.C) {
+	schemas := s.parse(c, serverMetadata)
+	c.Assert(schemas, gc.HasLen, 1)
+	server := schemas["server"]
+	c.Assert(server.Publishes["required"], jc.DeepEquals, []interface{}{"host", "port"})
+	c.Assert(server.Expects["properties"], jc.DeepEquals, map[string]interface{}{
+		"database": map[string]interface{}{"type": "string"},
+	})
+}
+
+func (s *SchemaSuite) TestParseNoSchemas(c *gc.C) {
+	schemas := s.parse(c, "name: mysql\n")
+	c.Assert(schemas, gc.HasLen, 0)
+}
+
+func (s *SchemaSuite) TestParseInvalidSchema(c *gc.C) {
+	_, err := relation.ParseSchemas([]byte(`
+relation-schemas:
+  server:
+    publishes: [not, a, schema]
+`))
+	c.Assert(err, gc.ErrorMatches, `endpoint "server" publishes schema: schema of type \[\]interface {} not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SchemaSuite) TestValidatePublished(c *gc.C) {
+	server := s.parse(c, serverMetadata)["server"]
+	err := server.ValidatePublished(map[string]string{"host": "10.0.0.1", "port": "3306"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = server.ValidatePublished(map[string]string{"host": "10.0.0.1", "port": "mysql"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `relation settings \(.*\) not valid`)
+
+	err = server.ValidatePublished(map[string]string{"host": "10.0.0.1"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `relation settings \(.*port.*\) not valid`)
+}
+
+func (s *SchemaSuite) TestValidatePublishedIgnoresJujuManagedKeys(c *gc.C) {
+	schema := relation.EndpointSchema{
+		Publishes: map[string]interface{}{
+			"type":                 "object",
+			"required":             []interface{}{"host"},
+			"additionalProperties": false,
+			"properties": map[string]interface{}{
+				"host": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+	err := schema.ValidatePublished(map[string]string{
+		"host":            "10.0.0.1",
+		"private-address": "10.0.0.1",
+		"ingress-address": "10.0.0.1",
+		"egress-subnets":  "10.0.0.1/32",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SchemaSuite) TestValidatePublishedNoSchema(c *gc.C) {
+	err := relation.EndpointSchema{}.ValidatePublished(map[string]string{"anything": "goes"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SchemaSuite) TestIncompatibilities(c *gc.C) {
+	server := s.parse(c, serverMetadata)["server"]
+	client := s.parse(c, `
+relation-schemas:
+  db:
+    publishes:
+      type: object
+      properties:
+        db-name: {type: string}
+    expects:
+      type: object
+      required: [host, port, user]
+      properties:
+        port: {type: integer}
+`)["db"]
+	problems := relation.Incompatibilities("wordpress:db", client, "mysql:server", server)
+	c.Assert(problems, jc.DeepEquals, []string{
+		`wordpress:db expects "user", which mysql:server does not publish`,
+		`wordpress:db expects "port" of type integer, but mysql:server publishes type string`,
+		`mysql:server expects "database", which wordpress:db does not publish`,
+	})
+}
+
+func (s *SchemaSuite) TestIncompatibilitiesMissingSchemas(c *gc.C) {
+	server := s.parse(c, serverMetadata)["server"]
+	problems := relation.Incompatibilities("wordpress:db", relation.EndpointSchema{}, "mysql:server", server)
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (s *SchemaSuite) TestMarshalRoundTrip(c *gc.C) {
+	schemas := s.parse(c, serverMetadata)
+	data, err := schemas.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := relation.UnmarshalSchemas(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schemas)
+
+	data, err = relation.Schemas(nil).Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.Equals, "")
+	result, err = relation.UnmarshalSchemas("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *SchemaSuite) TestReadCharmSchemas(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(serverMetadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schemas, err := relation.ReadCharmSchemas(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, jc.DeepEquals, s.parse(c, serverMetadata))
+}
@@ -153,6 +153,9 @@ func prepare(
 	if err != nil {
 		return nil, details, errors.Trace(err)
 	}
+	if err := environs.ValidateRootDiskEncryption(p, cfg); err != nil {
+		return nil, details, errors.Trace(err)
+	}
 	env, err := environs.Open(p, environs.OpenParams{
 		Cloud:  args.Cloud,
 		Config: cfg,
@@ -8,6 +8,8 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+
+	"github.com/juju/juju/environs/config"
 )
 
 var logger = loggo.GetLogger("juju.environs")
@@ -118,3 +120,17 @@ func RegisteredProviders() []string {
 func Provider(providerType string) (EnvironProvider, error) {
 	return GlobalProviderRegistry().Provider(providerType)
 }
+
+// ValidateRootDiskEncryption returns an error satisfying
+// errors.IsNotSupported if the model config enables root disk
+// encryption, and the provider cannot honour it.
+func ValidateRootDiskEncryption(p EnvironProvider, cfg *config.Config) error {
+	if !cfg.RootDiskEncryption() {
+		return nil
+	}
+	encrypter, ok := p.(RootDiskEncrypter)
+	if !ok {
+		return errors.NotSupportedf("%s with %q provider", config.RootDiskEncryption, cfg.Type())
+	}
+	return errors.Trace(encrypter.ValidateRootDiskEncryption(cfg))
+}
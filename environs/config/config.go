@@ -41,6 +41,12 @@ const (
 	// port opened.
 	FwGlobal = "global"
 
+	// FwApplication requests the use of a firewall group per application.
+	// Each machine is a member of the groups of the applications it hosts,
+	// and ports opened for relations refer to the groups of the related
+	// applications rather than to address ranges.
+	FwApplication = "application"
+
 	// FwNone requests that no firewalling should be performed inside
 	// the environment. No firewaller worker will be started. It's
 	// useful for clouds without support for either global or per
@@ -219,6 +225,32 @@ const (
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
+	// HookTimeout is how long a charm hook may run before it is
+	// killed and the unit put into an error state. A zero or empty
+	// value means hooks may run indefinitely.
+	HookTimeout = "hook-timeout"
+
+	// ExposeLoadBalancers is whether exposed applications are given a
+	// provider load balancer in front of their units, on providers
+	// that support them.
+	ExposeLoadBalancers = "expose-load-balancers"
+
+	// RootDiskEncryption is whether the root disks of new machines are
+	// encrypted at rest. Providers that cannot honour it refuse it.
+	// Changing it does not affect existing machines.
+	RootDiskEncryption = "root-disk-encryption"
+
+	// RootDiskKMSKey identifies the provider's key management service
+	// key with which the root disks of new machines are encrypted, when
+	// RootDiskEncryption is true. If it is empty, the provider's
+	// default key is used.
+	RootDiskKMSKey = "root-disk-kms-key"
+
+	// ReplaceTerminatedMachines is whether machines whose instances are
+	// terminated by the provider, such as reclaimed spot instances, are
+	// replaced by new machines hosting the same applications.
+	ReplaceTerminatedMachines = "replace-terminated-machines"
+
 	// EgressSubnets are the source addresses from which traffic from this model
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
@@ -443,6 +475,11 @@ var defaultConfigValues = map[string]interface{}{
 	"test-mode":                  false,
 	TransmitVendorMetricsKey:     true,
 	UpdateStatusHookInterval:     DefaultUpdateStatusHookInterval,
+	HookTimeout:                  "",
+	ExposeLoadBalancers:          false,
+	RootDiskEncryption:           false,
+	RootDiskKMSKey:               "",
+	ReplaceTerminatedMachines:    false,
 	EgressSubnets:                "",
 	FanConfig:                    "",
 	CloudInitUserDataKey:         "",
@@ -641,6 +678,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[HookTimeout].(string); ok && v != "" {
+		if f, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid hook timeout in model configuration")
+		} else if f < 0 {
+			return errors.Errorf("hook timeout %v cannot be negative", f)
+		}
+	}
+
+	if v, ok := cfg.defined[RootDiskKMSKey].(string); ok && v != "" && !cfg.RootDiskEncryption() {
+		return errors.Errorf("%s requires %s to be true", RootDiskKMSKey, RootDiskEncryption)
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -1069,8 +1118,8 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 }
 
 // FirewallMode returns whether the firewall should
-// manage ports per machine, globally, or not at all.
-// (FwInstance, FwGlobal, or FwNone).
+// manage ports per machine, globally, per application, or not at all.
+// (FwInstance, FwGlobal, FwApplication, or FwNone).
 func (c *Config) FirewallMode() string {
 	return c.mustString("firewall-mode")
 }
@@ -1267,7 +1316,19 @@ func (c *Config) StorageDefaultFilesystemSource() (string, bool) {
 // that Juju creates and manages, if the provider supports them. These
 // tags have no special meaning to Juju, but may be used for existing
 // chargeback accounting schemes or other identification purposes.
+//
+// The {{model}} template variable in tag values is replaced with the
+// model name; the variables identifying applications and units are
+// replaced with empty strings. Use ResourceTagTemplates to expand them
+// for resources used by particular units.
 func (c *Config) ResourceTags() (map[string]string, bool) {
+	templates, ok := c.ResourceTagTemplates()
+	return tags.ExpandTemplates(templates, tags.TemplateVars{Model: c.Name()}), ok
+}
+
+// ResourceTagTemplates returns the tags to set on environment resources
+// as specified, with template variables unexpanded.
+func (c *Config) ResourceTagTemplates() (map[string]string, bool) {
 	tags, err := c.resourceTags()
 	if err != nil {
 		panic(err) // should be prevented by Validate
@@ -1332,6 +1393,47 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// HookTimeout is how long a charm hook may run before it is killed.
+// A zero value means hooks may run indefinitely.
+func (c *Config) HookTimeout() time.Duration {
+	raw := c.asString(HookTimeout)
+	if raw == "" {
+		return 0
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
+// ExposeLoadBalancers returns whether exposed applications are given a
+// provider load balancer in front of their units.
+func (c *Config) ExposeLoadBalancers() bool {
+	value, _ := c.defined[ExposeLoadBalancers].(bool)
+	return value
+}
+
+// RootDiskEncryption returns whether the root disks of new machines
+// are encrypted at rest. Existing machines are unaffected by changes.
+func (c *Config) RootDiskEncryption() bool {
+	value, _ := c.defined[RootDiskEncryption].(bool)
+	return value
+}
+
+// RootDiskKMSKey returns the key management service key with which the
+// root disks of new machines are encrypted, or "" for the provider's
+// default key.
+func (c *Config) RootDiskKMSKey() string {
+	value, _ := c.defined[RootDiskKMSKey].(string)
+	return value
+}
+
+// ReplaceTerminatedMachines returns whether machines whose instances
+// are terminated by the provider are replaced by new machines.
+func (c *Config) ReplaceTerminatedMachines() bool {
+	value, _ := c.defined[ReplaceTerminatedMachines].(bool)
+	return value
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1494,6 +1596,11 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsAge:          schema.Omit,
 	MaxActionResultsSize:         schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
+	HookTimeout:                  schema.Omit,
+	ExposeLoadBalancers:          schema.Omit,
+	RootDiskEncryption:           schema.Omit,
+	RootDiskKMSKey:               schema.Omit,
+	ReplaceTerminatedMachines:    schema.Omit,
 	EgressSubnets:                schema.Omit,
 	FanConfig:                    schema.Omit,
 	CloudInitUserDataKey:         schema.Omit,
@@ -1755,11 +1862,16 @@ var configSchema = environschema.Fields{
 for a network port is enabled to one instance if any instance requires
 that port).
 
+'application' uses a firewall per application, and makes each instance
+a member of the firewalls of the applications it hosts. Access between
+related applications is granted by firewall rather than by address.
+Only supported on Amazon EC2, and on OpenStack with Neutron.
+
 'none' requests that no firewalling should be performed
 inside the model. It's useful for clouds without support for either
 global or per instance security groups.`,
 		Type:      environschema.Tstring,
-		Values:    []interface{}{FwInstance, FwGlobal, FwNone},
+		Values:    []interface{}{FwInstance, FwGlobal, FwApplication, FwNone},
 		Immutable: true,
 		Group:     environschema.EnvironGroup,
 	},
@@ -1869,7 +1981,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	ResourceTagsKey: {
-		Description: "resource tags",
+		Description: "resource tags, whose values may use the {{model}}, {{application}} and {{unit}} template variables",
 		Type:        environschema.Tattrs,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1978,6 +2090,31 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	HookTimeout: {
+		Description: "How long a charm hook may run before it is killed, in human-readable time format (default unlimited)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ExposeLoadBalancers: {
+		Description: "Whether exposed applications are given a provider load balancer in front of their units, where the provider supports them",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	RootDiskEncryption: {
+		Description: "Whether the root disks of new machines are encrypted at rest, where the provider supports it (existing machines are unaffected)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	RootDiskKMSKey: {
+		Description: "The key management service key with which root disks are encrypted when root-disk-encryption is true, or empty for the provider's default key",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ReplaceTerminatedMachines: {
+		Description: "Whether machines whose instances are terminated by the provider are replaced by new machines hosting the same applications, rather than just being marked down",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	EgressSubnets: {
 		Description: "Source address(es) for traffic originating from this model",
 		Type:        environschema.Tstring,
@@ -262,7 +262,7 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"firewall-mode": "",
 		}),
-		err: `firewall-mode: expected one of \[instance global none\], got ""`,
+		err: `firewall-mode: expected one of \[instance global application none\], got ""`,
 	}, {
 		about:       "Instance firewall mode",
 		useDefaults: config.UseDefaults,
@@ -275,6 +275,12 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"firewall-mode": config.FwGlobal,
 		}),
+	}, {
+		about:       "Application firewall mode",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"firewall-mode": config.FwApplication,
+		}),
 	}, {
 		about:       "None firewall mode",
 		useDefaults: config.UseDefaults,
@@ -287,7 +293,7 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"firewall-mode": "illegal",
 		}),
-		err: `firewall-mode: expected one of \[instance global none\], got "illegal"`,
+		err: `firewall-mode: expected one of \[instance global application none\], got "illegal"`,
 	}, {
 		about:       "ssl-hostname-verification off",
 		useDefaults: config.UseDefaults,
@@ -1318,6 +1324,89 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestHookTimeoutConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-timeout": "90m",
+	})
+	c.Assert(cfg.HookTimeout(), gc.Equals, 90*time.Minute)
+}
+
+func (s *ConfigSuite) TestExposeLoadBalancersConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ExposeLoadBalancers(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestExposeLoadBalancersConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"expose-load-balancers": true,
+	})
+	c.Assert(cfg.ExposeLoadBalancers(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestRootDiskEncryptionConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.RootDiskEncryption(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestRootDiskEncryptionConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"root-disk-encryption": true,
+	})
+	c.Assert(cfg.RootDiskEncryption(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestRootDiskKMSKeyConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"root-disk-encryption": true,
+		"root-disk-kms-key":    "alias/juju",
+	})
+	c.Assert(cfg.RootDiskKMSKey(), gc.Equals, "alias/juju")
+}
+
+func (s *ConfigSuite) TestRootDiskKMSKeyRequiresEncryption(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"root-disk-kms-key": "alias/juju",
+	}))
+	c.Assert(err, gc.ErrorMatches, "root-disk-kms-key requires root-disk-encryption to be true")
+}
+
+func (s *ConfigSuite) TestReplaceTerminatedMachinesConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ReplaceTerminatedMachines(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestReplaceTerminatedMachinesConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"replace-terminated-machines": true,
+	})
+	c.Assert(cfg.ReplaceTerminatedMachines(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigInvalid(c *gc.C) {
+	for _, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "soon",
+		err:   `invalid hook timeout in model configuration: time: invalid duration "?soon"?`,
+	}, {
+		value: "-1m",
+		err:   `hook timeout -1m0s cannot be negative`,
+	}} {
+		_, err := config.New(config.UseDefaults, testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid":         testing.ModelTag.Id(),
+			"hook-timeout": test.value,
+		})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
@@ -1404,6 +1493,17 @@ func (s *ConfigSuite) TestCoerceForStorage(c *gc.C) {
 	c.Assert(tagsMap, gc.DeepEquals, expectedTags)
 }
 
+func (s *ConfigSuite) TestResourceTagsTemplates(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"resource-tags": "model={{model}} app={{application}}"})
+	tags, ok := cfg.ResourceTags()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(tags, gc.DeepEquals, map[string]string{"model": cfg.Name(), "app": ""})
+	templates, ok := cfg.ResourceTagTemplates()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(templates, gc.DeepEquals, map[string]string{"model": "{{model}}", "app": "{{application}}"})
+}
+
 var specializeCharmRepoTests = []struct {
 	about    string
 	testMode bool
@@ -9,6 +9,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/manual"
 	"github.com/juju/juju/testing"
@@ -135,3 +136,34 @@ func (s *suite) TestUnregisterProvider(c *gc.C) {
 	_, err = environs.Provider("alias2")
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+type encryptingProvider struct {
+	dummyProvider
+	err error
+}
+
+func (p encryptingProvider) ValidateRootDiskEncryption(cfg *config.Config) error {
+	return p.err
+}
+
+func (s *suite) TestValidateRootDiskEncryptionDisabled(c *gc.C) {
+	cfg := testing.ModelConfig(c)
+	err := environs.ValidateRootDiskEncryption(&dummyProvider{}, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *suite) TestValidateRootDiskEncryptionNotSupported(c *gc.C) {
+	cfg := testing.CustomModelConfig(c, testing.Attrs{"root-disk-encryption": true})
+	err := environs.ValidateRootDiskEncryption(&dummyProvider{}, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `root-disk-encryption with "dummy" provider not supported`)
+}
+
+func (s *suite) TestValidateRootDiskEncryptionSupported(c *gc.C) {
+	cfg := testing.CustomModelConfig(c, testing.Attrs{"root-disk-encryption": true})
+	err := environs.ValidateRootDiskEncryption(encryptingProvider{}, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = environs.ValidateRootDiskEncryption(encryptingProvider{err: errors.New("nope")}, cfg)
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
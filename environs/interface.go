@@ -436,6 +436,72 @@ type Firewaller interface {
 	IngressRules(ctx context.ProviderCallContext) ([]network.IngressRule, error)
 }
 
+// ApplicationFirewaller exposes methods for managing a firewall group
+// per application, as used by the FwApplication firewall mode.
+type ApplicationFirewaller interface {
+	// OpenApplicationPorts opens the given port ranges, from the
+	// rules' source CIDRs, in the firewall group of the named
+	// application, creating the group if necessary.
+	OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error
+
+	// CloseApplicationPorts closes the given port ranges, from the
+	// rules' source CIDRs, in the firewall group of the named
+	// application.
+	CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error
+
+	// ApplicationIngressRules returns the ingress rules from source
+	// CIDRs applied to the firewall group of the named application.
+	// It is expected that there be only one ingress rule result for a
+	// given port range.
+	ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error)
+
+	// SetApplicationSources replaces the rules in the firewall group
+	// of the named application that allow traffic from the groups of
+	// other applications with the given rules, which must have
+	// SourceApplications set. Groups of source applications are
+	// created if necessary.
+	SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error
+
+	// SetInstanceApplications makes the specified instance a member of
+	// the firewall groups of exactly the named applications, creating
+	// the groups if necessary.
+	SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error
+}
+
+// LoadBalancer exposes methods for managing provider load balancers in
+// front of the instances of exposed applications. An application has a
+// load balancer for each of its exposed endpoints, an endpoint being a
+// TCP or UDP port range opened by the application's units. Environs
+// implementing LoadBalancer remove the model's load balancers when they
+// are destroyed.
+type LoadBalancer interface {
+	// EnsureLoadBalancer ensures that the load balancer of the given
+	// endpoint of the named application forwards the endpoint to
+	// exactly the given instances, creating it if necessary, and
+	// returns its addresses.
+	EnsureLoadBalancer(
+		ctx context.ProviderCallContext,
+		applicationName string,
+		endpoint network.PortRange,
+		ids []instance.Id,
+	) ([]network.Address, error)
+
+	// RemoveLoadBalancer removes the load balancer of the given
+	// endpoint of the named application. It is not an error if there
+	// is no load balancer.
+	RemoveLoadBalancer(ctx context.ProviderCallContext, applicationName string, endpoint network.PortRange) error
+}
+
+// RootDiskEncrypter is an optional interface that an EnvironProvider
+// may implement if the root disks of the instances started by its
+// environs can be encrypted at rest. Models whose provider does not
+// implement it may not enable root-disk-encryption.
+type RootDiskEncrypter interface {
+	// ValidateRootDiskEncryption returns an error if the provider
+	// cannot encrypt root disks with the given model config.
+	ValidateRootDiskEncryption(cfg *config.Config) error
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -445,6 +511,17 @@ type InstanceTagger interface {
 	TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error
 }
 
+// ModelResourceTagger is an interface that can be used for tagging the
+// provider resources shared by the model, such as security groups.
+type ModelResourceTagger interface {
+	// TagModelResources tags the model's shared resources with the
+	// specified tags.
+	//
+	// The specified tags will replace any existing ones with the
+	// same names, but other existing tags will be left alone.
+	TagModelResources(ctx context.ProviderCallContext, tags map[string]string) error
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
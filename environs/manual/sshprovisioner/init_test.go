@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/agent"
 	"github.com/juju/juju/environs/manual/sshprovisioner"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/testing"
@@ -169,3 +170,22 @@ func (s *initialisationSuite) TestInitUbuntuUserError(c *gc.C) {
 	err := sshprovisioner.InitUbuntuUser("testhost", "testuser", "", nil, nil)
 	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 123 \\(failed to create ubuntu user\\)")
 }
+
+func (s *initialisationSuite) TestUninstallMachine(c *gc.C) {
+	script, err := agent.UninstallScript("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	defer installFakeSSH(c, script, nil, 0)()
+	err = sshprovisioner.UninstallMachine("ubuntu", "hostname", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *initialisationSuite) TestUninstallMachineError(c *gc.C) {
+	defer installFakeSSH(c, nil, []string{"", "oh noes"}, 33)()
+	err := sshprovisioner.UninstallMachine("ubuntu", "hostname", "xenial")
+	c.Assert(err, gc.ErrorMatches, "uninstalling juju agents from hostname: subprocess encountered error code 33 \\(oh noes\\)")
+}
+
+func (s *initialisationSuite) TestUninstallMachineWindows(c *gc.C) {
+	err := sshprovisioner.UninstallMachine("ubuntu", "hostname", "win2012r2")
+	c.Assert(err, gc.ErrorMatches, "uninstalling agents from Windows machines not supported")
+}
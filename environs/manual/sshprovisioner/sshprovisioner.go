@@ -17,6 +17,7 @@ import (
 	"github.com/juju/utils/shell"
 	"github.com/juju/utils/ssh"
 
+	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig"
 	"github.com/juju/juju/cloudconfig/cloudinit"
@@ -185,6 +186,37 @@ func checkProvisioned(host string) (bool, error) {
 	return provisioned, nil
 }
 
+// UninstallMachine removes the juju agents, and everything they left
+// behind, from a manually provisioned machine of the given series,
+// connecting to it over SSH as the given user, who must be able to run
+// sudo without a password. It is used when the machine's agents are not
+// able to uninstall themselves.
+var UninstallMachine = uninstallMachine
+
+func uninstallMachine(login, host, machineSeries string) error {
+	logger.Infof("uninstalling juju agents from %s@%s", login, host)
+	script, err := agent.UninstallScript(machineSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	cmd := ssh.Command(login+"@"+host, []string{"sudo", "-n", "/bin/bash"}, nil)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Stdin = strings.NewReader(script)
+	err = cmd.Run()
+	logger.Debugf("uninstall script stdout: \n%s", stdout.String())
+	logger.Debugf("uninstall script stderr: \n%s", stderr.String())
+	if err != nil {
+		if stderr.Len() != 0 {
+			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		return errors.Annotatef(err, "uninstalling juju agents from %s", host)
+	}
+	return nil
+}
+
 // detectionScript is the script to run on the remote machine to
 // detect the OS series and hardware characteristics.
 const detectionScript = `#!/bin/bash
@@ -3,7 +3,14 @@
 
 package tags
 
-import "gopkg.in/juju/names.v2"
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+)
 
 const (
 	// JujuTagPrefix is the prefix for Juju-managed tags.
@@ -42,6 +49,21 @@ const (
 	JujuMachine = JujuTagPrefix + "machine-id"
 )
 
+const (
+	// TemplateModel is replaced, in user-specified resource tag
+	// values, with the name of the model.
+	TemplateModel = "{{model}}"
+
+	// TemplateApplication is replaced, in user-specified resource
+	// tag values, with the name of the application using the
+	// resource.
+	TemplateApplication = "{{application}}"
+
+	// TemplateUnit is replaced, in user-specified resource tag
+	// values, with the name of the unit using the resource.
+	TemplateUnit = "{{unit}}"
+)
+
 // ResourceTagger is an interface that can provide resource tags.
 type ResourceTagger interface {
 	// ResourceTags returns a set of resource tags, and a
@@ -67,3 +89,97 @@ func ResourceTags(modelTag names.ModelTag, controllerTag names.ControllerTag, ta
 	allTags[JujuController] = controllerTag.Id()
 	return allTags
 }
+
+// TemplateVars holds the values with which the template variables
+// in user-specified resource tag values are replaced. Where a resource
+// is used by several applications or units, their names are
+// space-separated; where it is used by none, they are empty.
+type TemplateVars struct {
+	Model       string
+	Application string
+	Unit        string
+}
+
+// ExpandTemplates returns a copy of the given tags, with the template
+// variables in their values replaced.
+func ExpandTemplates(tags map[string]string, vars TemplateVars) map[string]string {
+	if tags == nil {
+		return nil
+	}
+	replacer := strings.NewReplacer(
+		TemplateModel, vars.Model,
+		TemplateApplication, vars.Application,
+		TemplateUnit, vars.Unit,
+	)
+	expanded := make(map[string]string, len(tags))
+	for k, v := range tags {
+		expanded[k] = replacer.Replace(v)
+	}
+	return expanded
+}
+
+// ParseResourceTags parses user-specified resource tags, given as
+// space-separated key=value pairs. Keys may not use the prefix
+// reserved for Juju-managed tags.
+func ParseResourceTags(value string) (map[string]string, error) {
+	tags, err := keyvalues.Parse(strings.Fields(value), true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for k := range tags {
+		if strings.HasPrefix(k, JujuTagPrefix) {
+			return nil, errors.Errorf("tag %q uses reserved prefix %q", k, JujuTagPrefix)
+		}
+	}
+	return tags, nil
+}
+
+// UnitResourceTags returns the user-specified tags to set on a resource
+// used by the given units: the model's resource tags, overridden by the
+// resource tags of each of the units' applications in name order, with
+// template variables expanded. Application resource tags are keyed by
+// application name.
+func UnitResourceTags(
+	modelName string,
+	modelTags map[string]string,
+	unitNames []string,
+	applicationTags map[string]map[string]string,
+) map[string]string {
+	unitsByApplication := make(map[string][]string)
+	var applicationNames, allUnitNames []string
+	for _, unitName := range unitNames {
+		applicationName, err := names.UnitApplication(unitName)
+		if err != nil {
+			continue
+		}
+		if _, ok := unitsByApplication[applicationName]; !ok {
+			applicationNames = append(applicationNames, applicationName)
+		}
+		unitsByApplication[applicationName] = append(unitsByApplication[applicationName], unitName)
+		allUnitNames = append(allUnitNames, unitName)
+	}
+	sort.Strings(applicationNames)
+	sort.Strings(allUnitNames)
+
+	allTags := ExpandTemplates(modelTags, TemplateVars{
+		Model:       modelName,
+		Application: strings.Join(applicationNames, " "),
+		Unit:        strings.Join(allUnitNames, " "),
+	})
+	if allTags == nil {
+		allTags = make(map[string]string)
+	}
+	for _, applicationName := range applicationNames {
+		units := unitsByApplication[applicationName]
+		sort.Strings(units)
+		expanded := ExpandTemplates(applicationTags[applicationName], TemplateVars{
+			Model:       modelName,
+			Application: applicationName,
+			Unit:        strings.Join(units, " "),
+		})
+		for k, v := range expanded {
+			allTags[k] = v
+		}
+	}
+	return allTags
+}
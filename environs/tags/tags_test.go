@@ -61,6 +61,68 @@ func (*tagsSuite) TestResourceTagsResourceTaggers(c *gc.C) {
 	})
 }
 
+func (*tagsSuite) TestExpandTemplates(c *gc.C) {
+	expanded := tags.ExpandTemplates(map[string]string{
+		"cost-centre": "{{model}}/{{application}}",
+		"owner":       "{{unit}}",
+		"team":        "finance",
+	}, tags.TemplateVars{
+		Model:       "prod",
+		Application: "mysql",
+		Unit:        "mysql/0",
+	})
+	c.Assert(expanded, jc.DeepEquals, map[string]string{
+		"cost-centre": "prod/mysql",
+		"owner":       "mysql/0",
+		"team":        "finance",
+	})
+	c.Assert(tags.ExpandTemplates(nil, tags.TemplateVars{}), gc.IsNil)
+}
+
+func (*tagsSuite) TestParseResourceTags(c *gc.C) {
+	parsed, err := tags.ParseResourceTags("team=finance  app={{application}} empty=")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, map[string]string{
+		"team":  "finance",
+		"app":   "{{application}}",
+		"empty": "",
+	})
+
+	_, err = tags.ParseResourceTags("juju-foo=bar")
+	c.Assert(err, gc.ErrorMatches, `tag "juju-foo" uses reserved prefix "juju-"`)
+	_, err = tags.ParseResourceTags("novalue")
+	c.Assert(err, gc.ErrorMatches, `expected "key=value", got "novalue"`)
+}
+
+func (*tagsSuite) TestUnitResourceTags(c *gc.C) {
+	unitTags := tags.UnitResourceTags(
+		"prod",
+		map[string]string{
+			"model":  "{{model}}",
+			"apps":   "{{application}}",
+			"units":  "{{unit}}",
+			"shared": "model",
+		},
+		[]string{"wordpress/1", "mysql/0", "wordpress/0"},
+		map[string]map[string]string{
+			"mysql":     {"shared": "{{application}}", "db": "{{unit}}"},
+			"wordpress": {"shared": "{{application}}"},
+		},
+	)
+	c.Assert(unitTags, jc.DeepEquals, map[string]string{
+		"model":  "prod",
+		"apps":   "mysql wordpress",
+		"units":  "mysql/0 wordpress/0 wordpress/1",
+		"shared": "wordpress",
+		"db":     "mysql/0",
+	})
+}
+
+func (*tagsSuite) TestUnitResourceTagsNoUnits(c *gc.C) {
+	unitTags := tags.UnitResourceTags("prod", map[string]string{"app": "{{application}}"}, nil, nil)
+	c.Assert(unitTags, jc.DeepEquals, map[string]string{"app": ""})
+}
+
 func testResourceTags(c *gc.C, controller names.ControllerTag, model names.ModelTag, taggers []tags.ResourceTagger, expectTags map[string]string) {
 	tags := tags.ResourceTags(model, controller, taggers...)
 	c.Assert(tags, jc.DeepEquals, expectTags)
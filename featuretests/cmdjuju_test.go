@@ -110,6 +110,11 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  hook-timeout:
+    description: How long a charm hook may run before it is killed, in human-readable
+      time format (default model hook-timeout)
+    source: unset
+    type: string
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -153,6 +158,11 @@ settings:
 func (s *cmdJujuSuite) TestApplicationGetCAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  hook-timeout:
+    description: How long a charm hook may run before it is killed, in human-readable
+      time format (default model hook-timeout)
+    source: unset
+    type: string
   juju-application-path:
     default: /
     description: the relative http path used to access an application
@@ -164,6 +174,14 @@ application-config:
     source: user
     type: string
     value: ext-host
+  juju-operator-replicas:
+    description: the number of operator pods to run, including standbys
+    source: unset
+    type: int
+  juju-workload-logs:
+    description: whether to forward workload container output to the model log
+    source: unset
+    type: bool
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
@@ -188,6 +206,11 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-network-policy:
+    description: whether to only accept traffic from related applications, and any
+      source if exposed
+    source: unset
+    type: bool
   kubernetes-service-external-ips:
     description: list of IP addresses for which nodes in the cluster will also accept
       traffic
@@ -218,6 +241,25 @@ application-config:
     source: default
     type: string
     value: ClusterIP
+  kubernetes-update-max-surge:
+    description: maximum number or percentage of units which can be created above
+      the desired number during a rolling update
+    source: unset
+    type: string
+  kubernetes-update-max-unavailable:
+    description: maximum number or percentage of units which can be unavailable during
+      a rolling update
+    source: unset
+    type: string
+  kubernetes-update-partition:
+    description: for applications with storage, only units with an ordinal greater
+      or equal to the partition are updated
+    source: unset
+    type: int
+  kubernetes-update-paused:
+    description: whether a rolling update of the application's units is paused
+    source: unset
+    type: bool
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -495,6 +495,7 @@ func newState(controllerUUID string, environ environs.Environ, mongoInfo *mongo.
 		ControllerModelTag: modelTag,
 		MongoSession:       session,
 		NewPolicy:          newPolicyFunc,
+		SecretsMasterKey:   testing.SecretsMasterKey,
 	}
 	st, err := state.Open(args)
 	if errors.IsUnauthorized(errors.Cause(err)) {
@@ -22,6 +22,12 @@ type IngressRule struct {
 	// SourceCIDRs is a list of IP address blocks expressed in CIDR format
 	// to which this rule applies.
 	SourceCIDRs []string
+
+	// SourceApplications is a list of applications whose firewall
+	// groups this rule applies to. It is only used with the
+	// "application" firewall mode, and a rule with source applications
+	// has no source CIDRs.
+	SourceApplications []string
 }
 
 // NewIngressRule returns an IngressRule for the specified port
@@ -58,6 +64,20 @@ func MustNewIngressRule(protocol string, from, to int, sourceCIDRs ...string) In
 	return rule
 }
 
+// NewApplicationIngressRule returns an IngressRule for the specified
+// port range, allowing incoming traffic only from the firewall groups
+// of the specified applications.
+func NewApplicationIngressRule(protocol string, from, to int, sourceApplications ...string) IngressRule {
+	return IngressRule{
+		PortRange: PortRange{
+			Protocol: protocol,
+			FromPort: from,
+			ToPort:   to,
+		},
+		SourceApplications: sourceApplications,
+	}
+}
+
 // NewOpenIngressRule returns an IngressRule for the specified port
 // range. There is no restriction from where incoming traffic originates.
 func NewOpenIngressRule(protocol string, from, to int) IngressRule {
@@ -72,6 +92,9 @@ func (r IngressRule) String() string {
 	if from != "" && from != "0.0.0.0/0" {
 		source = " from " + from
 	}
+	if len(r.SourceApplications) > 0 {
+		source = " from applications " + strings.Join(r.SourceApplications, ",")
+	}
 	if r.FromPort == r.ToPort {
 		return fmt.Sprintf("%d/%s%s", r.FromPort, strings.ToLower(r.Protocol), source)
 	}
@@ -101,7 +124,12 @@ func (p IngressRuleSlice) Less(i, j int) bool {
 	}
 	s1 := strings.Join(p1.SourceCIDRs, ",")
 	s2 := strings.Join(p2.SourceCIDRs, ",")
-	return s1 < s2
+	if s1 != s2 {
+		return s1 < s2
+	}
+	a1 := strings.Join(p1.SourceApplications, ",")
+	a2 := strings.Join(p2.SourceApplications, ",")
+	return a1 < a2
 }
 
 // SortIngressRules sorts the given rules, first by protocol, then by ports.
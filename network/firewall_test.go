@@ -33,6 +33,10 @@ func (*FirewallSuite) TestStrings(c *gc.C) {
 	rule = network.MustNewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.1.0/24")
 	c.Assert(rule.String(), gc.Equals, "80-100/tcp from 0.0.0.0/0,192.168.1.0/24")
 	c.Assert(rule.GoString(), gc.Equals, "80-100/tcp from 0.0.0.0/0,192.168.1.0/24")
+
+	rule = network.NewApplicationIngressRule("tcp", 3306, 3306, "wordpress", "mediawiki")
+	c.Assert(rule.String(), gc.Equals, "3306/tcp from applications wordpress,mediawiki")
+	c.Assert(rule.GoString(), gc.Equals, "3306/tcp from applications wordpress,mediawiki")
 }
 
 func (*FirewallSuite) TestSortIngressRules(c *gc.C) {
@@ -68,6 +72,15 @@ func (*FirewallSuite) TestNewIngressRule(c *gc.C) {
 	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"0.0.0.0/0", "192.168.1.0/24"})
 }
 
+func (*FirewallSuite) TestNewApplicationIngressRule(c *gc.C) {
+	rule := network.NewApplicationIngressRule("tcp", 80, 100, "wordpress")
+	c.Assert(rule.Protocol, gc.Equals, "tcp")
+	c.Assert(rule.FromPort, gc.Equals, 80)
+	c.Assert(rule.ToPort, gc.Equals, 100)
+	c.Assert(rule.SourceCIDRs, gc.IsNil)
+	c.Assert(rule.SourceApplications, jc.DeepEquals, []string{"wordpress"})
+}
+
 func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
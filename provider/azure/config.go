@@ -4,6 +4,7 @@
 package azure
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/arm/storage"
@@ -12,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/common"
 )
 
 const (
@@ -57,6 +59,20 @@ func (*azureEnvironProvider) Validate(newCfg, oldCfg *config.Config) (*config.Co
 	return newCfg, nil
 }
 
+// ValidateRootDiskEncryption is part of the environs.RootDiskEncrypter
+// interface. Azure encrypts all managed disks and storage accounts at
+// rest, with Microsoft-managed keys; the compute API version used by
+// the provider cannot choose a customer-managed key.
+func (*azureEnvironProvider) ValidateRootDiskEncryption(cfg *config.Config) error {
+	if cfg.RootDiskKMSKey() != "" {
+		return errors.NewNotSupported(nil, fmt.Sprintf(
+			"Azure disks are encrypted with Microsoft-managed keys; %s is not supported",
+			config.RootDiskKMSKey,
+		))
+	}
+	return nil
+}
+
 func validateConfig(newCfg, oldCfg *config.Config) (*azureModelConfig, error) {
 	err := config.Validate(newCfg, oldCfg)
 	if err != nil {
@@ -109,6 +125,9 @@ Please choose a model name of no more than %d characters.`,
 	if newCfg.FirewallMode() == config.FwGlobal {
 		return nil, errors.New("global firewall mode is not supported")
 	}
+	if err := common.RejectApplicationFirewallMode(newCfg); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	storageAccountType := validated[configAttrStorageAccountType].(string)
 	if !isKnownStorageAccountType(storageAccountType) {
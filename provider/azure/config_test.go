@@ -51,6 +51,10 @@ func (s *configSuite) TestValidateInvalidFirewallMode(c *gc.C) {
 		c, testing.Attrs{"firewall-mode": "global"},
 		"global firewall mode is not supported",
 	)
+	s.assertConfigInvalid(
+		c, testing.Attrs{"firewall-mode": "application"},
+		`firewall-mode "application" not supported`,
+	)
 }
 
 func (s *configSuite) TestValidateModelNameLength(c *gc.C) {
@@ -71,6 +75,19 @@ func (s *configSuite) TestValidateStorageAccountTypeCantChange(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot change immutable "storage-account-type" config \(Standard_LRS -> Premium_LRS\)`)
 }
 
+func (s *configSuite) TestValidateRootDiskEncryption(c *gc.C) {
+	cfg := makeTestModelConfig(c, testing.Attrs{"root-disk-encryption": true})
+	err := environs.ValidateRootDiskEncryption(s.provider, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg = makeTestModelConfig(c, testing.Attrs{
+		"root-disk-encryption": true,
+		"root-disk-kms-key":    "https://vault.example.com/keys/key",
+	})
+	err = environs.ValidateRootDiskEncryption(s.provider, cfg)
+	c.Assert(err, gc.ErrorMatches, "Azure disks are encrypted with Microsoft-managed keys; root-disk-kms-key is not supported")
+}
+
 func (s *configSuite) assertConfigValid(c *gc.C, attrs testing.Attrs) {
 	cfg := makeTestModelConfig(c, attrs)
 	_, err := s.provider.Validate(cfg, nil)
@@ -375,6 +375,7 @@ func (env *azureEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.Zones,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -1527,10 +1527,10 @@ func (s *environSuite) TestStopInstancesStorageAccountKeysError(c *gc.C) {
 func (s *environSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	validator := s.constraintsValidator(c)
 	unsupported, err := validator.Validate(constraints.MustParse(
-		"arch=amd64 tags=foo cpu-power=100 virt-type=kvm",
+		"arch=amd64 tags=foo cpu-power=100 virt-type=kvm zones=az1",
 	))
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-power", "virt-type"})
+	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-power", "virt-type", "zones"})
 }
 
 func (s *environSuite) TestConstraintsValidatorVocabulary(c *gc.C) {
@@ -68,7 +68,7 @@ type azureStorageProvider struct {
 	env *azureEnviron
 }
 
-var _ storage.Provider = (*azureStorageProvider)(nil)
+var _ storage.EncryptingProvider = (*azureStorageProvider)(nil)
 
 var azureStorageConfigFields = schema.Fields{
 	accountTypeAttr: schema.OneOf(
@@ -106,6 +106,20 @@ func (e *azureStorageProvider) ValidateConfig(cfg *storage.Config) error {
 	return errors.Trace(err)
 }
 
+// ValidateEncryption is part of the EncryptingProvider interface.
+// Azure encrypts all managed disks and storage accounts at rest, with
+// Microsoft-managed keys; the compute API version used by the provider
+// cannot choose a customer-managed key.
+func (e *azureStorageProvider) ValidateEncryption(encrypted bool, kmsKey string) error {
+	if kmsKey != "" {
+		return errors.NewNotSupported(nil, fmt.Sprintf(
+			"Azure disks are encrypted with Microsoft-managed keys; %s is not supported",
+			storage.ConfigKMSKey,
+		))
+	}
+	return nil
+}
+
 // Supports is part of the Provider interface.
 func (e *azureStorageProvider) Supports(k storage.StorageKind) bool {
 	return k == storage.StorageKindBlock
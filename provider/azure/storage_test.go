@@ -25,6 +25,7 @@ import (
 	internalazurestorage "github.com/juju/juju/provider/azure/internal/azurestorage"
 	"github.com/juju/juju/provider/azure/internal/azuretesting"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing"
 )
 
@@ -155,6 +156,25 @@ func (s *storageSuite) TestFilesystemSource(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 }
 
+func (s *storageSuite) TestValidateEncryption(c *gc.C) {
+	storageConfig, err := storage.NewConfig("azure", "azure", map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(s.provider, storageConfig)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestValidateEncryptionKMSKey(c *gc.C) {
+	storageConfig, err := storage.NewConfig("azure", "azure", map[string]interface{}{
+		"kms-key": "https://vault.example.com/keys/key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(s.provider, storageConfig)
+	c.Assert(err, gc.ErrorMatches, "Azure disks are encrypted with Microsoft-managed keys; kms-key is not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *storageSuite) TestSupports(c *gc.C) {
 	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
 	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/provider/common"
 )
 
 var logger = loggo.GetLogger("juju.provider.cloudsigma")
@@ -122,6 +123,9 @@ func (environProvider) Validate(cfg, old *config.Config) (*config.Config, error)
 	if err != nil {
 		return nil, errors.Errorf("invalid config: %v", err)
 	}
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, errors.Errorf("invalid config: %v", err)
+	}
 	if old != nil {
 		oldEcfg, err := validateConfig(old, nil)
 		if err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
@@ -197,3 +198,23 @@ func ValidateAvailabilityZone(env ZonedEnviron, ctx context.ProviderCallContext,
 	}
 	return errors.NotValidf("availability zone %q", zone)
 }
+
+// RegisterZonesVocabulary registers the names of the environment's
+// availability zones as the vocabulary of the zones constraint. If the
+// environment does not support availability zones, the zones
+// constraint is registered as unsupported instead.
+func RegisterZonesVocabulary(validator constraints.Validator, env ZonedEnviron, ctx context.ProviderCallContext) error {
+	zones, err := env.AvailabilityZones(ctx)
+	if errors.IsNotImplemented(err) {
+		validator.RegisterUnsupported([]string{constraints.Zones})
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	zoneNames := make([]string, len(zones))
+	for i, zone := range zones {
+		zoneNames[i] = zone.Name()
+	}
+	validator.RegisterVocabulary(constraints.Zones, zoneNames)
+	return nil
+}
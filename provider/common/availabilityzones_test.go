@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
@@ -186,6 +187,30 @@ func (s *AvailabilityZoneSuite) TestValidateAvailabilityZone(c *gc.C) {
 	}
 }
 
+func (s *AvailabilityZoneSuite) TestRegisterZonesVocabulary(c *gc.C) {
+	validator := constraints.NewValidator()
+	err := common.RegisterZonesVocabulary(validator, &s.env, s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = validator.Validate(constraints.MustParse("zones=az0,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("zones=az1,az3"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=az3\nvalid values are:.*")
+}
+
+func (s *AvailabilityZoneSuite) TestRegisterZonesVocabularyNotImplemented(c *gc.C) {
+	s.PatchValue(&s.env.availabilityZones, func(context.ProviderCallContext) ([]common.AvailabilityZone, error) {
+		return nil, errors.NotImplementedf("availability zones")
+	})
+	validator := constraints.NewValidator()
+	err := common.RegisterZonesVocabulary(validator, &s.env, s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsupported, err := validator.Validate(constraints.MustParse("zones=az1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"zones"})
+}
+
 func (s *AvailabilityZoneSuite) TestDistributeInstancesGroup(c *gc.C) {
 	expectedGroup := []instance.Id{"0", "1", "2"}
 	var called bool
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/os/series"
 	"github.com/juju/utils"
 	"github.com/juju/utils/parallel"
+	"github.com/juju/utils/set"
 	"github.com/juju/utils/shell"
 	"github.com/juju/utils/ssh"
 	cryptossh "golang.org/x/crypto/ssh"
@@ -28,6 +29,7 @@ import (
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/sshinit"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -267,7 +269,7 @@ func startInstanceZones(env environs.Environ, ctx context.ProviderCallContext, a
 		return nil, errors.Trace(err)
 	}
 	if len(zones) > 0 {
-		return zones, nil
+		return constrainZones(zones, args.Constraints)
 	}
 	allZones, err := zonedEnviron.AvailabilityZones(ctx)
 	if err != nil {
@@ -282,7 +284,29 @@ func startInstanceZones(env environs.Environ, ctx context.ProviderCallContext, a
 	if len(zones) == 0 {
 		return nil, errors.New("no usable availability zones")
 	}
-	return zones, nil
+	return constrainZones(zones, args.Constraints)
+}
+
+// constrainZones returns those of the given zones which are permitted
+// by the zones constraint, if one was specified.
+func constrainZones(zones []string, cons constraints.Value) ([]string, error) {
+	if !cons.HasZones() {
+		return zones, nil
+	}
+	allowed := set.NewStrings(*cons.Zones...)
+	var result []string
+	for _, zone := range zones {
+		if allowed.Contains(zone) {
+			result = append(result, zone)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.Errorf(
+			"no usable availability zones match the zones constraint (%s)",
+			strings.Join(*cons.Zones, ", "),
+		)
+	}
+	return result, nil
 }
 
 func formatHardware(hw *instance.HardwareCharacteristics) string {
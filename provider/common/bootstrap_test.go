@@ -272,6 +272,56 @@ func (s *BootstrapSuite) TestStartInstanceAttemptAllZones(c *gc.C) {
 	c.Assert(callZones, jc.SameContents, []string{"z0", "z2"})
 }
 
+func (s *BootstrapSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, coretesting.FakeVersionNumber)
+	env := &mockZonedEnviron{
+		mockEnviron: mockEnviron{
+			storage: newStorage(s, c),
+			config:  configGetter(c),
+		},
+		deriveAvailabilityZones: func(context.ProviderCallContext, environs.StartInstanceParams) ([]string, error) {
+			return nil, nil
+		},
+		availabilityZones: func(ctx context.ProviderCallContext) ([]common.AvailabilityZone, error) {
+			z0 := &mockAvailabilityZone{"z0", true}
+			z1 := &mockAvailabilityZone{"z1", false}
+			z2 := &mockAvailabilityZone{"z2", true}
+			return []common.AvailabilityZone{z0, z1, z2}, nil
+		},
+	}
+
+	var callZones []string
+	env.startInstance = func(ctx context.ProviderCallContext, args environs.StartInstanceParams) (
+		instance.Instance,
+		*instance.HardwareCharacteristics,
+		[]network.InterfaceInfo,
+		error,
+	) {
+		callZones = append(callZones, args.AvailabilityZone)
+		return nil, nil, nil, errors.New("bloop")
+	}
+
+	ctx := envtesting.BootstrapContext(c)
+	_, err := common.Bootstrap(ctx, env, s.callCtx, environs.BootstrapParams{
+		ControllerConfig:     coretesting.FakeControllerConfig(),
+		BootstrapConstraints: constraints.MustParse("zones=z1,z2"),
+		AvailableTools:       fakeAvailableTools(),
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot start bootstrap instance in availability zone "z2": bloop`,
+	)
+	c.Assert(callZones, jc.DeepEquals, []string{"z2"})
+
+	_, err = common.Bootstrap(ctx, env, s.callCtx, environs.BootstrapParams{
+		ControllerConfig:     coretesting.FakeControllerConfig(),
+		BootstrapConstraints: constraints.MustParse("zones=z1"),
+		AvailableTools:       fakeAvailableTools(),
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot start bootstrap instance: no usable availability zones match the zones constraint \(z1\)`,
+	)
+}
+
 func (s *BootstrapSuite) TestStartInstanceStopOnZoneIndependentError(c *gc.C) {
 	s.PatchValue(&jujuversion.Current, coretesting.FakeVersionNumber)
 	env := &mockZonedEnviron{
@@ -0,0 +1,22 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// RejectApplicationFirewallMode returns an error if the config requests
+// the application firewall mode. Providers whose environs do not
+// implement environs.ApplicationFirewaller call it when validating
+// config, so that models cannot be created with a firewall mode that
+// no firewaller would apply.
+func RejectApplicationFirewallMode(cfg *config.Config) error {
+	if mode := cfg.FirewallMode(); mode == config.FwApplication {
+		return errors.NotSupportedf("firewall-mode %q", mode)
+	}
+	return nil
+}
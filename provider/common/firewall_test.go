@@ -0,0 +1,31 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/testing"
+)
+
+type firewallSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&firewallSuite{})
+
+func (s *firewallSuite) TestRejectApplicationFirewallMode(c *gc.C) {
+	for _, mode := range []string{config.FwInstance, config.FwGlobal, config.FwNone} {
+		cfg := testing.CustomModelConfig(c, testing.Attrs{"firewall-mode": mode})
+		c.Check(common.RejectApplicationFirewallMode(cfg), jc.ErrorIsNil)
+	}
+	cfg := testing.CustomModelConfig(c, testing.Attrs{"firewall-mode": config.FwApplication})
+	err := common.RejectApplicationFirewallMode(cfg)
+	c.Assert(err, gc.ErrorMatches, `firewall-mode "application" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
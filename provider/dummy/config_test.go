@@ -50,7 +50,7 @@ var firewallModeTests = []struct {
 	}, {
 		// Invalid mode.
 		configFirewallMode: "invalid",
-		errorMsg:           `firewall-mode: expected one of \[instance global none], got "invalid"`,
+		errorMsg:           `firewall-mode: expected one of \[instance global application none], got "invalid"`,
 	},
 }
 
@@ -30,6 +30,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
 	"github.com/juju/loggo"
@@ -250,6 +251,9 @@ type environState struct {
 	maxAddr        int // maximum allocated address last byte
 	insts          map[instance.Id]*dummyInstance
 	globalRules    network.IngressRuleSlice
+	appRules       map[string]*applicationRules
+	loadBalancers  map[loadBalancerKey]*loadBalancer
+	maxLBAddr      int // last byte of the last load balancer address
 	bootstrapped   bool
 	mux            *apiserverhttp.Mux
 	httpServer     *httptest.Server
@@ -273,6 +277,8 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.ApplicationFirewaller = (*environ)(nil)
+var _ environs.LoadBalancer = (*environ)(nil)
 
 // discardOperations discards all Operations written to it.
 var discardOperations = make(chan Operation)
@@ -426,6 +432,8 @@ func newState(name string, ops chan<- Operation, newStatePolicy state.NewPolicyF
 		ops:            ops,
 		newStatePolicy: newStatePolicy,
 		insts:          make(map[instance.Id]*dummyInstance),
+		appRules:       make(map[string]*applicationRules),
+		loadBalancers:  make(map[loadBalancerKey]*loadBalancer),
 		creator:        string(buf),
 	}
 	return s
@@ -608,6 +616,12 @@ func (e *environ) state() (*environState, error) {
 	return state, nil
 }
 
+// ValidateRootDiskEncryption is part of the environs.RootDiskEncrypter
+// interface.
+func (*environProvider) ValidateRootDiskEncryption(cfg *config.Config) error {
+	return nil
+}
+
 // Version is part of the EnvironProvider interface.
 func (*environProvider) Version() int {
 	return 0
@@ -808,6 +822,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.Provi
 				MongoSession:     session,
 				NewPolicy:        estate.newStatePolicy,
 				AdminPassword:    icfg.Controller.MongoInfo.Password,
+				SecretsMasterKey: testing.SecretsMasterKey,
 			})
 			if err != nil {
 				return err
@@ -1566,6 +1581,237 @@ func (e *environ) IngressRules(ctx context.ProviderCallContext) (rules []network
 	return
 }
 
+// applicationRules holds the ingress rules of an application's
+// firewall group: the source CIDRs, and the rules from the groups of
+// other applications.
+type applicationRules struct {
+	cidrs   map[network.PortRange]set.Strings
+	sources network.IngressRuleSlice
+}
+
+func (estate *environState) applicationRules(applicationName string) *applicationRules {
+	appRules, ok := estate.appRules[applicationName]
+	if !ok {
+		appRules = &applicationRules{
+			cidrs: make(map[network.PortRange]set.Strings),
+		}
+		estate.appRules[applicationName] = appRules
+	}
+	return appRules
+}
+
+func ruleCIDRs(rule network.IngressRule) []string {
+	if len(rule.SourceCIDRs) == 0 {
+		return []string{"0.0.0.0/0"}
+	}
+	return rule.SourceCIDRs
+}
+
+// OpenApplicationPorts is specified in the environs.ApplicationFirewaller
+// interface.
+func (e *environ) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwApplication {
+		return fmt.Errorf("invalid firewall mode %q for opening ports for application", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	appRules := estate.applicationRules(applicationName)
+	for _, r := range rules {
+		cidrs, ok := appRules.cidrs[r.PortRange]
+		if !ok {
+			cidrs = set.NewStrings()
+			appRules.cidrs[r.PortRange] = cidrs
+		}
+		for _, cidr := range ruleCIDRs(r) {
+			cidrs.Add(cidr)
+		}
+	}
+	return nil
+}
+
+// CloseApplicationPorts is specified in the environs.ApplicationFirewaller
+// interface.
+func (e *environ) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwApplication {
+		return fmt.Errorf("invalid firewall mode %q for closing ports for application", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	appRules := estate.applicationRules(applicationName)
+	for _, r := range rules {
+		cidrs, ok := appRules.cidrs[r.PortRange]
+		if !ok {
+			continue
+		}
+		for _, cidr := range ruleCIDRs(r) {
+			cidrs.Remove(cidr)
+		}
+		if cidrs.IsEmpty() {
+			delete(appRules.cidrs, r.PortRange)
+		}
+	}
+	return nil
+}
+
+// ApplicationIngressRules is specified in the
+// environs.ApplicationFirewaller interface.
+func (e *environ) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) (rules []network.IngressRule, err error) {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwApplication {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules for application", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for portRange, cidrs := range estate.applicationRules(applicationName).cidrs {
+		rules = append(rules, network.IngressRule{PortRange: portRange, SourceCIDRs: cidrs.SortedValues()})
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// SetApplicationSources is specified in the
+// environs.ApplicationFirewaller interface.
+func (e *environ) SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwApplication {
+		return fmt.Errorf("invalid firewall mode %q for setting application sources", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, r := range rules {
+		if len(r.SourceApplications) == 0 {
+			return errors.NotValidf("rule %v without source applications", r)
+		}
+	}
+	sources := append(network.IngressRuleSlice(nil), rules...)
+	network.SortIngressRules(sources)
+	estate.applicationRules(applicationName).sources = sources
+	return nil
+}
+
+// ApplicationSources returns the rules allowing traffic from the groups
+// of other applications to the firewall group of the named application.
+func ApplicationSources(env environs.Environ, applicationName string) []network.IngressRule {
+	estate, err := env.(*environ).state()
+	if err != nil {
+		panic(err)
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	return append([]network.IngressRule(nil), estate.applicationRules(applicationName).sources...)
+}
+
+// SetInstanceApplications is specified in the
+// environs.ApplicationFirewaller interface.
+func (e *environ) SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwApplication {
+		return fmt.Errorf("invalid firewall mode %q for setting instance applications", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	inst, ok := estate.insts[id]
+	if !ok {
+		return errors.NotFoundf("instance %q", id)
+	}
+	inst.applications = append([]string(nil), applicationNames...)
+	return nil
+}
+
+// loadBalancerKey identifies the load balancer of an application
+// endpoint.
+type loadBalancerKey struct {
+	application string
+	endpoint    network.PortRange
+}
+
+// loadBalancer records the address and instances of the load balancer
+// of an application endpoint.
+type loadBalancer struct {
+	address network.Address
+	ids     []instance.Id
+}
+
+// EnsureLoadBalancer is specified in the environs.LoadBalancer interface.
+func (e *environ) EnsureLoadBalancer(
+	ctx context.ProviderCallContext,
+	applicationName string,
+	endpoint network.PortRange,
+	ids []instance.Id,
+) ([]network.Address, error) {
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, id := range ids {
+		if _, ok := estate.insts[id]; !ok {
+			return nil, errors.NotFoundf("instance %q", id)
+		}
+	}
+	key := loadBalancerKey{applicationName, endpoint}
+	lb, ok := estate.loadBalancers[key]
+	if !ok {
+		estate.maxLBAddr++
+		lb = &loadBalancer{
+			address: network.NewScopedAddress(
+				fmt.Sprintf("203.0.113.%d", estate.maxLBAddr), network.ScopePublic,
+			),
+		}
+		estate.loadBalancers[key] = lb
+	}
+	lb.ids = append([]instance.Id(nil), ids...)
+	return []network.Address{lb.address}, nil
+}
+
+// RemoveLoadBalancer is specified in the environs.LoadBalancer interface.
+func (e *environ) RemoveLoadBalancer(ctx context.ProviderCallContext, applicationName string, endpoint network.PortRange) error {
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	delete(estate.loadBalancers, loadBalancerKey{applicationName, endpoint})
+	return nil
+}
+
+// LoadBalancers returns the instances that the load balancers of the
+// named application forward each of its endpoints to.
+func LoadBalancers(env environs.Environ, applicationName string) map[network.PortRange][]instance.Id {
+	estate, err := env.(*environ).state()
+	if err != nil {
+		panic(err)
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	result := make(map[network.PortRange][]instance.Id)
+	for key, lb := range estate.loadBalancers {
+		if key.application == applicationName {
+			result[key.endpoint] = append([]instance.Id(nil), lb.ids...)
+		}
+	}
+	return result
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
@@ -1579,6 +1825,7 @@ type dummyInstance struct {
 	series       string
 	firewallMode string
 	controller   bool
+	applications []string
 
 	mu        sync.Mutex
 	addresses []network.Address
@@ -1618,6 +1865,15 @@ func SetInstanceAddresses(inst instance.Instance, addrs []network.Address) {
 	inst0.mu.Unlock()
 }
 
+// InstanceApplications returns the names of the applications whose
+// firewall groups the given dummy instance is a member of.
+func InstanceApplications(inst instance.Instance) []string {
+	inst0 := inst.(*dummyInstance)
+	inst0.state.mu.Lock()
+	defer inst0.state.mu.Unlock()
+	return append([]string(nil), inst0.applications...)
+}
+
 // SetInstanceStatus sets the status associated with the given
 // dummy instance.
 func SetInstanceStatus(inst instance.Instance, status string) {
@@ -177,16 +177,18 @@ var ebsConfigFields = schema.Fields{
 		schema.Const(volumeTypeST1),
 		schema.Const(volumeTypeSC1),
 	),
-	EBS_IOPS:      schema.ForceInt(),
-	EBS_Encrypted: schema.Bool(),
+	EBS_IOPS:             schema.ForceInt(),
+	EBS_Encrypted:        schema.Bool(),
+	storage.ConfigKMSKey: schema.String(),
 }
 
 var ebsConfigChecker = schema.FieldMap(
 	ebsConfigFields,
 	schema.Defaults{
-		EBS_VolumeType: volumeAliasSSD,
-		EBS_IOPS:       schema.Omit,
-		EBS_Encrypted:  false,
+		EBS_VolumeType:       volumeAliasSSD,
+		EBS_IOPS:             schema.Omit,
+		EBS_Encrypted:        false,
+		storage.ConfigKMSKey: "",
 	},
 )
 
@@ -194,6 +196,7 @@ type ebsConfig struct {
 	volumeType string
 	iops       int
 	encrypted  bool
+	kmsKey     string
 }
 
 func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
@@ -208,6 +211,11 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 		volumeType: volumeType,
 		iops:       iops,
 		encrypted:  coerced[EBS_Encrypted].(bool),
+		kmsKey:     coerced[storage.ConfigKMSKey].(string),
+	}
+	if ebsConfig.kmsKey != "" {
+		// A key implies encryption.
+		ebsConfig.encrypted = true
 	}
 	switch ebsConfig.volumeType {
 	case volumeAliasMagnetic:
@@ -235,6 +243,13 @@ func (e *ebsProvider) ValidateConfig(cfg *storage.Config) error {
 	return errors.Trace(err)
 }
 
+// ValidateEncryption is defined on the EncryptingProvider interface.
+// Volumes are encrypted with the given key, which may be a key ID,
+// alias or ARN, or the account's default EBS key.
+func (e *ebsProvider) ValidateEncryption(encrypted bool, kmsKey string) error {
+	return nil
+}
+
 // Supports is defined on the Provider interface.
 func (e *ebsProvider) Supports(k storage.StorageKind) bool {
 	return k == storage.StorageKindBlock
@@ -286,6 +301,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeTagger = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -315,6 +331,17 @@ func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.Create
 	return vol, nil
 }
 
+// volumeEncryptionParams returns the CreateVolume parameters choosing
+// the key with which the volume is encrypted, which the amz.v3 client
+// does not support, or nil if the account's default key is to be used.
+func volumeEncryptionParams(attrs map[string]interface{}) map[string]string {
+	ebsConfig, err := newEbsConfig(attrs)
+	if err != nil || ebsConfig.kmsKey == "" {
+		return nil
+	}
+	return map[string]string{"KmsKeyId": ebsConfig.kmsKey}
+}
+
 // CreateVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) (_ []storage.CreateVolumesResult, err error) {
 
@@ -388,7 +415,8 @@ func (v *ebsVolumeSource) createVolume(ctx context.ProviderCallContext, p storag
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
-	resp, err := v.env.ec2.CreateVolume(vol)
+	client := withExtraParams(v.env.ec2, volumeEncryptionParams(p.Attributes))
+	resp, err := client.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(maybeConvertCredentialError(err, ctx))
 	}
@@ -987,6 +1015,11 @@ func (v *ebsVolumeSource) ImportVolume(ctx context.ProviderCallContext, volumeId
 	}, nil
 }
 
+// TagVolume is specified on the storage.VolumeTagger interface.
+func (v *ebsVolumeSource) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	return errors.Annotate(tagResources(v.env.ec2, ctx, tags, volumeId), "tagging volume")
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/ec2"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing"
 )
 
@@ -102,6 +103,38 @@ func (s *ebsSuite) TestValidateConfigUnknownConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil) // unknown attrs ignored
 }
 
+func (s *ebsSuite) TestValidateConfigEncryption(c *gc.C) {
+	p := s.ebsProvider(c)
+	cfg, err := storage.NewConfig("foo", ec2.EBS_ProviderType, map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(p, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ebsSuite) TestValidateConfigKMSKey(c *gc.C) {
+	p := s.ebsProvider(c)
+	cfg, err := storage.NewConfig("foo", ec2.EBS_ProviderType, map[string]interface{}{
+		"encrypted": true,
+		"kms-key":   "arn:aws:kms:us-east-1:123456789012:key/abcd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(p, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ebsSuite) TestVolumeEncryptionParams(c *gc.C) {
+	c.Assert(ec2.VolumeEncryptionParams(map[string]interface{}{"encrypted": true}), gc.IsNil)
+	params := ec2.VolumeEncryptionParams(map[string]interface{}{"kms-key": "alias/juju"})
+	c.Assert(params, jc.DeepEquals, map[string]string{"KmsKeyId": "alias/juju"})
+
+	// A key implies encryption.
+	vol, err := ec2.ParseVolumeOptions(10240, map[string]interface{}{"kms-key": "alias/juju"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vol.Encrypted, jc.IsTrue)
+}
+
 func (s *ebsSuite) TestSupports(c *gc.C) {
 	p := s.ebsProvider(c)
 	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
@@ -1064,6 +1097,30 @@ func (s *ebsSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *ebsSuite) TestTagVolume(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeTagger))
+
+	resp, err := s.srv.client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		VolumeType: "gp2",
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = vs.(storage.VolumeTagger).TagVolume(s.cloudCallCtx, resp.Id, map[string]string{
+		"team": "dba",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes, err := s.srv.client.Volumes([]string{resp.Id}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes.Volumes, gc.HasLen, 1)
+	c.Assert(volumes.Volumes[0].Tags, jc.DeepEquals, []awsec2.Tag{
+		{"team", "dba"},
+	})
+}
+
 func (s *ebsSuite) TestImportVolumeCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	c.Assert(vs, gc.Implements, new(storage.VolumeImporter))
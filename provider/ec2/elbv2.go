@@ -0,0 +1,379 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+)
+
+const (
+	// elbv2APIVersion is the version of the Elastic Load Balancing
+	// API that provides network load balancers.
+	elbv2APIVersion = "2015-12-01"
+
+	// elbv2MaxTagResources is the maximum number of resources whose
+	// tags may be described in a single request.
+	elbv2MaxTagResources = 20
+)
+
+// elbv2Endpoint returns the URL of the Elastic Load Balancing API in
+// the named region.
+var elbv2Endpoint = func(region string) string {
+	domain := "amazonaws.com"
+	if strings.HasPrefix(region, "cn-") {
+		domain = "amazonaws.com.cn"
+	}
+	return fmt.Sprintf("https://elasticloadbalancing.%s.%s/", region, domain)
+}
+
+// elbv2Client makes requests to version 2 of the Elastic Load Balancing
+// API, which the amz.v3 library does not provide, with the credentials
+// and region of an EC2 client. Failed requests return *ec2.Error, so
+// that they may be handled like EC2 errors.
+type elbv2Client struct {
+	auth     aws.Auth
+	endpoint string
+	sign     func(*http.Request, aws.Auth) error
+}
+
+func newELBv2Client(client *ec2.EC2) *elbv2Client {
+	return &elbv2Client{
+		auth:     client.Auth,
+		endpoint: elbv2Endpoint(client.Region.Name),
+		sign:     aws.SignV4Factory(client.Region.Name, "elasticloadbalancing"),
+	}
+}
+
+// elbLoadBalancer describes a load balancer.
+type elbLoadBalancer struct {
+	LoadBalancerArn  string
+	LoadBalancerName string
+	DNSName          string
+}
+
+// elbTargetGroup describes a target group.
+type elbTargetGroup struct {
+	TargetGroupArn  string
+	TargetGroupName string
+}
+
+// elbListener describes a listener of a load balancer.
+type elbListener struct {
+	ListenerArn string
+	Protocol    string
+	Port        int
+}
+
+type elbTag struct {
+	Key   string
+	Value string
+}
+
+type elbTagDescription struct {
+	ResourceArn string
+	Tags        []elbTag `xml:"Tags>member"`
+}
+
+type elbTargetHealthDescription struct {
+	Target struct {
+		Id string
+	}
+}
+
+// The response types decode the body of each response, whatever the
+// name of its root element.
+
+type elbLoadBalancersResponse struct {
+	LoadBalancers []elbLoadBalancer `xml:"DescribeLoadBalancersResult>LoadBalancers>member"`
+	NextMarker    string            `xml:"DescribeLoadBalancersResult>NextMarker"`
+}
+
+type elbCreateLoadBalancerResponse struct {
+	LoadBalancers []elbLoadBalancer `xml:"CreateLoadBalancerResult>LoadBalancers>member"`
+}
+
+type elbTargetGroupsResponse struct {
+	TargetGroups []elbTargetGroup `xml:"DescribeTargetGroupsResult>TargetGroups>member"`
+	NextMarker   string           `xml:"DescribeTargetGroupsResult>NextMarker"`
+}
+
+type elbCreateTargetGroupResponse struct {
+	TargetGroups []elbTargetGroup `xml:"CreateTargetGroupResult>TargetGroups>member"`
+}
+
+type elbListenersResponse struct {
+	Listeners  []elbListener `xml:"DescribeListenersResult>Listeners>member"`
+	NextMarker string        `xml:"DescribeListenersResult>NextMarker"`
+}
+
+type elbTagsResponse struct {
+	TagDescriptions []elbTagDescription `xml:"DescribeTagsResult>TagDescriptions>member"`
+}
+
+type elbTargetHealthResponse struct {
+	TargetHealthDescriptions []elbTargetHealthDescription `xml:"DescribeTargetHealthResult>TargetHealthDescriptions>member"`
+}
+
+type elbErrorResponse struct {
+	Error ec2.Error `xml:"Error"`
+}
+
+// do makes the request for the action with the given parameters, and
+// decodes the response into result, if it is not nil.
+func (c *elbv2Client) do(action string, params url.Values, result interface{}) error {
+	if params == nil {
+		params = make(url.Values)
+	}
+	params.Set("Action", action)
+	params.Set("Version", elbv2APIVersion)
+	req, err := http.NewRequest("GET", c.endpoint, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.URL.RawQuery = params.Encode()
+	if err := c.sign(req, c.auth); err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var errResp elbErrorResponse
+		if err := xml.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error.Code == "" {
+			return errors.Errorf("%s: %s", action, resp.Status)
+		}
+		ec2err := errResp.Error
+		ec2err.StatusCode = resp.StatusCode
+		return &ec2err
+	}
+	if result == nil {
+		return nil
+	}
+	return errors.Annotatef(xml.NewDecoder(resp.Body).Decode(result), "decoding %s response", action)
+}
+
+func setMembers(params url.Values, name string, values []string) {
+	for i, value := range values {
+		params.Set(fmt.Sprintf("%s.member.%d", name, i+1), value)
+	}
+}
+
+func setTags(params url.Values, tags map[string]string) {
+	i := 1
+	for key, value := range tags {
+		params.Set(fmt.Sprintf("Tags.member.%d.Key", i), key)
+		params.Set(fmt.Sprintf("Tags.member.%d.Value", i), value)
+		i++
+	}
+}
+
+// loadBalancers returns the load balancers with the given names, or all
+// load balancers if no names are given. If any of the named load
+// balancers does not exist, an error with the code LoadBalancerNotFound
+// is returned.
+func (c *elbv2Client) loadBalancers(names ...string) ([]elbLoadBalancer, error) {
+	var result []elbLoadBalancer
+	marker := ""
+	for {
+		params := make(url.Values)
+		setMembers(params, "Names", names)
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+		var resp elbLoadBalancersResponse
+		if err := c.do("DescribeLoadBalancers", params, &resp); err != nil {
+			return nil, err
+		}
+		result = append(result, resp.LoadBalancers...)
+		if resp.NextMarker == "" {
+			return result, nil
+		}
+		marker = resp.NextMarker
+	}
+}
+
+// createLoadBalancer creates an internet-facing network load balancer
+// in the given subnets, with the given tags.
+func (c *elbv2Client) createLoadBalancer(name string, subnetIds []string, tags map[string]string) (elbLoadBalancer, error) {
+	params := make(url.Values)
+	params.Set("Name", name)
+	params.Set("Type", "network")
+	params.Set("Scheme", "internet-facing")
+	setMembers(params, "Subnets", subnetIds)
+	setTags(params, tags)
+	var resp elbCreateLoadBalancerResponse
+	if err := c.do("CreateLoadBalancer", params, &resp); err != nil {
+		return elbLoadBalancer{}, err
+	}
+	if len(resp.LoadBalancers) != 1 {
+		return elbLoadBalancer{}, errors.Errorf("expected 1 load balancer, got %d", len(resp.LoadBalancers))
+	}
+	return resp.LoadBalancers[0], nil
+}
+
+func (c *elbv2Client) deleteLoadBalancer(arn string) error {
+	params := make(url.Values)
+	params.Set("LoadBalancerArn", arn)
+	return c.do("DeleteLoadBalancer", params, nil)
+}
+
+// targetGroups returns the target groups with the given names, or all
+// target groups if no names are given. If any of the named target
+// groups does not exist, an error with the code TargetGroupNotFound is
+// returned.
+func (c *elbv2Client) targetGroups(names ...string) ([]elbTargetGroup, error) {
+	var result []elbTargetGroup
+	marker := ""
+	for {
+		params := make(url.Values)
+		setMembers(params, "Names", names)
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+		var resp elbTargetGroupsResponse
+		if err := c.do("DescribeTargetGroups", params, &resp); err != nil {
+			return nil, err
+		}
+		result = append(result, resp.TargetGroups...)
+		if resp.NextMarker == "" {
+			return result, nil
+		}
+		marker = resp.NextMarker
+	}
+}
+
+// createTargetGroup creates a target group forwarding the protocol to
+// the port of instances in the VPC.
+func (c *elbv2Client) createTargetGroup(name, protocol string, port int, vpcId string) (elbTargetGroup, error) {
+	params := make(url.Values)
+	params.Set("Name", name)
+	params.Set("Protocol", protocol)
+	params.Set("Port", fmt.Sprint(port))
+	params.Set("VpcId", vpcId)
+	params.Set("TargetType", "instance")
+	var resp elbCreateTargetGroupResponse
+	if err := c.do("CreateTargetGroup", params, &resp); err != nil {
+		return elbTargetGroup{}, err
+	}
+	if len(resp.TargetGroups) != 1 {
+		return elbTargetGroup{}, errors.Errorf("expected 1 target group, got %d", len(resp.TargetGroups))
+	}
+	return resp.TargetGroups[0], nil
+}
+
+func (c *elbv2Client) deleteTargetGroup(arn string) error {
+	params := make(url.Values)
+	params.Set("TargetGroupArn", arn)
+	return c.do("DeleteTargetGroup", params, nil)
+}
+
+// listeners returns the listeners of the load balancer.
+func (c *elbv2Client) listeners(loadBalancerArn string) ([]elbListener, error) {
+	var result []elbListener
+	marker := ""
+	for {
+		params := make(url.Values)
+		params.Set("LoadBalancerArn", loadBalancerArn)
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+		var resp elbListenersResponse
+		if err := c.do("DescribeListeners", params, &resp); err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Listeners...)
+		if resp.NextMarker == "" {
+			return result, nil
+		}
+		marker = resp.NextMarker
+	}
+}
+
+// createListener creates a listener on the port of the load balancer,
+// forwarding to the target group.
+func (c *elbv2Client) createListener(loadBalancerArn, protocol string, port int, targetGroupArn string) error {
+	params := make(url.Values)
+	params.Set("LoadBalancerArn", loadBalancerArn)
+	params.Set("Protocol", protocol)
+	params.Set("Port", fmt.Sprint(port))
+	params.Set("DefaultActions.member.1.Type", "forward")
+	params.Set("DefaultActions.member.1.TargetGroupArn", targetGroupArn)
+	return c.do("CreateListener", params, nil)
+}
+
+// targets returns the IDs of the instances registered with the target
+// group.
+func (c *elbv2Client) targets(targetGroupArn string) ([]string, error) {
+	params := make(url.Values)
+	params.Set("TargetGroupArn", targetGroupArn)
+	var resp elbTargetHealthResponse
+	if err := c.do("DescribeTargetHealth", params, &resp); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(resp.TargetHealthDescriptions))
+	for i, desc := range resp.TargetHealthDescriptions {
+		ids[i] = desc.Target.Id
+	}
+	return ids, nil
+}
+
+func targetParams(targetGroupArn string, ids []string) url.Values {
+	params := make(url.Values)
+	params.Set("TargetGroupArn", targetGroupArn)
+	for i, id := range ids {
+		params.Set(fmt.Sprintf("Targets.member.%d.Id", i+1), id)
+	}
+	return params
+}
+
+func (c *elbv2Client) registerTargets(targetGroupArn string, ids []string) error {
+	return c.do("RegisterTargets", targetParams(targetGroupArn, ids), nil)
+}
+
+func (c *elbv2Client) deregisterTargets(targetGroupArn string, ids []string) error {
+	return c.do("DeregisterTargets", targetParams(targetGroupArn, ids), nil)
+}
+
+func (c *elbv2Client) addTags(arn string, tags map[string]string) error {
+	params := make(url.Values)
+	params.Set("ResourceArns.member.1", arn)
+	setTags(params, tags)
+	return c.do("AddTags", params, nil)
+}
+
+// tags returns the tags of the resources with the given ARNs, by ARN.
+func (c *elbv2Client) tags(arns []string) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string)
+	for len(arns) > 0 {
+		batch := arns
+		if len(batch) > elbv2MaxTagResources {
+			batch = batch[:elbv2MaxTagResources]
+		}
+		arns = arns[len(batch):]
+		params := make(url.Values)
+		setMembers(params, "ResourceArns", batch)
+		var resp elbTagsResponse
+		if err := c.do("DescribeTags", params, &resp); err != nil {
+			return nil, err
+		}
+		for _, desc := range resp.TagDescriptions {
+			tags := make(map[string]string)
+			for _, tag := range desc.Tags {
+				tags[tag.Key] = tag.Value
+			}
+			result[desc.ResourceArn] = tags
+		}
+	}
+	return result, nil
+}
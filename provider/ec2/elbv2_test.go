@@ -0,0 +1,535 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/ec2/ec2test"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/ec2"
+	coretesting "github.com/juju/juju/testing"
+)
+
+// fakeELBv2 is a fake Elastic Load Balancing API, providing the calls
+// used to manage network load balancers.
+type fakeELBv2 struct {
+	server *httptest.Server
+
+	mu            sync.Mutex
+	nextId        int
+	loadBalancers map[string]*fakeLoadBalancer
+	targetGroups  map[string]*fakeTargetGroup
+	tags          map[string]map[string]string
+}
+
+type fakeLoadBalancer struct {
+	arn       string
+	name      string
+	subnets   []string
+	listeners map[int]string
+}
+
+type fakeTargetGroup struct {
+	arn      string
+	name     string
+	protocol string
+	port     int
+	vpcId    string
+	targets  set.Strings
+}
+
+func newFakeELBv2() *fakeELBv2 {
+	srv := &fakeELBv2{
+		loadBalancers: make(map[string]*fakeLoadBalancer),
+		targetGroups:  make(map[string]*fakeTargetGroup),
+		tags:          make(map[string]map[string]string),
+	}
+	srv.server = httptest.NewServer(http.HandlerFunc(srv.serveHTTP))
+	return srv
+}
+
+func (srv *fakeELBv2) close() {
+	srv.server.Close()
+}
+
+// loadBalancerTags returns the names of the load balancers, and the
+// value of the given tag of each of them.
+func (srv *fakeELBv2) loadBalancerTags(key string) map[string]string {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	result := make(map[string]string)
+	for name, lb := range srv.loadBalancers {
+		result[name] = srv.tags[lb.arn][key]
+	}
+	return result
+}
+
+// targetGroupNames returns the names of the target groups.
+func (srv *fakeELBv2) targetGroupNames() []string {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	var result []string
+	for name := range srv.targetGroups {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// addLoadBalancer adds a load balancer with the given tags, as though
+// created by another model.
+func (srv *fakeELBv2) addLoadBalancer(name string, tags map[string]string) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	lb := &fakeLoadBalancer{
+		arn:       srv.newArn("loadbalancer/net/" + name),
+		name:      name,
+		listeners: make(map[int]string),
+	}
+	srv.loadBalancers[name] = lb
+	srv.tags[lb.arn] = tags
+}
+
+func (srv *fakeELBv2) newArn(resource string) string {
+	srv.nextId++
+	return fmt.Sprintf("arn:aws:elasticloadbalancing:test:123456789012:%s/%d", resource, srv.nextId)
+}
+
+type fakeELBError struct {
+	code    string
+	message string
+}
+
+func members(query url.Values, name string) []string {
+	var values []string
+	for i := 1; ; i++ {
+		value := query.Get(fmt.Sprintf("%s.member.%d", name, i))
+		if value == "" {
+			return values
+		}
+		values = append(values, value)
+	}
+}
+
+func queryTags(query url.Values) map[string]string {
+	tags := make(map[string]string)
+	for i := 1; ; i++ {
+		key := query.Get(fmt.Sprintf("Tags.member.%d.Key", i))
+		if key == "" {
+			return tags
+		}
+		tags[key] = query.Get(fmt.Sprintf("Tags.member.%d.Value", i))
+	}
+}
+
+type xmlLoadBalancer struct {
+	LoadBalancerArn  string
+	LoadBalancerName string
+	DNSName          string
+}
+
+type xmlTargetGroup struct {
+	TargetGroupArn  string
+	TargetGroupName string
+}
+
+type xmlListener struct {
+	ListenerArn string
+	Protocol    string
+	Port        int
+}
+
+type xmlTag struct {
+	Key   string
+	Value string
+}
+
+type xmlTagDescription struct {
+	ResourceArn string
+	Tags        []xmlTag `xml:"Tags>member"`
+}
+
+type xmlTargetHealth struct {
+	Target struct {
+		Id string
+	}
+}
+
+func (srv *fakeELBv2) xmlLoadBalancer(lb *fakeLoadBalancer) xmlLoadBalancer {
+	return xmlLoadBalancer{
+		LoadBalancerArn:  lb.arn,
+		LoadBalancerName: lb.name,
+		DNSName:          lb.name + ".elb.test.amazonaws.com",
+	}
+}
+
+func (srv *fakeELBv2) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	query := req.URL.Query()
+	action := query.Get("Action")
+	result, err := srv.handle(action, query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "<ErrorResponse><Error><Type>Sender</Type><Code>%s</Code><Message>%s</Message></Error></ErrorResponse>",
+			err.code, err.message)
+		return
+	}
+	var body []byte
+	if result != nil {
+		var marshalErr error
+		body, marshalErr = xml.Marshal(result)
+		if marshalErr != nil {
+			http.Error(w, marshalErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	fmt.Fprintf(w, "<%sResponse><%sResult>%s</%sResult></%sResponse>", action, action, body, action, action)
+}
+
+func (srv *fakeELBv2) handle(action string, query url.Values) (interface{}, *fakeELBError) {
+	switch action {
+	case "DescribeLoadBalancers":
+		var lbs []xmlLoadBalancer
+		if names := members(query, "Names"); len(names) > 0 {
+			for _, name := range names {
+				lb, ok := srv.loadBalancers[name]
+				if !ok {
+					return nil, &fakeELBError{"LoadBalancerNotFound", "load balancer not found"}
+				}
+				lbs = append(lbs, srv.xmlLoadBalancer(lb))
+			}
+		} else {
+			for _, lb := range srv.loadBalancers {
+				lbs = append(lbs, srv.xmlLoadBalancer(lb))
+			}
+		}
+		return struct {
+			XMLName xml.Name          `xml:"LoadBalancers"`
+			Members []xmlLoadBalancer `xml:"member"`
+		}{Members: lbs}, nil
+
+	case "CreateLoadBalancer":
+		name := query.Get("Name")
+		if query.Get("Type") != "network" || query.Get("Scheme") != "internet-facing" {
+			return nil, &fakeELBError{"ValidationError", "unexpected load balancer type or scheme"}
+		}
+		lb := &fakeLoadBalancer{
+			arn:       srv.newArn("loadbalancer/net/" + name),
+			name:      name,
+			subnets:   members(query, "Subnets"),
+			listeners: make(map[int]string),
+		}
+		srv.loadBalancers[name] = lb
+		srv.tags[lb.arn] = queryTags(query)
+		return struct {
+			XMLName xml.Name          `xml:"LoadBalancers"`
+			Members []xmlLoadBalancer `xml:"member"`
+		}{Members: []xmlLoadBalancer{srv.xmlLoadBalancer(lb)}}, nil
+
+	case "DeleteLoadBalancer":
+		arn := query.Get("LoadBalancerArn")
+		for name, lb := range srv.loadBalancers {
+			if lb.arn == arn {
+				delete(srv.loadBalancers, name)
+				delete(srv.tags, arn)
+			}
+		}
+		return nil, nil
+
+	case "DescribeTargetGroups":
+		var groups []xmlTargetGroup
+		if names := members(query, "Names"); len(names) > 0 {
+			for _, name := range names {
+				group, ok := srv.targetGroups[name]
+				if !ok {
+					return nil, &fakeELBError{"TargetGroupNotFound", "target group not found"}
+				}
+				groups = append(groups, xmlTargetGroup{group.arn, group.name})
+			}
+		} else {
+			for _, group := range srv.targetGroups {
+				groups = append(groups, xmlTargetGroup{group.arn, group.name})
+			}
+		}
+		return struct {
+			XMLName xml.Name         `xml:"TargetGroups"`
+			Members []xmlTargetGroup `xml:"member"`
+		}{Members: groups}, nil
+
+	case "CreateTargetGroup":
+		name := query.Get("Name")
+		port, _ := strconv.Atoi(query.Get("Port"))
+		group := &fakeTargetGroup{
+			arn:      srv.newArn("targetgroup/" + name),
+			name:     name,
+			protocol: query.Get("Protocol"),
+			port:     port,
+			vpcId:    query.Get("VpcId"),
+			targets:  set.NewStrings(),
+		}
+		srv.targetGroups[name] = group
+		return struct {
+			XMLName xml.Name         `xml:"TargetGroups"`
+			Members []xmlTargetGroup `xml:"member"`
+		}{Members: []xmlTargetGroup{{group.arn, group.name}}}, nil
+
+	case "DeleteTargetGroup":
+		arn := query.Get("TargetGroupArn")
+		for _, lb := range srv.loadBalancers {
+			for _, groupArn := range lb.listeners {
+				if groupArn == arn {
+					return nil, &fakeELBError{"ResourceInUse", "target group in use"}
+				}
+			}
+		}
+		for name, group := range srv.targetGroups {
+			if group.arn == arn {
+				delete(srv.targetGroups, name)
+				delete(srv.tags, arn)
+			}
+		}
+		return nil, nil
+
+	case "DescribeListeners":
+		var listeners []xmlListener
+		for _, lb := range srv.loadBalancers {
+			if lb.arn != query.Get("LoadBalancerArn") {
+				continue
+			}
+			for port := range lb.listeners {
+				listeners = append(listeners, xmlListener{
+					ListenerArn: fmt.Sprintf("%s/listener/%d", lb.arn, port),
+					Protocol:    "TCP",
+					Port:        port,
+				})
+			}
+		}
+		return struct {
+			XMLName xml.Name      `xml:"Listeners"`
+			Members []xmlListener `xml:"member"`
+		}{Members: listeners}, nil
+
+	case "CreateListener":
+		port, _ := strconv.Atoi(query.Get("Port"))
+		if query.Get("DefaultActions.member.1.Type") != "forward" {
+			return nil, &fakeELBError{"ValidationError", "unexpected action"}
+		}
+		for _, lb := range srv.loadBalancers {
+			if lb.arn == query.Get("LoadBalancerArn") {
+				lb.listeners[port] = query.Get("DefaultActions.member.1.TargetGroupArn")
+			}
+		}
+		return nil, nil
+
+	case "DescribeTargetHealth", "RegisterTargets", "DeregisterTargets":
+		var group *fakeTargetGroup
+		for _, g := range srv.targetGroups {
+			if g.arn == query.Get("TargetGroupArn") {
+				group = g
+			}
+		}
+		if group == nil {
+			return nil, &fakeELBError{"TargetGroupNotFound", "target group not found"}
+		}
+		for i := 1; ; i++ {
+			id := query.Get(fmt.Sprintf("Targets.member.%d.Id", i))
+			if id == "" {
+				break
+			}
+			if action == "RegisterTargets" {
+				group.targets.Add(id)
+			} else {
+				group.targets.Remove(id)
+			}
+		}
+		var health []xmlTargetHealth
+		for _, id := range group.targets.SortedValues() {
+			var h xmlTargetHealth
+			h.Target.Id = id
+			health = append(health, h)
+		}
+		return struct {
+			XMLName xml.Name          `xml:"TargetHealthDescriptions"`
+			Members []xmlTargetHealth `xml:"member"`
+		}{Members: health}, nil
+
+	case "AddTags":
+		for _, arn := range members(query, "ResourceArns") {
+			if srv.tags[arn] == nil {
+				srv.tags[arn] = make(map[string]string)
+			}
+			for key, value := range queryTags(query) {
+				srv.tags[arn][key] = value
+			}
+		}
+		return nil, nil
+
+	case "DescribeTags":
+		arns := members(query, "ResourceArns")
+		if len(arns) > 20 {
+			return nil, &fakeELBError{"ValidationError", "too many resources"}
+		}
+		var descs []xmlTagDescription
+		for _, arn := range arns {
+			desc := xmlTagDescription{ResourceArn: arn}
+			for key, value := range srv.tags[arn] {
+				desc.Tags = append(desc.Tags, xmlTag{key, value})
+			}
+			descs = append(descs, desc)
+		}
+		return struct {
+			XMLName xml.Name            `xml:"TagDescriptions"`
+			Members []xmlTagDescription `xml:"member"`
+		}{Members: descs}, nil
+	}
+	return nil, &fakeELBError{"InvalidAction", fmt.Sprintf("unknown action %q", action)}
+}
+
+// loadBalancerEnviron returns a controller environ, and a hosted model
+// environ with two running instances.
+func (t *localServerSuite) loadBalancerEnviron(c *gc.C) (environs.Environ, environs.Environ, []instance.Id) {
+	controllerEnv := t.prepareAndBootstrap(c)
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Running)
+	cfg, err := controllerEnv.Config().Apply(map[string]interface{}{
+		"uuid": "7e386e08-cba7-44a4-a76e-7c1633584210",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := environs.New(environs.OpenParams{
+		Cloud:  t.CloudSpec(),
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	inst0, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "0")
+	inst1, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+	return controllerEnv, env, []instance.Id{inst0.Id(), inst1.Id()}
+}
+
+func (t *localServerSuite) TestEnsureLoadBalancer(c *gc.C) {
+	_, env, ids := t.loadBalancerEnviron(c)
+	lbEnv := env.(environs.LoadBalancer)
+	endpoint := network.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 81}
+	name := ec2.LoadBalancerName(env, "wordpress", endpoint)
+	c.Assert(len(name) <= 32, jc.IsTrue)
+
+	addrs, err := lbEnv.EnsureLoadBalancer(t.callCtx, "wordpress", endpoint, ids)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress(name+".elb.test.amazonaws.com", network.ScopePublic),
+	})
+	c.Assert(t.srv.elbsrv.loadBalancerTags(tags.JujuModel), jc.DeepEquals, map[string]string{
+		name: env.Config().UUID(),
+	})
+	c.Assert(t.srv.elbsrv.targetGroupNames(), jc.DeepEquals, []string{name + "-80", name + "-81"})
+
+	lb := t.srv.elbsrv.loadBalancers[name]
+	c.Assert(lb.subnets, gc.Not(gc.HasLen), 0)
+	c.Assert(lb.listeners, gc.HasLen, 2)
+	for _, port := range []int{80, 81} {
+		group := t.srv.elbsrv.targetGroups[fmt.Sprintf("%s-%d", name, port)]
+		c.Check(lb.listeners[port], gc.Equals, group.arn)
+		c.Check(group.protocol, gc.Equals, "TCP")
+		c.Check(group.port, gc.Equals, port)
+		c.Check(group.vpcId, gc.Not(gc.Equals), "")
+		c.Check(group.targets.SortedValues(), jc.DeepEquals, []string{string(ids[0]), string(ids[1])})
+		c.Check(t.srv.elbsrv.tags[group.arn][tags.JujuController], gc.Equals, t.ControllerUUID)
+	}
+
+	// Instances are deregistered when they no longer open the endpoint.
+	_, err = lbEnv.EnsureLoadBalancer(t.callCtx, "wordpress", endpoint, ids[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.srv.elbsrv.loadBalancers, gc.HasLen, 1)
+	for _, port := range []int{80, 81} {
+		group := t.srv.elbsrv.targetGroups[fmt.Sprintf("%s-%d", name, port)]
+		c.Check(group.targets.SortedValues(), jc.DeepEquals, []string{string(ids[1])})
+	}
+}
+
+func (t *localServerSuite) TestEnsureLoadBalancerNotSupported(c *gc.C) {
+	_, env, ids := t.loadBalancerEnviron(c)
+	lbEnv := env.(environs.LoadBalancer)
+
+	_, err := lbEnv.EnsureLoadBalancer(t.callCtx, "wordpress", network.PortRange{
+		Protocol: "icmp", FromPort: -1, ToPort: -1,
+	}, ids)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = lbEnv.EnsureLoadBalancer(t.callCtx, "wordpress", network.PortRange{
+		Protocol: "tcp", FromPort: 8000, ToPort: 8010,
+	}, ids)
+	c.Assert(err, gc.ErrorMatches, `load balancing 11 ports \(maximum 10\) not supported`)
+	c.Assert(t.srv.elbsrv.loadBalancers, gc.HasLen, 0)
+}
+
+func (t *localServerSuite) TestRemoveLoadBalancer(c *gc.C) {
+	_, env, ids := t.loadBalancerEnviron(c)
+	lbEnv := env.(environs.LoadBalancer)
+	httpEndpoint := network.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 80}
+	httpsEndpoint := network.PortRange{Protocol: "tcp", FromPort: 443, ToPort: 443}
+	_, err := lbEnv.EnsureLoadBalancer(t.callCtx, "wordpress", httpEndpoint, ids)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = lbEnv.EnsureLoadBalancer(t.callCtx, "wordpress", httpsEndpoint, ids)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = lbEnv.RemoveLoadBalancer(t.callCtx, "wordpress", httpEndpoint)
+	c.Assert(err, jc.ErrorIsNil)
+	name := ec2.LoadBalancerName(env, "wordpress", httpsEndpoint)
+	c.Assert(t.srv.elbsrv.loadBalancerTags(tags.JujuModel), jc.DeepEquals, map[string]string{
+		name: env.Config().UUID(),
+	})
+	c.Assert(t.srv.elbsrv.targetGroupNames(), jc.DeepEquals, []string{name + "-443"})
+
+	// Removing a load balancer that does not exist is not an error.
+	err = lbEnv.RemoveLoadBalancer(t.callCtx, "wordpress", httpEndpoint)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestDestroyRemovesLoadBalancers(c *gc.C) {
+	_, env, ids := t.loadBalancerEnviron(c)
+	lbEnv := env.(environs.LoadBalancer)
+	_, err := lbEnv.EnsureLoadBalancer(t.callCtx, "wordpress", network.PortRange{
+		Protocol: "tcp", FromPort: 80, ToPort: 80,
+	}, ids)
+	c.Assert(err, jc.ErrorIsNil)
+	t.srv.elbsrv.addLoadBalancer("other", map[string]string{
+		tags.JujuModel: coretesting.ModelTag.Id(),
+	})
+
+	err = env.Destroy(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.srv.elbsrv.loadBalancerTags(tags.JujuModel), jc.DeepEquals, map[string]string{
+		"other": coretesting.ModelTag.Id(),
+	})
+	c.Assert(t.srv.elbsrv.targetGroupNames(), gc.HasLen, 0)
+}
+
+func (t *localServerSuite) TestDestroyControllerRemovesHostedModelLoadBalancers(c *gc.C) {
+	controllerEnv, env, ids := t.loadBalancerEnviron(c)
+	lbEnv := env.(environs.LoadBalancer)
+	_, err := lbEnv.EnsureLoadBalancer(t.callCtx, "wordpress", network.PortRange{
+		Protocol: "tcp", FromPort: 80, ToPort: 80,
+	}, ids)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = controllerEnv.DestroyController(t.callCtx, t.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.srv.elbsrv.loadBalancerTags(tags.JujuModel), gc.HasLen, 0)
+	c.Assert(t.srv.elbsrv.targetGroupNames(), gc.HasLen, 0)
+}
@@ -81,6 +81,8 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.InstanceTagger = (*environ)(nil)
+var _ environs.ModelResourceTagger = (*environ)(nil)
 
 func (e *environ) Config() *config.Config {
 	return e.ecfg().Config
@@ -184,6 +186,13 @@ func (e *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constra
 		instTypeNames[i] = itype.Name
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+	validator.RegisterVocabulary(constraints.InstanceLifecycle, []string{
+		constraints.OnDemand,
+		constraints.Spot,
+	})
+	if err := common.RegisterZonesVocabulary(validator, e, ctx); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return validator, nil
 }
 
@@ -331,6 +340,9 @@ func (e *environ) PrecheckInstance(ctx context.ProviderCallContext, args environ
 	); err != nil {
 		return errors.Trace(err)
 	}
+	if args.Constraints.HasMaxPrice() && spotInstanceParams(args.Constraints) == nil {
+		return errors.NotValidf("max-price without instance-lifecycle=spot")
+	}
 	if !args.Constraints.HasInstanceType() {
 		return nil
 	}
@@ -598,8 +610,12 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		logger.Debugf("selected subnet %q in zone %q", runArgs.SubnetId, availabilityZone)
 	}
 
+	client := withExtraParams(e.ec2, mergeParams(
+		spotInstanceParams(args.Constraints),
+		rootDiskEncryptionParams(e.Config()),
+	))
 	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
-	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	instResp, err = runInstances(client, ctx, runArgs, callback)
 	if err != nil {
 		if !isZoneOrSubnetConstrainedError(err) {
 			err = annotateWrapError(err, "cannot run instances")
@@ -793,6 +809,24 @@ func tagResources(e *ec2.EC2, ctx context.ProviderCallContext, tags map[string]s
 	return maybeConvertCredentialError(err, ctx)
 }
 
+// TagInstance implements environs.InstanceTagger.
+func (e *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	return errors.Annotate(tagResources(e.ec2, ctx, tags, string(id)), "tagging instance")
+}
+
+// TagModelResources implements environs.ModelResourceTagger, tagging
+// the model's security groups.
+func (e *environ) TagModelResources(ctx context.ProviderCallContext, tags map[string]string) error {
+	groupIds, err := e.modelSecurityGroupIDs(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(groupIds) == 0 {
+		return nil
+	}
+	return errors.Annotate(tagResources(e.ec2, ctx, tags, groupIds...), "tagging security groups")
+}
+
 func tagRootDisk(e *ec2.EC2, ctx context.ProviderCallContext, tags map[string]string, inst *ec2.Instance) error {
 	if len(tags) == 0 {
 		return nil
@@ -1281,6 +1315,9 @@ func (e *environ) Destroy(ctx context.ProviderCallContext) error {
 	if err := common.Destroy(e, ctx); err != nil {
 		return errors.Trace(maybeConvertCredentialError(err, ctx))
 	}
+	if err := e.removeLoadBalancers(ctx, tags.JujuModel, e.uuid()); err != nil {
+		return errors.Annotate(err, "cannot delete load balancers")
+	}
 	if err := e.cleanEnvironmentSecurityGroups(ctx); err != nil {
 		return errors.Annotate(maybeConvertCredentialError(err, ctx), "cannot delete environment security groups")
 	}
@@ -1327,7 +1364,19 @@ func (e *environ) destroyControllerManagedEnvirons(ctx context.ProviderCallConte
 		return errors.Annotatef(err, "destroying volume %q", volIds[i], err)
 	}
 
-	// Delete security groups managed by the controller.
+	// Delete all load balancers managed by the controller.
+	if err := e.removeLoadBalancers(ctx, tags.JujuController, controllerUUID); err != nil {
+		return errors.Annotate(err, "deleting load balancers")
+	}
+
+	// Delete security groups managed by the controller. Application
+	// groups may refer to each other, so those references are removed
+	// first.
+	filter := ec2.NewFilter()
+	e.addControllerFilter(filter, controllerUUID)
+	if _, err := e.releaseApplicationGroups(ctx, filter); err != nil {
+		return errors.Annotate(err, "releasing application security groups")
+	}
 	groups, err := e.controllerSecurityGroups(ctx, controllerUUID)
 	if err != nil {
 		return errors.Trace(err)
@@ -1536,6 +1585,18 @@ func (e *environ) modelSecurityGroupIDs(ctx context.ProviderCallContext) ([]stri
 // cleanEnvironmentSecurityGroups attempts to delete all security groups owned
 // by the environment.
 func (e *environ) cleanEnvironmentSecurityGroups(ctx context.ProviderCallContext) error {
+	filter := ec2.NewFilter()
+	e.addModelFilter(filter)
+	appGroups, err := e.releaseApplicationGroups(ctx, filter)
+	if err != nil {
+		return errors.Annotate(err, "cannot release application security groups")
+	}
+	for _, g := range appGroups {
+		if err := deleteSecurityGroupInsistently(e.ec2, ctx, g, clock.WallClock); err != nil {
+			return errors.Annotatef(err, "cannot delete application security group %q", g.Name)
+		}
+	}
+
 	jujuGroup := e.jujuGroupName()
 	g, err := e.groupByName(ctx, jujuGroup)
 	if isNotFoundError(err) {
@@ -1633,7 +1694,7 @@ func (e *environ) deleteSecurityGroupsForInstances(ctx context.ProviderCallConte
 	jujuGroup := e.jujuGroupName()
 
 	for _, deletable := range securityGroups {
-		if deletable.Name == jujuGroup {
+		if deletable.Name == jujuGroup || isApplicationGroupName(deletable.Name) {
 			continue
 		}
 		if err := deleteSecurityGroupInsistently(e.ec2, ctx, deletable, clock.WallClock); err != nil {
@@ -1756,6 +1817,10 @@ func (e *environ) setUpGroups(ctx context.ProviderCallContext, controllerUUID, m
 		machineGroup, err = e.ensureGroup(ctx, controllerUUID, e.machineGroupName(machineId), nil)
 	case config.FwGlobal:
 		machineGroup, err = e.ensureGroup(ctx, controllerUUID, e.globalGroupName(), nil)
+	case config.FwApplication:
+		// The instance joins the groups of its units' applications
+		// later, when the firewaller calls SetInstanceApplications.
+		return []ec2.SecurityGroup{jujuGroup}, nil
 	}
 	if err != nil {
 		return nil, err
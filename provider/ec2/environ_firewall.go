@@ -0,0 +1,353 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+var _ environs.ApplicationFirewaller = (*environ)(nil)
+
+// applicationGroupInfix separates the name of the model's juju group
+// from the application name in the names of application security
+// groups.
+const applicationGroupInfix = "-app-"
+
+// applicationGroupName returns the name of the security group of the
+// named application, used by the FwApplication firewall mode.
+func (e *environ) applicationGroupName(applicationName string) string {
+	return e.jujuGroupName() + applicationGroupInfix + applicationName
+}
+
+// isApplicationGroupName reports whether the named security group is
+// the security group of an application, in any model.
+func isApplicationGroupName(name string) bool {
+	return strings.Contains(name, applicationGroupInfix)
+}
+
+func (e *environ) checkApplicationMode() error {
+	if mode := e.Config().FirewallMode(); mode != config.FwApplication {
+		return errors.Errorf("invalid firewall mode %q for application security groups", mode)
+	}
+	return nil
+}
+
+// OpenApplicationPorts is part of the environs.ApplicationFirewaller interface.
+func (e *environ) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if _, err := e.applicationGroup(ctx, applicationName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.openPortsInGroup(ctx, e.applicationGroupName(applicationName), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in security group of application %q: %v", applicationName, rules)
+	return nil
+}
+
+// CloseApplicationPorts is part of the environs.ApplicationFirewaller interface.
+func (e *environ) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if err := e.checkApplicationMode(); err != nil {
+		return errors.Trace(err)
+	}
+	err := e.closePortsInGroup(ctx, e.applicationGroupName(applicationName), rules)
+	if isNotFoundError(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in security group of application %q: %v", applicationName, rules)
+	return nil
+}
+
+// ApplicationIngressRules is part of the environs.ApplicationFirewaller interface.
+func (e *environ) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error) {
+	if err := e.checkApplicationMode(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	group, err := e.groupInfoByName(ctx, e.applicationGroupName(applicationName))
+	if isNotFoundError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Permissions granted to the groups of other applications are
+	// managed by SetApplicationSources.
+	var rules []network.IngressRule
+	for _, p := range group.IPPerms {
+		if len(p.SourceIPs) == 0 {
+			continue
+		}
+		rule, err := network.NewIngressRule(p.Protocol, p.FromPort, p.ToPort, p.SourceIPs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// SetApplicationSources is part of the environs.ApplicationFirewaller interface.
+func (e *environ) SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	g, err := e.applicationGroup(ctx, applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := e.groupInfoByName(ctx, g.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	have := make(permSet)
+	for _, p := range info.IPPerms {
+		for _, source := range p.SourceGroups {
+			have[permKey{
+				protocol: p.Protocol,
+				fromPort: p.FromPort,
+				toPort:   p.ToPort,
+				groupId:  source.Id,
+			}] = true
+		}
+	}
+	want := make(permSet)
+	for _, rule := range rules {
+		if len(rule.SourceApplications) == 0 {
+			return errors.NotValidf("rule %v without source applications", rule)
+		}
+		for _, sourceName := range rule.SourceApplications {
+			source, err := e.applicationGroup(ctx, sourceName)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			want[permKey{
+				protocol: rule.Protocol,
+				fromPort: rule.FromPort,
+				toPort:   rule.ToPort,
+				groupId:  source.Id,
+			}] = true
+		}
+	}
+
+	revoke := make(permSet)
+	for p := range have {
+		if !want[p] {
+			revoke[p] = true
+		}
+	}
+	if len(revoke) > 0 {
+		if _, err := e.ec2.RevokeSecurityGroup(g, revoke.ipPerms()); err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "revoking security group %q", g.Id)
+		}
+	}
+	add := make(permSet)
+	for p := range want {
+		if !have[p] {
+			add[p] = true
+		}
+	}
+	if len(add) > 0 {
+		if _, err := e.ec2.AuthorizeSecurityGroup(g, add.ipPerms()); err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "authorizing security group %q", g.Id)
+		}
+	}
+	logger.Infof("set application sources in security group of application %q: %v", applicationName, rules)
+	return nil
+}
+
+// SetInstanceApplications is part of the environs.ApplicationFirewaller interface.
+func (e *environ) SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error {
+	if err := e.checkApplicationMode(); err != nil {
+		return errors.Trace(err)
+	}
+	wanted := set.NewStrings()
+	for _, appName := range applicationNames {
+		g, err := e.applicationGroup(ctx, appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		wanted.Add(g.Id)
+	}
+
+	resp, err := e.ec2.Instances([]string{string(id)}, nil)
+	if err != nil {
+		return errors.Annotatef(maybeConvertCredentialError(err, ctx), "getting instance %q", id)
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return errors.NotFoundf("instance %q", id)
+	}
+	inst := resp.Reservations[0].Instances[0]
+
+	// Every group of the instance is replaced at once, so the groups
+	// other than application groups are passed back unchanged.
+	var groupIds []string
+	changed := false
+	for _, g := range inst.SecurityGroups {
+		if !isApplicationGroupName(g.Name) {
+			groupIds = append(groupIds, g.Id)
+			continue
+		}
+		if wanted.Contains(g.Id) {
+			wanted.Remove(g.Id)
+			groupIds = append(groupIds, g.Id)
+			continue
+		}
+		changed = true
+	}
+	if !changed && wanted.IsEmpty() {
+		return nil
+	}
+	groupIds = append(groupIds, wanted.SortedValues()...)
+	if err := modifyInstanceGroups(e.ec2, string(id), groupIds); err != nil {
+		return errors.Annotatef(maybeConvertCredentialError(err, ctx), "setting security groups of instance %q", id)
+	}
+	logger.Infof("set applications of instance %q: %v", id, applicationNames)
+	return nil
+}
+
+// applicationGroup returns the security group of the named application,
+// creating it if it does not exist. Unlike ensureGroup, the permissions
+// of an existing group are left alone.
+func (e *environ) applicationGroup(ctx context.ProviderCallContext, applicationName string) (ec2.SecurityGroup, error) {
+	if err := e.checkApplicationMode(); err != nil {
+		return zeroGroup, errors.Trace(err)
+	}
+	name := e.applicationGroupName(applicationName)
+	g, err := e.groupByName(ctx, name)
+	if err == nil {
+		return g, nil
+	} else if !isNotFoundError(err) {
+		return zeroGroup, errors.Trace(err)
+	}
+	controllerUUID, err := e.modelControllerUUID(ctx)
+	if err != nil {
+		return zeroGroup, errors.Trace(err)
+	}
+
+	e.ensureGroupMutex.Lock()
+	defer e.ensureGroupMutex.Unlock()
+	chosenVPCID := e.ecfg().vpcID()
+	if !isVPCIDSet(chosenVPCID) {
+		chosenVPCID = ""
+	}
+	resp, err := e.ec2.CreateSecurityGroup(chosenVPCID, name, "juju application group")
+	if ec2ErrCode(err) == "InvalidGroup.Duplicate" {
+		return e.groupByName(ctx, name)
+	} else if err != nil {
+		return zeroGroup, errors.Annotatef(maybeConvertCredentialError(err, ctx), "creating security group %q", name)
+	}
+	g = resp.SecurityGroup
+	cfg := e.Config()
+	tags := tags.ResourceTags(
+		names.NewModelTag(cfg.UUID()),
+		names.NewControllerTag(controllerUUID),
+		cfg,
+	)
+	if err := tagResources(e.ec2, ctx, tags, g.Id); err != nil {
+		return g, errors.Annotate(err, "tagging security group")
+	}
+	logger.Debugf("created security group %q with ID %q", name, g.Id)
+	return g, nil
+}
+
+// modelControllerUUID returns the UUID of the controller managing the
+// model, as recorded in the tags of the model's instances. Application
+// groups are only needed once the model has instances.
+func (e *environ) modelControllerUUID(ctx context.ProviderCallContext) (string, error) {
+	filter := ec2.NewFilter()
+	filter.Add("instance-state-name", aliveInstanceStates...)
+	e.addModelFilter(filter)
+	resp, err := e.ec2.Instances(nil, filter)
+	if err != nil {
+		return "", errors.Annotate(maybeConvertCredentialError(err, ctx), "listing instances")
+	}
+	for _, r := range resp.Reservations {
+		for _, inst := range r.Instances {
+			for _, tag := range inst.Tags {
+				if tag.Key == tags.JujuController && tag.Value != "" {
+					return tag.Value, nil
+				}
+			}
+		}
+	}
+	return "", errors.NotFoundf("controller of model %q", e.uuid())
+}
+
+// releaseApplicationGroups revokes the permissions that the application
+// security groups matching the filter grant to other groups, so that
+// none of them is still referenced when they are deleted, and returns
+// the groups.
+func (e *environ) releaseApplicationGroups(ctx context.ProviderCallContext, filter *ec2.Filter) ([]ec2.SecurityGroup, error) {
+	resp, err := e.ec2.SecurityGroups(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing security groups")
+	}
+	var groups []ec2.SecurityGroup
+	for _, info := range resp.Groups {
+		if !isApplicationGroupName(info.Name) {
+			continue
+		}
+		groups = append(groups, info.SecurityGroup)
+		var perms []ec2.IPPerm
+		for _, p := range info.IPPerms {
+			if len(p.SourceGroups) > 0 {
+				perms = append(perms, ec2.IPPerm{
+					Protocol:     p.Protocol,
+					FromPort:     p.FromPort,
+					ToPort:       p.ToPort,
+					SourceGroups: p.SourceGroups,
+				})
+			}
+		}
+		if len(perms) == 0 {
+			continue
+		}
+		if _, err := e.ec2.RevokeSecurityGroup(info.SecurityGroup, perms); err != nil && !isNotFoundError(err) {
+			return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "revoking security group %q", info.Id)
+		}
+	}
+	return groups, nil
+}
+
+// modifyInstanceGroups replaces the security groups of the instance in
+// a VPC with the given groups. The amz.v3 client predates the
+// ModifyInstanceAttribute call, so the client's CreateTags request,
+// which has the same simple response, is turned into one just before
+// it is signed; the request is still sent, and any error decoded, by
+// the client.
+func modifyInstanceGroups(client *ec2.EC2, instId string, groupIds []string) error {
+	sign := client.Sign
+	modify := *client
+	modify.Sign = func(req *http.Request, auth aws.Auth) error {
+		query := req.URL.Query()
+		for key := range query {
+			if strings.HasPrefix(key, "ResourceId.") || strings.HasPrefix(key, "Tag.") {
+				query.Del(key)
+			}
+		}
+		query.Set("Action", "ModifyInstanceAttribute")
+		query.Set("Version", extendedAPIVersion)
+		query.Set("InstanceId", instId)
+		for i, id := range groupIds {
+			query.Set(fmt.Sprintf("GroupId.%d", i+1), id)
+		}
+		req.URL.RawQuery = query.Encode()
+		return sign(req, auth)
+	}
+	_, err := modify.CreateTags([]string{instId}, nil)
+	return err
+}
@@ -0,0 +1,299 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// maxLoadBalancerPorts is the largest port range that may be load
+// balanced. Each port needs its own listener and target group, and
+// load balancers have at most 50 listeners.
+const maxLoadBalancerPorts = 10
+
+var _ environs.LoadBalancer = (*environ)(nil)
+
+// loadBalancerName returns the name of the network load balancer for
+// the given endpoint of the named application. Load balancer names are
+// limited to 32 characters, so a hash of the model, application and
+// endpoint is used; the load balancers of a model are found by their
+// tags instead.
+func (e *environ) loadBalancerName(applicationName string, endpoint network.PortRange) string {
+	hash := sha256.Sum256([]byte(e.uuid() + "/" + applicationName + "/" + endpoint.String()))
+	return "juju-" + hex.EncodeToString(hash[:])[:16]
+}
+
+// targetGroupName returns the name of the target group to which the
+// named load balancer forwards the port.
+func targetGroupName(loadBalancerName string, port int) string {
+	return fmt.Sprintf("%s-%d", loadBalancerName, port)
+}
+
+// EnsureLoadBalancer implements environs.LoadBalancer. The load
+// balancer is an internet-facing network load balancer in the subnets
+// of the instances, with a listener and target group for each port.
+func (e *environ) EnsureLoadBalancer(
+	ctx context.ProviderCallContext,
+	applicationName string,
+	endpoint network.PortRange,
+	ids []instance.Id,
+) ([]network.Address, error) {
+	if endpoint.Protocol != "tcp" && endpoint.Protocol != "udp" {
+		return nil, errors.NotSupportedf("load balancing %s", endpoint.Protocol)
+	}
+	if n := endpoint.ToPort - endpoint.FromPort + 1; n > maxLoadBalancerPorts {
+		return nil, errors.NotSupportedf("load balancing %d ports (maximum %d)", n, maxLoadBalancerPorts)
+	}
+	vpcId, subnetIds, err := e.loadBalancerSubnets(ctx, ids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerUUID, err := e.modelControllerUUID(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg := e.Config()
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(cfg.UUID()),
+		names.NewControllerTag(controllerUUID),
+		cfg,
+	)
+
+	elb := newELBv2Client(e.ec2)
+	name := e.loadBalancerName(applicationName, endpoint)
+	lb, err := ensureNetworkLoadBalancer(elb, name, subnetIds, resourceTags)
+	if err != nil {
+		return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "creating load balancer %q", name)
+	}
+	listeners, err := elb.listeners(lb.LoadBalancerArn)
+	if err != nil {
+		return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "listing listeners of load balancer %q", name)
+	}
+	listening := set.NewInts()
+	for _, listener := range listeners {
+		listening.Add(listener.Port)
+	}
+	protocol := strings.ToUpper(endpoint.Protocol)
+	for port := endpoint.FromPort; port <= endpoint.ToPort; port++ {
+		group, err := ensureTargetGroup(elb, targetGroupName(name, port), protocol, port, vpcId, resourceTags)
+		if err != nil {
+			return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "creating target group for port %d", port)
+		}
+		if err := setTargets(elb, group.TargetGroupArn, ids); err != nil {
+			return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "registering targets for port %d", port)
+		}
+		if listening.Contains(port) {
+			continue
+		}
+		if err := elb.createListener(lb.LoadBalancerArn, protocol, port, group.TargetGroupArn); err != nil {
+			return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "creating listener for port %d", port)
+		}
+	}
+	return []network.Address{network.NewScopedAddress(lb.DNSName, network.ScopePublic)}, nil
+}
+
+// loadBalancerSubnets returns the VPC of the instances, and a subnet of
+// the instances in each of their availability zones. A network load
+// balancer only forwards to zones in which it has a subnet, and its
+// subnets cannot be changed once it is created.
+func (e *environ) loadBalancerSubnets(ctx context.ProviderCallContext, ids []instance.Id) (string, []string, error) {
+	if len(ids) == 0 {
+		return "", nil, errors.NotValidf("load balancer without instances")
+	}
+	instIds := make([]string, len(ids))
+	for i, id := range ids {
+		instIds[i] = string(id)
+	}
+	resp, err := e.ec2.Instances(instIds, nil)
+	if err != nil {
+		return "", nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing instances")
+	}
+	vpcId := ""
+	subnetsByZone := make(map[string]string)
+	found := set.NewStrings()
+	for _, r := range resp.Reservations {
+		for _, inst := range r.Instances {
+			found.Add(inst.InstanceId)
+			if inst.VPCId == "" || inst.SubnetId == "" {
+				return "", nil, errors.NotSupportedf("load balancing instance %q outside a VPC", inst.InstanceId)
+			}
+			if vpcId == "" {
+				vpcId = inst.VPCId
+			} else if inst.VPCId != vpcId {
+				return "", nil, errors.NotSupportedf("load balancing instances in VPCs %q and %q", vpcId, inst.VPCId)
+			}
+			if _, ok := subnetsByZone[inst.AvailZone]; !ok {
+				subnetsByZone[inst.AvailZone] = inst.SubnetId
+			}
+		}
+	}
+	for _, id := range instIds {
+		if !found.Contains(id) {
+			return "", nil, errors.NotFoundf("instance %q", id)
+		}
+	}
+	subnetIds := set.NewStrings()
+	for _, subnetId := range subnetsByZone {
+		subnetIds.Add(subnetId)
+	}
+	return vpcId, subnetIds.SortedValues(), nil
+}
+
+func ensureNetworkLoadBalancer(elb *elbv2Client, name string, subnetIds []string, tags map[string]string) (elbLoadBalancer, error) {
+	lbs, err := elb.loadBalancers(name)
+	if err == nil && len(lbs) == 1 {
+		return lbs[0], nil
+	} else if err != nil && ec2ErrCode(err) != "LoadBalancerNotFound" {
+		return elbLoadBalancer{}, err
+	}
+	return elb.createLoadBalancer(name, subnetIds, tags)
+}
+
+func ensureTargetGroup(elb *elbv2Client, name, protocol string, port int, vpcId string, tags map[string]string) (elbTargetGroup, error) {
+	groups, err := elb.targetGroups(name)
+	if err == nil && len(groups) == 1 {
+		return groups[0], nil
+	} else if err != nil && ec2ErrCode(err) != "TargetGroupNotFound" {
+		return elbTargetGroup{}, err
+	}
+	group, err := elb.createTargetGroup(name, protocol, port, vpcId)
+	if err != nil {
+		return elbTargetGroup{}, err
+	}
+	return group, elb.addTags(group.TargetGroupArn, tags)
+}
+
+// setTargets registers exactly the given instances with the target
+// group.
+func setTargets(elb *elbv2Client, targetGroupArn string, ids []instance.Id) error {
+	have, err := elb.targets(targetGroupArn)
+	if err != nil {
+		return err
+	}
+	haveSet := set.NewStrings(have...)
+	want := set.NewStrings()
+	for _, id := range ids {
+		want.Add(string(id))
+	}
+	if toAdd := want.Difference(haveSet); !toAdd.IsEmpty() {
+		if err := elb.registerTargets(targetGroupArn, toAdd.SortedValues()); err != nil {
+			return err
+		}
+	}
+	if toRemove := haveSet.Difference(want); !toRemove.IsEmpty() {
+		if err := elb.deregisterTargets(targetGroupArn, toRemove.SortedValues()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveLoadBalancer implements environs.LoadBalancer.
+func (e *environ) RemoveLoadBalancer(ctx context.ProviderCallContext, applicationName string, endpoint network.PortRange) error {
+	elb := newELBv2Client(e.ec2)
+	name := e.loadBalancerName(applicationName, endpoint)
+	lbs, err := elb.loadBalancers(name)
+	if err != nil && ec2ErrCode(err) != "LoadBalancerNotFound" {
+		return errors.Annotatef(maybeConvertCredentialError(err, ctx), "finding load balancer %q", name)
+	}
+	for _, lb := range lbs {
+		if err := elb.deleteLoadBalancer(lb.LoadBalancerArn); err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "deleting load balancer %q", name)
+		}
+	}
+	var groupNames []string
+	for port := endpoint.FromPort; port <= endpoint.ToPort; port++ {
+		groupNames = append(groupNames, targetGroupName(name, port))
+	}
+	// Target groups are described one at a time, as describing
+	// several fails if any of them does not exist.
+	for _, groupName := range groupNames {
+		groups, err := elb.targetGroups(groupName)
+		if ec2ErrCode(err) == "TargetGroupNotFound" {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "finding target group %q", groupName)
+		}
+		for _, group := range groups {
+			if err := deleteTargetGroup(elb, group.TargetGroupArn); err != nil {
+				return errors.Annotatef(maybeConvertCredentialError(err, ctx), "deleting target group %q", groupName)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteTargetGroup deletes the target group, retrying while it is
+// still in use by the listeners of a load balancer being deleted.
+func deleteTargetGroup(elb *elbv2Client, arn string) error {
+	var err error
+	for a := shortAttempt.Start(); a.Next(); {
+		err = elb.deleteTargetGroup(arn)
+		if ec2ErrCode(err) != "ResourceInUse" {
+			break
+		}
+	}
+	return err
+}
+
+// removeLoadBalancers deletes the load balancers and target groups
+// tagged with the given value of the tag.
+func (e *environ) removeLoadBalancers(ctx context.ProviderCallContext, tagKey, tagValue string) error {
+	elb := newELBv2Client(e.ec2)
+	lbs, err := elb.loadBalancers()
+	if err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "listing load balancers")
+	}
+	arns := make([]string, len(lbs))
+	for i, lb := range lbs {
+		arns[i] = lb.LoadBalancerArn
+	}
+	lbTags, err := elb.tags(arns)
+	if err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "listing load balancer tags")
+	}
+	for _, lb := range lbs {
+		if lbTags[lb.LoadBalancerArn][tagKey] != tagValue {
+			continue
+		}
+		if err := elb.deleteLoadBalancer(lb.LoadBalancerArn); err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "deleting load balancer %q", lb.LoadBalancerName)
+		}
+	}
+
+	groups, err := elb.targetGroups()
+	if err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "listing target groups")
+	}
+	arns = make([]string, len(groups))
+	for i, group := range groups {
+		arns[i] = group.TargetGroupArn
+	}
+	groupTags, err := elb.tags(arns)
+	if err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "listing target group tags")
+	}
+	for _, group := range groups {
+		if groupTags[group.TargetGroupArn][tagKey] != tagValue {
+			continue
+		}
+		if err := deleteTargetGroup(elb, group.TargetGroupArn); err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "deleting target group %q", group.TargetGroupName)
+		}
+	}
+	return nil
+}
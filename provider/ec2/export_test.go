@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/environs/imagemetadata"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	jujustorage "github.com/juju/juju/storage"
 )
 
@@ -50,6 +51,19 @@ func AllModelVolumes(e environs.Environ, ctx context.ProviderCallContext) ([]str
 	return e.(*environ).allModelVolumes(ctx, true)
 }
 
+func LoadBalancerName(e environs.Environ, applicationName string, endpoint network.PortRange) string {
+	return e.(*environ).loadBalancerName(applicationName, endpoint)
+}
+
+// PatchELBv2Endpoint directs Elastic Load Balancing requests in all
+// regions to the given URL, and returns a function restoring the
+// regional endpoints.
+func PatchELBv2Endpoint(url string) func() {
+	endpoint := elbv2Endpoint
+	elbv2Endpoint = func(string) string { return url }
+	return func() { elbv2Endpoint = endpoint }
+}
+
 func AllModelGroups(e environs.Environ, ctx context.ProviderCallContext) ([]string, error) {
 	return e.(*environ).modelSecurityGroupIDs(ctx)
 }
@@ -59,6 +73,8 @@ var (
 	RunInstances                   = &runInstances
 	BlockDeviceNamer               = blockDeviceNamer
 	GetBlockDeviceMappings         = getBlockDeviceMappings
+	VolumeEncryptionParams         = volumeEncryptionParams
+	ParseVolumeOptions             = parseVolumeOptions
 	IsVPCNotUsableError            = isVPCNotUsableError
 	IsVPCNotRecommendedError       = isVPCNotRecommendedError
 	ShortAttempt                   = &shortAttempt
@@ -4,7 +4,9 @@
 package ec2_test
 
 import (
+	"encoding/xml"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
@@ -131,6 +133,9 @@ type localServer struct {
 	client      *amzec2.EC2
 	region      aws.Region
 
+	elbsrv     *fakeELBv2
+	restoreELB func()
+
 	defaultVPC *amzec2.VPC
 	zones      []amzec2.AvailabilityZoneInfo
 	subnets    []amzec2.Subnet
@@ -182,6 +187,9 @@ func (srv *localServer) startServer(c *gc.C) {
 	defaultVPC, err := srv.ec2srv.AddDefaultVPCAndSubnets()
 	c.Assert(err, jc.ErrorIsNil)
 	srv.defaultVPC = &defaultVPC
+
+	srv.elbsrv = newFakeELBv2()
+	srv.restoreELB = ec2.PatchELBv2Endpoint(srv.elbsrv.server.URL)
 }
 
 // addSpice adds some "spice" to the local server
@@ -198,6 +206,8 @@ func (srv *localServer) addSpice(c *gc.C) {
 }
 
 func (srv *localServer) stopServer(c *gc.C) {
+	srv.restoreELB()
+	srv.elbsrv.close()
 	srv.proxyServer.Close()
 	srv.ec2srv.Reset(false)
 	srv.ec2srv.Quit()
@@ -718,6 +728,162 @@ func (t *localServerSuite) TestDestroyControllerDestroysHostedModelResources(c *
 	assertGroups("default")
 }
 
+func (t *localServerSuite) applicationModeEnviron(c *gc.C) (environs.Environ, instance.Instance) {
+	controllerEnv := t.prepareAndBootstrap(c)
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Running)
+	cfg, err := controllerEnv.Config().Apply(map[string]interface{}{
+		"uuid":          "7e386e08-cba7-44a4-a76e-7c1633584210",
+		"firewall-mode": "application",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := environs.New(environs.OpenParams{
+		Cloud:  t.CloudSpec(),
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "0")
+	return env, inst
+}
+
+func (t *localServerSuite) TestApplicationModeStartInstanceGroups(c *gc.C) {
+	env, inst := t.applicationModeEnviron(c)
+	groups := ec2.InstanceEC2(inst).SecurityGroups
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(groups[0].Name, gc.Equals, "juju-"+env.Config().UUID())
+}
+
+func (t *localServerSuite) TestApplicationPorts(c *gc.C) {
+	env, _ := t.applicationModeEnviron(c)
+	fw := env.(environs.ApplicationFirewaller)
+
+	rules, err := fw.ApplicationIngressRules(t.callCtx, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = fw.OpenApplicationPorts(t.callCtx, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/24"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fw.SetApplicationSources(t.callCtx, "wordpress", []network.IngressRule{
+		network.NewApplicationIngressRule("tcp", 8080, 8080, "haproxy"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Rules sourced from other applications' groups are not reported.
+	rules, err = fw.ApplicationIngressRules(t.callCtx, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/24"),
+	})
+	groups := t.modelGroups(c, env)
+	c.Assert(groups, jc.SameContents, []string{
+		"juju-" + env.Config().UUID(),
+		"juju-" + env.Config().UUID() + "-app-haproxy",
+		"juju-" + env.Config().UUID() + "-app-wordpress",
+	})
+
+	err = fw.CloseApplicationPorts(t.callCtx, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.ApplicationIngressRules(t.callCtx, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/24"),
+	})
+
+	// Closing ports of an application without a group does nothing.
+	err = fw.CloseApplicationPorts(t.callCtx, "mysql", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The application groups refer to each other, and are all
+	// deleted along with the model.
+	err = env.Destroy(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.modelGroups(c, env), gc.HasLen, 0)
+}
+
+func (t *localServerSuite) TestSetApplicationSourcesRequiresSources(c *gc.C) {
+	env, _ := t.applicationModeEnviron(c)
+	err := env.(environs.ApplicationFirewaller).SetApplicationSources(t.callCtx, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, gc.ErrorMatches, `rule 80/tcp without source applications not valid`)
+}
+
+func (t *localServerSuite) TestSetInstanceApplications(c *gc.C) {
+	env, inst := t.applicationModeEnviron(c)
+	var modified []url.Values
+	t.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		query := resp.Request.URL.Query()
+		if query.Get("Action") != "ModifyInstanceAttribute" {
+			return nil
+		}
+		modified = append(modified, query)
+		resp.StatusCode = http.StatusOK
+		return replaceResponseBody(resp, struct {
+			XMLName xml.Name `xml:"ModifyInstanceAttributeResponse"`
+			Return  bool     `xml:"return"`
+		}{Return: true})
+	}
+
+	fw := env.(environs.ApplicationFirewaller)
+	err := fw.SetInstanceApplications(t.callCtx, inst.Id(), []string{"wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modified, gc.HasLen, 1)
+
+	jujuGroupName := "juju-" + env.Config().UUID()
+	groupIds := make(map[string]string)
+	groupsResp, err := t.client.SecurityGroups(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, g := range groupsResp.Groups {
+		groupIds[g.Name] = g.Id
+	}
+	c.Assert(modified[0].Get("Version"), gc.Equals, "2016-11-15")
+	c.Assert(modified[0].Get("InstanceId"), gc.Equals, string(inst.Id()))
+	c.Assert(modified[0].Get("GroupId.1"), gc.Equals, groupIds[jujuGroupName])
+	c.Assert(modified[0].Get("GroupId.2"), gc.Equals, groupIds[jujuGroupName+"-app-wordpress"])
+	c.Assert(modified[0].Get("GroupId.3"), gc.Equals, "")
+}
+
+func (t *localServerSuite) TestSetInstanceApplicationsError(c *gc.C) {
+	env, inst := t.applicationModeEnviron(c)
+	t.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		if resp.Request.URL.Query().Get("Action") != "ModifyInstanceAttribute" {
+			return nil
+		}
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]amzec2.Error{{
+			Code:    "InvalidGroup.NotFound",
+			Message: "no such group",
+		}}})
+	}
+	err := env.(environs.ApplicationFirewaller).SetInstanceApplications(t.callCtx, inst.Id(), []string{"wordpress"})
+	c.Assert(err, gc.ErrorMatches, `setting security groups of instance ".*": no such group \(InvalidGroup.NotFound\)`)
+}
+
+func (t *localServerSuite) TestApplicationFirewallerRequiresApplicationMode(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	err := env.(environs.ApplicationFirewaller).OpenApplicationPorts(t.callCtx, "wordpress", nil)
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for application security groups`)
+}
+
+func (t *localServerSuite) modelGroups(c *gc.C, env environs.Environ) []string {
+	groupsResp, err := t.client.SecurityGroups(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, g := range groupsResp.Groups {
+		if strings.HasPrefix(g.Name, "juju-"+env.Config().UUID()) {
+			names = append(names, g.Name)
+		}
+	}
+	return names
+}
+
 func (t *localServerSuite) TestInstanceStatus(c *gc.C) {
 	env := t.Prepare(c)
 	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env,
@@ -1306,6 +1472,85 @@ func (t *localServerSuite) TestStartInstanceAvailZoneOneNoDefaultSubnetErr(c *gc
 	t.testStartInstanceAvailZoneOneConstrained(c, azNoDefaultSubnetErr)
 }
 
+func (t *localServerSuite) TestStartInstanceSpot(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	var query url.Values
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		// Sign a request to see the parameters the client adds.
+		req, err := http.NewRequest("GET", e.Region.EC2Endpoint+"?Action=RunInstances", nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := e.Sign(req, e.Auth); err != nil {
+			return nil, err
+		}
+		query = req.URL.Query()
+		return realRunInstances(e, ctx, ri, fakeCallback)
+	})
+
+	cons := constraints.MustParse("instance-lifecycle=spot max-price=0.25")
+	_, _, _, err := testing.StartInstanceWithConstraints(env, t.callCtx, t.ControllerUUID, "1", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(query.Get("Action"), gc.Equals, "RunInstances")
+	c.Check(query.Get("Version"), gc.Equals, "2016-11-15")
+	c.Check(query.Get("InstanceMarketOptions.MarketType"), gc.Equals, "spot")
+	c.Check(query.Get("InstanceMarketOptions.SpotOptions.SpotInstanceType"), gc.Equals, "one-time")
+	c.Check(query.Get("InstanceMarketOptions.SpotOptions.InstanceInterruptionBehavior"), gc.Equals, "terminate")
+	c.Check(query.Get("InstanceMarketOptions.SpotOptions.MaxPrice"), gc.Equals, "0.25")
+}
+
+func (t *localServerSuite) TestStartInstanceRootDiskEncryption(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	cfg, err := env.Config().Apply(map[string]interface{}{
+		"root-disk-encryption": true,
+		"root-disk-kms-key":    "alias/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var query url.Values
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		req, err := http.NewRequest("GET", e.Region.EC2Endpoint+"?Action=RunInstances", nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := e.Sign(req, e.Auth); err != nil {
+			return nil, err
+		}
+		query = req.URL.Query()
+		return realRunInstances(e, ctx, ri, fakeCallback)
+	})
+
+	_, _, _, err = testing.StartInstance(env, t.callCtx, t.ControllerUUID, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(query.Get("BlockDeviceMapping.1.Ebs.Encrypted"), gc.Equals, "true")
+	c.Check(query.Get("BlockDeviceMapping.1.Ebs.KmsKeyId"), gc.Equals, "alias/juju")
+}
+
+func (t *localServerSuite) TestStartInstanceOnDemand(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	var sign aws.Signer
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		sign = e.Sign
+		return realRunInstances(e, ctx, ri, fakeCallback)
+	})
+
+	cons := constraints.MustParse("instance-lifecycle=on-demand")
+	_, _, _, err := testing.StartInstanceWithConstraints(env, t.callCtx, t.ControllerUUID, "1", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	req, err := http.NewRequest("GET", t.srv.region.EC2Endpoint+"?Action=RunInstances", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sign(req, aws.Auth{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(req.URL.Query().Get("InstanceMarketOptions.MarketType"), gc.Equals, "")
+}
+
 func (t *localServerSuite) testStartInstanceAvailZoneOneConstrained(c *gc.C, runInstancesError *amzec2.Error) {
 	env := t.prepareAndBootstrap(c)
 
@@ -1371,7 +1616,7 @@ func (t *localServerSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	env := t.Prepare(c)
 	validator, err := env.ConstraintsValidator(t.callCtx)
 	c.Assert(err, jc.ErrorIsNil)
-	cons := constraints.MustParse("arch=amd64 tags=foo virt-type=kvm")
+	cons := constraints.MustParse("arch=amd64 tags=foo virt-type=kvm instance-lifecycle=spot max-price=0.1")
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type"})
@@ -1384,6 +1629,18 @@ func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	cons := constraints.MustParse("instance-type=foo")
 	_, err = validator.Validate(cons)
 	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-type=foo\nvalid values are:.*")
+
+	cons = constraints.MustParse("zones=test-available,foo")
+	_, err = validator.Validate(cons)
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=foo\nvalid values are:.*")
+
+	cons = constraints.MustParse("zones=test-available")
+	_, err = validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons = constraints.MustParse("instance-lifecycle=preemptible")
+	_, err = validator.Validate(cons)
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-lifecycle=preemptible\nvalid values are:.*")
 }
 
 func (t *localServerSuite) TestConstraintsValidatorVocabNoDefaultOrSpecifiedVPC(c *gc.C) {
@@ -1467,6 +1724,16 @@ func (t *localServerSuite) TestPrecheckInstanceUnsupportedArch(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid AWS instance type "cc1.4xlarge" and arch "i386" specified`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceMaxPriceWithoutSpot(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("max-price=0.1")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      supportedversion.SupportedLTS(),
+		Constraints: cons,
+	})
+	c.Assert(err, gc.ErrorMatches, "max-price without instance-lifecycle=spot not valid")
+}
+
 func (t *localServerSuite) TestPrecheckInstanceAvailZone(c *gc.C) {
 	env := t.Prepare(c)
 	placement := "zone=test-available"
@@ -1796,6 +2063,47 @@ func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestTagInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	instances, err := env.AllInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+
+	err = env.(environs.InstanceTagger).TagInstance(t.callCtx, instances[0].Id(), map[string]string{
+		"team": "dba",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	instances, err = env.AllInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	ec2Inst := ec2.InstanceEC2(instances[0])
+	c.Assert(ec2Inst.Tags, jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"juju-is-controller", "true"},
+		{"team", "dba"},
+	})
+}
+
+func (t *localServerSuite) TestTagModelResources(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	err := env.(environs.ModelResourceTagger).TagModelResources(t.callCtx, map[string]string{
+		"team": "ops",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ec2conn := ec2.EnvironEC2(env)
+	all, err := ec2conn.SecurityGroups(nil, makeFilter("tag:juju-model-uuid", coretesting.ModelTag.Id()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all.Groups, gc.Not(gc.HasLen), 0)
+	tagged, err := ec2conn.SecurityGroups(nil, makeFilter("tag:team", "ops"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tagged.Groups, gc.HasLen, len(all.Groups))
+}
+
 func (s *localServerSuite) TestBootstrapInstanceConstraints(c *gc.C) {
 	env := s.prepareAndBootstrap(c)
 	inst, err := env.AllInstances(s.callCtx)
@@ -154,6 +154,13 @@ func (environProvider) Validate(cfg, old *config.Config) (valid *config.Config,
 	return newEcfg.Apply(newEcfg.attrs)
 }
 
+// ValidateRootDiskEncryption implements environs.RootDiskEncrypter.
+// Root EBS volumes are encrypted with the model's root-disk-kms-key, or
+// the account's default EBS key.
+func (environProvider) ValidateRootDiskEncryption(cfg *config.Config) error {
+	return nil
+}
+
 // MetadataLookupParams returns parameters which are used to query image metadata to
 // find matching image information.
 func (p environProvider) MetadataLookupParams(region string) (*simplestreams.MetadataLookupParams, error) {
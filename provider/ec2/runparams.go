@@ -0,0 +1,91 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"net/http"
+
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+)
+
+// extendedAPIVersion is the EC2 API version used for requests carrying
+// parameters which the amz.v3 client predates. It is the first version
+// supporting instance market options.
+const extendedAPIVersion = "2016-11-15"
+
+// withExtraParams returns a copy of the given EC2 client whose requests
+// carry the given query parameters, in addition to those set by the
+// client itself, and use extendedAPIVersion. The parameters are added
+// to each request just before it is signed.
+func withExtraParams(client *ec2.EC2, params map[string]string) *ec2.EC2 {
+	if len(params) == 0 {
+		return client
+	}
+	sign := client.Sign
+	extended := *client
+	extended.Sign = func(req *http.Request, auth aws.Auth) error {
+		query := req.URL.Query()
+		for name, value := range params {
+			query.Set(name, value)
+		}
+		query.Set("Version", extendedAPIVersion)
+		req.URL.RawQuery = query.Encode()
+		return sign(req, auth)
+	}
+	return &extended
+}
+
+// spotInstanceParams returns the RunInstances parameters requesting a
+// one-time spot instance if the constraints ask for one, or nil. EC2
+// terminates such instances when it reclaims the capacity. If the
+// constraints specify a maximum price it is passed on, otherwise EC2
+// caps the price at the on-demand price.
+func spotInstanceParams(cons constraints.Value) map[string]string {
+	if !cons.HasInstanceLifecycle() || *cons.InstanceLifecycle != constraints.Spot {
+		return nil
+	}
+	params := map[string]string{
+		"InstanceMarketOptions.MarketType":                               "spot",
+		"InstanceMarketOptions.SpotOptions.SpotInstanceType":             "one-time",
+		"InstanceMarketOptions.SpotOptions.InstanceInterruptionBehavior": "terminate",
+	}
+	if cons.HasMaxPrice() {
+		params["InstanceMarketOptions.SpotOptions.MaxPrice"] = *cons.MaxPrice
+	}
+	return params
+}
+
+// rootDiskEncryptionParams returns the RunInstances parameters
+// encrypting the root disk, which is the first block device mapping,
+// if the model config asks for it, or nil.
+func rootDiskEncryptionParams(cfg *config.Config) map[string]string {
+	if !cfg.RootDiskEncryption() {
+		return nil
+	}
+	params := map[string]string{
+		"BlockDeviceMapping.1.Ebs.Encrypted": "true",
+	}
+	if kmsKey := cfg.RootDiskKMSKey(); kmsKey != "" {
+		params["BlockDeviceMapping.1.Ebs.KmsKeyId"] = kmsKey
+	}
+	return params
+}
+
+// mergeParams returns the union of the given parameters.
+func mergeParams(all ...map[string]string) map[string]string {
+	var merged map[string]string
+	for _, params := range all {
+		for name, value := range params {
+			if merged == nil {
+				merged = make(map[string]string)
+			}
+			merged[name] = value
+		}
+	}
+	return merged
+}
@@ -5,6 +5,7 @@ package gce
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 
@@ -22,6 +23,9 @@ const (
 	storageProviderType = storage.ProviderType("gce")
 )
 
+// kmsKeyNameRegexp matches the resource names of Cloud KMS keys.
+var kmsKeyNameRegexp = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
 // StorageProviderTypes implements storage.ProviderRegistry.
 func (env *environ) StorageProviderTypes() ([]storage.ProviderType, error) {
 	return []storage.ProviderType{storageProviderType}, nil
@@ -39,12 +43,31 @@ type storageProvider struct {
 	env *environ
 }
 
-var _ storage.Provider = (*storageProvider)(nil)
+var _ storage.EncryptingProvider = (*storageProvider)(nil)
 
 func (g *storageProvider) ValidateConfig(cfg *storage.Config) error {
 	return nil
 }
 
+// ValidateEncryption is defined on the EncryptingProvider interface.
+// GCE encrypts all persistent disks at rest, with Google-managed keys
+// unless a Cloud KMS key is given.
+func (g *storageProvider) ValidateEncryption(encrypted bool, kmsKey string) error {
+	return errors.Annotate(validateKMSKeyName(kmsKey), storage.ConfigKMSKey)
+}
+
+// validateKMSKeyName returns an error if the key is neither empty nor
+// the resource name of a Cloud KMS key.
+func validateKMSKeyName(kmsKey string) error {
+	if kmsKey == "" || kmsKeyNameRegexp.MatchString(kmsKey) {
+		return nil
+	}
+	return errors.NotValidf(
+		"Cloud KMS key %q (expected projects/PROJECT/locations/LOCATION/keyRings/RING/cryptoKeys/KEY)",
+		kmsKey,
+	)
+}
+
 func (g *storageProvider) Supports(k storage.StorageKind) bool {
 	return k == storage.StorageKindBlock
 }
@@ -206,11 +229,13 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 	}
 	// TODO(perrito666) the volumeName is arbitrary and it was crafted this
 	// way to help solve the need to have zone all over the place.
+	kmsKey, _ := p.Attributes[storage.ConfigKMSKey].(string)
 	disk := google.DiskSpec{
 		SizeHintGB:         mibToGib(p.Size),
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Labels:             resourceTagsToDiskLabels(p.ResourceTags),
+		KMSKeyName:         kmsKey,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
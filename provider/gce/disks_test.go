@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
 )
 
 type storageProviderSuite struct {
@@ -38,6 +39,33 @@ func (s *storageProviderSuite) TestValidateConfig(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *storageProviderSuite) TestValidateEncryption(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "gce", map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(s.provider, cfg)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *storageProviderSuite) TestValidateEncryptionKMSKey(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "gce", map[string]interface{}{
+		"kms-key": "projects/spam/locations/global/keyRings/ham/cryptoKeys/eggs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(s.provider, cfg)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *storageProviderSuite) TestValidateEncryptionKMSKeyInvalid(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "gce", map[string]interface{}{
+		"kms-key": "eggs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(s.provider, cfg)
+	c.Check(err, gc.ErrorMatches, `kms-key: Cloud KMS key "eggs" \(expected .*\) not valid`)
+}
+
 func (s *storageProviderSuite) TestBlockStorageSupport(c *gc.C) {
 	supports := s.provider.Supports(storage.StorageKindBlock)
 	c.Check(supports, jc.IsTrue)
@@ -166,6 +194,27 @@ func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 }
 
+func (s *volumeSourceSuite) TestCreateVolumesKMSKey(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: s.BaseDisk.Name,
+		DeviceName: "home-zone-1234567",
+		Mode:       "READ_WRITE",
+	}
+	kmsKey := "projects/spam/locations/global/keyRings/ham/cryptoKeys/eggs"
+	s.params[0].Attributes = map[string]interface{}{"kms-key": kmsKey}
+	res, err := s.source.CreateVolumes(s.CallCtx, s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+
+	createCalled, call := s.FakeConn.WasCalled("CreateDisks")
+	c.Assert(createCalled, jc.IsTrue)
+	c.Assert(call[0].Disks[0].KMSKeyName, gc.Equals, kmsKey)
+}
+
 func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	errs, err := s.source.DestroyVolumes(s.CallCtx, []string{"a--volume-name"})
 	c.Check(err, jc.ErrorIsNil)
@@ -36,6 +36,15 @@ type gceConnection interface {
 	OpenPorts(fwname string, rules ...network.IngressRule) error
 	ClosePorts(fwname string, rules ...network.IngressRule) error
 
+	// EnsureLoadBalancer ensures that the named load balancer forwards
+	// the port range to exactly the instances, and returns its address.
+	EnsureLoadBalancer(name string, portRange network.PortRange, instances []google.Instance) (string, error)
+	// RemoveLoadBalancer removes the named load balancer, if it exists.
+	RemoveLoadBalancer(name string) error
+	// RemoveLoadBalancers removes all load balancers whose names start
+	// with the prefix.
+	RemoveLoadBalancers(prefix string) error
+
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 	// Subnetworks returns the subnetworks that machines can be
 	// assigned to in the given region.
@@ -240,6 +249,10 @@ func (env *environ) Destroy(ctx context.ProviderCallContext) error {
 		}
 	}
 
+	if err := env.gce.RemoveLoadBalancers(env.loadBalancerNamePrefix()); err != nil {
+		return errors.Annotate(err, "removing load balancers")
+	}
+
 	return destroyEnv(env, ctx)
 }
 
@@ -164,6 +164,9 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 	if err != nil {
 		return nil, common.ZoneIndependentError(err)
 	}
+	// The root disk is always encrypted; root-disk-kms-key chooses
+	// the key instead of a Google-managed one.
+	disks[0].KMSKeyName = env.Config().RootDiskKMSKey()
 
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
@@ -177,6 +180,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		Metadata:          metadata,
 		Tags:              tags,
 		AvailabilityZone:  args.AvailabilityZone,
+		Preemptible:       isPreemptible(args.Constraints),
 		// Network is omitted (left empty).
 	})
 	if err != nil {
@@ -188,6 +192,12 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 	return inst, nil
 }
 
+// isPreemptible reports whether the constraints request a preemptible
+// instance.
+func isPreemptible(cons constraints.Value) bool {
+	return cons.HasInstanceLifecycle() && *cons.InstanceLifecycle == constraints.Preemptible
+}
+
 // getMetadata builds the raw "user-defined" metadata for the new
 // instance (relative to the provided args) and returns it.
 func getMetadata(args environs.StartInstanceParams, os jujuos.OSType) (map[string]string, error) {
@@ -158,6 +158,21 @@ func (s *environBrokerSuite) TestNewRawInstance(c *gc.C) {
 	c.Check(inst, jc.DeepEquals, s.BaseInstance)
 }
 
+func (s *environBrokerSuite) TestNewRawInstanceRootDiskKMSKey(c *gc.C) {
+	kmsKey := "projects/spam/locations/global/keyRings/ham/cryptoKeys/eggs"
+	s.UpdateConfig(c, map[string]interface{}{
+		"root-disk-encryption": true,
+		"root-disk-kms-key":    kmsKey,
+	})
+	s.FakeConn.Inst = s.BaseInstance
+
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	called, calls := s.FakeConn.WasCalled("AddInstance")
+	c.Assert(called, jc.IsTrue)
+	c.Check(calls[0].InstanceSpec.Disks[0].KMSKeyName, gc.Equals, kmsKey)
+}
+
 func (s *environBrokerSuite) TestNewRawInstanceZoneSpecificError(c *gc.C) {
 	s.FakeConn.Err = errors.New("blargh")
 
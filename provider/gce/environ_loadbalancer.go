@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+var _ environs.LoadBalancer = (*environ)(nil)
+
+// loadBalancerNamePrefix returns the prefix of the names of the
+// model's load balancer resources.
+func (env *environ) loadBalancerNamePrefix() string {
+	return "juju-" + env.uuid + "-lb-"
+}
+
+// loadBalancerName returns the name of the load balancer resources of
+// the given endpoint of the named application. Application names may
+// be too long for GCE resource names, so a hash of the application and
+// endpoint is used after the model's prefix.
+func (env *environ) loadBalancerName(applicationName string, endpoint network.PortRange) string {
+	hash := sha256.Sum256([]byte(applicationName + "/" + endpoint.String()))
+	return env.loadBalancerNamePrefix() + hex.EncodeToString(hash[:])[:16]
+}
+
+// EnsureLoadBalancer implements environs.LoadBalancer. The load
+// balancer is a GCE network load balancer in the model's region.
+func (env *environ) EnsureLoadBalancer(
+	ctx context.ProviderCallContext,
+	applicationName string,
+	endpoint network.PortRange,
+	ids []instance.Id,
+) ([]network.Address, error) {
+	gceInstances, err := env.gceInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byId := make(map[instance.Id]google.Instance)
+	for _, inst := range gceInstances {
+		byId[instance.Id(inst.ID)] = inst
+	}
+	var instances []google.Instance
+	for _, id := range ids {
+		inst, ok := byId[id]
+		if !ok {
+			return nil, errors.NotFoundf("instance %q", id)
+		}
+		instances = append(instances, inst)
+	}
+	address, err := env.gce.EnsureLoadBalancer(env.loadBalancerName(applicationName, endpoint), endpoint, instances)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []network.Address{network.NewScopedAddress(address, network.ScopePublic)}, nil
+}
+
+// RemoveLoadBalancer implements environs.LoadBalancer.
+func (env *environ) RemoveLoadBalancer(ctx context.ProviderCallContext, applicationName string, endpoint network.PortRange) error {
+	err := env.gce.RemoveLoadBalancer(env.loadBalancerName(applicationName, endpoint))
+	return errors.Trace(err)
+}
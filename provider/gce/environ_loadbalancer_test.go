@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+type environLoadBalancerSuite struct {
+	gce.BaseSuite
+}
+
+var _ = gc.Suite(&environLoadBalancerSuite{})
+
+var httpEndpoint = network.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 80}
+
+func (s *environLoadBalancerSuite) TestLoadBalancerName(c *gc.C) {
+	name := gce.LoadBalancerName(s.Env, "wordpress", httpEndpoint)
+	c.Check(name, gc.Matches, "juju-"+s.Env.Config().UUID()+"-lb-[0-9a-f]{16}")
+	c.Check(len(name) <= 63, jc.IsTrue)
+	c.Check(gce.LoadBalancerName(s.Env, "mysql", httpEndpoint), gc.Not(gc.Equals), name)
+	httpsEndpoint := network.PortRange{Protocol: "tcp", FromPort: 443, ToPort: 443}
+	c.Check(gce.LoadBalancerName(s.Env, "wordpress", httpsEndpoint), gc.Not(gc.Equals), name)
+}
+
+func (s *environLoadBalancerSuite) TestEnsureLoadBalancer(c *gc.C) {
+	spam := s.NewBaseInstance(c, "spam")
+	ham := s.NewBaseInstance(c, "ham")
+	s.FakeConn.Insts = []google.Instance{*spam, *ham}
+	s.FakeConn.LoadBalancerAddress = "203.0.113.1"
+
+	addrs, err := s.Env.EnsureLoadBalancer(s.CallCtx, "wordpress", httpEndpoint, []instance.Id{"ham"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("203.0.113.1", network.ScopePublic),
+	})
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Instances")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "EnsureLoadBalancer")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, gce.LoadBalancerName(s.Env, "wordpress", httpEndpoint))
+	c.Check(s.FakeConn.Calls[1].PortRange, jc.DeepEquals, httpEndpoint)
+	c.Check(s.FakeConn.Calls[1].Instances, jc.DeepEquals, []google.Instance{*ham})
+}
+
+func (s *environLoadBalancerSuite) TestEnsureLoadBalancerUnknownInstance(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.NewBaseInstance(c, "spam")}
+
+	_, err := s.Env.EnsureLoadBalancer(s.CallCtx, "wordpress", httpEndpoint, []instance.Id{"ham"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+}
+
+func (s *environLoadBalancerSuite) TestRemoveLoadBalancer(c *gc.C) {
+	err := s.Env.RemoveLoadBalancer(s.CallCtx, "wordpress", httpEndpoint)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveLoadBalancer")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, gce.LoadBalancerName(s.Env, "wordpress", httpEndpoint))
+}
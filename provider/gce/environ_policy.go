@@ -34,6 +34,8 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	// Preemptible instances are charged at a fixed price.
+	constraints.MaxPrice,
 }
 
 // instanceTypeConstraints defines the fields defined on each of the
@@ -72,6 +74,10 @@ func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (const
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
 
 	validator.RegisterVocabulary(constraints.Container, []string{vtype})
+	validator.RegisterVocabulary(constraints.InstanceLifecycle, []string{
+		constraints.OnDemand,
+		constraints.Preemptible,
+	})
 
 	return validator, nil
 }
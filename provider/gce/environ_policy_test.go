@@ -186,6 +186,20 @@ func (s *environPolSuite) TestConstraintsValidatorVocabInstType(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: instance-type=foo\nvalid values are:.*")
 }
 
+func (s *environPolSuite) TestConstraintsValidatorVocabInstanceLifecycle(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("instance-lifecycle=spot")
+	_, err = validator.Validate(cons)
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: instance-lifecycle=spot\nvalid values are:.*")
+
+	cons = constraints.MustParse("instance-lifecycle=preemptible max-price=0.5")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unsupported, jc.SameContents, []string{"max-price"})
+}
+
 func (s *environPolSuite) TestConstraintsValidatorVocabContainer(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
@@ -150,10 +150,12 @@ func (s *environSuite) TestDestroyAPI(c *gc.C) {
 	err := s.Env.Destroy(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	fwname := common.EnvFullName(s.Env.Config().UUID())
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveLoadBalancers")
+	c.Check(s.FakeConn.Calls[1].Prefix, gc.Equals, "juju-"+s.Env.Config().UUID()+"-lb-")
 	s.FakeCommon.CheckCalls(c, []gce.FakeCall{{
 		FuncName: "Destroy",
 		Args: gce.FakeCallArgs{
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce/google"
 )
 
@@ -73,3 +74,7 @@ func GetHardwareCharacteristics(env *environ, spec *instances.InstanceSpec, inst
 func GetInstances(env *environ) ([]instance.Instance, error) {
 	return env.instances()
 }
+
+func LoadBalancerName(env *environ, applicationName string, endpoint network.PortRange) string {
+	return env.loadBalancerName(applicationName, endpoint)
+}
@@ -104,6 +104,66 @@ type rawConnectionWrapper interface {
 
 	// ListNetworks returns a list of Networks available in the given project.
 	ListNetworks(projectID string) ([]*compute.Network, error)
+
+	// ListAddresses returns the static addresses in the given project
+	// and region whose names start with the given prefix.
+	ListAddresses(projectID, region, namePrefix string) ([]*compute.Address, error)
+
+	// GetAddress returns the named static address in the given project
+	// and region. If it does not exist then errors.NotFound is returned.
+	GetAddress(projectID, region, name string) (*compute.Address, error)
+
+	// AddAddress reserves a static address in the given project and
+	// region. The call blocks until the address is reserved or the
+	// request fails.
+	AddAddress(projectID, region string, address *compute.Address) error
+
+	// RemoveAddress releases the named static address. If it does not
+	// exist then errors.NotFound is returned. The call blocks until the
+	// address is released or the request fails.
+	RemoveAddress(projectID, region, name string) error
+
+	// ListTargetPools returns the target pools in the given project
+	// and region whose names start with the given prefix.
+	ListTargetPools(projectID, region, namePrefix string) ([]*compute.TargetPool, error)
+
+	// GetTargetPool returns the named target pool in the given project
+	// and region. If it does not exist then errors.NotFound is returned.
+	GetTargetPool(projectID, region, name string) (*compute.TargetPool, error)
+
+	// AddTargetPool creates a target pool in the given project and
+	// region. The call blocks until the pool is created or the request
+	// fails.
+	AddTargetPool(projectID, region string, pool *compute.TargetPool) error
+
+	// RemoveTargetPool removes the named target pool. If it does not
+	// exist then errors.NotFound is returned. The call blocks until the
+	// pool is removed or the request fails.
+	RemoveTargetPool(projectID, region, name string) error
+
+	// AddTargetPoolInstances adds the instances with the given URLs to
+	// the named target pool. The call blocks until the instances are
+	// added or the request fails.
+	AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error
+
+	// RemoveTargetPoolInstances removes the instances with the given
+	// URLs from the named target pool. The call blocks until the
+	// instances are removed or the request fails.
+	RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error
+
+	// ListForwardingRules returns the forwarding rules in the given
+	// project and region whose names start with the given prefix.
+	ListForwardingRules(projectID, region, namePrefix string) ([]*compute.ForwardingRule, error)
+
+	// AddForwardingRule creates a forwarding rule in the given project
+	// and region. The call blocks until the rule is created or the
+	// request fails.
+	AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error
+
+	// RemoveForwardingRule removes the named forwarding rule. If it does
+	// not exist then errors.NotFound is returned. The call blocks until
+	// the rule is removed or the request fails.
+	RemoveForwardingRule(projectID, region, name string) error
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -104,6 +104,22 @@ func (s *instanceSuite) TestConnectionAddInstanceAPI(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestConnectionAddInstancePreemptible(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.Preemptible = true
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	automaticRestart := false
+	c.Check(s.FakeConn.Calls[0].InstValue.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		Preemptible:       true,
+		AutomaticRestart:  &automaticRestart,
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
 func (s *connSuite) TestConnectionAddInstanceFailed(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
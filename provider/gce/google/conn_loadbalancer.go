@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/juju/juju/network"
+)
+
+// A load balancer is made up of a static address, a target pool of
+// instances and a forwarding rule from the address to the pool for a
+// single port range, all with the same name.
+
+// instanceURL returns the partial URL identifying the instance, which
+// is accepted where the API refers to instances.
+func (gce *Connection) instanceURL(inst Instance) string {
+	return fmt.Sprintf("projects/%s/zones/%s/instances/%s", gce.projectID, inst.ZoneName, inst.ID)
+}
+
+// partialURL strips any scheme, host and API version from the
+// resource URL, so that URLs returned by the API may be compared with
+// the ones we construct.
+func partialURL(url string) string {
+	if i := strings.Index(url, "projects/"); i >= 0 {
+		return url[i:]
+	}
+	return url
+}
+
+// EnsureLoadBalancer ensures that the named load balancer in the
+// connection's region forwards the given port range to exactly the
+// given instances, creating it if necessary, and returns its address.
+func (gce *Connection) EnsureLoadBalancer(name string, portRange network.PortRange, instances []Instance) (string, error) {
+	if portRange.Protocol == "icmp" {
+		return "", errors.NotSupportedf("load balancing icmp")
+	}
+	address, err := gce.ensureLoadBalancerAddress(name)
+	if err != nil {
+		return "", errors.Annotatef(err, "reserving address for load balancer %q", name)
+	}
+	pool, err := gce.ensureTargetPool(name, instances)
+	if err != nil {
+		return "", errors.Annotatef(err, "updating target pool of load balancer %q", name)
+	}
+	if err := gce.ensureForwardingRule(name, address, pool, portRange); err != nil {
+		return "", errors.Annotatef(err, "updating forwarding rule of load balancer %q", name)
+	}
+	return address, nil
+}
+
+func (gce *Connection) ensureLoadBalancerAddress(name string) (string, error) {
+	address, err := gce.raw.GetAddress(gce.projectID, gce.region, name)
+	if errors.IsNotFound(err) {
+		err = gce.raw.AddAddress(gce.projectID, gce.region, &compute.Address{
+			Name:        name,
+			Description: "juju load balancer",
+		})
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		address, err = gce.raw.GetAddress(gce.projectID, gce.region, name)
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return address.Address, nil
+}
+
+func (gce *Connection) ensureTargetPool(name string, instances []Instance) (string, error) {
+	want := set.NewStrings()
+	for _, inst := range instances {
+		want.Add(gce.instanceURL(inst))
+	}
+	pool, err := gce.raw.GetTargetPool(gce.projectID, gce.region, name)
+	if errors.IsNotFound(err) {
+		err = gce.raw.AddTargetPool(gce.projectID, gce.region, &compute.TargetPool{
+			Name:        name,
+			Description: "juju load balancer",
+			Instances:   want.SortedValues(),
+		})
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		pool, err = gce.raw.GetTargetPool(gce.projectID, gce.region, name)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return pool.SelfLink, nil
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Removing instances requires the URLs as the pool has them.
+	have := set.NewStrings()
+	var toRemove []string
+	for _, url := range pool.Instances {
+		have.Add(partialURL(url))
+		if !want.Contains(partialURL(url)) {
+			toRemove = append(toRemove, url)
+		}
+	}
+	if toAdd := want.Difference(have); !toAdd.IsEmpty() {
+		if err := gce.raw.AddTargetPoolInstances(gce.projectID, gce.region, name, toAdd.SortedValues()); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	if len(toRemove) > 0 {
+		if err := gce.raw.RemoveTargetPoolInstances(gce.projectID, gce.region, name, toRemove); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	return pool.SelfLink, nil
+}
+
+func (gce *Connection) ensureForwardingRule(name, address, pool string, portRange network.PortRange) error {
+	rules, err := gce.raw.ListForwardingRules(gce.projectID, gce.region, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rule := range rules {
+		if rule.Name == name {
+			return nil
+		}
+	}
+	err = gce.raw.AddForwardingRule(gce.projectID, gce.region, &compute.ForwardingRule{
+		Name:                name,
+		Description:         "juju load balancer",
+		IPAddress:           address,
+		IPProtocol:          strings.ToUpper(portRange.Protocol),
+		PortRange:           fmt.Sprintf("%d-%d", portRange.FromPort, portRange.ToPort),
+		Target:              pool,
+		LoadBalancingScheme: "EXTERNAL",
+	})
+	return errors.Trace(err)
+}
+
+// RemoveLoadBalancer removes the forwarding rule, target pool and
+// address of the named load balancer. It is not an error if any of
+// them do not exist.
+func (gce *Connection) RemoveLoadBalancer(name string) error {
+	if err := gce.raw.RemoveForwardingRule(gce.projectID, gce.region, name); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "removing forwarding rule of load balancer %q", name)
+	}
+	if err := gce.raw.RemoveTargetPool(gce.projectID, gce.region, name); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "removing target pool of load balancer %q", name)
+	}
+	if err := gce.raw.RemoveAddress(gce.projectID, gce.region, name); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "releasing address of load balancer %q", name)
+	}
+	return nil
+}
+
+// RemoveLoadBalancers removes the forwarding rules, target pools and
+// addresses of all load balancers in the connection's region whose
+// names start with the given prefix.
+func (gce *Connection) RemoveLoadBalancers(prefix string) error {
+	rules, err := gce.raw.ListForwardingRules(gce.projectID, gce.region, prefix)
+	if err != nil {
+		return errors.Annotate(err, "listing load balancer forwarding rules")
+	}
+	for _, rule := range rules {
+		if err := gce.raw.RemoveForwardingRule(gce.projectID, gce.region, rule.Name); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing forwarding rule %q", rule.Name)
+		}
+	}
+	pools, err := gce.raw.ListTargetPools(gce.projectID, gce.region, prefix)
+	if err != nil {
+		return errors.Annotate(err, "listing load balancer target pools")
+	}
+	for _, pool := range pools {
+		if err := gce.raw.RemoveTargetPool(gce.projectID, gce.region, pool.Name); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing target pool %q", pool.Name)
+		}
+	}
+	addresses, err := gce.raw.ListAddresses(gce.projectID, gce.region, prefix)
+	if err != nil {
+		return errors.Annotate(err, "listing load balancer addresses")
+	}
+	for _, address := range addresses {
+		if err := gce.raw.RemoveAddress(gce.projectID, gce.region, address.Name); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "releasing address %q", address.Name)
+		}
+	}
+	return nil
+}
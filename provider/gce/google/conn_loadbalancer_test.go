@@ -0,0 +1,154 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"google.golang.org/api/compute/v1"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+func fakeInstance(id string) google.Instance {
+	return *google.NewInstance(google.InstanceSummary{ID: id, ZoneName: "a-zone"}, nil)
+}
+
+func (s *connSuite) TestConnectionEnsureLoadBalancer(c *gc.C) {
+	s.FakeConn.Address = &compute.Address{Name: "lb", Address: "203.0.113.1"}
+	s.FakeConn.TargetPool = &compute.TargetPool{
+		Name:     "lb",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/spam/regions/a/targetPools/lb",
+		Instances: []string{
+			"https://www.googleapis.com/compute/v1/projects/spam/zones/a-zone/instances/inst-0",
+			"https://www.googleapis.com/compute/v1/projects/spam/zones/a-zone/instances/inst-1",
+		},
+	}
+	// Another load balancer's name starts with this one's.
+	s.FakeConn.ForwardingRules = []*compute.ForwardingRule{{Name: "lb-other"}}
+
+	address, err := s.Conn.EnsureLoadBalancer("lb", network.PortRange{
+		Protocol: "tcp", FromPort: 80, ToPort: 80,
+	}, []google.Instance{fakeInstance("inst-1"), fakeInstance("inst-2")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "203.0.113.1")
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 6)
+	c.Check(calls[0].FuncName, gc.Equals, "GetAddress")
+	c.Check(calls[0].Region, gc.Equals, "a")
+	c.Check(calls[0].Name, gc.Equals, "lb")
+	c.Check(calls[1].FuncName, gc.Equals, "GetTargetPool")
+	c.Check(calls[2].FuncName, gc.Equals, "AddTargetPoolInstances")
+	c.Check(calls[2].InstanceURLs, jc.DeepEquals, []string{
+		"projects/spam/zones/a-zone/instances/inst-2",
+	})
+	c.Check(calls[3].FuncName, gc.Equals, "RemoveTargetPoolInstances")
+	c.Check(calls[3].InstanceURLs, jc.DeepEquals, []string{
+		"https://www.googleapis.com/compute/v1/projects/spam/zones/a-zone/instances/inst-0",
+	})
+	c.Check(calls[4].FuncName, gc.Equals, "ListForwardingRules")
+	c.Check(calls[4].Prefix, gc.Equals, "lb")
+	c.Check(calls[5].FuncName, gc.Equals, "AddForwardingRule")
+	c.Check(calls[5].ForwardingRule, jc.DeepEquals, &compute.ForwardingRule{
+		Name:                "lb",
+		Description:         "juju load balancer",
+		IPAddress:           "203.0.113.1",
+		IPProtocol:          "TCP",
+		PortRange:           "80-80",
+		Target:              "https://www.googleapis.com/compute/v1/projects/spam/regions/a/targetPools/lb",
+		LoadBalancingScheme: "EXTERNAL",
+	})
+}
+
+func (s *connSuite) TestConnectionEnsureLoadBalancerExistingRule(c *gc.C) {
+	s.FakeConn.Address = &compute.Address{Name: "lb", Address: "203.0.113.1"}
+	s.FakeConn.TargetPool = &compute.TargetPool{Name: "lb"}
+	s.FakeConn.ForwardingRules = []*compute.ForwardingRule{{Name: "lb"}}
+
+	_, err := s.Conn.EnsureLoadBalancer("lb", network.PortRange{
+		Protocol: "udp", FromPort: 53, ToPort: 53,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 3)
+	c.Check(calls[2].FuncName, gc.Equals, "ListForwardingRules")
+}
+
+func (s *connSuite) TestConnectionEnsureLoadBalancerICMP(c *gc.C) {
+	_, err := s.Conn.EnsureLoadBalancer("lb", network.PortRange{
+		Protocol: "icmp", FromPort: -1, ToPort: -1,
+	}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 0)
+}
+
+func (s *connSuite) TestConnectionEnsureLoadBalancerReservesAddress(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("address")
+	s.FakeConn.Address = &compute.Address{Name: "lb", Address: "203.0.113.1"}
+	s.FakeConn.TargetPool = &compute.TargetPool{Name: "lb"}
+
+	address, err := s.Conn.EnsureLoadBalancer("lb", network.PortRange{
+		Protocol: "tcp", FromPort: 80, ToPort: 80,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(address, gc.Equals, "203.0.113.1")
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 6)
+	c.Check(calls[0].FuncName, gc.Equals, "GetAddress")
+	c.Check(calls[1].FuncName, gc.Equals, "AddAddress")
+	c.Check(calls[1].Address, jc.DeepEquals, &compute.Address{
+		Name:        "lb",
+		Description: "juju load balancer",
+	})
+	c.Check(calls[2].FuncName, gc.Equals, "GetAddress")
+}
+
+func (s *connSuite) TestConnectionRemoveLoadBalancer(c *gc.C) {
+	// The target pool is already gone.
+	s.FakeConn.Err = errors.NotFoundf("target pool")
+	s.FakeConn.FailOnCall = 1
+
+	err := s.Conn.RemoveLoadBalancer("lb")
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 3)
+	c.Check(calls[0].FuncName, gc.Equals, "RemoveForwardingRule")
+	c.Check(calls[0].Name, gc.Equals, "lb")
+	c.Check(calls[1].FuncName, gc.Equals, "RemoveTargetPool")
+	c.Check(calls[1].Name, gc.Equals, "lb")
+	c.Check(calls[2].FuncName, gc.Equals, "RemoveAddress")
+	c.Check(calls[2].Name, gc.Equals, "lb")
+}
+
+func (s *connSuite) TestConnectionRemoveLoadBalancers(c *gc.C) {
+	s.FakeConn.ForwardingRules = []*compute.ForwardingRule{{Name: "juju-lb-1"}}
+	s.FakeConn.TargetPools = []*compute.TargetPool{{Name: "juju-lb-1"}, {Name: "juju-lb-2"}}
+	s.FakeConn.Addresses = []*compute.Address{{Name: "juju-lb-2"}}
+
+	err := s.Conn.RemoveLoadBalancers("juju-lb-")
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.HasLen, 7)
+	c.Check(calls[0].FuncName, gc.Equals, "ListForwardingRules")
+	c.Check(calls[0].Prefix, gc.Equals, "juju-lb-")
+	c.Check(calls[1].FuncName, gc.Equals, "RemoveForwardingRule")
+	c.Check(calls[1].Name, gc.Equals, "juju-lb-1")
+	c.Check(calls[2].FuncName, gc.Equals, "ListTargetPools")
+	c.Check(calls[2].Prefix, gc.Equals, "juju-lb-")
+	c.Check(calls[3].FuncName, gc.Equals, "RemoveTargetPool")
+	c.Check(calls[3].Name, gc.Equals, "juju-lb-1")
+	c.Check(calls[4].FuncName, gc.Equals, "RemoveTargetPool")
+	c.Check(calls[4].Name, gc.Equals, "juju-lb-2")
+	c.Check(calls[5].FuncName, gc.Equals, "ListAddresses")
+	c.Check(calls[5].Prefix, gc.Equals, "juju-lb-")
+	c.Check(calls[6].FuncName, gc.Equals, "RemoveAddress")
+	c.Check(calls[6].Name, gc.Equals, "juju-lb-2")
+}
@@ -111,6 +111,10 @@ type DiskSpec struct {
 	// Labels holds labels/metadata for the disk. Labels are used for
 	// storing volume resource tags.
 	Labels map[string]string
+	// KMSKeyName is the resource name of the Cloud KMS key with which
+	// the disk is encrypted. If it is empty, the disk is encrypted
+	// with a Google-managed key.
+	KMSKeyName string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -158,10 +162,20 @@ func (ds *DiskSpec) newAttached() *compute.AttachedDisk {
 		},
 		// Interface (defaults to SCSI)
 		// DeviceName (GCE sets this, persistent disk only)
+		DiskEncryptionKey: ds.diskEncryptionKey(),
 	}
 	return &disk
 }
 
+// diskEncryptionKey returns the customer-managed encryption key of the
+// disk, or nil if it is encrypted with a Google-managed key.
+func (ds *DiskSpec) diskEncryptionKey() *compute.CustomerEncryptionKey {
+	if ds.KMSKeyName == "" {
+		return nil
+	}
+	return &compute.CustomerEncryptionKey{KmsKeyName: ds.KMSKeyName}
+}
+
 // newDetached creates a new detached persistent disk representation,
 // this DOES NOT create a disk in gce, just creates the spec.
 // reference in https://cloud.google.com/compute/docs/reference/latest/disks#resource
@@ -173,11 +187,12 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
 	return &compute.Disk{
-		Name:        ds.Name,
-		SizeGb:      int64(ds.SizeGB()),
-		SourceImage: ds.ImageURL,
-		Type:        string(ds.PersistentDiskType),
-		Labels:      ds.Labels,
+		Name:              ds.Name,
+		SizeGb:            int64(ds.SizeGB()),
+		SourceImage:       ds.ImageURL,
+		Type:              string(ds.PersistentDiskType),
+		Labels:            ds.Labels,
+		DiskEncryptionKey: ds.diskEncryptionKey(),
 	}, nil
 }
 
@@ -128,6 +128,23 @@ func (s *diskSuite) TestDiskSpecNewAttachedReadOnly(c *gc.C) {
 	})
 }
 
+func (s *diskSuite) TestDiskSpecNewAttachedKMSKey(c *gc.C) {
+	c.Check(google.NewAttached(s.DiskSpec).DiskEncryptionKey, gc.IsNil)
+
+	s.DiskSpec.KMSKeyName = "projects/spam/locations/global/keyRings/ham/cryptoKeys/eggs"
+	attached := google.NewAttached(s.DiskSpec)
+	c.Assert(attached.DiskEncryptionKey, gc.NotNil)
+	c.Check(attached.DiskEncryptionKey.KmsKeyName, gc.Equals, s.DiskSpec.KMSKeyName)
+}
+
+func (s *diskSuite) TestDiskSpecNewDetachedKMSKey(c *gc.C) {
+	s.DiskSpec.KMSKeyName = "projects/spam/locations/global/keyRings/ham/cryptoKeys/eggs"
+	detached, err := google.NewDetached(s.DiskSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(detached.DiskEncryptionKey, gc.NotNil)
+	c.Check(detached.DiskEncryptionKey.KmsKeyName, gc.Equals, s.DiskSpec.KMSKeyName)
+}
+
 func (s *diskSuite) TestRootDiskInstance(c *gc.C) {
 	attached := s.Instance.RootDisk()
 
@@ -52,6 +52,10 @@ type InstanceSpec struct {
 	// AvailabilityZone holds the name of the availability zone in which
 	// to create the instance.
 	AvailabilityZone string
+
+	// Preemptible indicates whether the instance should be created as
+	// a preemptible instance, which GCE may terminate at any time.
+	Preemptible bool
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
@@ -61,10 +65,26 @@ func (is InstanceSpec) raw() *compute.Instance {
 		NetworkInterfaces: is.networkInterfaces(),
 		Metadata:          packMetadata(is.Metadata),
 		Tags:              &compute.Tags{Items: is.Tags},
+		Scheduling:        is.scheduling(),
 		// MachineType is set in the addInstance call.
 	}
 }
 
+// scheduling returns the scheduling options for the instance, or nil
+// to use the GCE defaults. Preemptible instances cannot be restarted
+// automatically or migrated on host maintenance.
+func (is InstanceSpec) scheduling() *compute.Scheduling {
+	if !is.Preemptible {
+		return nil
+	}
+	automaticRestart := false
+	return &compute.Scheduling{
+		Preemptible:       true,
+		AutomaticRestart:  &automaticRestart,
+		OnHostMaintenance: "TERMINATE",
+	}
+}
+
 // Summary builds an InstanceSummary based on the spec and returns it.
 func (is InstanceSpec) Summary() InstanceSummary {
 	raw := is.raw()
@@ -111,6 +131,9 @@ type InstanceSummary struct {
 	// NetworkInterfaces are the network connections associated with
 	// the instance.
 	NetworkInterfaces []*compute.NetworkInterface
+	// Preemptible indicates whether the instance may be terminated
+	// by GCE at any time.
+	Preemptible bool
 }
 
 func newInstanceSummary(raw *compute.Instance) InstanceSummary {
@@ -121,6 +144,7 @@ func newInstanceSummary(raw *compute.Instance) InstanceSummary {
 		Metadata:          unpackMetadata(raw.Metadata),
 		Addresses:         extractAddresses(raw.NetworkInterfaces...),
 		NetworkInterfaces: raw.NetworkInterfaces,
+		Preemptible:       raw.Scheduling != nil && raw.Scheduling.Preemptible,
 	}
 }
 
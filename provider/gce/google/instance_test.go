@@ -29,6 +29,14 @@ func (s *instanceSuite) TestNewInstance(c *gc.C) {
 	c.Check(spec, jc.DeepEquals, &s.InstanceSpec)
 }
 
+func (s *instanceSuite) TestNewInstancePreemptible(c *gc.C) {
+	raw := s.RawInstanceFull
+	raw.Scheduling = &compute.Scheduling{Preemptible: true}
+	inst := google.NewInstanceRaw(&raw, nil)
+
+	c.Check(inst.Preemptible, jc.IsTrue)
+}
+
 func (s *instanceSuite) TestNewInstanceNoSpec(c *gc.C) {
 	inst := google.NewInstanceRaw(&s.RawInstanceFull, nil)
 
@@ -421,3 +421,161 @@ func (rc *rawConn) ListNetworks(projectID string) ([]*compute.Network, error) {
 	}
 	return results, nil
 }
+
+func (rc *rawConn) ListAddresses(projectID, region, namePrefix string) ([]*compute.Address, error) {
+	ctx := context.Background()
+	call := rc.Addresses.List(projectID, region)
+	var results []*compute.Address
+	err := call.Pages(ctx, func(page *compute.AddressList) error {
+		for _, address := range page.Items {
+			if strings.HasPrefix(address.Name, namePrefix) {
+				results = append(results, address)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) GetAddress(projectID, region, name string) (*compute.Address, error) {
+	call := rc.Addresses.Get(projectID, region, name)
+	address, err := call.Do()
+	return address, errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) AddAddress(projectID, region string, address *compute.Address) error {
+	call := rc.Addresses.Insert(projectID, region, address)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveAddress(projectID, region, name string) error {
+	call := rc.Addresses.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) ListTargetPools(projectID, region, namePrefix string) ([]*compute.TargetPool, error) {
+	ctx := context.Background()
+	call := rc.TargetPools.List(projectID, region)
+	var results []*compute.TargetPool
+	err := call.Pages(ctx, func(page *compute.TargetPoolList) error {
+		for _, pool := range page.Items {
+			if strings.HasPrefix(pool.Name, namePrefix) {
+				results = append(results, pool)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) GetTargetPool(projectID, region, name string) (*compute.TargetPool, error) {
+	call := rc.TargetPools.Get(projectID, region, name)
+	pool, err := call.Do()
+	return pool, errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) AddTargetPool(projectID, region string, pool *compute.TargetPool) error {
+	call := rc.TargetPools.Insert(projectID, region, pool)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveTargetPool(projectID, region, name string) error {
+	call := rc.TargetPools.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
+
+func instanceReferences(instanceURLs []string) []*compute.InstanceReference {
+	refs := make([]*compute.InstanceReference, len(instanceURLs))
+	for i, url := range instanceURLs {
+		refs[i] = &compute.InstanceReference{Instance: url}
+	}
+	return refs
+}
+
+func (rc *rawConn) AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := rc.TargetPools.AddInstance(projectID, region, name, &compute.TargetPoolsAddInstanceRequest{
+		Instances: instanceReferences(instanceURLs),
+	})
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := rc.TargetPools.RemoveInstance(projectID, region, name, &compute.TargetPoolsRemoveInstanceRequest{
+		Instances: instanceReferences(instanceURLs),
+	})
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) ListForwardingRules(projectID, region, namePrefix string) ([]*compute.ForwardingRule, error) {
+	ctx := context.Background()
+	call := rc.ForwardingRules.List(projectID, region)
+	var results []*compute.ForwardingRule
+	err := call.Pages(ctx, func(page *compute.ForwardingRuleList) error {
+		for _, rule := range page.Items {
+			if strings.HasPrefix(rule.Name, namePrefix) {
+				results = append(results, rule)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error {
+	call := rc.ForwardingRules.Insert(projectID, region, rule)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveForwardingRule(projectID, region, name string) error {
+	call := rc.ForwardingRules.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
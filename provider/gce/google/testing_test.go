@@ -170,6 +170,10 @@ type fakeCall struct {
 	Metadata         *compute.Metadata
 	LabelFingerprint string
 	Labels           map[string]string
+	Address          *compute.Address
+	TargetPool       *compute.TargetPool
+	ForwardingRule   *compute.ForwardingRule
+	InstanceURLs     []string
 }
 
 type fakeConn struct {
@@ -187,6 +191,12 @@ type fakeConn struct {
 	AttachedDisks []*compute.AttachedDisk
 	Networks      []*compute.Network
 	Subnetworks   []*compute.Subnetwork
+
+	Address         *compute.Address
+	Addresses       []*compute.Address
+	TargetPool      *compute.TargetPool
+	TargetPools     []*compute.TargetPool
+	ForwardingRules []*compute.ForwardingRule
 }
 
 func (rc *fakeConn) GetProject(projectID string) (*compute.Project, error) {
@@ -542,3 +552,228 @@ func (rc *fakeConn) ListSubnetworks(projectID, region string) ([]*compute.Subnet
 	}
 	return rc.Subnetworks, nil
 }
+
+func (rc *fakeConn) ListAddresses(projectID, region, namePrefix string) ([]*compute.Address, error) {
+	call := fakeCall{
+		FuncName:  "ListAddresses",
+		ProjectID: projectID,
+		Region:    region,
+		Prefix:    namePrefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc.Addresses, nil
+}
+
+func (rc *fakeConn) GetAddress(projectID, region, name string) (*compute.Address, error) {
+	call := fakeCall{
+		FuncName:  "GetAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc.Address, nil
+}
+
+func (rc *fakeConn) AddAddress(projectID, region string, address *compute.Address) error {
+	call := fakeCall{
+		FuncName:  "AddAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Address:   address,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveAddress(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListTargetPools(projectID, region, namePrefix string) ([]*compute.TargetPool, error) {
+	call := fakeCall{
+		FuncName:  "ListTargetPools",
+		ProjectID: projectID,
+		Region:    region,
+		Prefix:    namePrefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc.TargetPools, nil
+}
+
+func (rc *fakeConn) GetTargetPool(projectID, region, name string) (*compute.TargetPool, error) {
+	call := fakeCall{
+		FuncName:  "GetTargetPool",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc.TargetPool, nil
+}
+
+func (rc *fakeConn) AddTargetPool(projectID, region string, pool *compute.TargetPool) error {
+	call := fakeCall{
+		FuncName:   "AddTargetPool",
+		ProjectID:  projectID,
+		Region:     region,
+		TargetPool: pool,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveTargetPool(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveTargetPool",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := fakeCall{
+		FuncName:     "AddTargetPoolInstances",
+		ProjectID:    projectID,
+		Region:       region,
+		Name:         name,
+		InstanceURLs: instanceURLs,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := fakeCall{
+		FuncName:     "RemoveTargetPoolInstances",
+		ProjectID:    projectID,
+		Region:       region,
+		Name:         name,
+		InstanceURLs: instanceURLs,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListForwardingRules(projectID, region, namePrefix string) ([]*compute.ForwardingRule, error) {
+	call := fakeCall{
+		FuncName:  "ListForwardingRules",
+		ProjectID: projectID,
+		Region:    region,
+		Prefix:    namePrefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc.ForwardingRules, nil
+}
+
+func (rc *fakeConn) AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error {
+	call := fakeCall{
+		FuncName:       "AddForwardingRule",
+		ProjectID:      projectID,
+		Region:         region,
+		ForwardingRule: rule,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveForwardingRule(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveForwardingRule",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
@@ -46,9 +46,15 @@ func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.In
 	default:
 		jujuStatus = status.Empty
 	}
+	message := instStatus
+	if instStatus == google.StatusTerminated && inst.base.Preemptible {
+		// GCE terminates preemptible instances when it needs the
+		// capacity back, so make it clear why the instance went away.
+		message = "preempted"
+	}
 	return instance.InstanceStatus{
 		Status:  jujuStatus,
-		Message: instStatus,
+		Message: message,
 	}
 }
 
@@ -40,6 +40,15 @@ func (s *instanceSuite) TestStatus(c *gc.C) {
 	s.CheckNoAPI(c)
 }
 
+func (s *instanceSuite) TestStatusPreempted(c *gc.C) {
+	s.BaseInstance.InstanceSummary.Status = google.StatusTerminated
+	s.BaseInstance.InstanceSummary.Preemptible = true
+	status := s.Instance.Status(s.CallCtx).Message
+
+	c.Check(status, gc.Equals, "preempted")
+	s.CheckNoAPI(c)
+}
+
 func (s *instanceSuite) TestAddresses(c *gc.C) {
 	addresses, err := s.Instance.Addresses(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
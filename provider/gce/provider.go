@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
 )
 
 const (
@@ -117,9 +118,19 @@ func configWithDefaults(cfg *config.Config) (*config.Config, error) {
 
 // Validate implements environs.EnvironProvider.Validate.
 func (environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
 	newCfg, err := newConfig(cfg, old)
 	if err != nil {
 		return nil, errors.Annotate(err, "invalid config")
 	}
 	return newCfg.config, nil
 }
+
+// ValidateRootDiskEncryption implements environs.RootDiskEncrypter.
+// GCE encrypts all persistent disks at rest, with Google-managed keys
+// unless root-disk-kms-key names a Cloud KMS key.
+func (environProvider) ValidateRootDiskEncryption(cfg *config.Config) error {
+	return errors.Annotate(validateKMSKeyName(cfg.RootDiskKMSKey()), config.RootDiskKMSKey)
+}
@@ -87,6 +87,18 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	c.Assert(s.Config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
 
+func (s *providerSuite) TestValidateRootDiskEncryption(c *gc.C) {
+	cfg, err := s.Config.Apply(map[string]interface{}{"root-disk-encryption": true})
+	c.Assert(err, jc.ErrorIsNil)
+	err = environs.ValidateRootDiskEncryption(s.provider, cfg)
+	c.Check(err, jc.ErrorIsNil)
+
+	cfg, err = cfg.Apply(map[string]interface{}{"root-disk-kms-key": "eggs"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = environs.ValidateRootDiskEncryption(s.provider, cfg)
+	c.Check(err, gc.ErrorMatches, `root-disk-kms-key: Cloud KMS key "eggs" \(expected .*\) not valid`)
+}
+
 func (s *providerSuite) TestUpgradeConfig(c *gc.C) {
 	c.Assert(s.provider, gc.Implements, new(environs.ModelConfigUpgrader))
 	upgrader := s.provider.(environs.ModelConfigUpgrader)
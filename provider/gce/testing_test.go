@@ -490,6 +490,9 @@ type fakeConnCall struct {
 	Value            string
 	LabelFingerprint string
 	Labels           map[string]string
+	Name             string
+	PortRange        network.PortRange
+	Instances        []google.Instance
 }
 
 type fakeConn struct {
@@ -507,6 +510,8 @@ type fakeConn struct {
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
 
+	LoadBalancerAddress string
+
 	Err        error
 	FailOnCall int
 }
@@ -596,6 +601,32 @@ func (fc *fakeConn) ClosePorts(fwname string, rules ...network.IngressRule) erro
 	return fc.err()
 }
 
+func (fc *fakeConn) EnsureLoadBalancer(name string, portRange network.PortRange, instances []google.Instance) (string, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:  "EnsureLoadBalancer",
+		Name:      name,
+		PortRange: portRange,
+		Instances: instances,
+	})
+	return fc.LoadBalancerAddress, fc.err()
+}
+
+func (fc *fakeConn) RemoveLoadBalancer(name string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveLoadBalancer",
+		Name:     name,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) RemoveLoadBalancers(prefix string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveLoadBalancers",
+		Prefix:   prefix,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AvailabilityZones(region string) ([]google.AvailabilityZone, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "AvailabilityZones",
@@ -49,6 +49,7 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/provider/common"
 )
 
 var logger = loggo.GetLogger("juju.provider.joyent")
@@ -136,6 +137,9 @@ func (joyentProvider) Validate(cfg, old *config.Config) (valid *config.Config, e
 	if err != nil {
 		return nil, errors.Errorf("invalid Joyent provider config: %v", err)
 	}
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, errors.Errorf("invalid Joyent provider config: %v", err)
+	}
 	return cfg.Apply(newEcfg.attrs)
 }
 
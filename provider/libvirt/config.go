@@ -0,0 +1,129 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// The libvirt-specific config keys.
+const (
+	cfgNetworkBridge = "network-bridge"
+	cfgVolumePool    = "volume-pool"
+)
+
+// configFields is the spec for each libvirt config value's type.
+var (
+	configFields = schema.Fields{
+		cfgNetworkBridge: schema.String(),
+		cfgVolumePool:    schema.String(),
+	}
+
+	configDefaults = schema.Defaults{
+		// virbr0 is the bridge of libvirt's "default" NAT network,
+		// which leases addresses to guests over DHCP.
+		cfgNetworkBridge: "virbr0",
+		cfgVolumePool:    "default",
+	}
+
+	configRequiredFields  = []string{cfgNetworkBridge, cfgVolumePool}
+	configImmutableFields = []string{cfgVolumePool}
+)
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+// newConfig builds a new environConfig from the provided Config and
+// returns it.
+func newConfig(cfg *config.Config) *environConfig {
+	return &environConfig{
+		Config: cfg,
+		attrs:  cfg.UnknownAttrs(),
+	}
+}
+
+// newValidConfig builds a new environConfig from the provided Config
+// and returns it. The resulting config values are validated.
+func newValidConfig(cfg *config.Config) (*environConfig, error) {
+	// Ensure that the provided config is valid.
+	if err := config.Validate(cfg, nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Apply the defaults and coerce/validate the custom config attrs.
+	validated, err := cfg.ValidateUnknownAttrs(configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	validCfg, err := cfg.Apply(validated)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Build the config.
+	ecfg := newConfig(validCfg)
+
+	// Do final validation.
+	if err := ecfg.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return ecfg, nil
+}
+
+// networkBridge returns the name of the host bridge that instances'
+// network interfaces are attached to.
+func (c *environConfig) networkBridge() string {
+	return c.attrs[cfgNetworkBridge].(string)
+}
+
+// volumePool returns the name of the libvirt storage pool that holds
+// instances' disks and the images they are based on.
+func (c *environConfig) volumePool() string {
+	return c.attrs[cfgVolumePool].(string)
+}
+
+// validate checks libvirt-specific config values.
+func (c environConfig) validate() error {
+	// All fields must be populated, even with just the default.
+	for _, field := range configRequiredFields {
+		if c.attrs[field].(string) == "" {
+			return errors.Errorf("%s: must not be empty", field)
+		}
+	}
+	return nil
+}
+
+// update applies changes from the provided config to the env config.
+// Changes to any immutable attributes result in an error.
+func (c *environConfig) update(cfg *config.Config) error {
+	// Validate the updates. newValidConfig does not modify the "known"
+	// config attributes so it is safe to call Validate here first.
+	if err := config.Validate(cfg, c.Config); err != nil {
+		return errors.Trace(err)
+	}
+
+	updates, err := newValidConfig(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Check that no immutable fields have changed.
+	attrs := updates.UnknownAttrs()
+	for _, field := range configImmutableFields {
+		if attrs[field] != c.attrs[field] {
+			return errors.Errorf("%s: cannot change from %v to %v", field, c.attrs[field], attrs[field])
+		}
+	}
+
+	// Apply the updates.
+	c.Config = updates.Config
+	c.attrs = updates.UnknownAttrs()
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"io"
+
+	"github.com/juju/juju/cloud"
+	kvmlibvirt "github.com/juju/juju/container/kvm/libvirt"
+)
+
+// ConnectFunc is a function type for connecting to a libvirt host,
+// identified by its connection URI (e.g. qemu+ssh://user@host/system),
+// with the given credential. The credential may be nil, in which case
+// the connection relies on whatever access the local user has.
+type ConnectFunc func(uri string, credential *cloud.Credential) (Connection, error)
+
+// Connection is an interface for interacting with a libvirt host. It
+// covers only the domain and volume pool operations the provider needs.
+type Connection interface {
+	// Close releases any resources held by the connection.
+	Close() error
+
+	// Domains returns all of the domains defined on the host.
+	Domains() ([]DomainInfo, error)
+
+	// DefineDomain defines a persistent domain on the host, recording
+	// the given tags in the domain's description.
+	DefineDomain(domain kvmlibvirt.Domain, tags map[string]string) error
+
+	// StartDomain starts the named domain, and marks it to be started
+	// automatically when the host restarts.
+	StartDomain(name string) error
+
+	// DestroyDomain stops the named domain if it is running, and
+	// removes its definition from the host.
+	DestroyDomain(name string) error
+
+	// DomainAddresses returns the IP addresses leased to the named
+	// domain's network interfaces.
+	DomainAddresses(name string) ([]string, error)
+
+	// DomainDisks returns the disks attached to the named domain.
+	DomainDisks(name string) ([]DomainDisk, error)
+
+	// AttachDisk attaches the disk to the named domain, both to the
+	// running domain and to its persistent definition.
+	AttachDisk(name string, disk DomainDisk) error
+
+	// DetachDisk detaches the disk with the given target device from
+	// the named domain, and from its persistent definition.
+	DetachDisk(name, target string) error
+
+	// Volumes returns the names of the volumes in the given pool.
+	Volumes(pool string) ([]string, error)
+
+	// CreateVolume creates an empty volume in the given pool, and
+	// returns the volume's path on the host.
+	CreateVolume(pool string, args VolumeParams) (string, error)
+
+	// UploadVolume creates a volume in the given pool with the content
+	// read from r, and returns the volume's path on the host.
+	UploadVolume(pool string, args VolumeParams, r io.Reader) (string, error)
+
+	// VolumeInfo returns information about the named volume in the
+	// given pool.
+	VolumeInfo(pool, name string) (VolumeInfo, error)
+
+	// DeleteVolume deletes the named volume from the given pool.
+	DeleteVolume(pool, name string) error
+}
+
+// DomainInfo describes a domain defined on a libvirt host.
+type DomainInfo struct {
+	// Name is the name of the domain, which is unique on the host.
+	Name string
+
+	// State is the domain state as reported by libvirt, e.g.
+	// "running" or "shut off".
+	State string
+
+	// Tags holds the tags recorded when the domain was defined.
+	Tags map[string]string
+}
+
+// DomainDisk describes a disk attached to a domain.
+type DomainDisk struct {
+	// Target is the device name of the disk in the domain, e.g. "vdc".
+	Target string
+
+	// Source is the path on the host of the volume backing the disk.
+	Source string
+}
+
+// VolumeInfo describes a volume in a libvirt storage pool.
+type VolumeInfo struct {
+	// Path is the volume's path on the host.
+	Path string
+
+	// Size is the capacity of the volume in bytes.
+	Size uint64
+}
+
+// VolumeParams holds the parameters for creating a volume in a
+// libvirt storage pool.
+type VolumeParams struct {
+	// Name is the name of the volume, which is unique within the pool.
+	Name string
+
+	// Format is the volume format, e.g. "qcow2" or "raw".
+	Format string
+
+	// Size is the capacity of the volume in bytes.
+	Size uint64
+
+	// BackingVolume, if non-empty, is the name of a qcow2 volume in the
+	// same pool to use as the new volume's backing store.
+	BackingVolume string
+}
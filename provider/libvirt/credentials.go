@@ -0,0 +1,77 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+const (
+	// sshKeyAuthType is the auth-type for qemu+ssh:// endpoints: the
+	// host is reached over SSH, authenticating with a private key.
+	sshKeyAuthType cloud.AuthType = "ssh-key"
+
+	credAttrPrivateKey = "private-key"
+	credAttrCACert     = "ca-cert"
+	credAttrClientCert = "client-cert"
+	credAttrClientKey  = "client-key"
+)
+
+// environProviderCredentials implements environs.ProviderCredentials.
+// The controller connects to the libvirt host with the model's
+// credential, so the credential must carry everything needed to reach
+// the host: an SSH private key for qemu+ssh:// endpoints, or a client
+// certificate for qemu+tls:// endpoints.
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		sshKeyAuthType: {
+			{
+				Name: credAttrPrivateKey,
+				CredentialAttr: cloud.CredentialAttr{
+					Description:    "The SSH private key authorised to connect to the libvirt host.",
+					Hidden:         true,
+					ExpandFilePath: true,
+				},
+			},
+		},
+		cloud.CertificateAuthType: {
+			{
+				Name: credAttrCACert,
+				CredentialAttr: cloud.CredentialAttr{
+					Description:    "The CA certificate of the libvirt host, PEM-encoded.",
+					ExpandFilePath: true,
+				},
+			}, {
+				Name: credAttrClientCert,
+				CredentialAttr: cloud.CredentialAttr{
+					Description:    "The libvirt client certificate, PEM-encoded.",
+					ExpandFilePath: true,
+				},
+			}, {
+				Name: credAttrClientKey,
+				CredentialAttr: cloud.CredentialAttr{
+					Description:    "The libvirt client key, PEM-encoded.",
+					Hidden:         true,
+					ExpandFilePath: true,
+				},
+			},
+		},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	return nil, errors.NotFoundf("credentials")
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/juju/errors"
+
+	kvmlibvirt "github.com/juju/juju/container/kvm/libvirt"
+)
+
+// uefiLoader is the path on the host to the firmware used to UEFI boot
+// ARM64 guests.
+const uefiLoader = "/usr/share/AAVMF/AAVMF_CODE.fd"
+
+// domainParams implements the parameters required by
+// kvmlibvirt.NewDomain.
+type domainParams struct {
+	name       string
+	arch       string
+	cpus       uint64
+	memory     uint64 // MiB
+	disks      []kvmlibvirt.DiskInfo
+	interfaces []kvmlibvirt.InterfaceInfo
+}
+
+// Arch is part of the domain parameters.
+func (p domainParams) Arch() string {
+	return p.arch
+}
+
+// CPUs is part of the domain parameters.
+func (p domainParams) CPUs() uint64 {
+	return p.cpus
+}
+
+// DiskInfo is part of the domain parameters.
+func (p domainParams) DiskInfo() []kvmlibvirt.DiskInfo {
+	return p.disks
+}
+
+// Host is part of the domain parameters.
+func (p domainParams) Host() string {
+	return p.name
+}
+
+// Loader is part of the domain parameters.
+func (p domainParams) Loader() string {
+	return uefiLoader
+}
+
+// NetworkInfo is part of the domain parameters.
+func (p domainParams) NetworkInfo() []kvmlibvirt.InterfaceInfo {
+	return p.interfaces
+}
+
+// RAM is part of the domain parameters.
+func (p domainParams) RAM() uint64 {
+	return p.memory
+}
+
+// ValidateDomainParams is part of the domain parameters.
+func (p domainParams) ValidateDomainParams() error {
+	if p.name == "" {
+		return errors.New("missing required domain name")
+	}
+	if len(p.disks) != 2 {
+		// We need the system disk and the cloud-init data source disk.
+		return errors.Errorf("got %d disks, need 2", len(p.disks))
+	}
+	return nil
+}
+
+// diskInfo implements kvmlibvirt.DiskInfo.
+type diskInfo struct {
+	driver, source string
+}
+
+// Driver implements kvmlibvirt.DiskInfo.
+func (d diskInfo) Driver() string {
+	return d.driver
+}
+
+// Source implements kvmlibvirt.DiskInfo.
+func (d diskInfo) Source() string {
+	return d.source
+}
+
+// interfaceInfo implements kvmlibvirt.InterfaceInfo for a network
+// interface attached to a host bridge.
+type interfaceInfo struct {
+	mac, name, bridge string
+}
+
+// MACAddress implements kvmlibvirt.InterfaceInfo.
+func (i interfaceInfo) MACAddress() string {
+	return i.mac
+}
+
+// InterfaceName implements kvmlibvirt.InterfaceInfo.
+func (i interfaceInfo) InterfaceName() string {
+	return i.name
+}
+
+// ParentInterfaceName implements kvmlibvirt.InterfaceInfo.
+func (i interfaceInfo) ParentInterfaceName() string {
+	return i.bridge
+}
+
+// generateMAC returns a random MAC address with the 52:54:00 prefix
+// conventionally used for QEMU guests.
+var generateMAC = func() (string, error) {
+	buf := make([]byte, 3)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("52:54:00:%02x:%02x:%02x", buf[0], buf[1], buf[2]), nil
+}
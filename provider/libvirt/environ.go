@@ -0,0 +1,153 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+type environ struct {
+	name     string
+	cloud    environs.CloudSpec
+	provider *environProvider
+
+	// namespace is used to create the domain names.
+	namespace instance.Namespace
+
+	lock sync.Mutex // lock protects access the following fields.
+	ecfg *environConfig
+}
+
+func newEnviron(
+	provider *environProvider,
+	cloud environs.CloudSpec,
+	cfg *config.Config,
+) (*environ, error) {
+	ecfg, err := newValidConfig(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	env := &environ{
+		name:      ecfg.Name(),
+		cloud:     cloud,
+		provider:  provider,
+		ecfg:      ecfg,
+		namespace: namespace,
+	}
+	return env, nil
+}
+
+func (env *environ) withConnection(f func(Connection) error) error {
+	conn, err := env.provider.connect(env.cloud.Endpoint, env.cloud.Credential)
+	if err != nil {
+		return errors.Annotate(err, "connecting to libvirt")
+	}
+	defer conn.Close()
+	return f(conn)
+}
+
+// Name is part of the environs.Environ interface.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider is part of the environs.Environ interface.
+func (env *environ) Provider() environs.EnvironProvider {
+	return env.provider
+}
+
+// SetConfig is part of the environs.Environ interface.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	if env.ecfg == nil {
+		return errors.New("cannot set config on uninitialized env")
+	}
+
+	if err := env.ecfg.update(cfg); err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
+	return nil
+}
+
+// Config is part of the environs.Environ interface.
+func (env *environ) Config() *config.Config {
+	return env.environConfig().Config
+}
+
+func (env *environ) environConfig() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(ctx context.ProviderCallContext, args environs.CreateParams) error {
+	return nil
+}
+
+// Bootstrap is part of the environs.Environ interface.
+func (env *environ) Bootstrap(
+	ctx environs.BootstrapContext,
+	callCtx context.ProviderCallContext,
+	args environs.BootstrapParams,
+) (*environs.BootstrapResult, error) {
+	return common.Bootstrap(ctx, env, callCtx, args)
+}
+
+// AdoptResources is part of the Environ interface.
+func (env *environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	// Domain tags can only be changed by redefining the domain, so the
+	// controller UUID is left as it was; DestroyController on the new
+	// controller will not remove the model's domains.
+	return nil
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *environ) Destroy(ctx context.ProviderCallContext) error {
+	return common.Destroy(env, ctx)
+}
+
+// DestroyController implements the Environ interface.
+func (env *environ) DestroyController(ctx context.ProviderCallContext, controllerUUID string) error {
+	if err := env.Destroy(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	// Remove the domains of any hosted models.
+	return env.withConnection(func(conn Connection) error {
+		domains, err := conn.Domains()
+		if err != nil {
+			return errors.Annotate(err, "listing domains")
+		}
+		var ids []instance.Id
+		for _, domain := range domains {
+			if domain.Tags[tags.JujuController] == controllerUUID {
+				ids = append(ids, instance.Id(domain.Name))
+			}
+		}
+		return errors.Trace(env.stopInstances(conn, ids...))
+	})
+}
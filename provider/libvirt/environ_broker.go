@@ -0,0 +1,324 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	kvmlibvirt "github.com/juju/juju/container/kvm/libvirt"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/tools"
+)
+
+const (
+	// defaultMemory is the memory, in MiB, given to instances that have
+	// no memory constraint.
+	defaultMemory = 2048
+
+	// defaultControllerMemory is the memory, in MiB, given to controller
+	// instances that have no memory constraint.
+	defaultControllerMemory = 3584
+)
+
+// rootDiskName returns the name of the volume holding the named
+// domain's system disk.
+func rootDiskName(domainName string) string {
+	return domainName + ".qcow2"
+}
+
+// dataSourceDiskName returns the name of the volume holding the named
+// domain's cloud-init data source image.
+func dataSourceDiskName(domainName string) string {
+	return domainName + "-ds.iso"
+}
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	if args.AvailabilityZone != "" {
+		return nil, errors.NotSupportedf("availability zones")
+	}
+	imageArch, err := instanceArch(args)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	if err := env.finishInstanceConfig(args, imageArch); err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+
+	var result *environs.StartInstanceResult
+	err = env.withConnection(func(conn Connection) error {
+		var err error
+		result, err = env.newRawInstance(conn, args, imageArch)
+		return err
+	})
+	if err != nil {
+		if args.StatusCallback != nil {
+			args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		}
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("started instance %q", result.Instance.Id())
+	return result, nil
+}
+
+// instanceArch returns the architecture of the instance to start: the
+// arch constraint if there is one, otherwise amd64 if agent binaries are
+// available for it, otherwise the first architecture with binaries.
+func instanceArch(args environs.StartInstanceParams) (string, error) {
+	arches := args.Tools.Arches()
+	if len(arches) == 0 {
+		return "", errors.New("agent binaries not found")
+	}
+	if args.Constraints.HasArch() {
+		return *args.Constraints.Arch, nil
+	}
+	for _, a := range arches {
+		if a == arch.AMD64 {
+			return a, nil
+		}
+	}
+	return arches[0], nil
+}
+
+// finishInstanceConfig updates args.InstanceConfig in place, setting up
+// the agent binaries, API and controller information, and SSH keys.
+func (env *environ) finishInstanceConfig(args environs.StartInstanceParams, imageArch string) error {
+	envTools, err := args.Tools.Match(tools.Filter{Arch: imageArch})
+	if err != nil {
+		return errors.Errorf("chosen architecture %v not present in %v", imageArch, args.Tools.Arches())
+	}
+	if err := args.InstanceConfig.SetTools(envTools); err != nil {
+		return errors.Trace(err)
+	}
+	return instancecfg.FinishInstanceConfig(args.InstanceConfig, env.Config())
+}
+
+// newRawInstance creates the volumes and domain for a new instance and
+// starts it. Anything created is removed again if a later step fails.
+func (env *environ) newRawInstance(
+	conn Connection,
+	args environs.StartInstanceParams,
+	imageArch string,
+) (_ *environs.StartInstanceResult, err error) {
+	ecfg := env.environConfig()
+	pool := ecfg.volumePool()
+
+	name, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	series := args.InstanceConfig.Series
+	cloudcfg, err := cloudinit.New(series)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	if args.InstanceConfig.Controller != nil {
+		// The controller's provisioner drives the libvirt host with
+		// virsh, and builds each instance's data source image with
+		// genisoimage.
+		cloudcfg.AddPackage("libvirt-clients")
+		cloudcfg.AddPackage("genisoimage")
+	}
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudcfg, libvirtRenderer{})
+	if err != nil {
+		return nil, common.ZoneIndependentError(
+			errors.Annotate(err, "cannot make user data"),
+		)
+	}
+	logger.Debugf("libvirt user data; %d bytes", len(userData))
+
+	updateProgress := func(message string) {
+		if args.StatusCallback != nil {
+			args.StatusCallback(status.Provisioning, message, nil)
+		}
+	}
+	baseImage, err := ensureBaseImage(conn, pool, series, imageArch, ecfg.ImageStream(), updateProgress)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Obtain the final constraints by merging with defaults.
+	cons := args.Constraints
+	if cons.Mem == nil {
+		mem := uint64(defaultMemory)
+		if args.InstanceConfig.Controller != nil {
+			mem = defaultControllerMemory
+		}
+		cons.Mem = &mem
+	}
+	if cons.CpuCores == nil {
+		cores := uint64(1)
+		cons.CpuCores = &cores
+	}
+	minRootDisk := common.MinRootDiskSizeGiB(series) * 1024
+	if cons.RootDisk == nil || *cons.RootDisk < minRootDisk {
+		cons.RootDisk = &minRootDisk
+	}
+
+	defer func() {
+		if err != nil {
+			env.removeInstance(conn, name, false)
+		}
+	}()
+
+	updateProgress("creating instance disks")
+	rootDiskPath, err := conn.CreateVolume(pool, VolumeParams{
+		Name:          rootDiskName(name),
+		Format:        "qcow2",
+		Size:          common.MiBToGiB(*cons.RootDisk) << 30,
+		BackingVolume: baseImage,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	iso, err := makeDataSourceISO(name, userData)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	dataSourcePath, err := conn.UploadVolume(pool, VolumeParams{
+		Name:   dataSourceDiskName(name),
+		Format: "raw",
+		Size:   uint64(len(iso)),
+	}, bytes.NewReader(iso))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	mac, err := generateMAC()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	domain, err := kvmlibvirt.NewDomain(domainParams{
+		name:   name,
+		arch:   imageArch,
+		cpus:   *cons.CpuCores,
+		memory: *cons.Mem,
+		disks: []kvmlibvirt.DiskInfo{
+			diskInfo{driver: "qcow2", source: rootDiskPath},
+			diskInfo{driver: "raw", source: dataSourcePath},
+		},
+		interfaces: []kvmlibvirt.InterfaceInfo{
+			interfaceInfo{mac: mac, name: "eth0", bridge: ecfg.networkBridge()},
+		},
+	})
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+
+	updateProgress("starting instance")
+	if err := conn.DefineDomain(domain, args.InstanceConfig.Tags); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := conn.StartDomain(name); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	hw := &instance.HardwareCharacteristics{
+		Arch:     &imageArch,
+		Mem:      cons.Mem,
+		CpuCores: cons.CpuCores,
+		RootDisk: cons.RootDisk,
+	}
+	inst := newInstance(DomainInfo{
+		Name:  name,
+		State: "running",
+		Tags:  args.InstanceConfig.Tags,
+	}, env)
+	return &environs.StartInstanceResult{
+		Instance: inst,
+		Hardware: hw,
+	}, nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances(ctx context.ProviderCallContext) (instances []instance.Instance, err error) {
+	err = env.withConnection(func(conn Connection) error {
+		instances, err = env.allInstances(conn)
+		return err
+	})
+	return instances, err
+}
+
+// allInstances returns the instances for the domains tagged with the
+// model's UUID.
+func (env *environ) allInstances(conn Connection) ([]instance.Instance, error) {
+	domains, err := conn.Domains()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing domains")
+	}
+	modelUUID := env.Config().UUID()
+	var results []instance.Instance
+	for _, domain := range domains {
+		if domain.Tags[tags.JujuModel] == modelUUID {
+			results = append(results, newInstance(domain, env))
+		}
+	}
+	return results, nil
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	return env.withConnection(func(conn Connection) error {
+		return env.stopInstances(conn, ids...)
+	})
+}
+
+func (env *environ) stopInstances(conn Connection, ids ...instance.Id) error {
+	var errIds []instance.Id
+	var errs []error
+	for _, id := range ids {
+		if err := env.removeInstance(conn, string(id), true); err != nil {
+			errIds = append(errIds, id)
+			errs = append(errs, err)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.Annotatef(errs[0], "failed to stop instance %s", errIds[0])
+	default:
+		return errors.Errorf(
+			"failed to stop instances %s: %s",
+			errIds, errs,
+		)
+	}
+}
+
+// removeInstance destroys the named domain and deletes its volumes. The
+// base image is left alone, as it may be used by other domains. If the
+// domain is expected to exist, failing to destroy it is an error; when
+// cleaning up after a failed start, it may never have been defined.
+func (env *environ) removeInstance(conn Connection, name string, defined bool) error {
+	if err := conn.DestroyDomain(name); err != nil {
+		if defined {
+			return errors.Trace(err)
+		}
+		logger.Debugf("destroying domain %q: %v", name, err)
+	}
+	pool := env.environConfig().volumePool()
+	for _, volume := range []string{rootDiskName(name), dataSourceDiskName(name)} {
+		if err := conn.DeleteVolume(pool, volume); err != nil {
+			logger.Infof("removing volume %q: %v", volume, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	kvmlibvirt "github.com/juju/juju/container/kvm/libvirt"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environBrokerSuite struct {
+	EnvironFixture
+	statusCallbackStub testing.Stub
+	userData           []byte
+}
+
+var _ = gc.Suite(&environBrokerSuite{})
+
+func (s *environBrokerSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.statusCallbackStub.ResetCalls()
+
+	// The base image is already in the pool, so nothing is downloaded.
+	s.conn.volumes = []string{"juju-trusty-amd64-base"}
+	s.userData = nil
+	s.PatchValue(libvirt.MakeDataSourceISO, func(hostname string, userData []byte) ([]byte, error) {
+		s.userData = userData
+		return []byte("iso"), nil
+	})
+	s.PatchValue(libvirt.GenerateMAC, func() (string, error) {
+		return "52:54:00:01:02:03", nil
+	})
+}
+
+func (s *environBrokerSuite) createStartInstanceArgs(c *gc.C) environs.StartInstanceParams {
+	var cons constraints.Value
+	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(
+		coretesting.FakeControllerConfig(), cons, cons, "trusty", "",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.AuthorizedKeys = fakeConfig(c).AuthorizedKeys()
+	instanceConfig.Tags = map[string]string{
+		"juju-model-uuid": "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+	}
+
+	tools := coretools.List{{
+		Version: version.Binary{
+			Number: version.MustParse("1.2.3"),
+			Arch:   arch.AMD64,
+			Series: "trusty",
+		},
+		URL: "https://example.org",
+	}}
+	err = instanceConfig.SetTools(tools)
+	c.Assert(err, jc.ErrorIsNil)
+
+	return environs.StartInstanceParams{
+		ControllerUUID: instanceConfig.Controller.Config.ControllerUUID(),
+		InstanceConfig: instanceConfig,
+		Tools:          tools,
+		Constraints:    cons,
+		StatusCallback: func(status status.Status, info string, data map[string]interface{}) error {
+			s.statusCallbackStub.AddCall("StatusCallback", status, info, data)
+			return s.statusCallbackStub.NextErr()
+		},
+	}
+}
+
+func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	result, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+
+	// Controllers get more memory by default, and the root disk is
+	// no smaller than the series minimum.
+	c.Assert(*result.Hardware.Arch, gc.Equals, arch.AMD64)
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(3584))
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(1))
+	c.Assert(*result.Hardware.RootDisk, gc.Equals, uint64(8192))
+
+	cred := fakeCredential()
+	s.connectStub.CheckCall(c, 0, "Connect", "qemu+ssh://ubuntu@host/system", &cred)
+	s.conn.CheckCallNames(c,
+		"Volumes", "CreateVolume", "UploadVolume", "DefineDomain", "StartDomain", "Close",
+	)
+	s.conn.CheckCall(c, 1, "CreateVolume", "default", libvirt.VolumeParams{
+		Name:          "juju-f75cba-0.qcow2",
+		Format:        "qcow2",
+		Size:          8 << 30,
+		BackingVolume: "juju-trusty-amd64-base",
+	})
+	s.conn.CheckCall(c, 2, "UploadVolume", "default", libvirt.VolumeParams{
+		Name:   "juju-f75cba-0-ds.iso",
+		Format: "raw",
+		Size:   3,
+	})
+
+	defineArgs := s.conn.Calls()[3].Args
+	domain := defineArgs[0].(kvmlibvirt.Domain)
+	c.Assert(domain.Name, gc.Equals, "juju-f75cba-0")
+	c.Assert(domain.VCPU, gc.Equals, uint64(1))
+	c.Assert(domain.Disk, gc.HasLen, 2)
+	c.Assert(domain.Disk[0].Source.File, gc.Equals, "/pools/default/juju-f75cba-0.qcow2")
+	c.Assert(domain.Disk[1].Source.File, gc.Equals, "/pools/default/juju-f75cba-0-ds.iso")
+	c.Assert(domain.Interface, gc.HasLen, 1)
+	c.Assert(domain.Interface[0].MAC.Address, gc.Equals, "52:54:00:01:02:03")
+	c.Assert(domain.Interface[0].Source.Bridge, gc.Equals, "virbr0")
+	c.Assert(defineArgs[1], jc.DeepEquals, args.InstanceConfig.Tags)
+
+	s.conn.CheckCall(c, 4, "StartDomain", "juju-f75cba-0")
+	s.statusCallbackStub.CheckCallNames(c, "StatusCallback", "StatusCallback")
+}
+
+func (s *environBrokerSuite) TestStartInstanceControllerPackages(c *gc.C) {
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(s.userData), jc.Contains, "- libvirt-clients")
+	c.Assert(string(s.userData), jc.Contains, "- genisoimage")
+}
+
+func (s *environBrokerSuite) TestStartInstanceConstraints(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.Constraints = constraints.MustParse("mem=4G cores=2 root-disk=20G")
+	result, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(4096))
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(2))
+	c.Assert(*result.Hardware.RootDisk, gc.Equals, uint64(20480))
+
+	createArgs := s.conn.Calls()[1].Args[1].(libvirt.VolumeParams)
+	c.Assert(createArgs.Size, gc.Equals, uint64(20<<30))
+}
+
+func (s *environBrokerSuite) TestStartInstanceCleansUpOnFailure(c *gc.C) {
+	s.conn.SetErrors(
+		nil,                // Volumes
+		nil,                // CreateVolume
+		nil,                // UploadVolume
+		nil,                // DefineDomain
+		errors.New("boom"), // StartDomain
+	)
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	s.conn.CheckCallNames(c,
+		"Volumes", "CreateVolume", "UploadVolume", "DefineDomain", "StartDomain",
+		"DestroyDomain", "DeleteVolume", "DeleteVolume", "Close",
+	)
+	s.conn.CheckCall(c, 6, "DeleteVolume", "default", "juju-f75cba-0.qcow2")
+	s.conn.CheckCall(c, 7, "DeleteVolume", "default", "juju-f75cba-0-ds.iso")
+}
+
+func (s *environBrokerSuite) TestStartInstanceAvailabilityZone(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.AvailabilityZone = "z1"
+	_, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, gc.ErrorMatches, "availability zones not supported")
+	s.conn.CheckNoCalls(c)
+}
+
+func (s *environBrokerSuite) TestAllInstances(c *gc.C) {
+	s.conn.domains = []libvirt.DomainInfo{{
+		Name:  "juju-f75cba-0",
+		State: "running",
+		Tags:  map[string]string{"juju-model-uuid": "2d02eeac-9dbb-11e4-89d3-123b93f75cba"},
+	}, {
+		Name:  "juju-ffffff-0",
+		State: "running",
+		Tags:  map[string]string{"juju-model-uuid": "another-model"},
+	}, {
+		Name:  "not-juju",
+		State: "shut off",
+	}}
+
+	instances, err := s.env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+	c.Assert(instances[0].Status(s.callCtx).Status, gc.Equals, status.Running)
+}
+
+func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
+	err := s.env.StopInstances(s.callCtx, "juju-f75cba-0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.conn.CheckCallNames(c, "DestroyDomain", "DeleteVolume", "DeleteVolume", "Close")
+	s.conn.CheckCall(c, 0, "DestroyDomain", "juju-f75cba-0")
+}
+
+func (s *environBrokerSuite) TestStopInstancesDestroyFails(c *gc.C) {
+	s.conn.SetErrors(errors.New("boom"))
+	err := s.env.StopInstances(s.callCtx, "juju-f75cba-0")
+	c.Assert(err, gc.ErrorMatches, "failed to stop instance juju-f75cba-0: boom")
+	s.conn.CheckCallNames(c, "DestroyDomain", "Close")
+}
+
+func (s *environBrokerSuite) TestControllerInstances(c *gc.C) {
+	s.conn.domains = []libvirt.DomainInfo{{
+		Name: "juju-f75cba-0",
+		Tags: map[string]string{
+			"juju-model-uuid":      "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+			"juju-is-controller":   "true",
+		},
+	}, {
+		Name: "juju-f75cba-1",
+		Tags: map[string]string{
+			"juju-model-uuid":      "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+		},
+	}}
+
+	ids, err := s.env.ControllerInstances(s.callCtx, coretesting.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"juju-f75cba-0"})
+}
+
+func (s *environBrokerSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
+	_, err := s.env.ControllerInstances(s.callCtx, coretesting.ControllerTag.Id())
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
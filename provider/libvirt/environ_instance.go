@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+)
+
+// Instances is part of the environs.Environ interface.
+func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instance.Instance, error) {
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+
+	allInstances, err := env.AllInstances(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to get instances")
+	}
+	findInst := func(id instance.Id) instance.Instance {
+		for _, inst := range allInstances {
+			if id == inst.Id() {
+				return inst
+			}
+		}
+		return nil
+	}
+
+	var numFound int
+	results := make([]instance.Instance, len(ids))
+	for i, id := range ids {
+		if inst := findInst(id); inst != nil {
+			results[i] = inst
+			numFound++
+		}
+	}
+	if numFound == 0 {
+		return nil, environs.ErrNoInstances
+	} else if numFound != len(ids) {
+		err = environs.ErrPartialInstances
+	}
+	return results, err
+}
+
+// ControllerInstances is part of the environs.Environ interface.
+func (env *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
+	instances, err := env.AllInstances(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var results []instance.Id
+	for _, inst := range instances {
+		domainTags := inst.(*environInstance).domain.Tags
+		if domainTags[tags.JujuIsController] == "true" &&
+			domainTags[tags.JujuController] == controllerUUID {
+			results = append(results, inst.Id())
+		}
+	}
+	if len(results) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	return results, nil
+}
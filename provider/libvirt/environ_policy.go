@@ -0,0 +1,42 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// PrecheckInstance is part of the environs.Environ interface.
+func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	if args.Placement != "" {
+		return errors.Errorf("unknown placement directive: %v", args.Placement)
+	}
+	return nil
+}
+
+var unsupportedConstraints = []string{
+	constraints.Tags,
+	constraints.InstanceType,
+	constraints.Zones,
+	constraints.VirtType,
+	constraints.CpuPower,
+	constraints.InstanceLifecycle,
+	constraints.MaxPrice,
+}
+
+// ConstraintsValidator returns a Validator value which is used to
+// validate and merge constraints.
+func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{
+		arch.AMD64, arch.ARM64,
+	})
+	return validator, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+)
+
+type environPolSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environPolSuite{})
+
+func (s *environPolSuite) TestConstraintsValidator(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("arch=amd64 mem=4G cores=2 root-disk=20G")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(unsupported, gc.HasLen, 0)
+}
+
+func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("arch=amd64 tags=foo virt-type=kvm instance-type=large cpu-power=100")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(unsupported, jc.SameContents, []string{"tags", "virt-type", "instance-type", "cpu-power"})
+}
+
+func (s *environPolSuite) TestConstraintsValidatorVocabArch(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("arch=ppc64el")
+	_, err = validator.Validate(cons)
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: arch=ppc64el\nvalid values are:.*")
+}
+
+func (s *environPolSuite) TestPrecheckInstancePlacement(c *gc.C) {
+	err := s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Series:    "xenial",
+		Placement: "zone=foo",
+	})
+	c.Assert(err, gc.ErrorMatches, "unknown placement directive: zone=foo")
+}
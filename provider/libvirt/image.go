@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/container/kvm"
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/simplestreams"
+)
+
+// imageDownloadSource returns the simplestreams source of cloud image
+// downloads. It is patched in tests.
+var imageDownloadSource func() simplestreams.DataSource
+
+// baseImageName returns the name of the volume holding the cloud image
+// that instances of the given series and architecture are based on.
+func baseImageName(series, arch string) string {
+	return fmt.Sprintf("juju-%s-%s-base", series, arch)
+}
+
+// ensureBaseImage ensures that the pool holds a cloud image for the given
+// series and architecture, downloading and uploading it if necessary, and
+// returns the name of its volume. The image is shared by every instance
+// of the series and architecture, and is never removed by the provider.
+func ensureBaseImage(
+	conn Connection,
+	pool, series, imageArch, stream string,
+	updateProgress func(string),
+) (string, error) {
+	name := baseImageName(series, imageArch)
+	volumes, err := conn.Volumes(pool)
+	if err != nil {
+		return "", errors.Annotatef(err, "listing volumes in pool %q", pool)
+	}
+	for _, volume := range volumes {
+		if volume == name {
+			return name, nil
+		}
+	}
+
+	ftype := kvm.BIOSFType
+	if imageArch == arch.ARM64 {
+		ftype = kvm.UEFIFType
+	}
+	md, err := imagedownloads.One(imageArch, series, stream, ftype, imageDownloadSource)
+	if err != nil {
+		return "", errors.Annotatef(err, "finding %s %s image", series, imageArch)
+	}
+	imageURL, err := md.DownloadURL()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	updateProgress(fmt.Sprintf("downloading image %s", imageURL))
+	resp, err := http.Get(imageURL.String())
+	if err != nil {
+		return "", errors.Annotatef(err, "downloading image %s", imageURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("downloading image %s: %s", imageURL, resp.Status)
+	}
+
+	hash := sha256.New()
+	if _, err := conn.UploadVolume(pool, VolumeParams{
+		Name:   name,
+		Format: "qcow2",
+		Size:   uint64(md.Size),
+	}, io.TeeReader(resp.Body, hash)); err != nil {
+		return "", errors.Annotatef(err, "uploading image %s", imageURL)
+	}
+	if md.SHA256 != "" && hex.EncodeToString(hash.Sum(nil)) != md.SHA256 {
+		if err := conn.DeleteVolume(pool, name); err != nil {
+			logger.Errorf("failed to remove corrupt image: %v", err)
+		}
+		return "", errors.Errorf("image %s failed checksum verification", imageURL)
+	}
+	return name, nil
+}
@@ -0,0 +1,14 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/juju/environs"
+)
+
+func init() {
+	environs.RegisterProvider(providerType, NewEnvironProvider(EnvironProviderConfig{
+		Connect: Connect,
+	}))
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/status"
+)
+
+type environInstance struct {
+	domain DomainInfo
+	env    *environ
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+
+func newInstance(domain DomainInfo, env *environ) *environInstance {
+	return &environInstance{
+		domain: domain,
+		env:    env,
+	}
+}
+
+// Id implements instance.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(inst.domain.Name)
+}
+
+// Status implements instance.Instance.
+func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.InstanceStatus {
+	instanceStatus := instance.InstanceStatus{
+		Status:  status.Stopped,
+		Message: inst.domain.State,
+	}
+	if inst.domain.State == "running" {
+		instanceStatus.Status = status.Running
+	}
+	return instanceStatus
+}
+
+// Addresses implements instance.Instance.
+func (inst *environInstance) Addresses(ctx context.ProviderCallContext) ([]network.Address, error) {
+	var ips []string
+	err := inst.env.withConnection(func(conn Connection) error {
+		var err error
+		ips, err = conn.DomainAddresses(inst.domain.Name)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := make([]network.Address, 0, len(ips))
+	for _, ip := range ips {
+		res = append(res, network.NewAddress(ip))
+	}
+	return res, nil
+}
+
+// Instances are attached directly to a host bridge, so there is no
+// firewall to manage.
+
+// OpenPorts implements instance.Instance.
+func (inst *environInstance) OpenPorts(ctx context.ProviderCallContext, machineID string, rules []network.IngressRule) error {
+	return nil
+}
+
+// ClosePorts implements instance.Instance.
+func (inst *environInstance) ClosePorts(ctx context.ProviderCallContext, machineID string, rules []network.IngressRule) error {
+	return nil
+}
+
+// IngressRules implements instance.Instance.
+func (inst *environInstance) IngressRules(ctx context.ProviderCallContext, machineID string) ([]network.IngressRule, error) {
+	return nil, nil
+}
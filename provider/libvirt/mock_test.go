@@ -0,0 +1,138 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"io"
+	"sync"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/cloud"
+	kvmlibvirt "github.com/juju/juju/container/kvm/libvirt"
+	"github.com/juju/juju/provider/libvirt"
+)
+
+func newMockConnectFunc(connectStub *testing.Stub, conn libvirt.Connection) libvirt.ConnectFunc {
+	return func(uri string, credential *cloud.Credential) (libvirt.Connection, error) {
+		connectStub.AddCall("Connect", uri, credential)
+		if err := connectStub.NextErr(); err != nil {
+			return nil, err
+		}
+		return conn, nil
+	}
+}
+
+type mockConnection struct {
+	// mu guards testing.Stub access, to ensure that the recorded
+	// method calls correspond to the errors returned.
+	mu sync.Mutex
+	testing.Stub
+
+	domains   []libvirt.DomainInfo
+	volumes   []string
+	addresses []string
+	disks     []libvirt.DomainDisk
+}
+
+func (c *mockConnection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "Close")
+	return c.NextErr()
+}
+
+func (c *mockConnection) Domains() ([]libvirt.DomainInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "Domains")
+	return c.domains, c.NextErr()
+}
+
+func (c *mockConnection) DefineDomain(domain kvmlibvirt.Domain, tags map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DefineDomain", domain, tags)
+	return c.NextErr()
+}
+
+func (c *mockConnection) StartDomain(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "StartDomain", name)
+	return c.NextErr()
+}
+
+func (c *mockConnection) DestroyDomain(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DestroyDomain", name)
+	return c.NextErr()
+}
+
+func (c *mockConnection) DomainAddresses(name string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DomainAddresses", name)
+	return c.addresses, c.NextErr()
+}
+
+func (c *mockConnection) DomainDisks(name string) ([]libvirt.DomainDisk, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DomainDisks", name)
+	return c.disks, c.NextErr()
+}
+
+func (c *mockConnection) AttachDisk(name string, disk libvirt.DomainDisk) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "AttachDisk", name, disk)
+	return c.NextErr()
+}
+
+func (c *mockConnection) DetachDisk(name, target string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DetachDisk", name, target)
+	return c.NextErr()
+}
+
+func (c *mockConnection) Volumes(pool string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "Volumes", pool)
+	return c.volumes, c.NextErr()
+}
+
+func (c *mockConnection) CreateVolume(pool string, args libvirt.VolumeParams) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "CreateVolume", pool, args)
+	return "/pools/" + pool + "/" + args.Name, c.NextErr()
+}
+
+func (c *mockConnection) UploadVolume(pool string, args libvirt.VolumeParams, r io.Reader) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "UploadVolume", pool, args)
+	return "/pools/" + pool + "/" + args.Name, c.NextErr()
+}
+
+func (c *mockConnection) VolumeInfo(pool, name string) (libvirt.VolumeInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "VolumeInfo", pool, name)
+	return libvirt.VolumeInfo{
+		Path: "/pools/" + pool + "/" + name,
+		Size: 1 << 30,
+	}, c.NextErr()
+}
+
+func (c *mockConnection) DeleteVolume(pool, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DeleteVolume", pool, name)
+	return c.NextErr()
+}
@@ -0,0 +1,200 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package libvirt implements a Juju provider that runs every machine in
+// a model as a full KVM virtual machine on a libvirt host. It builds on
+// the domain definitions in container/kvm/libvirt: instance disks are
+// volumes in a libvirt storage pool, backed by a cached Ubuntu cloud
+// image, and instances are attached to a host bridge.
+package libvirt
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var logger = loggo.GetLogger("juju.provider.libvirt")
+
+const (
+	providerType = "libvirt"
+)
+
+type environProvider struct {
+	environProviderCredentials
+	connect ConnectFunc
+}
+
+var _ environs.CloudEnvironProvider = (*environProvider)(nil)
+
+// EnvironProviderConfig contains configuration for the EnvironProvider.
+type EnvironProviderConfig struct {
+	// Connect is a function used for connecting to libvirt hosts.
+	Connect ConnectFunc
+}
+
+// NewEnvironProvider returns a new environs.EnvironProvider that will
+// connect to libvirt hosts with the given connect function.
+func NewEnvironProvider(config EnvironProviderConfig) environs.CloudEnvironProvider {
+	return &environProvider{
+		connect: config.Connect,
+	}
+}
+
+// Version implements environs.EnvironProvider.
+func (p *environProvider) Version() int {
+	return 0
+}
+
+// Open implements environs.EnvironProvider.
+func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(p, args.Cloud, args.Config)
+	return env, errors.Trace(err)
+}
+
+var cloudSchema = &jsonschema.Schema{
+	Type:     []jsonschema.Type{jsonschema.ObjectType},
+	Required: []string{cloud.EndpointKey, cloud.AuthTypesKey},
+	Properties: map[string]*jsonschema.Schema{
+		cloud.EndpointKey: {
+			Singular: "the libvirt connection URI, reachable from the controller (e.g. qemu+ssh://user@host/system)",
+			Type:     []jsonschema.Type{jsonschema.StringType},
+			Format:   jsonschema.FormatURI,
+		},
+		cloud.AuthTypesKey: {
+			Singular:    "auth type",
+			Plural:      "auth types",
+			Type:        []jsonschema.Type{jsonschema.ArrayType},
+			UniqueItems: jsonschema.Bool(true),
+			Items: &jsonschema.ItemSpec{
+				Schemas: []*jsonschema.Schema{{
+					Type: []jsonschema.Type{jsonschema.StringType},
+					Enum: []interface{}{
+						string(sshKeyAuthType),
+						string(cloud.CertificateAuthType),
+					},
+				}},
+			},
+		},
+	},
+}
+
+// CloudSchema returns the schema for adding new clouds of this type.
+func (p *environProvider) CloudSchema() *jsonschema.Schema {
+	return cloudSchema
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (p *environProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
+	if err := validateEndpoint(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	conn, err := p.connect(endpoint, nil)
+	if err != nil {
+		logger.Errorf("unexpected error connecting to libvirt: %v", err)
+		return errors.Errorf("no libvirt host available at %s", endpoint)
+	}
+	return errors.Trace(conn.Close())
+}
+
+// PrepareConfig implements environs.EnvironProvider.
+func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate implements environs.EnvironProvider.
+func (*environProvider) Validate(cfg, old *config.Config) (valid *config.Config, err error) {
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	if old == nil {
+		ecfg, err := newValidConfig(cfg)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid config")
+		}
+		return ecfg.Config, nil
+	}
+
+	ecfg, err := newValidConfig(old)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid base config")
+	}
+
+	if err := ecfg.update(cfg); err != nil {
+		return nil, errors.Annotate(err, "invalid config change")
+	}
+
+	return ecfg.Config, nil
+}
+
+// ConfigSchema returns extra config attributes specific
+// to this provider only.
+func (p *environProvider) ConfigSchema() schema.Fields {
+	return configFields
+}
+
+// ConfigDefaults returns the default values for the
+// provider specific config attributes.
+func (p *environProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+func validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateEndpoint(spec.Endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	// The endpoint has been validated, so it parses.
+	u, _ := url.Parse(spec.Endpoint)
+	switch authType := spec.Credential.AuthType(); authType {
+	case sshKeyAuthType, cloud.CertificateAuthType:
+		if expected := authTypes[u.Scheme]; authType != expected {
+			return errors.NotValidf("%q auth-type for %s endpoint (expected %q)", authType, u.Scheme, expected)
+		}
+	default:
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	return nil
+}
+
+// authTypes maps the schemes of the supported connection URIs to the
+// auth-type of the credential used to connect with them.
+var authTypes = map[string]cloud.AuthType{
+	"qemu+ssh": sshKeyAuthType,
+	"qemu+tls": cloud.CertificateAuthType,
+}
+
+// validateEndpoint checks that the endpoint is a QEMU/KVM connection URI
+// for a remote host, such as qemu+ssh://user@host/system. The URI must
+// be usable from the controller as well as from the client, so local
+// URIs such as qemu:///system are not valid.
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return errors.NotValidf("endpoint %q", endpoint)
+	}
+	if _, ok := authTypes[u.Scheme]; !ok || u.Host == "" {
+		return errors.NotValidf("endpoint %q (expected a qemu+ssh or qemu+tls connection URI with a host)", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type providerSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) TestRegistered(c *gc.C) {
+	provider, err := environs.Provider("libvirt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.NotNil)
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	envConfig := env.Config()
+	c.Assert(envConfig.Name(), gc.Equals, "testmodel")
+	c.Assert(envConfig.UnknownAttrs()["network-bridge"], gc.Equals, "virbr0")
+	c.Assert(envConfig.UnknownAttrs()["volume-pool"], gc.Equals, "default")
+}
+
+func (s *providerSuite) TestOpenInvalidEndpoint(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "https://example.com"
+	s.testOpenError(c, spec, `validating cloud spec: endpoint "https://example.com" \(expected a qemu\+ssh or qemu\+tls connection URI with a host\) not valid`)
+}
+
+func (s *providerSuite) TestOpenLocalEndpoint(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "qemu:///system"
+	s.testOpenError(c, spec, `validating cloud spec: endpoint "qemu:///system" \(expected a qemu\+ssh or qemu\+tls connection URI with a host\) not valid`)
+}
+
+func (s *providerSuite) TestOpenMismatchedCredential(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "qemu+tls://host/system"
+	s.testOpenError(c, spec, `validating cloud spec: "ssh-key" auth-type for qemu\+tls endpoint \(expected "certificate"\) not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{})
+	spec := fakeCloudSpec()
+	spec.Credential = &credential
+	s.testOpenError(c, spec, `validating cloud spec: "userpass" auth-type not supported`)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, spec environs.CloudSpec, expect string) {
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestValidate(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{"network-bridge": "br0"})
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.UnknownAttrs()["network-bridge"], gc.Equals, "br0")
+}
+
+func (s *providerSuite) TestValidateApplicationFirewallMode(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{"firewall-mode": "application"})
+	_, err := s.provider.Validate(config, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: firewall-mode "application" not supported`)
+}
+
+func (s *providerSuite) TestValidateImmutableVolumePool(c *gc.C) {
+	old := fakeConfig(c)
+	config := fakeConfig(c, coretesting.Attrs{"volume-pool": "juju"})
+	_, err := s.provider.Validate(config, old)
+	c.Assert(err, gc.ErrorMatches, "invalid config change: volume-pool: cannot change from default to juju")
+}
+
+func (s *providerSuite) TestPing(c *gc.C) {
+	err := s.provider.Ping(s.callCtx, "qemu+ssh://ubuntu@host/system")
+	c.Assert(err, jc.ErrorIsNil)
+	s.connectStub.CheckCall(c, 0, "Connect", "qemu+ssh://ubuntu@host/system", (*cloud.Credential)(nil))
+	s.conn.CheckCallNames(c, "Close")
+}
+
+func (s *providerSuite) TestPingConnectFails(c *gc.C) {
+	s.connectStub.SetErrors(errors.New("boom"))
+	err := s.provider.Ping(s.callCtx, "qemu+ssh://ubuntu@host/system")
+	c.Assert(err, gc.ErrorMatches, "no libvirt host available at qemu\\+ssh://ubuntu@host/system")
+}
+
+func (s *providerSuite) TestPingInvalidEndpoint(c *gc.C) {
+	err := s.provider.Ping(s.callCtx, "lxd://host")
+	c.Assert(err, gc.ErrorMatches, `endpoint "lxd://host" \(expected a qemu\+ssh or qemu\+tls connection URI with a host\) not valid`)
+	s.connectStub.CheckNoCalls(c)
+}
@@ -0,0 +1,310 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	storageProviderType = storage.ProviderType("libvirt")
+
+	// volumeFormat is the format of the volumes created for storage.
+	// Raw volumes are attached as they are, with no backing store.
+	volumeFormat = "raw"
+)
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (*environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return []storage.ProviderType{storageProviderType}, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == storageProviderType {
+		return &storageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// storageProvider creates volumes in the model's volume pool, and
+// attaches them to instances as virtio disks.
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// ValidateConfig is part of the storage.Provider interface.
+func (*storageProvider) ValidateConfig(cfg *storage.Config) error {
+	return nil
+}
+
+// Supports is part of the storage.Provider interface.
+func (*storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface.
+func (*storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (*storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is part of the storage.Provider interface.
+func (*storageProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is part of the storage.Provider interface.
+func (*storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (*storageProvider) FilesystemSource(*storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// VolumeSource is part of the storage.Provider interface.
+func (p *storageProvider) VolumeSource(*storage.Config) (storage.VolumeSource, error) {
+	return &volumeSource{env: p.env}, nil
+}
+
+type volumeSource struct {
+	env *environ
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// volumeNamePrefix returns the prefix of the names of the model's
+// volumes, which distinguishes them from the volumes of other models
+// and from instance disks.
+func (v *volumeSource) volumeNamePrefix() string {
+	return v.env.namespace.Prefix() + "volume-"
+}
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(params))
+	pool := v.env.environConfig().volumePool()
+	err := v.env.withConnection(func(conn Connection) error {
+		for i, p := range params {
+			if err := v.ValidateVolumeParams(p); err != nil {
+				results[i].Error = err
+				continue
+			}
+			name := v.env.namespace.Value(p.Tag.String())
+			size := p.Size << 20
+			if _, err := conn.CreateVolume(pool, VolumeParams{
+				Name:   name,
+				Format: volumeFormat,
+				Size:   size,
+			}); err != nil {
+				results[i].Error = errors.Trace(err)
+				continue
+			}
+			results[i].Volume = &storage.Volume{
+				Tag: p.Tag,
+				VolumeInfo: storage.VolumeInfo{
+					VolumeId:   name,
+					Size:       p.Size,
+					Persistent: true,
+				},
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	var volumes []string
+	pool := v.env.environConfig().volumePool()
+	err := v.env.withConnection(func(conn Connection) error {
+		names, err := conn.Volumes(pool)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		prefix := v.volumeNamePrefix()
+		for _, name := range names {
+			if strings.HasPrefix(name, prefix) {
+				volumes = append(volumes, name)
+			}
+		}
+		return nil
+	})
+	return volumes, errors.Trace(err)
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	pool := v.env.environConfig().volumePool()
+	err := v.env.withConnection(func(conn Connection) error {
+		for i, volId := range volIds {
+			info, err := conn.VolumeInfo(pool, volId)
+			if err != nil {
+				results[i].Error = errors.Trace(err)
+				continue
+			}
+			results[i].VolumeInfo = &storage.VolumeInfo{
+				VolumeId:   volId,
+				Size:       info.Size >> 20,
+				Persistent: true,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	results := make([]error, len(volIds))
+	pool := v.env.environConfig().volumePool()
+	err := v.env.withConnection(func(conn Connection) error {
+		for i, volId := range volIds {
+			results[i] = conn.DeleteVolume(pool, volId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// ReleaseVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	return nil, errors.NotSupportedf("releasing volumes")
+}
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(params))
+	pool := v.env.environConfig().volumePool()
+	err := v.env.withConnection(func(conn Connection) error {
+		for i, p := range params {
+			target, err := attachVolume(conn, pool, p.InstanceId, p.VolumeId)
+			if err != nil {
+				results[i].Error = errors.Annotatef(err, "attaching %v to %v", p.Volume.Id(), p.Machine.Id())
+				continue
+			}
+			results[i].VolumeAttachment = &storage.VolumeAttachment{
+				Volume:  p.Volume,
+				Machine: p.Machine,
+				VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+					DeviceName: target,
+				},
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// attachVolume attaches the volume to the domain, if it is not attached
+// already, and returns its target device name.
+func attachVolume(conn Connection, pool string, instId instance.Id, volId string) (string, error) {
+	info, err := conn.VolumeInfo(pool, volId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	disks, err := conn.DomainDisks(string(instId))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	targets := set.NewStrings()
+	for _, disk := range disks {
+		if disk.Source == info.Path {
+			return disk.Target, nil
+		}
+		targets.Add(disk.Target)
+	}
+	target, err := nextDiskTarget(targets)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := conn.AttachDisk(string(instId), DomainDisk{
+		Target: target,
+		Source: info.Path,
+	}); err != nil {
+		return "", errors.Trace(err)
+	}
+	return target, nil
+}
+
+// nextDiskTarget returns the first virtio disk device name that is not
+// in use.
+func nextDiskTarget(inUse set.Strings) (string, error) {
+	for c := 'a'; c <= 'z'; c++ {
+		target := fmt.Sprintf("vd%c", c)
+		if !inUse.Contains(target) {
+			return target, nil
+		}
+	}
+	return "", errors.New("no free disk device names")
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(params))
+	pool := v.env.environConfig().volumePool()
+	err := v.env.withConnection(func(conn Connection) error {
+		for i, p := range params {
+			results[i] = detachVolume(conn, pool, p.InstanceId, p.VolumeId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// detachVolume detaches the volume from the domain, if it is attached.
+func detachVolume(conn Connection, pool string, instId instance.Id, volId string) error {
+	info, err := conn.VolumeInfo(pool, volId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	disks, err := conn.DomainDisks(string(instId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, disk := range disks {
+		if disk.Source == info.Path {
+			return errors.Trace(conn.DetachDisk(string(instId), disk.Target))
+		}
+	}
+	return nil
+}
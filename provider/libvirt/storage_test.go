@@ -0,0 +1,149 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	EnvironFixture
+	source storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	registry := s.env.(storage.ProviderRegistry)
+	types, err := registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"libvirt"})
+
+	provider, err := registry.StorageProvider("libvirt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(provider.Dynamic(), jc.IsTrue)
+	s.source, err = provider.VolumeSource(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	results, err := s.source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     1024,
+		Provider: "libvirt",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		Tag: names.NewVolumeTag("0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   "juju-f75cba-volume-0",
+			Size:       1024,
+			Persistent: true,
+		},
+	})
+	s.conn.CheckCallNames(c, "CreateVolume", "Close")
+	s.conn.CheckCall(c, 0, "CreateVolume", "default", libvirt.VolumeParams{
+		Name:   "juju-f75cba-volume-0",
+		Format: "raw",
+		Size:   1 << 30,
+	})
+}
+
+func (s *storageSuite) TestListVolumes(c *gc.C) {
+	s.conn.volumes = []string{
+		"juju-f75cba-0.qcow2",
+		"juju-f75cba-volume-0",
+		"juju-ffffff-volume-0",
+		"juju-trusty-amd64-base",
+	}
+	volumes, err := s.source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, jc.DeepEquals, []string{"juju-f75cba-volume-0"})
+}
+
+func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
+	results, err := s.source.DescribeVolumes(s.callCtx, []string{"juju-f75cba-volume-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.DescribeVolumesResult{{
+		VolumeInfo: &storage.VolumeInfo{
+			VolumeId:   "juju-f75cba-volume-0",
+			Size:       1024,
+			Persistent: true,
+		},
+	}})
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	results, err := s.source.DestroyVolumes(s.callCtx, []string{"juju-f75cba-volume-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+	s.conn.CheckCall(c, 0, "DeleteVolume", "default", "juju-f75cba-volume-0")
+}
+
+func (s *storageSuite) attachmentParams() []storage.VolumeAttachmentParams {
+	return []storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "libvirt",
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "juju-f75cba-0",
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju-f75cba-volume-0",
+	}}
+}
+
+func (s *storageSuite) TestAttachVolumes(c *gc.C) {
+	s.conn.disks = []libvirt.DomainDisk{
+		{Target: "vda", Source: "/pools/default/juju-f75cba-0.qcow2"},
+		{Target: "vdb", Source: "/pools/default/juju-f75cba-0-ds.iso"},
+	}
+	results, err := s.source.AttachVolumes(s.callCtx, s.attachmentParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		Volume:  names.NewVolumeTag("0"),
+		Machine: names.NewMachineTag("0"),
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceName: "vdc",
+		},
+	})
+	s.conn.CheckCallNames(c, "VolumeInfo", "DomainDisks", "AttachDisk", "Close")
+	s.conn.CheckCall(c, 2, "AttachDisk", "juju-f75cba-0", libvirt.DomainDisk{
+		Target: "vdc",
+		Source: "/pools/default/juju-f75cba-volume-0",
+	})
+}
+
+func (s *storageSuite) TestAttachVolumesAlreadyAttached(c *gc.C) {
+	s.conn.disks = []libvirt.DomainDisk{
+		{Target: "vda", Source: "/pools/default/juju-f75cba-0.qcow2"},
+		{Target: "vdd", Source: "/pools/default/juju-f75cba-volume-0"},
+	}
+	results, err := s.source.AttachVolumes(s.callCtx, s.attachmentParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment.DeviceName, gc.Equals, "vdd")
+	s.conn.CheckCallNames(c, "VolumeInfo", "DomainDisks", "Close")
+}
+
+func (s *storageSuite) TestDetachVolumes(c *gc.C) {
+	s.conn.disks = []libvirt.DomainDisk{
+		{Target: "vda", Source: "/pools/default/juju-f75cba-0.qcow2"},
+		{Target: "vdc", Source: "/pools/default/juju-f75cba-volume-0"},
+	}
+	results, err := s.source.DetachVolumes(s.callCtx, s.attachmentParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+	s.conn.CheckCall(c, 2, "DetachDisk", "juju-f75cba-0", "vdc")
+}
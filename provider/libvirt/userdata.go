@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+)
+
+type libvirtRenderer struct{}
+
+// Render implements renderers.ProviderRenderer.
+func (libvirtRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		bytes, err := renderers.RenderYAML(cfg)
+		return bytes, errors.Trace(err)
+	default:
+		return nil, errors.Errorf("cannot encode userdata for OS %q", os)
+	}
+}
+
+// makeDataSourceISO returns an ISO image holding the user-data and
+// meta-data for cloud-init's NoCloud data source. See:
+// http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html
+var makeDataSourceISO = func(hostname string, userData []byte) ([]byte, error) {
+	dir, err := ioutil.TempDir("", "juju-libvirt-ds")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	metaData := fmt.Sprintf(`{"instance-id": %q, "local-hostname": %q}`, hostname, hostname)
+	if err := ioutil.WriteFile(filepath.Join(dir, "meta-data"), []byte(metaData), 0600); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "user-data"), userData, 0600); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The files must be named exactly user-data and meta-data at the
+	// root of a volume labelled "cidata".
+	isoPath := filepath.Join(dir, "ds.iso")
+	if _, err := runCommand(
+		"genisoimage",
+		"-output", isoPath,
+		"-volid", "cidata",
+		"-joliet", "-rock",
+		filepath.Join(dir, "user-data"),
+		filepath.Join(dir, "meta-data"),
+	); err != nil {
+		return nil, errors.Annotate(err, "creating cloud-init data source image")
+	}
+	return ioutil.ReadFile(isoPath)
+}
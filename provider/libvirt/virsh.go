@@ -0,0 +1,435 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+// This file contains the default Connection implementation, which wraps
+// the virsh executable from the libvirt-clients package. Every command is
+// run against the connection URI of the remote host, such as
+// qemu+ssh://user@host/system.
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/cloud"
+	kvmlibvirt "github.com/juju/juju/container/kvm/libvirt"
+)
+
+// runFunc provides the signature for running an external command and
+// returning the combined output.
+type runFunc func(string, ...string) (string, error)
+
+// runCommand runs the command and returns the combined output.
+func runCommand(command string, args ...string) (string, error) {
+	logger.Tracef("%s %v", command, args)
+	output, err := utils.RunCommand(command, args...)
+	if err != nil {
+		return output, errors.Errorf("%s %s: %v (%s)", command, args[0], err, strings.TrimSpace(output))
+	}
+	return output, nil
+}
+
+// Connect returns a Connection to the libvirt host with the given URI,
+// using the virsh executable. If a credential is given, the files it
+// holds are written to a temporary directory, which the URI is updated
+// to refer to, and which is removed when the connection is closed.
+func Connect(uri string, credential *cloud.Credential) (Connection, error) {
+	conn := &virshConnection{uri: uri, run: runCommand}
+	if credential != nil {
+		if err := conn.useCredential(*credential); err != nil {
+			return nil, errors.Annotate(err, "using credential")
+		}
+	}
+	if _, err := conn.virsh("uri"); err != nil {
+		conn.Close()
+		return nil, errors.Annotatef(err, "connecting to %q", uri)
+	}
+	return conn, nil
+}
+
+type virshConnection struct {
+	uri string
+	run runFunc
+
+	// dir is the temporary directory holding the credential files,
+	// if any.
+	dir string
+}
+
+// useCredential writes the files held by the credential to a temporary
+// directory, and adds the parameters that tell libvirt to use them to
+// the connection URI.
+func (c *virshConnection) useCredential(credential cloud.Credential) (err error) {
+	u, err := url.Parse(c.uri)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.dir, err = ioutil.TempDir("", "juju-libvirt")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			c.Close()
+		}
+	}()
+
+	attrs := credential.Attributes()
+	query := u.Query()
+	switch credential.AuthType() {
+	case sshKeyAuthType:
+		keyPath := filepath.Join(c.dir, "id")
+		if err := ioutil.WriteFile(keyPath, []byte(attrs[credAttrPrivateKey]), 0600); err != nil {
+			return errors.Trace(err)
+		}
+		// The controller has no record of the host's key, and
+		// nobody to ask about it.
+		query.Set("keyfile", keyPath)
+		query.Set("no_verify", "1")
+		query.Set("no_tty", "1")
+	case cloud.CertificateAuthType:
+		// These are the file names libvirt looks for in pkipath.
+		files := map[string]string{
+			"cacert.pem":     attrs[credAttrCACert],
+			"clientcert.pem": attrs[credAttrClientCert],
+			"clientkey.pem":  attrs[credAttrClientKey],
+		}
+		for name, content := range files {
+			if err := ioutil.WriteFile(filepath.Join(c.dir, name), []byte(content), 0600); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		query.Set("pkipath", c.dir)
+	default:
+		return errors.NotSupportedf("%q auth-type", credential.AuthType())
+	}
+	u.RawQuery = query.Encode()
+	c.uri = u.String()
+	return nil
+}
+
+var _ Connection = (*virshConnection)(nil)
+
+func (c *virshConnection) virsh(args ...string) (string, error) {
+	return c.run("virsh", append([]string{"--connect", c.uri}, args...)...)
+}
+
+// Close is part of the Connection interface. Each virsh command makes its
+// own connection, so only the credential files need to be removed.
+func (c *virshConnection) Close() error {
+	if c.dir == "" {
+		return nil
+	}
+	return errors.Trace(os.RemoveAll(c.dir))
+}
+
+// Domains is part of the Connection interface.
+func (c *virshConnection) Domains() ([]DomainInfo, error) {
+	output, err := c.virsh("list", "--all", "--name")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var domains []DomainInfo
+	for _, name := range strings.Split(output, "\n") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		state, err := c.virsh("domstate", name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		desc, err := c.virsh("desc", name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		domains = append(domains, DomainInfo{
+			Name:  name,
+			State: strings.TrimSpace(state),
+			Tags:  parseDescription(desc),
+		})
+	}
+	return domains, nil
+}
+
+// DefineDomain is part of the Connection interface.
+func (c *virshConnection) DefineDomain(domain kvmlibvirt.Domain, tags map[string]string) error {
+	data, err := xml.MarshalIndent(&domain, "", "    ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dir, err := ioutil.TempDir("", "juju-libvirt")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	domainPath := filepath.Join(dir, domain.Name+".xml")
+	if err := ioutil.WriteFile(domainPath, data, 0600); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := c.virsh("define", domainPath); err != nil {
+		return errors.Annotatef(err, "defining domain %q", domain.Name)
+	}
+	if _, err := c.virsh("desc", domain.Name, "--config", "--new-desc", formatDescription(tags)); err != nil {
+		return errors.Annotatef(err, "tagging domain %q", domain.Name)
+	}
+	return nil
+}
+
+// StartDomain is part of the Connection interface.
+func (c *virshConnection) StartDomain(name string) error {
+	if _, err := c.virsh("start", name); err != nil {
+		return errors.Annotatef(err, "starting domain %q", name)
+	}
+	if _, err := c.virsh("autostart", name); err != nil {
+		return errors.Annotatef(err, "setting domain %q to autostart", name)
+	}
+	return nil
+}
+
+// DestroyDomain is part of the Connection interface.
+func (c *virshConnection) DestroyDomain(name string) error {
+	// The domain may not be running, so failing to stop it is not
+	// an error; failing to undefine it is.
+	if _, err := c.virsh("destroy", name); err != nil {
+		logger.Debugf("stopping domain %q: %v", name, err)
+	}
+	// The nvram flag removes the pflash drive used to boot ARM64 guests.
+	if _, err := c.virsh("undefine", "--nvram", name); err != nil {
+		return errors.Annotatef(err, "undefining domain %q", name)
+	}
+	return nil
+}
+
+// DomainAddresses is part of the Connection interface.
+func (c *virshConnection) DomainAddresses(name string) ([]string, error) {
+	output, err := c.virsh("domifaddr", name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return parseDomainAddresses(output), nil
+}
+
+// DomainDisks is part of the Connection interface.
+func (c *virshConnection) DomainDisks(name string) ([]DomainDisk, error) {
+	output, err := c.virsh("domblklist", name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return parseDomainDisks(output), nil
+}
+
+// AttachDisk is part of the Connection interface.
+func (c *virshConnection) AttachDisk(name string, disk DomainDisk) error {
+	_, err := c.virsh(
+		"attach-disk", name, disk.Source, disk.Target,
+		"--driver", "qemu", "--subdriver", "raw", "--persistent",
+	)
+	return errors.Annotatef(err, "attaching %q to domain %q", disk.Source, name)
+}
+
+// DetachDisk is part of the Connection interface.
+func (c *virshConnection) DetachDisk(name, target string) error {
+	_, err := c.virsh("detach-disk", name, target, "--persistent")
+	return errors.Annotatef(err, "detaching %q from domain %q", target, name)
+}
+
+// Volumes is part of the Connection interface.
+func (c *virshConnection) Volumes(pool string) ([]string, error) {
+	output, err := c.virsh("vol-list", "--pool", pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return parseVolumes(output), nil
+}
+
+// CreateVolume is part of the Connection interface.
+func (c *virshConnection) CreateVolume(pool string, args VolumeParams) (string, error) {
+	createArgs := []string{
+		"vol-create-as", pool, args.Name,
+		strconv.FormatUint(args.Size, 10),
+		"--format", args.Format,
+	}
+	if args.BackingVolume != "" {
+		createArgs = append(createArgs,
+			"--backing-vol", args.BackingVolume,
+			"--backing-vol-format", "qcow2",
+		)
+	}
+	if _, err := c.virsh(createArgs...); err != nil {
+		return "", errors.Annotatef(err, "creating volume %q", args.Name)
+	}
+	return c.volumePath(pool, args.Name)
+}
+
+// UploadVolume is part of the Connection interface.
+func (c *virshConnection) UploadVolume(pool string, args VolumeParams, r io.Reader) (string, error) {
+	f, err := ioutil.TempFile("", "juju-libvirt")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return "", errors.Annotatef(err, "reading content for volume %q", args.Name)
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	path, err := c.CreateVolume(pool, args)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := c.virsh("vol-upload", "--pool", pool, args.Name, f.Name()); err != nil {
+		return "", errors.Annotatef(err, "uploading volume %q", args.Name)
+	}
+	return path, nil
+}
+
+// VolumeInfo is part of the Connection interface.
+func (c *virshConnection) VolumeInfo(pool, name string) (VolumeInfo, error) {
+	path, err := c.volumePath(pool, name)
+	if err != nil {
+		return VolumeInfo{}, errors.Trace(err)
+	}
+	output, err := c.virsh("vol-info", "--bytes", "--pool", pool, name)
+	if err != nil {
+		return VolumeInfo{}, errors.Trace(err)
+	}
+	size, err := parseVolumeCapacity(output)
+	if err != nil {
+		return VolumeInfo{}, errors.Annotatef(err, "volume %q", name)
+	}
+	return VolumeInfo{Path: path, Size: size}, nil
+}
+
+// DeleteVolume is part of the Connection interface.
+func (c *virshConnection) DeleteVolume(pool, name string) error {
+	_, err := c.virsh("vol-delete", "--pool", pool, name)
+	return errors.Annotatef(err, "deleting volume %q", name)
+}
+
+func (c *virshConnection) volumePath(pool, name string) (string, error) {
+	output, err := c.virsh("vol-path", "--pool", pool, name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// formatDescription renders tags as a domain description, with one
+// "key=value" line per tag.
+func formatDescription(tags map[string]string) string {
+	lines := make([]string, 0, len(tags))
+	for k, v := range tags {
+		lines = append(lines, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// parseDescription parses the tags from a domain description written by
+// formatDescription. Lines that are not tags are ignored, so domains not
+// created by Juju have no tags.
+func parseDescription(desc string) map[string]string {
+	tags := make(map[string]string)
+	for _, line := range strings.Split(desc, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		tags[parts[0]] = parts[1]
+	}
+	return tags
+}
+
+// parseDomainAddresses parses the output of "virsh domifaddr", e.g.
+//
+//	 Name       MAC address          Protocol     Address
+//	-------------------------------------------------------------------
+//	 vnet0      52:54:00:8e:3a:0b    ipv4         192.168.122.12/24
+func parseDomainAddresses(output string) []string {
+	var addrs []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 || (fields[2] != "ipv4" && fields[2] != "ipv6") {
+			continue
+		}
+		addrs = append(addrs, strings.SplitN(fields[3], "/", 2)[0])
+	}
+	return addrs
+}
+
+// parseDomainDisks parses the output of "virsh domblklist", e.g.
+//
+//	 Target     Source
+//	------------------------------------------------
+//	 vda        /var/lib/libvirt/images/juju-f75cba-0.qcow2
+//	 vdb        /var/lib/libvirt/images/juju-f75cba-0-ds.iso
+func parseDomainDisks(output string) []DomainDisk {
+	var disks []DomainDisk
+	var inBody bool
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case strings.HasPrefix(fields[0], "---"):
+			inBody = true
+		case inBody && len(fields) == 2:
+			disks = append(disks, DomainDisk{Target: fields[0], Source: fields[1]})
+		}
+	}
+	return disks
+}
+
+// parseVolumeCapacity parses the capacity from the output of
+// "virsh vol-info --bytes", e.g.
+//
+//	Name:           juju-f75cba-volume-0
+//	Type:           file
+//	Capacity:       1073741824 bytes
+//	Allocation:     1073741824 bytes
+func parseVolumeCapacity(output string) (uint64, error) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "Capacity:" {
+			return strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+	return 0, errors.New("capacity not found")
+}
+
+// parseVolumes parses the volume names from the output of
+// "virsh vol-list", e.g.
+//
+//	 Name                 Path
+//	------------------------------------------------------------------
+//	 juju-xenial-amd64    /var/lib/libvirt/images/juju-xenial-amd64
+func parseVolumes(output string) []string {
+	var names []string
+	var inBody bool
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case strings.HasPrefix(fields[0], "---"):
+			inBody = true
+		case inBody:
+			names = append(names, fields[0])
+		}
+	}
+	return names
+}
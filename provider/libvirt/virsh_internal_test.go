@@ -0,0 +1,207 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+)
+
+type virshSuite struct {
+	testing.IsolationSuite
+	stub testing.Stub
+	out  []string
+	conn *virshConnection
+}
+
+var _ = gc.Suite(&virshSuite{})
+
+func (s *virshSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+	s.out = nil
+	s.conn = &virshConnection{
+		uri: "qemu+ssh://ubuntu@host/system",
+		run: func(cmd string, args ...string) (string, error) {
+			s.stub.AddCall(cmd, strings.Join(args, " "))
+			var out string
+			if len(s.out) > 0 {
+				out, s.out = s.out[0], s.out[1:]
+			}
+			return out, s.stub.NextErr()
+		},
+	}
+}
+
+func (s *virshSuite) TestDomains(c *gc.C) {
+	s.out = []string{
+		"juju-f75cba-0\nmanual\n\n",
+		"running\n\n",
+		"juju-model-uuid=f75cba\njuju-is-controller=true\n",
+		"shut off\n\n",
+		"\n",
+	}
+	domains, err := s.conn.Domains()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(domains, jc.DeepEquals, []DomainInfo{{
+		Name:  "juju-f75cba-0",
+		State: "running",
+		Tags: map[string]string{
+			"juju-model-uuid":    "f75cba",
+			"juju-is-controller": "true",
+		},
+	}, {
+		Name:  "manual",
+		State: "shut off",
+		Tags:  map[string]string{},
+	}})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"virsh", []interface{}{"--connect qemu+ssh://ubuntu@host/system list --all --name"}},
+		{"virsh", []interface{}{"--connect qemu+ssh://ubuntu@host/system domstate juju-f75cba-0"}},
+		{"virsh", []interface{}{"--connect qemu+ssh://ubuntu@host/system desc juju-f75cba-0"}},
+		{"virsh", []interface{}{"--connect qemu+ssh://ubuntu@host/system domstate manual"}},
+		{"virsh", []interface{}{"--connect qemu+ssh://ubuntu@host/system desc manual"}},
+	})
+}
+
+func (s *virshSuite) TestCreateVolume(c *gc.C) {
+	s.out = []string{"", "/var/lib/libvirt/images/juju-f75cba-0.qcow2\n"}
+	path, err := s.conn.CreateVolume("default", VolumeParams{
+		Name:          "juju-f75cba-0.qcow2",
+		Format:        "qcow2",
+		Size:          8 << 30,
+		BackingVolume: "juju-xenial-amd64-base",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, "/var/lib/libvirt/images/juju-f75cba-0.qcow2")
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"virsh", []interface{}{"--connect qemu+ssh://ubuntu@host/system vol-create-as default juju-f75cba-0.qcow2 8589934592 --format qcow2 --backing-vol juju-xenial-amd64-base --backing-vol-format qcow2"}},
+		{"virsh", []interface{}{"--connect qemu+ssh://ubuntu@host/system vol-path --pool default juju-f75cba-0.qcow2"}},
+	})
+}
+
+func (s *virshSuite) TestAttachDisk(c *gc.C) {
+	err := s.conn.AttachDisk("juju-f75cba-0", DomainDisk{
+		Target: "vdc",
+		Source: "/var/lib/libvirt/images/juju-f75cba-volume-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"virsh", []interface{}{"--connect qemu+ssh://ubuntu@host/system attach-disk juju-f75cba-0 /var/lib/libvirt/images/juju-f75cba-volume-0 vdc --driver qemu --subdriver raw --persistent"}},
+	})
+}
+
+func (s *virshSuite) TestVolumeInfo(c *gc.C) {
+	s.out = []string{
+		"/var/lib/libvirt/images/juju-f75cba-volume-0\n",
+		"Name:           juju-f75cba-volume-0\nType:           file\nCapacity:       1073741824 bytes\nAllocation:     4096 bytes\n",
+	}
+	info, err := s.conn.VolumeInfo("default", "juju-f75cba-volume-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, VolumeInfo{
+		Path: "/var/lib/libvirt/images/juju-f75cba-volume-0",
+		Size: 1 << 30,
+	})
+}
+
+func (s *virshSuite) TestUseSSHKeyCredential(c *gc.C) {
+	err := s.conn.useCredential(cloud.NewCredential(sshKeyAuthType, map[string]string{
+		"private-key": "key",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	defer s.conn.Close()
+
+	keyPath := filepath.Join(s.conn.dir, "id")
+	c.Assert(s.conn.uri, gc.Equals, "qemu+ssh://ubuntu@host/system?keyfile="+url.QueryEscape(keyPath)+"&no_tty=1&no_verify=1")
+	data, err := ioutil.ReadFile(keyPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "key")
+
+	c.Assert(s.conn.Close(), jc.ErrorIsNil)
+	_, err = os.Stat(s.conn.dir)
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *virshSuite) TestUseCertificateCredential(c *gc.C) {
+	s.conn.uri = "qemu+tls://host/system"
+	err := s.conn.useCredential(cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"ca-cert":     "ca",
+		"client-cert": "cert",
+		"client-key":  "key",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	defer s.conn.Close()
+
+	c.Assert(s.conn.uri, gc.Equals, "qemu+tls://host/system?pkipath="+url.QueryEscape(s.conn.dir))
+	for name, content := range map[string]string{
+		"cacert.pem":     "ca",
+		"clientcert.pem": "cert",
+		"clientkey.pem":  "key",
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(s.conn.dir, name))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(data), gc.Equals, content)
+	}
+}
+
+func (s *virshSuite) TestFormatDescription(c *gc.C) {
+	desc := formatDescription(map[string]string{"b": "2", "a": "1"})
+	c.Assert(desc, gc.Equals, "a=1\nb=2")
+	c.Assert(parseDescription(desc), jc.DeepEquals, map[string]string{"a": "1", "b": "2"})
+}
+
+func (s *virshSuite) TestParseDescriptionIgnoresOtherText(c *gc.C) {
+	tags := parseDescription("My handmade domain\n=nokey\nkey=value=with=equals\n")
+	c.Assert(tags, jc.DeepEquals, map[string]string{"key": "value=with=equals"})
+}
+
+func (s *virshSuite) TestParseDomainAddresses(c *gc.C) {
+	output := `
+ Name       MAC address          Protocol     Address
+-------------------------------------------------------------------------------
+ vnet0      52:54:00:8e:3a:0b    ipv4         192.168.122.12/24
+ vnet0      52:54:00:8e:3a:0b    ipv6         fe80::5054:ff:fe8e:3a0b/64
+`
+	c.Assert(parseDomainAddresses(output), jc.DeepEquals, []string{
+		"192.168.122.12",
+		"fe80::5054:ff:fe8e:3a0b",
+	})
+}
+
+func (s *virshSuite) TestParseDomainDisks(c *gc.C) {
+	output := `
+ Target     Source
+------------------------------------------------
+ vda        /var/lib/libvirt/images/juju-f75cba-0.qcow2
+ vdb        /var/lib/libvirt/images/juju-f75cba-0-ds.iso
+
+`
+	c.Assert(parseDomainDisks(output), jc.DeepEquals, []DomainDisk{
+		{Target: "vda", Source: "/var/lib/libvirt/images/juju-f75cba-0.qcow2"},
+		{Target: "vdb", Source: "/var/lib/libvirt/images/juju-f75cba-0-ds.iso"},
+	})
+}
+
+func (s *virshSuite) TestParseVolumes(c *gc.C) {
+	output := `
+ Name                     Path
+------------------------------------------------------------------------------
+ juju-f75cba-0.qcow2      /var/lib/libvirt/images/juju-f75cba-0.qcow2
+ juju-xenial-amd64-base   /var/lib/libvirt/images/juju-xenial-amd64-base
+
+`
+	c.Assert(parseVolumes(output), jc.DeepEquals, []string{
+		"juju-f75cba-0.qcow2",
+		"juju-xenial-amd64-base",
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/lxd/lxdnames"
 )
 
@@ -182,6 +183,9 @@ func (*environProvider) Validate(cfg, old *config.Config) (valid *config.Config,
 	if _, err := newValidConfig(cfg); err != nil {
 		return nil, errors.Annotate(err, "invalid base config")
 	}
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
 	return cfg, nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/common"
 )
 
 var configSchema = environschema.Fields{}
@@ -65,6 +66,9 @@ func (prov MaasEnvironProvider) Validate(cfg, oldCfg *config.Config) (*config.Co
 	if err != nil {
 		return nil, err
 	}
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, err
+	}
 	validated, err := cfg.ValidateUnknownAttrs(configFields, configDefaults)
 	if err != nil {
 		return nil, err
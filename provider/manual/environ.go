@@ -324,6 +324,7 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
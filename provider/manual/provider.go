@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/manual/sshprovisioner"
+	"github.com/juju/juju/provider/common"
 )
 
 // ManualProvider contains the logic for using a random ubuntu machine as a
@@ -177,5 +178,8 @@ func (p ManualProvider) Validate(cfg, old *config.Config) (valid *config.Config,
 	if err != nil {
 		return nil, err
 	}
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, err
+	}
 	return cfg.Apply(envConfig.attrs)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
 	providerCommon "github.com/juju/juju/provider/oci/common"
 )
 
@@ -396,6 +397,9 @@ func (e EnvironProvider) Validate(cfg, old *config.Config) (valid *config.Config
 	if err := config.Validate(cfg, old); err != nil {
 		return nil, err
 	}
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, err
+	}
 	newAttrs, err := cfg.ValidateUnknownAttrs(
 		configFields, configDefaults,
 	)
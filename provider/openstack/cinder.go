@@ -173,8 +173,34 @@ func (s *cinderProvider) Scope() storage.Scope {
 func (p *cinderProvider) ValidateConfig(cfg *storage.Config) error {
 	// TODO(axw) 2015-05-01 #1450737
 	// Reject attempts to create non-persistent volumes.
-	_, err := newCinderConfig(cfg.Attrs())
-	return errors.Trace(err)
+	cinderConfig, err := newCinderConfig(cfg.Attrs())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if encrypted, _ := cfg.Encryption(); encrypted && cinderConfig.volumeType == "" {
+		// Whether a Cinder volume is encrypted is determined by the
+		// encryption settings of its volume type, which only the cloud
+		// administrator may configure, so the pool must name one.
+		return errors.NotValidf(
+			"%s without %s set to an encrypted volume type",
+			storage.ConfigEncrypted, cinderVolumeType,
+		)
+	}
+	return nil
+}
+
+// ValidateEncryption implements storage.EncryptingProvider. Volumes are
+// encrypted by giving them an encrypted volume type.
+func (p *cinderProvider) ValidateEncryption(encrypted bool, kmsKey string) error {
+	if kmsKey != "" {
+		// The key is chosen by the volume type's encryption settings.
+		return errors.NewNotSupported(nil, fmt.Sprintf(
+			"Cinder volumes are encrypted with the key of their volume type; "+
+				"%s is not supported, set %s to an encrypted volume type instead",
+			storage.ConfigKMSKey, cinderVolumeType,
+		))
+	}
+	return nil
 }
 
 // Dynamic implements storage.Provider.
@@ -200,6 +226,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeTagger = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -503,6 +530,14 @@ func (s *cinderVolumeSource) ImportVolume(ctx context.ProviderCallContext, volum
 	return cinderToJujuVolumeInfo(volume), nil
 }
 
+// TagVolume is part of the storage.VolumeTagger interface.
+func (s *cinderVolumeSource) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	if _, err := s.storageAdapter.SetVolumeMetadata(volumeId, tags); err != nil {
+		return errors.Annotatef(err, "tagging volume %q", volumeId)
+	}
+	return nil
+}
+
 func waitVolume(
 	storageAdapter OpenstackStorage,
 	volumeId string,
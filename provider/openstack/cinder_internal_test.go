@@ -4,6 +4,7 @@
 package openstack
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -11,6 +12,8 @@ import (
 	"gopkg.in/goose.v2/identity"
 
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
 )
 
 // TODO(axw) 2016-10-03 #1629721
@@ -46,6 +49,37 @@ func (s *cinderInternalSuite) TestStorageProviderTypesNotSupported(c *gc.C) {
 	c.Assert(types, gc.HasLen, 0)
 }
 
+func (s *cinderInternalSuite) TestValidateConfigEncrypted(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", CinderProviderType, map[string]interface{}{
+		"encrypted":   true,
+		"volume-type": "LUKS",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(&cinderProvider{}, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *cinderInternalSuite) TestValidateConfigEncryptedNoVolumeType(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", CinderProviderType, map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(&cinderProvider{}, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "encrypted without volume-type set to an encrypted volume type not valid")
+}
+
+func (s *cinderInternalSuite) TestValidateConfigKMSKey(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", CinderProviderType, map[string]interface{}{
+		"kms-key":     "key",
+		"volume-type": "LUKS",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(&cinderProvider{}, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "Cinder volumes are encrypted with the key of their volume type; kms-key is not supported, .*")
+}
+
 type testAuthClient struct {
 	client.AuthenticatingClient
 	regionEndpoints map[string]identity.ServiceURLs
@@ -595,6 +595,19 @@ func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *cinderVolumeSourceSuite) TestTagVolume(c *gc.C) {
+	mockAdapter := &mockAdapter{}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeTagger))
+
+	tags := map[string]string{"team": "dba"}
+	err := volSource.(storage.VolumeTagger).TagVolume(s.callCtx, mockVolId, tags)
+	c.Assert(err, jc.ErrorIsNil)
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SetVolumeMetadata", []interface{}{mockVolId, tags}},
+	})
+}
+
 func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
 	mockAdapter := &mockAdapter{
 		getVolume: func(volumeId string) (*cinder.Volume, error) {
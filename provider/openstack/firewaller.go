@@ -82,6 +82,27 @@ type Firewaller interface {
 
 	// InstanceIngressRules returns the ingress rules applied to the specified  instance.
 	InstanceIngressRules(ctx context.ProviderCallContext, inst instance.Instance, machineId string) ([]network.IngressRule, error)
+
+	// OpenApplicationPorts opens the given port ranges in the security
+	// group of the specified application.
+	OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error
+
+	// CloseApplicationPorts closes the given port ranges in the security
+	// group of the specified application.
+	CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error
+
+	// ApplicationIngressRules returns the ingress rules from source CIDRs
+	// applied to the security group of the specified application.
+	ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error)
+
+	// SetApplicationSources replaces the ingress rules from the security
+	// groups of other applications applied to the security group of the
+	// specified application.
+	SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error
+
+	// SetInstanceApplications sets the applications whose security
+	// groups the specified instance is a member of.
+	SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error
 }
 
 type firewallerFactory struct {
@@ -205,6 +226,41 @@ func (f *switchingFirewaller) InstanceIngressRules(ctx context.ProviderCallConte
 	return f.fw.InstanceIngressRules(ctx, inst, machineId)
 }
 
+func (f *switchingFirewaller) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenApplicationPorts(ctx, applicationName, rules)
+}
+
+func (f *switchingFirewaller) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseApplicationPorts(ctx, applicationName, rules)
+}
+
+func (f *switchingFirewaller) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.ApplicationIngressRules(ctx, applicationName)
+}
+
+func (f *switchingFirewaller) SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.SetApplicationSources(ctx, applicationName, rules)
+}
+
+func (f *switchingFirewaller) SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.SetInstanceApplications(ctx, id, applicationNames)
+}
+
 type firewallerBase struct {
 	environ          *Environ
 	ensureGroupMutex sync.Mutex
@@ -404,6 +460,10 @@ func (c *firewallerBase) machineGroupName(controllerUUID, machineId string) stri
 	return fmt.Sprintf("%s-%s", c.jujuGroupName(controllerUUID), machineId)
 }
 
+func (c *firewallerBase) applicationGroupName(jujuGroupName, applicationName string) string {
+	return fmt.Sprintf("%s-app-%s", jujuGroupName, applicationName)
+}
+
 func (c *firewallerBase) jujuGroupName(controllerUUID string) string {
 	cfg := c.environ.Config()
 	return fmt.Sprintf("juju-%v-%v", controllerUUID, cfg.UUID())
@@ -422,6 +482,10 @@ func (c *firewallerBase) globalGroupRegexp() string {
 	return fmt.Sprintf("%s-global", c.jujuGroupRegexp())
 }
 
+func (c *firewallerBase) applicationGroupRegexp(applicationName string) string {
+	return fmt.Sprintf("%s-app-%s$", c.jujuGroupRegexp(), regexp.QuoteMeta(applicationName))
+}
+
 func (c *firewallerBase) machineGroupRegexp(machineId string) string {
 	// we are only looking to match 1 machine
 	return fmt.Sprintf("%s-%s$", c.jujuGroupRegexp(), machineId)
@@ -457,7 +521,12 @@ func (c *neutronFirewaller) SetUpGroups(ctx context.ProviderCallContext, control
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	groups := []string{jujuGroup.Name, machineGroup.Name}
+	groups := []string{jujuGroup.Name}
+	// In application mode, instances join the groups of the
+	// applications they host once units are assigned to them.
+	if machineGroup.Name != "" {
+		groups = append(groups, machineGroup.Name)
+	}
 	if c.environ.ecfg().useDefaultSecurityGroup() {
 		groups = append(groups, "default")
 	}
@@ -809,6 +878,175 @@ func (c *neutronFirewaller) InstanceIngressRules(ctx context.ProviderCallContext
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenApplicationPorts implements Firewaller interface.
+func (c *neutronFirewaller) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if _, err := c.applicationGroup(applicationName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.openPortsInGroup(c.applicationGroupRegexp(applicationName), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in security group of application %q: %v", applicationName, rules)
+	return nil
+}
+
+// CloseApplicationPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	if err := c.closePortsInGroup(c.applicationGroupRegexp(applicationName), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in security group of application %q: %v", applicationName, rules)
+	return nil
+}
+
+// ApplicationIngressRules implements Firewaller interface.
+func (c *neutronFirewaller) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error) {
+	if err := c.checkApplicationMode(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	group, err := c.matchingGroup(c.applicationGroupRegexp(applicationName))
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Rules sourced from the groups of other applications have no
+	// RemoteIPPrefix; they are managed by SetApplicationSources.
+	var cidrRules []neutron.SecurityGroupRuleV2
+	for _, p := range group.Rules {
+		if p.RemoteIPPrefix != "" {
+			cidrRules = append(cidrRules, p)
+		}
+	}
+	return groupIngressRules(cidrRules)
+}
+
+// SetApplicationSources implements Firewaller interface.
+func (c *neutronFirewaller) SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	group, err := c.applicationGroup(applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, p := range group.Rules {
+		if p.Direction == "egress" || p.RemoteIPPrefix != "" {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, rule := range rules {
+		if len(rule.SourceApplications) == 0 {
+			return errors.NotValidf("rule %v without source applications", rule)
+		}
+		for _, sourceName := range rule.SourceApplications {
+			source, err := c.applicationGroup(sourceName)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if _, err := neutronClient.CreateSecurityGroupRuleV2(neutron.RuleInfoV2{
+				Direction:     "ingress",
+				ParentGroupId: group.Id,
+				PortRangeMin:  rule.FromPort,
+				PortRangeMax:  rule.ToPort,
+				IPProtocol:    rule.Protocol,
+				RemoteGroupId: source.Id,
+			}); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	logger.Infof("set application sources in security group of application %q: %v", applicationName, rules)
+	return nil
+}
+
+// SetInstanceApplications implements Firewaller interface.
+func (c *neutronFirewaller) SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error {
+	if err := c.checkApplicationMode(); err != nil {
+		return errors.Trace(err)
+	}
+	re, err := regexp.Compile(fmt.Sprintf("%s-app-.+$", c.jujuGroupRegexp()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	wanted := make(map[string]bool)
+	for _, appName := range applicationNames {
+		group, err := c.applicationGroup(appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		wanted[group.Name] = true
+	}
+
+	novaClient := c.environ.nova()
+	groups, err := novaClient.GetServerSecurityGroups(string(id))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, group := range groups {
+		if !re.MatchString(group.Name) {
+			continue
+		}
+		if wanted[group.Name] {
+			delete(wanted, group.Name)
+			continue
+		}
+		if err := novaClient.RemoveServerSecurityGroup(string(id), group.Name); err != nil {
+			return errors.Annotatef(err, "removing instance %q from security group %q", id, group.Name)
+		}
+	}
+	for name := range wanted {
+		if err := novaClient.AddServerSecurityGroup(string(id), name); err != nil {
+			return errors.Annotatef(err, "adding instance %q to security group %q", id, name)
+		}
+	}
+	return nil
+}
+
+func (c *neutronFirewaller) checkApplicationMode() error {
+	if mode := c.environ.Config().FirewallMode(); mode != config.FwApplication {
+		return errors.Errorf("invalid firewall mode %q for application security groups", mode)
+	}
+	return nil
+}
+
+// applicationGroup returns the security group of the named application,
+// creating it if it does not exist. Unlike ensureGroup, the rules of an
+// existing group are left alone.
+func (c *neutronFirewaller) applicationGroup(applicationName string) (neutron.SecurityGroupV2, error) {
+	if err := c.checkApplicationMode(); err != nil {
+		return zeroGroup, errors.Trace(err)
+	}
+	group, err := c.matchingGroup(c.applicationGroupRegexp(applicationName))
+	if err == nil {
+		return group, nil
+	} else if !errors.IsNotFound(err) {
+		return zeroGroup, errors.Trace(err)
+	}
+
+	// The application group is named after the model's juju group, so
+	// that it is cleaned up along with the model's other groups.
+	jujuGroup, err := c.matchingGroup(c.jujuGroupRegexp() + "$")
+	if err != nil {
+		return zeroGroup, errors.Annotate(err, "finding model security group")
+	}
+	c.ensureGroupMutex.Lock()
+	defer c.ensureGroupMutex.Unlock()
+	name := c.applicationGroupName(jujuGroup.Name, applicationName)
+	groupsFound, err := c.environ.neutron().SecurityGroupByNameV2(name)
+	if err == nil && len(groupsFound) == 1 {
+		return groupsFound[0], nil
+	} else if err != nil && !strings.Contains(err.Error(), "failed to find security group") {
+		return zeroGroup, errors.Trace(err)
+	}
+	newGroup, err := c.environ.neutron().CreateSecurityGroupV2(name, "juju application group")
+	if err != nil {
+		return zeroGroup, errors.Trace(err)
+	}
+	return *newGroup, nil
+}
+
 // Matching a security group by name only works if each name is unqiue.  Neutron
 // security groups are not required to have unique names.  Juju constructs unique
 // names, but there are frequently multiple matches to 'default'
@@ -910,9 +1148,15 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return groupIngressRules(group.Rules)
+}
+
+// groupIngressRules returns the ingress rules corresponding to the given
+// security group rules, with one rule per port range.
+func groupIngressRules(groupRules []neutron.SecurityGroupRuleV2) (rules []network.IngressRule, err error) {
 	// Keep track of all the RemoteIPPrefixes for each port range.
 	portSourceCIDRs := make(map[network.PortRange]*[]string)
-	for _, p := range group.Rules {
+	for _, p := range groupRules {
 		// Skip the default Security Group Rules created by Neutron
 		if p.Direction == "egress" {
 			continue
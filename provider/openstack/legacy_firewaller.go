@@ -37,6 +37,8 @@ func (c *legacyNovaFirewaller) SetUpGroups(ctx context.ProviderCallContext, cont
 	}
 	var machineGroup nova.SecurityGroup
 	switch c.environ.Config().FirewallMode() {
+	case config.FwApplication:
+		return nil, errors.NotSupportedf("application firewall mode without neutron")
 	case config.FwInstance:
 		machineGroup, err = c.ensureGroup(c.machineGroupName(controllerUUID, machineId), nil)
 	case config.FwGlobal:
@@ -240,6 +242,31 @@ func (c *legacyNovaFirewaller) InstanceIngressRules(ctx context.ProviderCallCont
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenApplicationPorts is not supported.
+func (c *legacyNovaFirewaller) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("application firewall mode without neutron")
+}
+
+// CloseApplicationPorts is not supported.
+func (c *legacyNovaFirewaller) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("application firewall mode without neutron")
+}
+
+// ApplicationIngressRules is not supported.
+func (c *legacyNovaFirewaller) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("application firewall mode without neutron")
+}
+
+// SetApplicationSources is not supported.
+func (c *legacyNovaFirewaller) SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("application firewall mode without neutron")
+}
+
+// SetInstanceApplications is not supported.
+func (c *legacyNovaFirewaller) SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error {
+	return errors.NotSupportedf("application firewall mode without neutron")
+}
+
 func (c *legacyNovaFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
 	re, err := regexp.Compile(nameRegExp)
 	if err != nil {
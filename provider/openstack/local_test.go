@@ -1285,6 +1285,14 @@ func (s *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	cons = constraints.MustParse("virt-type=foo")
 	_, err = validator.Validate(cons)
 	c.Assert(err, gc.ErrorMatches, regexp.QuoteMeta("invalid constraint value: virt-type=foo\nvalid values are: [kvm lxd]"))
+
+	cons = constraints.MustParse("zones=foo")
+	_, err = validator.Validate(cons)
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=foo\nvalid values are:.*")
+
+	cons = constraints.MustParse("zones=test-available")
+	_, err = validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *localServerSuite) TestConstraintsMerge(c *gc.C) {
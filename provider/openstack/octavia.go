@@ -0,0 +1,564 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// maxLoadBalancerPorts is the largest port range that may be load
+// balanced. Each port needs its own listener and pool.
+const maxLoadBalancerPorts = 10
+
+// loadBalancerAttempt is used to wait for load balancers to finish
+// provisioning, which they must do between changes. A new load
+// balancer may take minutes to boot its amphorae.
+var loadBalancerAttempt = utils.AttemptStrategy{
+	Total: 10 * time.Minute,
+	Delay: 5 * time.Second,
+}
+
+var _ environs.LoadBalancer = (*Environ)(nil)
+
+// openstackREST makes JSON requests to an OpenStack API endpoint, for
+// the APIs that goose does not provide.
+type openstackREST struct {
+	endpoint string
+	token    func() string
+}
+
+// do makes the request, sending body and decoding the response into
+// result if they are not nil. A response with status 404 returns an
+// error satisfying errors.IsNotFound.
+func (c *openstackREST) do(method, path string, query url.Values, body, result interface{}) error {
+	u := strings.TrimSuffix(c.endpoint, "/") + "/" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = json.Marshal(body); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	req, err := http.NewRequest(method, u, bytes.NewReader(reqBody))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("X-Auth-Token", c.token())
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.NotFoundf("%s", path)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("%s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(message))
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return errors.Annotatef(json.NewDecoder(resp.Body).Decode(result), "decoding %s %s response", method, path)
+}
+
+type octaviaLoadBalancer struct {
+	ID                 string `json:"id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Description        string `json:"description,omitempty"`
+	VipSubnetID        string `json:"vip_subnet_id,omitempty"`
+	VipAddress         string `json:"vip_address,omitempty"`
+	VipPortID          string `json:"vip_port_id,omitempty"`
+	ProvisioningStatus string `json:"provisioning_status,omitempty"`
+}
+
+type octaviaListener struct {
+	ID             string `json:"id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Protocol       string `json:"protocol,omitempty"`
+	ProtocolPort   int    `json:"protocol_port,omitempty"`
+	LoadBalancerID string `json:"loadbalancer_id,omitempty"`
+	DefaultPoolID  string `json:"default_pool_id,omitempty"`
+}
+
+type octaviaPool struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Protocol    string `json:"protocol,omitempty"`
+	LBAlgorithm string `json:"lb_algorithm,omitempty"`
+	ListenerID  string `json:"listener_id,omitempty"`
+}
+
+type octaviaMember struct {
+	ID           string `json:"id,omitempty"`
+	Address      string `json:"address,omitempty"`
+	ProtocolPort int    `json:"protocol_port,omitempty"`
+	SubnetID     string `json:"subnet_id,omitempty"`
+}
+
+type neutronFixedIP struct {
+	SubnetID  string `json:"subnet_id"`
+	IPAddress string `json:"ip_address"`
+}
+
+type neutronPort struct {
+	ID       string           `json:"id"`
+	FixedIPs []neutronFixedIP `json:"fixed_ips"`
+}
+
+type neutronFloatingIP struct {
+	ID                string `json:"id,omitempty"`
+	FloatingNetworkID string `json:"floating_network_id,omitempty"`
+	FloatingIPAddress string `json:"floating_ip_address,omitempty"`
+	PortID            string `json:"port_id,omitempty"`
+	Description       string `json:"description,omitempty"`
+}
+
+// octavia manages load balancers with the Octavia API, and their
+// floating IPs and members' addresses with the Neutron API.
+type octavia struct {
+	lb      *openstackREST
+	network *openstackREST
+}
+
+// octavia returns the model's Octavia client. If the cloud has no
+// load-balancer endpoint, an error satisfying errors.IsNotSupported
+// is returned.
+func (e *Environ) octavia() (*octavia, error) {
+	client := e.client()
+	if !client.IsAuthenticated() {
+		if err := authenticateClient(client); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	endpoints := client.EndpointsForRegion(e.cloud.Region)
+	lbURL, ok := endpoints["load-balancer"]
+	if !ok {
+		return nil, errors.NotSupportedf("load balancers without Octavia")
+	}
+	networkURL, ok := endpoints["network"]
+	if !ok {
+		return nil, errors.NotSupportedf("load balancers without Neutron")
+	}
+	return &octavia{
+		lb:      &openstackREST{endpoint: lbURL, token: client.Token},
+		network: &openstackREST{endpoint: networkURL, token: client.Token},
+	}, nil
+}
+
+// loadBalancerNamePrefix returns the prefix of the names of the
+// model's load balancers.
+func (e *Environ) loadBalancerNamePrefix() string {
+	return "juju-" + e.uuid + "-lb-"
+}
+
+// loadBalancerName returns the name of the load balancer for the given
+// endpoint of the named application.
+func (e *Environ) loadBalancerName(applicationName string, endpoint network.PortRange) string {
+	hash := sha256.Sum256([]byte(applicationName + "/" + endpoint.String()))
+	return e.loadBalancerNamePrefix() + hex.EncodeToString(hash[:])[:16]
+}
+
+// loadBalancerDescription returns the description of load balancers of
+// models managed by the controller, by which they are found when the
+// controller is destroyed.
+func loadBalancerDescription(controllerUUID string) string {
+	return "juju load balancer, controller " + controllerUUID
+}
+
+// EnsureLoadBalancer implements environs.LoadBalancer. The load
+// balancer is an Octavia load balancer with a listener and pool for
+// each port, and a floating IP on the model's external network.
+func (e *Environ) EnsureLoadBalancer(
+	ctx context.ProviderCallContext,
+	applicationName string,
+	endpoint network.PortRange,
+	ids []instance.Id,
+) ([]network.Address, error) {
+	if endpoint.Protocol != "tcp" && endpoint.Protocol != "udp" {
+		return nil, errors.NotSupportedf("load balancing %s", endpoint.Protocol)
+	}
+	if n := endpoint.ToPort - endpoint.FromPort + 1; n > maxLoadBalancerPorts {
+		return nil, errors.NotSupportedf("load balancing %d ports (maximum %d)", n, maxLoadBalancerPorts)
+	}
+	if len(ids) == 0 {
+		return nil, errors.NotValidf("load balancer without instances")
+	}
+	o, err := e.octavia()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	server, err := e.nova().GetServer(string(ids[0]))
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting instance %q", ids[0])
+	}
+	controllerUUID := server.Metadata[tags.JujuController]
+	members := make([]octaviaMember, len(ids))
+	for i, id := range ids {
+		if members[i], err = o.instanceMember(id); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	name := e.loadBalancerName(applicationName, endpoint)
+	lb, err := o.ensureLoadBalancer(name, loadBalancerDescription(controllerUUID), members[0].SubnetID)
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating load balancer %q", name)
+	}
+	for port := endpoint.FromPort; port <= endpoint.ToPort; port++ {
+		if err := o.ensurePort(lb, strings.ToUpper(endpoint.Protocol), port, members); err != nil {
+			return nil, errors.Annotatef(err, "forwarding port %d of load balancer %q", port, name)
+		}
+	}
+
+	addresses := []network.Address{network.NewScopedAddress(lb.VipAddress, network.ScopeCloudLocal)}
+	externalNetworkId, err := e.loadBalancerExternalNetwork()
+	if errors.IsNotFound(err) {
+		logger.Warningf("load balancer %q has no public address: %v", name, err)
+		return addresses, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fip, err := o.ensureFloatingIP(lb, externalNetworkId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "assigning floating IP to load balancer %q", name)
+	}
+	return append([]network.Address{
+		network.NewScopedAddress(fip.FloatingIPAddress, network.ScopePublic),
+	}, addresses...), nil
+}
+
+// loadBalancerExternalNetwork returns the ID of the network from which
+// load balancers' floating IPs are allocated: the model's external
+// network if it has one, otherwise the first external network.
+func (e *Environ) loadBalancerExternalNetwork() (string, error) {
+	neutronClient := e.neutron()
+	if name := e.ecfg().externalNetwork(); name != "" {
+		return resolveNeutronNetwork(neutronClient, name, true)
+	}
+	networks, err := neutronClient.ListNetworksV2(externalNetworkFilter())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(networks) == 0 {
+		return "", errors.NotFoundf("external network")
+	}
+	return networks[0].Id, nil
+}
+
+// RemoveLoadBalancer implements environs.LoadBalancer.
+func (e *Environ) RemoveLoadBalancer(ctx context.ProviderCallContext, applicationName string, endpoint network.PortRange) error {
+	o, err := e.octavia()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := e.loadBalancerName(applicationName, endpoint)
+	lbs, err := o.loadBalancers(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, lb := range lbs {
+		if err := o.removeLoadBalancer(lb); err != nil {
+			return errors.Annotatef(err, "removing load balancer %q", name)
+		}
+	}
+	return nil
+}
+
+// removeLoadBalancers removes the load balancers matching the filter,
+// and their floating IPs. It does nothing if the cloud has no Octavia.
+func (e *Environ) removeLoadBalancers(match func(octaviaLoadBalancer) bool) error {
+	o, err := e.octavia()
+	if errors.IsNotSupported(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	lbs, err := o.loadBalancers("")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, lb := range lbs {
+		if !match(lb) {
+			continue
+		}
+		if err := o.removeLoadBalancer(lb); err != nil {
+			return errors.Annotatef(err, "removing load balancer %q", lb.Name)
+		}
+	}
+	return nil
+}
+
+// instanceMember returns the pool member for the instance: the first
+// IPv4 address of its ports, and that address's subnet.
+func (o *octavia) instanceMember(id instance.Id) (octaviaMember, error) {
+	var resp struct {
+		Ports []neutronPort `json:"ports"`
+	}
+	query := url.Values{"device_id": {string(id)}}
+	if err := o.network.do("GET", "v2.0/ports", query, nil, &resp); err != nil {
+		return octaviaMember{}, errors.Annotatef(err, "listing ports of instance %q", id)
+	}
+	for _, port := range resp.Ports {
+		for _, fixedIP := range port.FixedIPs {
+			if network.DeriveAddressType(fixedIP.IPAddress) == network.IPv4Address {
+				return octaviaMember{Address: fixedIP.IPAddress, SubnetID: fixedIP.SubnetID}, nil
+			}
+		}
+	}
+	return octaviaMember{}, errors.NotFoundf("IPv4 address of instance %q", id)
+}
+
+// loadBalancers returns the load balancers with the given name, or all
+// load balancers if the name is empty.
+func (o *octavia) loadBalancers(name string) ([]octaviaLoadBalancer, error) {
+	var query url.Values
+	if name != "" {
+		query = url.Values{"name": {name}}
+	}
+	var resp struct {
+		LoadBalancers []octaviaLoadBalancer `json:"loadbalancers"`
+	}
+	if err := o.lb.do("GET", "v2.0/lbaas/loadbalancers", query, nil, &resp); err != nil {
+		return nil, errors.Annotate(err, "listing load balancers")
+	}
+	return resp.LoadBalancers, nil
+}
+
+// waitActive waits for the load balancer to finish provisioning, and
+// returns it.
+func (o *octavia) waitActive(id string) (octaviaLoadBalancer, error) {
+	var resp struct {
+		LoadBalancer octaviaLoadBalancer `json:"loadbalancer"`
+	}
+	for a := loadBalancerAttempt.Start(); a.Next(); {
+		if err := o.lb.do("GET", "v2.0/lbaas/loadbalancers/"+id, nil, nil, &resp); err != nil {
+			return octaviaLoadBalancer{}, errors.Trace(err)
+		}
+		switch resp.LoadBalancer.ProvisioningStatus {
+		case "ACTIVE":
+			return resp.LoadBalancer, nil
+		case "ERROR":
+			return octaviaLoadBalancer{}, errors.Errorf("load balancer %q failed to provision", resp.LoadBalancer.Name)
+		}
+	}
+	return octaviaLoadBalancer{}, errors.Errorf(
+		"load balancer %q still %s", resp.LoadBalancer.Name, resp.LoadBalancer.ProvisioningStatus,
+	)
+}
+
+func (o *octavia) ensureLoadBalancer(name, description, subnetId string) (octaviaLoadBalancer, error) {
+	lbs, err := o.loadBalancers(name)
+	if err != nil {
+		return octaviaLoadBalancer{}, errors.Trace(err)
+	}
+	if len(lbs) > 0 {
+		return o.waitActive(lbs[0].ID)
+	}
+	var resp struct {
+		LoadBalancer octaviaLoadBalancer `json:"loadbalancer"`
+	}
+	req := map[string]interface{}{
+		"loadbalancer": octaviaLoadBalancer{
+			Name:        name,
+			Description: description,
+			VipSubnetID: subnetId,
+		},
+	}
+	if err := o.lb.do("POST", "v2.0/lbaas/loadbalancers", nil, req, &resp); err != nil {
+		return octaviaLoadBalancer{}, errors.Trace(err)
+	}
+	return o.waitActive(resp.LoadBalancer.ID)
+}
+
+// ensurePort ensures that the load balancer has a listener on the port,
+// forwarding to a pool of exactly the given members.
+func (o *octavia) ensurePort(lb octaviaLoadBalancer, protocol string, port int, members []octaviaMember) error {
+	var listeners struct {
+		Listeners []octaviaListener `json:"listeners"`
+	}
+	query := url.Values{"loadbalancer_id": {lb.ID}}
+	if err := o.lb.do("GET", "v2.0/lbaas/listeners", query, nil, &listeners); err != nil {
+		return errors.Trace(err)
+	}
+	var listener *octaviaListener
+	for i, l := range listeners.Listeners {
+		if l.ProtocolPort == port {
+			listener = &listeners.Listeners[i]
+		}
+	}
+	if listener == nil {
+		var resp struct {
+			Listener octaviaListener `json:"listener"`
+		}
+		req := map[string]interface{}{
+			"listener": octaviaListener{
+				Name:           fmt.Sprintf("%s-%d", lb.Name, port),
+				Protocol:       protocol,
+				ProtocolPort:   port,
+				LoadBalancerID: lb.ID,
+			},
+		}
+		if err := o.lb.do("POST", "v2.0/lbaas/listeners", nil, req, &resp); err != nil {
+			return errors.Annotate(err, "creating listener")
+		}
+		listener = &resp.Listener
+		if _, err := o.waitActive(lb.ID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	poolId := listener.DefaultPoolID
+	if poolId == "" {
+		var resp struct {
+			Pool octaviaPool `json:"pool"`
+		}
+		req := map[string]interface{}{
+			"pool": octaviaPool{
+				Name:        listener.Name,
+				Protocol:    protocol,
+				LBAlgorithm: "ROUND_ROBIN",
+				ListenerID:  listener.ID,
+			},
+		}
+		if err := o.lb.do("POST", "v2.0/lbaas/pools", nil, req, &resp); err != nil {
+			return errors.Annotate(err, "creating pool")
+		}
+		poolId = resp.Pool.ID
+		if _, err := o.waitActive(lb.ID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(o.setMembers(lb.ID, poolId, port, members))
+}
+
+// setMembers makes the pool's members exactly the given members,
+// receiving on the port.
+func (o *octavia) setMembers(lbId, poolId string, port int, members []octaviaMember) error {
+	membersPath := "v2.0/lbaas/pools/" + poolId + "/members"
+	var resp struct {
+		Members []octaviaMember `json:"members"`
+	}
+	if err := o.lb.do("GET", membersPath, nil, nil, &resp); err != nil {
+		return errors.Annotate(err, "listing pool members")
+	}
+	have := make(map[string]bool)
+	for _, member := range resp.Members {
+		have[member.Address] = true
+	}
+	want := make(map[string]bool)
+	for _, member := range members {
+		want[member.Address] = true
+		if have[member.Address] {
+			continue
+		}
+		member.ProtocolPort = port
+		req := map[string]interface{}{"member": member}
+		if err := o.lb.do("POST", membersPath, nil, req, nil); err != nil {
+			return errors.Annotatef(err, "adding pool member %s", member.Address)
+		}
+		if _, err := o.waitActive(lbId); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, member := range resp.Members {
+		if want[member.Address] {
+			continue
+		}
+		if err := o.lb.do("DELETE", membersPath+"/"+member.ID, nil, nil, nil); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing pool member %s", member.Address)
+		}
+		if _, err := o.waitActive(lbId); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// floatingIPs returns the floating IPs assigned to the port.
+func (o *octavia) floatingIPs(portId string) ([]neutronFloatingIP, error) {
+	var resp struct {
+		FloatingIPs []neutronFloatingIP `json:"floatingips"`
+	}
+	query := url.Values{"port_id": {portId}}
+	if err := o.network.do("GET", "v2.0/floatingips", query, nil, &resp); err != nil {
+		return nil, errors.Annotate(err, "listing floating IPs")
+	}
+	return resp.FloatingIPs, nil
+}
+
+// ensureFloatingIP returns the floating IP assigned to the load
+// balancer's virtual IP, allocating one from the external network if
+// there is none.
+func (o *octavia) ensureFloatingIP(lb octaviaLoadBalancer, externalNetworkId string) (neutronFloatingIP, error) {
+	fips, err := o.floatingIPs(lb.VipPortID)
+	if err != nil {
+		return neutronFloatingIP{}, errors.Trace(err)
+	}
+	if len(fips) > 0 {
+		return fips[0], nil
+	}
+	var resp struct {
+		FloatingIP neutronFloatingIP `json:"floatingip"`
+	}
+	req := map[string]interface{}{
+		"floatingip": neutronFloatingIP{
+			FloatingNetworkID: externalNetworkId,
+			PortID:            lb.VipPortID,
+			Description:       "juju load balancer " + lb.Name,
+		},
+	}
+	if err := o.network.do("POST", "v2.0/floatingips", nil, req, &resp); err != nil {
+		return neutronFloatingIP{}, errors.Trace(err)
+	}
+	return resp.FloatingIP, nil
+}
+
+// removeLoadBalancer releases the floating IPs of the load balancer,
+// and deletes it with its listeners, pools and members.
+func (o *octavia) removeLoadBalancer(lb octaviaLoadBalancer) error {
+	if lb.VipPortID != "" {
+		fips, err := o.floatingIPs(lb.VipPortID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, fip := range fips {
+			err := o.network.do("DELETE", "v2.0/floatingips/"+fip.ID, nil, nil, nil)
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Annotatef(err, "releasing floating IP %s", fip.FloatingIPAddress)
+			}
+		}
+	}
+	query := url.Values{"cascade": {"true"}}
+	err := o.lb.do("DELETE", "v2.0/lbaas/loadbalancers/"+lb.ID, query, nil, nil)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
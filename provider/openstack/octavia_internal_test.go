@@ -0,0 +1,339 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v2/identity"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+type octaviaInternalSuite struct {
+	testing.IsolationSuite
+
+	server *httptest.Server
+	fake   *fakeOctavia
+	env    *Environ
+}
+
+var _ = gc.Suite(&octaviaInternalSuite{})
+
+func (s *octaviaInternalSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(&loadBalancerAttempt, utils.AttemptStrategy{})
+	s.fake = &fakeOctavia{
+		ports: map[string]neutronPort{
+			"inst-0": {ID: "port-0", FixedIPs: []neutronFixedIP{{SubnetID: "subnet-0", IPAddress: "10.0.0.10"}}},
+			"inst-1": {ID: "port-1", FixedIPs: []neutronFixedIP{{SubnetID: "subnet-0", IPAddress: "10.0.0.11"}}},
+		},
+	}
+	s.server = httptest.NewServer(s.fake)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.env = &Environ{
+		uuid:  "model-uuid",
+		cloud: environs.CloudSpec{Region: "foo"},
+		clientUnlocked: &testAuthClient{
+			regionEndpoints: map[string]identity.ServiceURLs{
+				"foo": {
+					"load-balancer": s.server.URL + "/lb",
+					"network":       s.server.URL + "/network",
+				},
+			},
+		},
+	}
+}
+
+func (s *octaviaInternalSuite) TestNotSupportedWithoutOctavia(c *gc.C) {
+	env := &Environ{clientUnlocked: &testAuthClient{}}
+	_, err := env.EnsureLoadBalancer(context.NewCloudCallContext(), "wordpress", network.MustParsePortRange("80/tcp"), []instance.Id{"inst-0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(env.removeLoadBalancers(func(octaviaLoadBalancer) bool { return true }), jc.ErrorIsNil)
+}
+
+func (s *octaviaInternalSuite) TestEnsureLoadBalancerICMP(c *gc.C) {
+	_, err := s.env.EnsureLoadBalancer(context.NewCloudCallContext(), "wordpress", network.MustParsePortRange("icmp"), nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.fake.requests, gc.HasLen, 0)
+}
+
+func (s *octaviaInternalSuite) TestLoadBalancerName(c *gc.C) {
+	name := s.env.loadBalancerName("wordpress", network.MustParsePortRange("80/tcp"))
+	c.Assert(strings.HasPrefix(name, "juju-model-uuid-lb-"), jc.IsTrue)
+	c.Assert(name, gc.Not(gc.Equals), s.env.loadBalancerName("wordpress", network.MustParsePortRange("443/tcp")))
+	c.Assert(name, gc.Not(gc.Equals), s.env.loadBalancerName("mediawiki", network.MustParsePortRange("80/tcp")))
+}
+
+func (s *octaviaInternalSuite) TestEnsurePorts(c *gc.C) {
+	o, err := s.env.octavia()
+	c.Assert(err, jc.ErrorIsNil)
+	member0, err := o.instanceMember("inst-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(member0, jc.DeepEquals, octaviaMember{Address: "10.0.0.10", SubnetID: "subnet-0"})
+	member1, err := o.instanceMember("inst-1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	lb, err := o.ensureLoadBalancer("juju-model-uuid-lb-0", "description", "subnet-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lb.ProvisioningStatus, gc.Equals, "ACTIVE")
+	c.Assert(o.ensurePort(lb, "TCP", 80, []octaviaMember{member0, member1}), jc.ErrorIsNil)
+	c.Assert(s.fake.members["pool-0"], gc.HasLen, 2)
+
+	// Ensuring again reuses the load balancer, listener and pool, and
+	// removes members not given.
+	again, err := o.ensureLoadBalancer("juju-model-uuid-lb-0", "description", "subnet-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again.ID, gc.Equals, lb.ID)
+	c.Assert(o.ensurePort(lb, "TCP", 80, []octaviaMember{member1}), jc.ErrorIsNil)
+	c.Assert(s.fake.loadBalancers, gc.HasLen, 1)
+	c.Assert(s.fake.listeners, gc.HasLen, 1)
+	c.Assert(s.fake.members["pool-0"], jc.DeepEquals, []octaviaMember{
+		{ID: "member-1", Address: "10.0.0.11", ProtocolPort: 80, SubnetID: "subnet-0"},
+	})
+
+	fip, err := o.ensureFloatingIP(lb, "ext-net")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fip.PortID, gc.Equals, lb.VipPortID)
+	again2, err := o.ensureFloatingIP(lb, "ext-net")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again2.ID, gc.Equals, fip.ID)
+}
+
+func (s *octaviaInternalSuite) TestRemoveLoadBalancer(c *gc.C) {
+	endpoint := network.MustParsePortRange("80/tcp")
+	s.fake.addLoadBalancer(s.env.loadBalancerName("wordpress", endpoint), "")
+	s.fake.addLoadBalancer(s.env.loadBalancerName("wordpress", network.MustParsePortRange("443/tcp")), "")
+
+	err := s.env.RemoveLoadBalancer(context.NewCloudCallContext(), "wordpress", endpoint)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.loadBalancers, gc.HasLen, 1)
+	c.Assert(s.fake.floatingIPs, gc.HasLen, 1)
+	c.Assert(s.fake.loadBalancers[0].Name, gc.Equals, s.env.loadBalancerName("wordpress", network.MustParsePortRange("443/tcp")))
+
+	// Removing a load balancer that does not exist is not an error.
+	err = s.env.RemoveLoadBalancer(context.NewCloudCallContext(), "wordpress", endpoint)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *octaviaInternalSuite) TestRemoveLoadBalancers(c *gc.C) {
+	s.fake.addLoadBalancer(s.env.loadBalancerName("wordpress", network.MustParsePortRange("80/tcp")), "")
+	s.fake.addLoadBalancer("juju-other-model-lb-0", loadBalancerDescription("controller-uuid"))
+	s.fake.addLoadBalancer("not-juju", "")
+
+	prefix := s.env.loadBalancerNamePrefix()
+	err := s.env.removeLoadBalancers(func(lb octaviaLoadBalancer) bool {
+		return strings.HasPrefix(lb.Name, prefix)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.loadBalancers, gc.HasLen, 2)
+
+	description := loadBalancerDescription("controller-uuid")
+	err = s.env.removeLoadBalancers(func(lb octaviaLoadBalancer) bool {
+		return lb.Description == description
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.loadBalancers, gc.HasLen, 1)
+	c.Assert(s.fake.loadBalancers[0].Name, gc.Equals, "not-juju")
+	c.Assert(s.fake.floatingIPs, gc.HasLen, 1)
+}
+
+func (r *testAuthClient) Token() string {
+	return "token"
+}
+
+// fakeOctavia is an HTTP server implementing the parts of the Octavia
+// and Neutron APIs used to manage load balancers.
+type fakeOctavia struct {
+	mu            sync.Mutex
+	nextId        int
+	requests      []string
+	ports         map[string]neutronPort
+	loadBalancers []octaviaLoadBalancer
+	listeners     []octaviaListener
+	members       map[string][]octaviaMember
+	floatingIPs   []neutronFloatingIP
+}
+
+func (f *fakeOctavia) id(kind string) string {
+	id := fmt.Sprintf("%s-%d", kind, f.nextId)
+	f.nextId++
+	return id
+}
+
+func (f *fakeOctavia) addLoadBalancer(name, description string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lb := octaviaLoadBalancer{
+		ID:                 f.id("lb"),
+		Name:               name,
+		Description:        description,
+		VipPortID:          f.id("vip"),
+		ProvisioningStatus: "ACTIVE",
+	}
+	f.loadBalancers = append(f.loadBalancers, lb)
+	f.floatingIPs = append(f.floatingIPs, neutronFloatingIP{ID: f.id("fip"), PortID: lb.VipPortID})
+}
+
+func (f *fakeOctavia) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if req.Header.Get("X-Auth-Token") != "token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+	var body map[string]json.RawMessage
+	if req.Body != nil {
+		json.NewDecoder(req.Body).Decode(&body)
+	}
+	query := req.URL.Query()
+	path := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(path) < 3 {
+		http.NotFound(w, req)
+		return
+	}
+	var result interface{}
+	switch strings.Join(path[:3], "/") {
+	case "network/v2.0/ports":
+		var ports []neutronPort
+		if port, ok := f.ports[query.Get("device_id")]; ok {
+			ports = append(ports, port)
+		}
+		result = map[string]interface{}{"ports": ports}
+	case "network/v2.0/floatingips":
+		result = f.serveFloatingIPs(w, req, path, body)
+	case "lb/v2.0/lbaas":
+		result = f.serveLBaaS(w, req, path, body)
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	if result == nil {
+		return
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeOctavia) serveFloatingIPs(w http.ResponseWriter, req *http.Request, path []string, body map[string]json.RawMessage) interface{} {
+	switch req.Method {
+	case "GET":
+		fips := []neutronFloatingIP{}
+		for _, fip := range f.floatingIPs {
+			if fip.PortID == req.URL.Query().Get("port_id") {
+				fips = append(fips, fip)
+			}
+		}
+		return map[string]interface{}{"floatingips": fips}
+	case "POST":
+		var fip neutronFloatingIP
+		json.Unmarshal(body["floatingip"], &fip)
+		fip.ID = f.id("fip")
+		fip.FloatingIPAddress = "203.0.113.1"
+		f.floatingIPs = append(f.floatingIPs, fip)
+		return map[string]interface{}{"floatingip": fip}
+	case "DELETE":
+		for i, fip := range f.floatingIPs {
+			if fip.ID == path[3] {
+				f.floatingIPs = append(f.floatingIPs[:i], f.floatingIPs[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return nil
+			}
+		}
+	}
+	http.NotFound(w, req)
+	return nil
+}
+
+func (f *fakeOctavia) serveLBaaS(w http.ResponseWriter, req *http.Request, path []string, body map[string]json.RawMessage) interface{} {
+	resource := strings.Join(path[3:], "/")
+	switch {
+	case resource == "loadbalancers" && req.Method == "GET":
+		lbs := []octaviaLoadBalancer{}
+		for _, lb := range f.loadBalancers {
+			if name := req.URL.Query().Get("name"); name == "" || lb.Name == name {
+				lbs = append(lbs, lb)
+			}
+		}
+		return map[string]interface{}{"loadbalancers": lbs}
+	case resource == "loadbalancers" && req.Method == "POST":
+		var lb octaviaLoadBalancer
+		json.Unmarshal(body["loadbalancer"], &lb)
+		lb.ID = f.id("lb")
+		lb.VipAddress = "10.0.0.100"
+		lb.VipPortID = f.id("vip")
+		lb.ProvisioningStatus = "PENDING_CREATE"
+		f.loadBalancers = append(f.loadBalancers, lb)
+		return map[string]interface{}{"loadbalancer": lb}
+	case len(path) == 5 && path[3] == "loadbalancers":
+		for i, lb := range f.loadBalancers {
+			if lb.ID != path[4] {
+				continue
+			}
+			if req.Method == "DELETE" {
+				f.loadBalancers = append(f.loadBalancers[:i], f.loadBalancers[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return nil
+			}
+			f.loadBalancers[i].ProvisioningStatus = "ACTIVE"
+			return map[string]interface{}{"loadbalancer": f.loadBalancers[i]}
+		}
+	case resource == "listeners" && req.Method == "GET":
+		return map[string]interface{}{"listeners": f.listeners}
+	case resource == "listeners" && req.Method == "POST":
+		var listener octaviaListener
+		json.Unmarshal(body["listener"], &listener)
+		listener.ID = f.id("listener")
+		f.listeners = append(f.listeners, listener)
+		return map[string]interface{}{"listener": listener}
+	case resource == "pools" && req.Method == "POST":
+		var pool octaviaPool
+		json.Unmarshal(body["pool"], &pool)
+		pool.ID = "pool-0"
+		for i, listener := range f.listeners {
+			if listener.ID == pool.ListenerID {
+				f.listeners[i].DefaultPoolID = pool.ID
+			}
+		}
+		return map[string]interface{}{"pool": pool}
+	case len(path) >= 6 && path[3] == "pools" && path[5] == "members":
+		if f.members == nil {
+			f.members = make(map[string][]octaviaMember)
+		}
+		poolId := path[4]
+		switch req.Method {
+		case "GET":
+			return map[string]interface{}{"members": f.members[poolId]}
+		case "POST":
+			var member octaviaMember
+			json.Unmarshal(body["member"], &member)
+			member.ID = "member-" + strings.TrimPrefix(member.Address, "10.0.0.1")
+			f.members[poolId] = append(f.members[poolId], member)
+			return map[string]interface{}{"member": member}
+		case "DELETE":
+			for i, member := range f.members[poolId] {
+				if member.ID == path[6] {
+					f.members[poolId] = append(f.members[poolId][:i], f.members[poolId][i+1:]...)
+					w.WriteHeader(http.StatusNoContent)
+					return nil
+				}
+			}
+		}
+	}
+	http.NotFound(w, req)
+	return nil
+}
@@ -249,6 +249,18 @@ func (p EnvironProvider) PrepareConfig(args environs.PrepareConfigParams) (*conf
 	return cfg, nil
 }
 
+// ValidateRootDiskEncryption implements environs.RootDiskEncrypter.
+// Instances boot from images onto the compute hosts' ephemeral storage,
+// whose encryption is configured in Nova by the cloud administrator and
+// cannot be requested per instance.
+func (EnvironProvider) ValidateRootDiskEncryption(cfg *config.Config) error {
+	return errors.NewNotSupported(nil, fmt.Sprintf(
+		"OpenStack root disks are ephemeral disks, whose encryption is configured by the cloud administrator; "+
+			"%s is not supported, use Cinder volumes with an encrypted volume type for data",
+		config.RootDiskEncryption,
+	))
+}
+
 // MetadataLookupParams returns parameters which are used to query image metadata to
 // find matching image information.
 func (p EnvironProvider) MetadataLookupParams(region string) (*simplestreams.MetadataLookupParams, error) {
@@ -307,6 +319,7 @@ var _ environs.NetworkingEnviron = (*Environ)(nil)
 var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.ApplicationFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -521,6 +534,9 @@ func (e *Environ) ConstraintsValidator(ctx context.ProviderCallContext) (constra
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
 	validator.RegisterVocabulary(constraints.VirtType, []string{"kvm", "lxd"})
+	if err := common.RegisterZonesVocabulary(validator, e, ctx); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return validator, nil
 }
 
@@ -1566,6 +1582,12 @@ func (e *Environ) Destroy(ctx context.ProviderCallContext) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	prefix := e.loadBalancerNamePrefix()
+	if err := e.removeLoadBalancers(func(lb octaviaLoadBalancer) bool {
+		return strings.HasPrefix(lb.Name, prefix)
+	}); err != nil {
+		return errors.Annotate(err, "removing load balancers")
+	}
 	// Delete all security groups remaining in the model.
 	return e.firewaller.DeleteAllModelGroups(ctx)
 }
@@ -1581,6 +1603,12 @@ func (e *Environ) DestroyController(ctx context.ProviderCallContext, controllerU
 	if err := e.destroyControllerManagedEnvirons(controllerUUID); err != nil {
 		return errors.Annotate(err, "destroying managed models")
 	}
+	description := loadBalancerDescription(controllerUUID)
+	if err := e.removeLoadBalancers(func(lb octaviaLoadBalancer) bool {
+		return lb.Description == description
+	}); err != nil {
+		return errors.Annotate(err, "removing managed models' load balancers")
+	}
 	return e.firewaller.DeleteAllControllerGroups(ctx, controllerUUID)
 }
 
@@ -1672,6 +1700,31 @@ func (e *Environ) IngressRules(ctx context.ProviderCallContext) ([]network.Ingre
 	return e.firewaller.IngressRules(ctx)
 }
 
+// OpenApplicationPorts is specified in environs.ApplicationFirewaller.
+func (e *Environ) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return e.firewaller.OpenApplicationPorts(ctx, applicationName, rules)
+}
+
+// CloseApplicationPorts is specified in environs.ApplicationFirewaller.
+func (e *Environ) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return e.firewaller.CloseApplicationPorts(ctx, applicationName, rules)
+}
+
+// ApplicationIngressRules is specified in environs.ApplicationFirewaller.
+func (e *Environ) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error) {
+	return e.firewaller.ApplicationIngressRules(ctx, applicationName)
+}
+
+// SetApplicationSources is specified in environs.ApplicationFirewaller.
+func (e *Environ) SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return e.firewaller.SetApplicationSources(ctx, applicationName, rules)
+}
+
+// SetInstanceApplications is specified in environs.ApplicationFirewaller.
+func (e *Environ) SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error {
+	return e.firewaller.SetInstanceApplications(ctx, id, applicationNames)
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
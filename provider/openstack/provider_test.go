@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
 )
 
 // localTests contains tests which do not require a live service or test double to run.
@@ -593,3 +595,10 @@ func (s *providerUnitTests) TestIdentityClientVersion_ParsesGoodURL(c *gc.C) {
 	checkIdentityClientVersion(c, "https://keystone.internal", -1)
 	checkIdentityClientVersion(c, "https://keystone.internal/", -1)
 }
+
+func (s *providerUnitTests) TestValidateRootDiskEncryption(c *gc.C) {
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{"root-disk-encryption": true})
+	err := environs.ValidateRootDiskEncryption(EnvironProvider{}, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "OpenStack root disks are ephemeral disks, .* root-disk-encryption is not supported, .*")
+}
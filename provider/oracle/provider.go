@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
 )
 
 var logger = loggo.GetLogger("juju.provider.oracle")
@@ -127,6 +128,9 @@ func (e EnvironProvider) Validate(cfg, old *config.Config) (valid *config.Config
 	if err := config.Validate(cfg, old); err != nil {
 		return nil, err
 	}
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, err
+	}
 	newAttrs, err := cfg.ValidateUnknownAttrs(
 		schema.Fields{}, schema.Defaults{},
 	)
@@ -93,6 +93,31 @@ func (c *rackspaceFirewaller) InstanceIngressRules(ctx context.ProviderCallConte
 	return configurator.FindIngressRules()
 }
 
+// OpenApplicationPorts is not supported.
+func (c *rackspaceFirewaller) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenApplicationPorts")
+}
+
+// CloseApplicationPorts is not supported.
+func (c *rackspaceFirewaller) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseApplicationPorts")
+}
+
+// ApplicationIngressRules is not supported.
+func (c *rackspaceFirewaller) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("ApplicationIngressRules")
+}
+
+// SetApplicationSources is not supported.
+func (c *rackspaceFirewaller) SetApplicationSources(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	return errors.NotSupportedf("SetApplicationSources")
+}
+
+// SetInstanceApplications is not supported.
+func (c *rackspaceFirewaller) SetInstanceApplications(ctx context.ProviderCallContext, id instance.Id, applicationNames []string) error {
+	return errors.NotSupportedf("SetInstanceApplications")
+}
+
 func (c *rackspaceFirewaller) changeIngressRules(ctx context.ProviderCallContext, inst instance.Instance, insert bool, rules []network.IngressRule) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(ctx, inst)
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
 )
 
 type environProvider struct {
@@ -37,6 +38,15 @@ func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*con
 	return p.CloudEnvironProvider.PrepareConfig(args)
 }
 
+// Validate is part of the EnvironProvider interface. Rackspace does not
+// support the security groups the application firewall mode relies on.
+func (p *environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return p.CloudEnvironProvider.Validate(cfg, old)
+}
+
 // Open is part of the EnvironProvider interface.
 func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	args.Cloud = transformCloudSpec(args.Cloud)
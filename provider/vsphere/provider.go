@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	callcontext "github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
 )
 
 var logger = loggo.GetLogger("juju.provider.vmware")
@@ -144,6 +145,9 @@ func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*con
 
 // Validate implements environs.EnvironProvider.
 func (*environProvider) Validate(cfg, old *config.Config) (valid *config.Config, err error) {
+	if err := common.RejectApplicationFirewallMode(cfg); err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
 	if old == nil {
 		ecfg, err := newValidConfig(cfg)
 		if err != nil {
@@ -277,6 +277,33 @@ func allCollections() collectionSchema {
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC: {},
 
+		// unitCharmStatesC holds the key-value state persisted by
+		// each unit's charm.
+		unitCharmStatesC: {},
+
+		// secretsC holds the metadata of secrets created by charms,
+		// and secretRevisionsC their encrypted values.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+		secretRevisionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}},
+		},
+
+		// secretKeysC holds the key used to encrypt each model's
+		// secret values.
+		secretKeysC: {},
+
+		// unitHookHistoryC holds the most recent hook executions of
+		// each unit. It is written directly, outside transactions.
+		unitHookHistoryC: {
+			rawAccess: true,
+		},
+
 		// These collections hold reference counts which are used
 		// by the nsRefcounts struct.
 		refcountsC: {}, // Per model.
@@ -560,6 +587,9 @@ const (
 	relationScopesC            = "relationscopes"
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
+	secretKeysC                = "secretkeys"
+	secretRevisionsC           = "secretrevisions"
+	secretsC                   = "secrets"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
@@ -580,6 +610,8 @@ const (
 	toolsmetadataC             = "toolsmetadata"
 	txnLogC                    = "txns.log"
 	txnsC                      = "txns"
+	unitCharmStatesC           = "unitcharmstates"
+	unitHookHistoryC           = "unithookhistory"
 	unitsC                     = "units"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
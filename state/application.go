@@ -62,6 +62,10 @@ type applicationDoc struct {
 	TxnRevno             int64        `bson:"txn-revno"`
 	MetricCredentials    []byte       `bson:"metric-credentials"`
 	PasswordHash         string       `bson:"passwordhash"`
+
+	// LoadBalancerAddrs holds the addresses of the load balancers of
+	// the application's exposed endpoints, keyed by port range.
+	LoadBalancerAddrs map[string][]string `bson:"load-balancer-addresses,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -388,6 +392,12 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, removeOfferOps...)
 
+	removeSecretOps, err := removeApplicationSecretsOps(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeSecretOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -451,6 +461,45 @@ func (a *Application) setExposed(exposed bool) (err error) {
 	return nil
 }
 
+// LoadBalancerAddresses returns the addresses of the provider load
+// balancers in front of the application's exposed endpoints, keyed by
+// endpoint port range (e.g. "80/tcp"), as recorded by the firewaller.
+// They are empty if the application has no load balancers.
+func (a *Application) LoadBalancerAddresses() map[string][]string {
+	return a.doc.LoadBalancerAddrs
+}
+
+// SetLoadBalancerAddresses records the addresses of the provider load
+// balancers in front of the application's exposed endpoints, keyed by
+// endpoint port range. Passing no addresses records that the
+// application has no load balancers.
+func (a *Application) SetLoadBalancerAddresses(addresses map[string][]string) error {
+	var update bson.D
+	if len(addresses) > 0 {
+		update = bson.D{{"$set", bson.D{{"load-balancer-addresses", addresses}}}}
+	} else {
+		update = bson.D{{"$unset", bson.D{{"load-balancer-addresses", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			return errors.NotFoundf("application %q", a)
+		}
+		return errors.Annotatef(err, "cannot set load balancer addresses for application %q", a)
+	}
+	if len(addresses) > 0 {
+		a.doc.LoadBalancerAddrs = addresses
+	} else {
+		a.doc.LoadBalancerAddrs = nil
+	}
+	return nil
+}
+
 // Charm returns the application's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (a *Application) Charm() (ch *Charm, force bool, err error) {
@@ -1688,6 +1737,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 			Remove: true,
 		},
 		removeMeterStatusOp(a.st, u.globalMeterStatusKey()),
+		removeUnitCharmStateOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(u.globalAgentKey()),
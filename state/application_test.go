@@ -1815,6 +1815,48 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestLoadBalancerAddresses(c *gc.C) {
+	c.Assert(s.mysql.LoadBalancerAddresses(), gc.HasLen, 0)
+
+	addresses := map[string][]string{
+		"3306/tcp":      {"10.0.0.1", "2001:db8::1"},
+		"4567-4568/tcp": {"10.0.0.2"},
+	}
+	err := s.mysql.SetLoadBalancerAddresses(addresses)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LoadBalancerAddresses(), jc.DeepEquals, addresses)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LoadBalancerAddresses(), jc.DeepEquals, addresses)
+
+	err = s.mysql.SetLoadBalancerAddresses(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LoadBalancerAddresses(), gc.HasLen, 0)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LoadBalancerAddresses(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetLoadBalancerAddressesDyingApplication(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The firewaller removes the load balancer of a dying
+	// application, so its addresses can still be cleared.
+	err = s.mysql.SetLoadBalancerAddresses(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestSetLoadBalancerAddressesRemovedApplication(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetLoadBalancerAddresses(map[string][]string{"80/tcp": {"10.0.0.1"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -2551,6 +2593,54 @@ func (s *ApplicationSuite) TestWatchUnitsLifecycle(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *ApplicationSuite) TestWatchGoalState(c *gc.C) {
+	w := s.mysql.WatchGoalState()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Add a unit; check change.
+	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Change the unit without adding or removing it; check no change.
+	err = unit.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Add an unrelated application with a unit; check no change.
+	wp := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err = wp.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Relate the application; check change.
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Add a unit to the related application; check change.
+	wpUnit, err := wp.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Remove the unit of the related application; check change.
+	err = wpUnit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Remove the relation; check change.
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *ApplicationSuite) TestWatchRelations(c *gc.C) {
 	// TODO(fwereade) split this test up a bit.
 	w := s.mysql.WatchRelations()
@@ -3363,6 +3453,32 @@ func sampleApplicationConfigSchema() environschema.Fields {
 	return schema
 }
 
+func (s *ApplicationSuite) TestWatchApplicationsConfig(c *gc.C) {
+	w := s.State.WatchApplicationsConfig()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Change an application's config; check change.
+	err := s.mysql.UpdateApplicationConfig(application.ConfigAttributes{"title": "value"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Add an application; check change.
+	wp := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wc.AssertOneChange()
+
+	// Change an application's charm config; check no change.
+	err = wp.UpdateCharmConfig(charm.Settings{"blog-title": "awesome"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Change the model config; check no change.
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"resource-tags": "a=b"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *ApplicationSuite) TestUpdateApplicationConfigWithDyingApplication(c *gc.C) {
 	_, err := s.mysql.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -3584,6 +3700,41 @@ func (s *CAASApplicationSuite) TestServiceInfoEmptyProviderId(c *gc.C) {
 	}
 }
 
+func (s *CAASApplicationSuite) TestServiceStatus(c *gc.C) {
+	_, err := s.app.ServiceStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.app.UpdateCloudService("id", []network.Address{{Value: "10.0.0.1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	serviceStatus, err := s.app.ServiceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(serviceStatus.Status, gc.Equals, status.Active)
+
+	now := coretesting.ZeroTime()
+	err = s.app.SetServiceStatus(status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: "rolling update: 1 of 3 units updated",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	serviceStatus, err = s.app.ServiceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(serviceStatus.Status, gc.Equals, status.Maintenance)
+	c.Assert(serviceStatus.Message, gc.Equals, "rolling update: 1 of 3 units updated")
+
+	// The application status, which belongs to the charm, is unaffected.
+	appStatus, err := s.app.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appStatus.Status, gc.Not(gc.Equals), status.Maintenance)
+}
+
+func (s *CAASApplicationSuite) TestSetServiceStatusInvalid(c *gc.C) {
+	err := s.app.UpdateCloudService("id", []network.Address{{Value: "10.0.0.1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetServiceStatus(status.StatusInfo{Status: status.Running})
+	c.Assert(err, gc.ErrorMatches, `cannot set invalid service status "running"`)
+}
+
 func (s *CAASApplicationSuite) TestRemoveUnitDeletesServiceInfo(c *gc.C) {
 	err := s.app.UpdateCloudService("id", []network.Address{{Value: "10.0.0.1"}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -18,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/storage"
@@ -84,6 +85,11 @@ type charmDoc struct {
 	Config  *charm.Config  `bson:"config"`
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
+
+	// RelationSchemas holds the JSON encoding of the relation data
+	// schemas declared in the charm's metadata, if any. These are
+	// not part of charm.Meta, so they are read from the charm itself.
+	RelationSchemas string `bson:"relation-schemas,omitempty"`
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -103,16 +109,21 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 		return nil, errors.New("*charm.URL was nil")
 	}
 
+	relationSchemas, err := charmRelationSchemas(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	doc := charmDoc{
-		DocID:        info.ID.String(),
-		URL:          info.ID,
-		CharmVersion: info.Version,
-		Meta:         info.Charm.Meta(),
-		Config:       safeConfig(info.Charm),
-		Metrics:      info.Charm.Metrics(),
-		Actions:      info.Charm.Actions(),
-		BundleSha256: info.SHA256,
-		StoragePath:  info.StoragePath,
+		DocID:           info.ID.String(),
+		URL:             info.ID,
+		CharmVersion:    info.Version,
+		Meta:            info.Charm.Meta(),
+		Config:          safeConfig(info.Charm),
+		Metrics:         info.Charm.Metrics(),
+		Actions:         info.Charm.Actions(),
+		RelationSchemas: relationSchemas,
+		BundleSha256:    info.SHA256,
+		StoragePath:     info.StoragePath,
 	}
 	if err := checkCharmDataIsStorable(doc); err != nil {
 		return nil, errors.Trace(err)
@@ -210,12 +221,17 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 	}
 	op.Assert = append(lifeAssert, assert...)
 
+	relationSchemas, err := charmRelationSchemas(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	data := bson.D{
 		{"charm-version", info.Version},
 		{"meta", info.Charm.Meta()},
 		{"config", safeConfig(info.Charm)},
 		{"actions", info.Charm.Actions()},
 		{"metrics", info.Charm.Metrics()},
+		{"relation-schemas", relationSchemas},
 		{"storagepath", info.StoragePath},
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
@@ -237,6 +253,16 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 	return []txn.Op{op}, nil
 }
 
+// charmRelationSchemas returns the encoded relation data schemas
+// declared by the charm.
+func charmRelationSchemas(ch charm.Charm) (string, error) {
+	schemas, err := relation.ReadCharmSchemas(ch)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot read relation schemas")
+	}
+	return schemas.Marshal()
+}
+
 // convertPlaceholderCharmOps returns the txn operations necessary to convert
 // the charm with the supplied docId from a placeholder to one marked for
 // pending upload.
@@ -463,6 +489,13 @@ func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
 }
 
+// RelationSchemas returns the relation data schemas declared by the
+// charm, keyed by endpoint name.
+func (c *Charm) RelationSchemas() (relation.Schemas, error) {
+	schemas, err := relation.UnmarshalSchemas(c.doc.RelationSchemas)
+	return schemas, errors.Annotatef(err, "cannot decode relation schemas for charm %q", c.doc.URL)
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
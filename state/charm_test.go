@@ -6,6 +6,7 @@ package state_test
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -18,6 +19,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
@@ -286,6 +288,53 @@ func (s *CharmSuite) TestAddCharm(c *gc.C) {
 	c.Assert(doc.CharmVersion, gc.Equals, expVersion)
 }
 
+func (s *CharmSuite) TestAddCharmRelationSchemas(c *gc.C) {
+	chDir := testcharms.Repo.ClonedDirPath(c.MkDir(), "mysql")
+	metadata, err := ioutil.ReadFile(filepath.Join(chDir, "metadata.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	metadata = append(metadata, []byte(`
+relation-schemas:
+  server:
+    publishes:
+      $schema: http://json-schema.org/draft-04/schema#
+      type: object
+      required: [host]
+      properties:
+        host: {type: string}
+`)...)
+	err = ioutil.WriteFile(filepath.Join(chDir, "metadata.yaml"), metadata, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmDir(chDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       ch,
+		ID:          charm.MustParseURL("local:quantal/mysql-1"),
+		StoragePath: "mysql-1",
+		SHA256:      "mysql-1-sha256",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	schemas, err := sch.RelationSchemas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.DeepEquals, relation.Schemas{
+		"server": {
+			Publishes: map[string]interface{}{
+				"$schema":  "http://json-schema.org/draft-04/schema#",
+				"type":     "object",
+				"required": []interface{}{"host"},
+				"properties": map[string]interface{}{
+					"host": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	})
+
+	// Charms without schemas have none.
+	schemas, err = s.charm.RelationSchemas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.HasLen, 0)
+}
+
 func (s *CharmSuite) TestAddCharmWithAuth(c *gc.C) {
 	// Check that adding charms from scratch works correctly.
 	info := s.dummyCharm(c, "")
@@ -5,10 +5,12 @@ package state
 
 import (
 	"github.com/juju/errors"
-	"github.com/juju/juju/network"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/status"
 )
 
 // CloudService represents the state of a CAAS service.
@@ -66,18 +68,28 @@ func (a *Application) cloudService() (*cloudServiceDoc, error) {
 	return &doc, nil
 }
 
+// serviceGlobalKey returns the global database key for the status
+// of the application's cloud service.
+func (a *Application) serviceGlobalKey() string {
+	return a.globalKey() + "#service"
+}
+
 func (a *Application) saveServiceOps(doc cloudServiceDoc) ([]txn.Op, error) {
 	existing, err := a.cloudService()
 	if err != nil && !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	statusOps, statusErr := a.ensureServiceStatusOps()
+	if statusErr != nil {
+		return nil, errors.Trace(statusErr)
+	}
 	if err != nil {
-		return []txn.Op{{
+		return append(statusOps, txn.Op{
 			C:      cloudServicesC,
 			Id:     doc.Id,
 			Assert: txn.DocMissing,
 			Insert: doc,
-		}}, nil
+		}), nil
 	}
 	var asserts bson.D
 	providerValueAssert := bson.DocElem{"provider-id", existing.ProviderId}
@@ -87,7 +99,7 @@ func (a *Application) saveServiceOps(doc cloudServiceDoc) ([]txn.Op, error) {
 		asserts = bson.D{{"$or",
 			[]bson.D{{providerValueAssert}, {{"provider-id", bson.D{{"$exists", false}}}}}}}
 	}
-	return []txn.Op{{
+	return append(statusOps, txn.Op{
 		C:      cloudServicesC,
 		Id:     existing.Id,
 		Assert: asserts,
@@ -97,7 +109,50 @@ func (a *Application) saveServiceOps(doc cloudServiceDoc) ([]txn.Op, error) {
 					{"addresses", doc.Addresses}},
 			},
 		},
-	}}, nil
+	}), nil
+}
+
+// ensureServiceStatusOps returns the operations needed to create the
+// status document of the application's cloud service, if it does not
+// already exist.
+func (a *Application) ensureServiceStatusOps() ([]txn.Op, error) {
+	_, err := getStatus(a.st.db(), a.serviceGlobalKey(), "service")
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{createStatusOp(a.st, a.serviceGlobalKey(), statusDoc{
+		Status:  status.Active,
+		Updated: a.st.clock().Now().UnixNano(),
+	})}, nil
+}
+
+// ServiceStatus returns the status of the application's cloud service.
+// This reports the progress of any rolling update of the application's
+// units, and is separate from the application's workload status, which
+// is set by the charm. This is only used for CAAS models.
+func (a *Application) ServiceStatus() (status.StatusInfo, error) {
+	return getStatus(a.st.db(), a.serviceGlobalKey(), "service")
+}
+
+// SetServiceStatus sets the status of the application's cloud service.
+// The cloud service must have been recorded with UpdateCloudService.
+func (a *Application) SetServiceStatus(statusInfo status.StatusInfo) error {
+	switch statusInfo.Status {
+	case status.Active, status.Maintenance, status.Waiting, status.Blocked, status.Error:
+	default:
+		return errors.Errorf("cannot set invalid service status %q", statusInfo.Status)
+	}
+	return setStatus(a.st.db(), setStatusParams{
+		badge:     "service",
+		globalKey: a.serviceGlobalKey(),
+		status:    statusInfo.Status,
+		message:   statusInfo.Message,
+		rawData:   statusInfo.Data,
+		updated:   timeOrNow(statusInfo.Since, a.st.clock()),
+	})
 }
 
 func (a *Application) removeCloudServiceOps() []txn.Op {
@@ -105,6 +160,6 @@ func (a *Application) removeCloudServiceOps() []txn.Op {
 		C:      cloudServicesC,
 		Id:     a.globalKey(),
 		Remove: true,
-	}}
+	}, removeStatusOp(a.st, a.serviceGlobalKey())}
 	return ops
 }
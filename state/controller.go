@@ -43,6 +43,7 @@ type Controller struct {
 	policy                 Policy
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
+	secretsMasterKey       []byte
 }
 
 // Close the connection to the database.
@@ -66,6 +67,7 @@ func (ctlr *Controller) NewState(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	st.secretsMasterKey = ctlr.secretsMasterKey
 	if err := st.start(ctlr.controllerTag, nil); err != nil {
 		return nil, errors.Trace(err)
 	}
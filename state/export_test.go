@@ -47,6 +47,8 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	MaxHookExecutions = maxHookExecutions
 )
 
 var (
@@ -87,6 +89,7 @@ func EnsureWorkersStarted(st *State) {
 	st.workers.presenceWatcher()
 	st.workers.leadershipManager()
 	st.workers.singularManager()
+	st.workers.operatorLeaseManager()
 }
 
 func SetTestHooks(c *gc.C, st *State, hooks ...jujutxn.TestHook) txntesting.TransactionChecker {
@@ -125,6 +128,11 @@ func newRunnerForHooks(st *State) jujutxn.Runner {
 
 // SetPolicy updates the State's policy field to the
 // given Policy, and returns the old value.
+// SetSecretsMasterKey sets the key used to wrap model secret keys.
+func SetSecretsMasterKey(st *State, key []byte) {
+	st.secretsMasterKey = key
+}
+
 func SetPolicy(st *State, p Policy) Policy {
 	old := st.policy
 	st.policy = p
@@ -62,6 +62,10 @@ type InitializeParams struct {
 
 	// AdminPassword holds the password for the initial user.
 	AdminPassword string
+
+	// SecretsMasterKey wraps the keys used to encrypt each model's
+	// secret values; see OpenParams.
+	SecretsMasterKey []byte
 }
 
 // Validate checks that the state initialization parameters are valid.
@@ -155,6 +159,7 @@ func Initialize(args InitializeParams) (_ *Controller, _ *State, err error) {
 		MongoSession:       args.MongoSession,
 		NewPolicy:          args.NewPolicy,
 		InitDatabaseFunc:   InitDatabase,
+		SecretsMasterKey:   args.SecretsMasterKey,
 	})
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "opening controller")
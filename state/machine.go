@@ -142,6 +142,10 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// ReplacedBy holds the id of the machine added to replace this
+	// one, after its instance was terminated by the provider.
+	ReplacedBy string `bson:"replacedby,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -534,6 +538,114 @@ func (m *Machine) ForceDestroy() error {
 	return nil
 }
 
+// Replace adds a new machine with the same series, constraints and
+// jobs as this one, adds a unit of each application with a principal
+// unit on this machine to the new machine, and then queues this
+// machine for complete removal. It is used to replace machines whose
+// instances have been terminated by the provider. Controller machines
+// and machines hosting containers cannot be replaced.
+//
+// The replacement is recorded on this machine when it is added, so
+// calling Replace again only completes any work left undone; it never
+// adds a second replacement.
+func (m *Machine) Replace() (*Machine, error) {
+	if m.IsManager() {
+		return nil, errors.NotSupportedf("replacing controller machine %s", m.Id())
+	}
+	containers, err := m.Containers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(containers) > 0 {
+		return nil, errors.NotSupportedf("replacing machine %s hosting containers", m.Id())
+	}
+	replacement, err := m.replacementMachine()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot add replacement for machine %s", m.Id())
+	}
+	replaced := make(map[string]bool)
+	for _, unitName := range replacement.Principals() {
+		if appName, err := names.UnitApplication(unitName); err == nil {
+			replaced[appName] = true
+		}
+	}
+	for _, unitName := range m.Principals() {
+		unit, err := m.st.Unit(unitName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := unit.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive || replaced[app.Name()] {
+			continue
+		}
+		newUnit, err := app.AddUnit(AddUnitParams{})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := newUnit.AssignToMachine(replacement); err != nil {
+			return nil, errors.Trace(err)
+		}
+		replaced[app.Name()] = true
+	}
+	if err := m.ForceDestroy(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return replacement, nil
+}
+
+// replacementMachine returns the machine replacing this one, adding it
+// if there is none yet. The new machine is recorded on this one in the
+// same transaction that adds it.
+func (m *Machine) replacementMachine() (*Machine, error) {
+	var replacement *machineDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		replacement = nil
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.ReplacedBy != "" {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if m.doc.Life != Alive {
+			return nil, errors.Errorf("machine %s is not alive", m.Id())
+		}
+		cons, err := m.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		mdoc, ops, err := m.st.addMachineOps(MachineTemplate{
+			Series:      m.Series(),
+			Constraints: cons,
+			Jobs:        m.Jobs(),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		replacement = mdoc
+		return append(ops, txn.Op{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"replacedby", bson.D{{"$exists", false}}}),
+			Update: bson.D{{"$set", bson.D{{"replacedby", mdoc.Id}}}},
+		}, assertModelActiveOp(m.st.ModelUUID())), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if replacement == nil {
+		return m.st.Machine(m.doc.ReplacedBy)
+	}
+	m.doc.ReplacedBy = replacement.Id
+	return newMachine(m.st, replacement), nil
+}
+
 func (m *Machine) forceDestroyOps() ([]txn.Op, error) {
 	if m.IsManager() {
 		controllerInfo, err := m.st.ControllerInfo()
@@ -1814,6 +1926,20 @@ func (m *Machine) SetStatus(statusInfo status.StatusInfo) error {
 	})
 }
 
+// SetDown sets the machine status to down with the given message. It
+// is used by the controller when the provider has terminated the
+// machine's instance; SetStatus refuses down because it is otherwise
+// inferred from the absence of the machine agent.
+func (m *Machine) SetDown(message string) error {
+	return setStatus(m.st.db(), setStatusParams{
+		badge:     "machine",
+		globalKey: m.globalKey(),
+		status:    status.Down,
+		message:   message,
+		updated:   timeOrNow(nil, m.st.clock()),
+	})
+}
+
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
 // or items as old as filter.Date or items newer than now - filter.Delta time
 // representing past statuses for this machine.
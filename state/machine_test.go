@@ -350,6 +350,99 @@ func (s *MachineSuite) TestLifeJobHostUnits(c *gc.C) {
 	c.Assert(m.Life(), gc.Equals, state.Dead)
 }
 
+func (s *MachineSuite) TestReplace(c *gc.C) {
+	err := s.machine.SetConstraints(constraints.MustParse("mem=4G instance-lifecycle=spot"))
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	replacement, err := s.machine.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Id(), gc.Not(gc.Equals), s.machine.Id())
+	c.Assert(replacement.Series(), gc.Equals, s.machine.Series())
+	c.Assert(replacement.Jobs(), jc.DeepEquals, s.machine.Jobs())
+	cons, err := replacement.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G instance-lifecycle=spot"))
+	c.Assert(replacement.Principals(), jc.DeepEquals, []string{"wordpress/1"})
+
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Life(), gc.Equals, state.Dead)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Name(), gc.Equals, "wordpress/1")
+}
+
+func (s *MachineSuite) TestReplaceAgain(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	replacement, err := s.machine.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Replacing the machine again, as the instance poller does
+	// when it sees the terminated instance again, adds nothing.
+	machine, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	again, err := machine.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again.Id(), gc.Equals, replacement.Id())
+	c.Assert(again.Principals(), jc.DeepEquals, []string{"wordpress/1"})
+
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 3)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+}
+
+func (s *MachineSuite) TestReplaceConcurrentlyReplaced(c *gc.C) {
+	var replacement *state.Machine
+	defer state.SetBeforeHooks(c, s.State, func() {
+		machine, err := s.State.Machine(s.machine.Id())
+		c.Assert(err, jc.ErrorIsNil)
+		replacement, err = machine.Replace()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	again, err := s.machine.Replace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again.Id(), gc.Equals, replacement.Id())
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 3)
+}
+
+func (s *MachineSuite) TestReplaceMachineWithContainer(c *gc.C) {
+	_, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.machine.Replace()
+	c.Assert(err, gc.ErrorMatches, "replacing machine 1 hosting containers not supported")
+}
+
+func (s *MachineSuite) TestReplaceControllerMachine(c *gc.C) {
+	_, err := s.machine0.Replace()
+	c.Assert(err, gc.ErrorMatches, "replacing controller machine 0 not supported")
+}
+
 func (s *MachineSuite) TestDestroyRemovePorts(c *gc.C) {
 	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	unit, err := app.AddUnit(state.AddUnitParams{})
@@ -0,0 +1,151 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Some unit data can't be held by the model description yet, so it is
+// carried in reserved unit annotations. The exporter adds them to the
+// unit's own annotations, and the importer removes them again before
+// setting the rest on the unit.
+const (
+	// charmStateAnnotation holds the unit's JSON encoded charm state.
+	charmStateAnnotation = "juju-charm-state"
+
+	// pausedAnnotation is set to "true" if the unit is paused.
+	pausedAnnotation = "juju-paused"
+)
+
+// unitMigrationData holds the unit data carried in annotations.
+type unitMigrationData struct {
+	charmState map[string]string
+	paused     bool
+}
+
+// withUnitMigrationAnnotations returns the unit annotations to export,
+// including those which carry the specified data.
+func withUnitMigrationAnnotations(annotations map[string]string, data unitMigrationData) (map[string]string, error) {
+	if len(data.charmState) == 0 && !data.paused {
+		return annotations, nil
+	}
+	result := make(map[string]string, len(annotations)+2)
+	for key, value := range annotations {
+		result[key] = value
+	}
+	if len(data.charmState) > 0 {
+		encoded, err := json.Marshal(data.charmState)
+		if err != nil {
+			return nil, errors.Annotate(err, "encoding charm state")
+		}
+		result[charmStateAnnotation] = string(encoded)
+	}
+	if data.paused {
+		result[pausedAnnotation] = "true"
+	}
+	return result, nil
+}
+
+// splitUnitMigrationAnnotations returns the imported unit annotations
+// without those which carry unit data, and the data they hold.
+func splitUnitMigrationAnnotations(annotations map[string]string) (map[string]string, unitMigrationData, error) {
+	var data unitMigrationData
+	result := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		switch key {
+		case charmStateAnnotation:
+			if err := json.Unmarshal([]byte(value), &data.charmState); err != nil {
+				return nil, unitMigrationData{}, errors.Annotate(err, "decoding charm state")
+			}
+		case pausedAnnotation:
+			data.paused = value == "true"
+		default:
+			result[key] = value
+		}
+	}
+	return result, data, nil
+}
+
+// Model secrets can't be held by the model description yet either, so
+// they are carried in a reserved model annotation. The values of each
+// revision stay encrypted with the model's secret key; the key itself
+// is exported unwrapped, and the importer wraps it with the target
+// controller's secrets master key.
+const secretsAnnotation = "juju-secrets"
+
+// secretsMigrationData holds the model's secrets and the key their
+// values are encrypted with.
+type secretsMigrationData struct {
+	Key     []byte                `json:"key"`
+	Secrets []secretMigrationData `json:"secrets"`
+}
+
+// secretMigrationData holds a secret and all of its revisions.
+type secretMigrationData struct {
+	ID             string                        `json:"id"`
+	Owner          string                        `json:"owner"`
+	Description    string                        `json:"description,omitempty"`
+	Revision       int                           `json:"revision"`
+	RotateInterval time.Duration                 `json:"rotate-interval,omitempty"`
+	NextRotateTime time.Time                     `json:"next-rotate-time"`
+	Grants         []secretGrantMigrationData    `json:"grants,omitempty"`
+	CreateTime     time.Time                     `json:"create-time"`
+	UpdateTime     time.Time                     `json:"update-time"`
+	Revisions      []secretRevisionMigrationData `json:"revisions"`
+}
+
+// secretGrantMigrationData holds a grant of a secret to an application.
+type secretGrantMigrationData struct {
+	Application string `json:"application"`
+	Relation    string `json:"relation"`
+}
+
+// secretRevisionMigrationData holds the encrypted values of one
+// revision of a secret.
+type secretRevisionMigrationData struct {
+	Revision   int       `json:"revision"`
+	CreateTime time.Time `json:"create-time"`
+	Data       []byte    `json:"data"`
+}
+
+// withModelMigrationAnnotations returns the model annotations to
+// export, including the one which carries the model's secrets.
+func withModelMigrationAnnotations(annotations map[string]string, secrets *secretsMigrationData) (map[string]string, error) {
+	if secrets == nil {
+		return annotations, nil
+	}
+	result := make(map[string]string, len(annotations)+1)
+	for key, value := range annotations {
+		result[key] = value
+	}
+	encoded, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, errors.Annotate(err, "encoding secrets")
+	}
+	result[secretsAnnotation] = string(encoded)
+	return result, nil
+}
+
+// splitModelMigrationAnnotations returns the imported model annotations
+// without the one which carries the model's secrets, and the secrets
+// it holds, if any.
+func splitModelMigrationAnnotations(annotations map[string]string) (map[string]string, *secretsMigrationData, error) {
+	var secrets *secretsMigrationData
+	result := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		if key != secretsAnnotation {
+			result[key] = value
+			continue
+		}
+		secrets = &secretsMigrationData{}
+		if err := json.Unmarshal([]byte(value), secrets); err != nil {
+			return nil, nil, errors.Annotate(err, "decoding secrets")
+		}
+	}
+	return result, secrets, nil
+}
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/storage/poolmanager"
@@ -40,17 +41,19 @@ type ExportConfig struct {
 }
 
 // ExportPartial the current model for the State optionally skipping
-// aspects as defined by the ExportConfig.
+// aspects as defined by the ExportConfig. The model's secrets are
+// never included.
 func (st *State) ExportPartial(cfg ExportConfig) (description.Model, error) {
-	return st.exportImpl(cfg)
+	return st.exportImpl(cfg, false)
 }
 
-// Export the current model for the State.
+// Export the current model for the State, including its secrets so
+// that it can be migrated.
 func (st *State) Export() (description.Model, error) {
-	return st.exportImpl(ExportConfig{})
+	return st.exportImpl(ExportConfig{}, true)
 }
 
-func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
+func (st *State) exportImpl(cfg ExportConfig, includeSecrets bool) (description.Model, error) {
 	dbModel, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -116,7 +119,17 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 		})
 	}
 	modelKey := dbModel.globalKey()
-	export.model.SetAnnotations(export.getAnnotations(modelKey))
+	var secrets *secretsMigrationData
+	if includeSecrets {
+		if secrets, err = export.readSecrets(); err != nil {
+			return nil, errors.Annotate(err, "reading secrets")
+		}
+	}
+	modelAnnotations, err := withModelMigrationAnnotations(export.getAnnotations(modelKey), secrets)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	export.model.SetAnnotations(modelAnnotations)
 	if err := export.sequences(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -571,6 +584,11 @@ func (e *exporter) applications() error {
 		return errors.Trace(err)
 	}
 
+	charmStates, err := e.readAllUnitCharmStates()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	podSpecs, err := e.readAllPodSpecs()
 	if err != nil {
 		return errors.Trace(err)
@@ -600,6 +618,7 @@ func (e *exporter) applications() error {
 			application:      application,
 			units:            applicationUnits,
 			meterStatus:      meterStatus,
+			charmStates:      charmStates,
 			podSpecs:         podSpecs,
 			cloudServices:    cloudServices,
 			cloudContainers:  cloudContainers,
@@ -661,6 +680,7 @@ type addApplicationContext struct {
 	application      *Application
 	units            []*Unit
 	meterStatus      map[string]*meterStatusDoc
+	charmStates      map[string]map[string]string
 	leader           string
 	payloads         map[string][]payload.FullPayloadInfo
 	resources        resource.ApplicationResources
@@ -816,7 +836,14 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 				Size:    tools.Size,
 			})
 		}
-		exUnit.SetAnnotations(e.getAnnotations(globalKey))
+		annotations, err := withUnitMigrationAnnotations(e.getAnnotations(globalKey), unitMigrationData{
+			charmState: ctx.charmStates[globalKey],
+			paused:     unit.Paused(),
+		})
+		if err != nil {
+			return errors.Annotatef(err, "exporting unit %q", unit.Name())
+		}
+		exUnit.SetAnnotations(annotations)
 
 		constraintsArgs, err := e.constraintsArgs(agentKey)
 		if err != nil {
@@ -1335,6 +1362,95 @@ func (e *exporter) readAllCloudContainers() (map[string]*cloudContainerDoc, erro
 	return result, nil
 }
 
+func (e *exporter) readAllUnitCharmStates() (map[string]map[string]string, error) {
+	coll, closer := e.st.db().GetCollection(unitCharmStatesC)
+	defer closer()
+
+	var docs []unitCharmStateDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all unit charm state docs")
+	}
+	e.logger.Debugf("found %d unit charm state docs", len(docs))
+	result := make(map[string]map[string]string)
+	for _, doc := range docs {
+		state := make(map[string]string, len(doc.State))
+		for key, value := range doc.State {
+			state[utils.UnescapeKey(key)] = value
+		}
+		result[e.st.localID(doc.DocID)] = state
+	}
+	return result, nil
+}
+
+// readSecrets returns the model's secrets, with the values of each
+// revision still encrypted, and the key they are encrypted with. It
+// returns nil if the model has no secrets.
+func (e *exporter) readSecrets() (*secretsMigrationData, error) {
+	secrets, closer := e.st.db().GetCollection(secretsC)
+	defer closer()
+	var docs []secretDoc
+	if err := secrets.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all secret docs")
+	}
+	e.logger.Debugf("found %d secret docs", len(docs))
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	if len(e.st.secretsMasterKey) == 0 {
+		return nil, errors.NotSupportedf("exporting secrets without a secrets master key")
+	}
+
+	keys, closer := e.st.db().GetCollection(secretKeysC)
+	defer closer()
+	var keyDoc secretKeyDoc
+	if err := keys.FindId(secretKeyLocalID).One(&keyDoc); err != nil {
+		return nil, errors.Annotate(err, "cannot get secret key")
+	}
+	key, err := e.st.unwrapSecretKey(keyDoc.WrappedKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	revisionsColl, closer := e.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+	var revisionDocs []secretRevisionDoc
+	if err := revisionsColl.Find(nil).Sort("secret-id", "revision").All(&revisionDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all secret revision docs")
+	}
+	revisions := make(map[string][]secretRevisionMigrationData)
+	for _, doc := range revisionDocs {
+		revisions[doc.SecretID] = append(revisions[doc.SecretID], secretRevisionMigrationData{
+			Revision:   doc.Revision,
+			CreateTime: doc.CreateTime,
+			Data:       doc.Data,
+		})
+	}
+
+	result := &secretsMigrationData{Key: key}
+	for _, doc := range docs {
+		id := e.st.localID(doc.DocID)
+		secret := secretMigrationData{
+			ID:             id,
+			Owner:          doc.Owner,
+			Description:    doc.Description,
+			Revision:       doc.Revision,
+			RotateInterval: doc.RotateInterval,
+			NextRotateTime: doc.NextRotateTime,
+			CreateTime:     doc.CreateTime,
+			UpdateTime:     doc.UpdateTime,
+			Revisions:      revisions[id],
+		}
+		for _, grant := range doc.Grants {
+			secret.Grants = append(secret.Grants, secretGrantMigrationData{
+				Application: grant.Application,
+				Relation:    grant.Relation,
+			})
+		}
+		result.Secrets = append(result.Secrets, secret)
+	}
+	return result, nil
+}
+
 func (e *exporter) cloudContainer(doc *cloudContainerDoc) *description.CloudContainerArgs {
 	result := &description.CloudContainerArgs{
 		ProviderId: doc.ProviderId,
@@ -1616,9 +1732,24 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		Tags:         optionalStringSlice("tags"),
 		VirtType:     optionalString("virttype"),
 	}
+	// The description can't hold these constraints yet, so they are
+	// dropped rather than refusing to migrate the model.
+	var dropped []string
+	if zones := optionalStringSlice("zones"); len(zones) > 0 {
+		dropped = append(dropped, "zones="+strings.Join(zones, ","))
+	}
+	if lifecycle := optionalString("instancelifecycle"); lifecycle != "" {
+		dropped = append(dropped, "instance-lifecycle="+lifecycle)
+	}
+	if maxPrice := optionalString("maxprice"); maxPrice != "" {
+		dropped = append(dropped, "max-price="+maxPrice)
+	}
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
 	}
+	if len(dropped) > 0 {
+		e.logger.Warningf("dropping constraints %q for %q, which can't be migrated", strings.Join(dropped, " "), globalKey)
+	}
 	return result, nil
 }
 
@@ -5,8 +5,10 @@ package state_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/juju/description"
@@ -199,6 +201,87 @@ func (s *MigrationExportSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestSecrets(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: app.Name(),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Rotate(map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	encoded, ok := model.Annotations()["juju-secrets"]
+	c.Assert(ok, jc.IsTrue)
+	var exported struct {
+		Key     []byte `json:"key"`
+		Secrets []struct {
+			ID        string `json:"id"`
+			Owner     string `json:"owner"`
+			Revision  int    `json:"revision"`
+			Revisions []struct {
+				Revision int    `json:"revision"`
+				Data     []byte `json:"data"`
+			} `json:"revisions"`
+		} `json:"secrets"`
+	}
+	err = json.Unmarshal([]byte(encoded), &exported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exported.Key, gc.HasLen, 32)
+	c.Assert(exported.Secrets, gc.HasLen, 1)
+	c.Assert(exported.Secrets[0].ID, gc.Equals, secret.Id())
+	c.Assert(exported.Secrets[0].Owner, gc.Equals, app.Name())
+	c.Assert(exported.Secrets[0].Revision, gc.Equals, 2)
+	revisions := exported.Secrets[0].Revisions
+	c.Assert(revisions, gc.HasLen, 2)
+	c.Assert(revisions[0].Revision, gc.Equals, 1)
+	c.Assert(revisions[1].Revision, gc.Equals, 2)
+	// The values are exported encrypted, never in the clear.
+	for _, revision := range revisions {
+		c.Assert(strings.Contains(string(revision.Data), "s3cret"), jc.IsFalse)
+		c.Assert(strings.Contains(string(revision.Data), "n3w"), jc.IsFalse)
+	}
+}
+
+func (s *MigrationExportSuite) TestExportPartialOmitsSecrets(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateSecret(state.CreateSecretParams{
+		Owner: app.Name(),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.ExportPartial(state.ExportConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := model.Annotations()["juju-secrets"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *MigrationExportSuite) TestDropsUnmigratableConstraints(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("mem=8G zones=az1,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("cores=2 instance-lifecycle=spot max-price=0.5"),
+	})
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.Constraints().Memory(), gc.Equals, uint64(8*1024))
+	machines := model.Machines()
+	c.Assert(machines, gc.HasLen, 1)
+	c.Assert(machines[0].Constraints().CpuCores(), gc.Equals, uint64(2))
+	c.Assert(c.GetTestLog(), jc.Contains, `dropping constraints "zones=az1,az2" for "e", which can't be migrated`)
+	c.Assert(c.GetTestLog(), jc.Contains, `dropping constraints "instance-lifecycle=spot max-price=0.5" for "m#0", which can't be migrated`)
+}
+
 func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	// Make sure we have some last connection times for the admin user,
 	// and create a few other users.
@@ -619,6 +702,30 @@ func (s *MigrationExportSuite) TestApplicationLeadership(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestUnitMigrationAnnotations(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetCharmState(map[string]string{"db.host": "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(unit, testAnnotations)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	units := model.Applications()[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	expected := map[string]string{
+		"juju-charm-state": `{"db.host":"10.0.0.1"}`,
+		"juju-paused":      "true",
+	}
+	for key, value := range testAnnotations {
+		expected[key] = value
+	}
+	c.Assert(units[0].Annotations(), jc.DeepEquals, expected)
+}
+
 func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, nil)
 	err := unit.OpenPorts("tcp", 1234, 2345)
@@ -184,6 +184,9 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
+	if err := restore.importSecrets(); err != nil {
+		return nil, nil, errors.Annotate(err, "secrets")
+	}
 	if err := restore.spaces(); err != nil {
 		return nil, nil, errors.Annotate(err, "spaces")
 	}
@@ -236,6 +239,9 @@ type importer struct {
 	// applicationUnits is populated at the end of loading the applications, and is a
 	// map of application name to the units of that application.
 	applicationUnits map[string]map[string]*Unit
+	// secrets is populated from the model annotations, and holds
+	// the model's secrets, if any.
+	secrets *secretsMigrationData
 }
 
 func (i *importer) modelExtras() error {
@@ -245,7 +251,12 @@ func (i *importer) modelExtras() error {
 		}
 	}
 
-	if annotations := i.model.Annotations(); len(annotations) > 0 {
+	annotations, secrets, err := splitModelMigrationAnnotations(i.model.Annotations())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	i.secrets = secrets
+	if len(annotations) > 0 {
 		if err := i.dbModel.SetAnnotations(i.dbModel, annotations); err != nil {
 			return errors.Trace(err)
 		}
@@ -906,11 +917,17 @@ func (i *importer) storageConstraints(cons map[string]description.StorageConstra
 func (i *importer) unit(s description.Application, u description.Unit) error {
 	i.logger.Debugf("importing unit %s", u.Name())
 
+	annotations, migrated, err := splitUnitMigrationAnnotations(u.Annotations())
+	if err != nil {
+		return errors.Annotatef(err, "importing unit %q", u.Name())
+	}
+
 	// 1. construct a unitDoc
 	udoc, err := i.makeUnitDoc(s, u)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	udoc.Paused = migrated.paused
 
 	// 2. construct a statusDoc for the workload status and agent status
 	agentStatus := u.AgentStatus()
@@ -991,11 +1008,16 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 		return errors.Trace(err)
 	}
 	unit := newUnit(i.st, model.Type(), udoc)
-	if annotations := u.Annotations(); len(annotations) > 0 {
+	if len(annotations) > 0 {
 		if err := i.dbModel.SetAnnotations(unit, annotations); err != nil {
 			return errors.Trace(err)
 		}
 	}
+	if len(migrated.charmState) > 0 {
+		if err := unit.SetCharmState(migrated.charmState); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if err := i.importStatusHistory(unit.globalKey(), u.WorkloadStatusHistory()); err != nil {
 		return errors.Trace(err)
 	}
@@ -1224,6 +1246,65 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 	return doc
 }
 
+// importSecrets adds the model's secrets. Their values stay encrypted
+// with the model's secret key, which is wrapped with this controller's
+// secrets master key.
+func (i *importer) importSecrets() error {
+	if i.secrets == nil {
+		return nil
+	}
+	if len(i.st.secretsMasterKey) == 0 {
+		return errors.NotSupportedf("importing secrets into a controller without a secrets master key")
+	}
+	wrapped, err := sealSecret(i.st.secretsMasterKey, i.secrets.Key, []byte(i.st.ModelUUID()))
+	if err != nil {
+		return errors.Annotate(err, "cannot wrap secret key")
+	}
+	ops := []txn.Op{{
+		C:      secretKeysC,
+		Id:     i.st.docID(secretKeyLocalID),
+		Assert: txn.DocMissing,
+		Insert: &secretKeyDoc{
+			DocID:      i.st.docID(secretKeyLocalID),
+			ModelUUID:  i.st.ModelUUID(),
+			WrappedKey: wrapped,
+		},
+	}}
+	for _, secret := range i.secrets.Secrets {
+		doc := &secretDoc{
+			DocID:          i.st.docID(secret.ID),
+			ModelUUID:      i.st.ModelUUID(),
+			Owner:          secret.Owner,
+			Description:    secret.Description,
+			Revision:       secret.Revision,
+			RotateInterval: secret.RotateInterval,
+			NextRotateTime: secret.NextRotateTime,
+			CreateTime:     secret.CreateTime,
+			UpdateTime:     secret.UpdateTime,
+		}
+		for _, grant := range secret.Grants {
+			doc.Grants = append(doc.Grants, secretGrantDoc{
+				Application: grant.Application,
+				Relation:    grant.Relation,
+			})
+		}
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+		for _, revision := range secret.Revisions {
+			ops = append(ops, newSecretRevisionOp(i.st, secret.ID, revision.Revision, revision.CreateTime, revision.Data))
+		}
+	}
+	i.logger.Debugf("importing %d secrets", len(i.secrets.Secrets))
+	if err := i.st.db().RunTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (i *importer) spaces() error {
 	i.logger.Debugf("importing spaces")
 	for _, s := range i.model.Spaces() {
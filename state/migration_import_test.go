@@ -699,6 +699,48 @@ func (s *MigrationImportSuite) TestApplicationsSubordinatesAfter(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestSecrets(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	exported, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner:          app.Name(),
+		Description:    "db password",
+		RotateInterval: time.Hour,
+		Data:           map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = exported.Rotate(map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt := s.importModel(c, s.State)
+
+	secrets, err := newSt.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 1)
+	imported := secrets[0]
+	c.Assert(imported.Id(), gc.Equals, exported.Id())
+	c.Assert(imported.Owner(), gc.Equals, app.Name())
+	c.Assert(imported.Description(), gc.Equals, "db password")
+	c.Assert(imported.Revision(), gc.Equals, 2)
+	c.Assert(imported.RotateInterval(), gc.Equals, time.Hour)
+	revisions, err := imported.Revisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 2)
+	value, err := imported.Value(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+	value, err = imported.Value(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "n3w"})
+
+	// The annotation carrying the secrets isn't set on the model.
+	annotations, err := newModel.Annotations(newModel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := annotations["juju-secrets"]
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	s.assertUnitsMigrated(c, s.State, constraints.MustParse("arch=amd64 mem=8G"))
 }
@@ -724,6 +766,10 @@ func (s *MigrationImportSuite) assertUnitsMigrated(c *gc.C, st *state.State, con
 	c.Assert(err, jc.ErrorIsNil)
 	err = exported.SetWorkloadVersion("amethyst")
 	c.Assert(err, jc.ErrorIsNil)
+	err = exported.SetCharmState(map[string]string{"db.host": "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = exported.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
 	model, err := st.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	err = model.SetAnnotations(exported, testAnnotations)
@@ -760,6 +806,10 @@ func (s *MigrationImportSuite) assertUnitsMigrated(c *gc.C, st *state.State, con
 	version, err := imported.WorkloadVersion()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(version, gc.Equals, "amethyst")
+	charmState, err := imported.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"db.host": "10.0.0.1"})
+	c.Assert(imported.Paused(), jc.IsTrue)
 
 	if newModel.Type() == state.ModelTypeIAAS {
 		exportedMachineId, err := exported.AssignedMachineId()
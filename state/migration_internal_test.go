@@ -41,7 +41,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		applicationsC,
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
+		unitCharmStatesC,
 		payloadsC,
+		secretsC,
+		secretRevisionsC,
+		secretKeysC,
 		"resources",
 
 		// relation
@@ -111,6 +115,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		usermodelnameC,
 		// Metrics aren't migrated.
 		metricsC,
+		// Hook history is diagnostic only, and isn't migrated.
+		unitHookHistoryC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
 		// reference counts are implementation details that should be
@@ -307,6 +313,8 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// ReplacedBy is only set on machines which are being removed.
+		"ReplacedBy",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -372,6 +380,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// LoadBalancerAddrs are recorded again by the firewaller
+		// of the target model when it ensures the load balancer.
+		"LoadBalancerAddrs",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -408,6 +419,7 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Name",
 		"Principal",
 		"Subordinates",
+		"Paused",
 		"StorageAttachmentCount",
 		"MachineId",
 		"Tools",
@@ -552,6 +564,11 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		// The model description can't hold Zones, InstanceLifecycle
+		// or MaxPrice yet, so they are dropped with a warning.
+		"Zones",
+		"InstanceLifecycle",
+		"MaxPrice",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -861,6 +878,56 @@ func (s *MigrationSuite) TestEndpointBindingFields(c *gc.C) {
 	s.AssertExportedFields(c, endpointBindingsDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestSecretDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Owner",
+		"Description",
+		"Revision",
+		"RotateInterval",
+		"NextRotateTime",
+		"Grants",
+		"CreateTime",
+		"UpdateTime",
+	)
+	s.AssertExportedFields(c, secretDoc{}, fields)
+	s.AssertExportedFields(c, secretGrantDoc{}, set.NewStrings("Application", "Relation"))
+}
+
+func (s *MigrationSuite) TestSecretRevisionDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// SecretID is defined through containment.
+		"SecretID",
+		"Revision",
+		"CreateTime",
+		"Data",
+	)
+	s.AssertExportedFields(c, secretRevisionDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestSecretKeyDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// WrappedKey is exported unwrapped, and wrapped again
+		// with the target controller's secrets master key.
+		"WrappedKey",
+	)
+	s.AssertExportedFields(c, secretKeyDoc{}, fields)
+}
+
 func (s *MigrationSuite) AssertExportedFields(c *gc.C, doc interface{}, fields set.Strings) {
 	expected := testing.GetExportedFields(doc)
 	unknown := expected.Difference(fields)
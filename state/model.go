@@ -386,6 +386,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 	newSt.controllerModelTag = st.controllerModelTag
+	newSt.secretsMasterKey = st.secretsMasterKey
 
 	modelOps, modelStatusDoc, err := newSt.modelSetupOps(st.controllerTag.Id(), args, nil)
 	if err != nil {
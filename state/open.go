@@ -47,6 +47,12 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// SecretsMasterKey, if non-nil, wraps the keys used to encrypt
+	// each model's secret values. It is held by the controller agents
+	// and is never stored in the database; without it, secrets can't
+	// be created or read.
+	SecretsMasterKey []byte
 }
 
 // Validate validates the OpenParams.
@@ -90,6 +96,7 @@ func OpenController(args OpenParams) (*Controller, error) {
 		session:                session,
 		newPolicy:              args.NewPolicy,
 		runTransactionObserver: args.RunTransactionObserver,
+		secretsMasterKey:       args.SecretsMasterKey,
 	}, nil
 }
 
@@ -116,6 +123,7 @@ func Open(args OpenParams) (*State, error) {
 		session.Close()
 		return nil, errors.Trace(err)
 	}
+	st.secretsMasterKey = args.SecretsMasterKey
 	if _, err := st.Model(); err != nil {
 		if err := st.Close(); err != nil {
 			logger.Errorf("closing State for %s: %v", args.ControllerModelTag, err)
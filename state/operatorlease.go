@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// operatorSecretary implements lease.Secretary; it checks that leases are
+// application names, and holders are non-empty. Holders identify the
+// individual operator instances (e.g. pods) running for an application,
+// which have no tags of their own.
+type operatorSecretary struct{}
+
+// CheckLease is part of the lease.Secretary interface.
+func (operatorSecretary) CheckLease(name string) error {
+	if !names.IsValidApplication(name) {
+		return errors.NewNotValid(nil, "not an application name")
+	}
+	return nil
+}
+
+// CheckHolder is part of the lease.Secretary interface.
+func (operatorSecretary) CheckHolder(name string) error {
+	if name == "" {
+		return errors.NewNotValid(nil, "empty holder")
+	}
+	return nil
+}
+
+// CheckDuration is part of the lease.Secretary interface.
+func (operatorSecretary) CheckDuration(duration time.Duration) error {
+	if duration <= 0 {
+		return errors.NewNotValid(nil, "non-positive")
+	}
+	return nil
+}
+
+// OperatorLeaseClaimer returns a lease.Claimer representing the exclusive
+// right of one of an application's operator instances to run its charm.
+func (st *State) OperatorLeaseClaimer() lease.Claimer {
+	return lazyLeaseClaimer{func() (lease.Claimer, error) {
+		manager := st.workers.operatorLeaseManager()
+		return manager.Claimer(applicationOperatorNamespace, st.modelUUID())
+	}}
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type OperatorLeaseSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&OperatorLeaseSuite{})
+
+func (s *OperatorLeaseSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	err := s.State.SetClockForTesting(s.Clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *OperatorLeaseSuite) TestClaimBadLease(c *gc.C) {
+	claimer := s.State.OperatorLeaseClaimer()
+	err := claimer.Claim("not/valid", "juju-operator-gitlab-0", time.Minute)
+	c.Check(err, gc.ErrorMatches, `cannot claim lease "not/valid": not an application name`)
+}
+
+func (s *OperatorLeaseSuite) TestClaimBadHolder(c *gc.C) {
+	claimer := s.State.OperatorLeaseClaimer()
+	err := claimer.Claim("gitlab", "", time.Minute)
+	c.Check(err, gc.ErrorMatches, `cannot claim lease for holder "": empty holder`)
+}
+
+func (s *OperatorLeaseSuite) TestClaim(c *gc.C) {
+	claimer := s.State.OperatorLeaseClaimer()
+	err := claimer.Claim("gitlab", "juju-operator-gitlab-0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = claimer.Claim("gitlab", "juju-operator-gitlab-0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = claimer.Claim("gitlab", "juju-operator-gitlab-1", time.Minute)
+	c.Assert(err, gc.Equals, lease.ErrClaimDenied)
+
+	// Leases for different applications are independent.
+	err = claimer.Claim("mariadb", "juju-operator-mariadb-1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *OperatorLeaseSuite) TestExpire(c *gc.C) {
+	claimer := s.State.OperatorLeaseClaimer()
+	err := claimer.Claim("gitlab", "juju-operator-gitlab-0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	wait := make(chan error)
+	go func() {
+		wait <- claimer.WaitUntilExpired("gitlab", nil)
+	}()
+
+	g, err := s.State.GlobalClockUpdater()
+	c.Assert(err, jc.ErrorIsNil)
+	err = g.Advance(time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Hour)
+	select {
+	case err := <-wait:
+		c.Check(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("never expired")
+	}
+
+	err = claimer.Claim("gitlab", "juju-operator-gitlab-1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
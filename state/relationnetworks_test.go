@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type relationNetworksSuite struct {
@@ -145,3 +146,37 @@ func (s *relationEgressNetworksSuite) TestCrossContanination(c *gc.C) {
 	_, err = ingress.Networks(s.relation.Tag().Id())
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *relationIngressNetworksSuite) TestWatchApplicationRelationIngressNetworks(c *gc.C) {
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	w := mysql.WatchRelationIngressNetworks()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Save ingress networks for the relation; check change.
+	_, err = s.relationNetworks.Save("wordpress:db mysql:server", false, []string{"192.168.1.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Save egress networks for the relation; check no change.
+	egress := state.NewRelationEgressNetworks(s.State)
+	_, err = egress.Save("wordpress:db mysql:server", false, []string{"10.0.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Save ingress networks for another application's relation; check no change.
+	logging := s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	loggingEP, err := logging.Endpoint("info")
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	wordpressEP, err := wordpress.Endpoint("juju-info")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(loggingEP, wordpressEP)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.relationNetworks.Save(rel.Tag().Id(), false, []string{"192.168.1.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
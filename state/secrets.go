@@ -0,0 +1,637 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// secretKeyLocalID is the id of the document holding the wrapped key
+// used to encrypt a model's secret values.
+const secretKeyLocalID = "secret-key"
+
+// secretDoc records the metadata of a secret created by a charm.
+// The secret's values are held in secretRevisionDocs.
+type secretDoc struct {
+	DocID          string           `bson:"_id"`
+	ModelUUID      string           `bson:"model-uuid"`
+	Owner          string           `bson:"owner"`
+	Description    string           `bson:"description"`
+	Revision       int              `bson:"revision"`
+	RotateInterval time.Duration    `bson:"rotate-interval"`
+	NextRotateTime time.Time        `bson:"next-rotate-time"`
+	Grants         []secretGrantDoc `bson:"grants"`
+	CreateTime     time.Time        `bson:"create-time"`
+	UpdateTime     time.Time        `bson:"update-time"`
+}
+
+// secretGrantDoc records an application which may read a secret, and
+// the relation over which it was granted. Grants are removed with the
+// relation.
+type secretGrantDoc struct {
+	Application string `bson:"application"`
+	Relation    string `bson:"relation"`
+}
+
+// secretRevisionDoc records the encrypted values of one revision
+// of a secret.
+type secretRevisionDoc struct {
+	DocID      string    `bson:"_id"`
+	ModelUUID  string    `bson:"model-uuid"`
+	SecretID   string    `bson:"secret-id"`
+	Revision   int       `bson:"revision"`
+	CreateTime time.Time `bson:"create-time"`
+	Data       []byte    `bson:"data"`
+}
+
+// secretKeyDoc holds the key used to encrypt a model's secret values,
+// itself encrypted with the controller's secrets master key. The
+// master key is held by the controller agents, never in the database.
+type secretKeyDoc struct {
+	DocID      string `bson:"_id"`
+	ModelUUID  string `bson:"model-uuid"`
+	WrappedKey []byte `bson:"wrapped-key"`
+}
+
+// Secret represents a secret owned by an application's charm.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+// SecretRevision describes a single revision of a secret.
+type SecretRevision struct {
+	Revision   int
+	CreateTime time.Time
+}
+
+// CreateSecretParams holds the parameters for creating a secret.
+type CreateSecretParams struct {
+	// Owner is the name of the application that owns the secret.
+	Owner string
+
+	// Description is an optional description of the secret.
+	Description string
+
+	// RotateInterval, if non-zero, is how often the owner is
+	// asked to rotate the secret.
+	RotateInterval time.Duration
+
+	// Data holds the secret's values.
+	Data map[string]string
+}
+
+// Validate returns an error if the parameters are not valid.
+func (p CreateSecretParams) Validate() error {
+	if !names.IsValidApplication(p.Owner) {
+		return errors.NotValidf("owner %q", p.Owner)
+	}
+	if len(p.Data) == 0 {
+		return errors.NotValidf("empty secret value")
+	}
+	if p.RotateInterval < 0 {
+		return errors.NotValidf("negative rotate interval")
+	}
+	return nil
+}
+
+// Id returns the secret's unique identifier.
+func (s *Secret) Id() string {
+	return s.st.localID(s.doc.DocID)
+}
+
+// Owner returns the name of the application that owns the secret.
+func (s *Secret) Owner() string {
+	return s.doc.Owner
+}
+
+// Description returns the secret's description.
+func (s *Secret) Description() string {
+	return s.doc.Description
+}
+
+// Revision returns the secret's latest revision.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// RotateInterval returns how often the secret should be rotated,
+// or zero if it is not rotated automatically.
+func (s *Secret) RotateInterval() time.Duration {
+	return s.doc.RotateInterval
+}
+
+// NextRotateTime returns when the secret is next due to be rotated.
+// The result is meaningless if RotateInterval is zero.
+func (s *Secret) NextRotateTime() time.Time {
+	return s.doc.NextRotateTime
+}
+
+// Grants returns the names of the applications, other than the
+// owner, that may read the secret.
+func (s *Secret) Grants() []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, grant := range s.doc.Grants {
+		if !seen[grant.Application] {
+			seen[grant.Application] = true
+			result = append(result, grant.Application)
+		}
+	}
+	return result
+}
+
+// CreateTime returns when the secret was created.
+func (s *Secret) CreateTime() time.Time {
+	return s.doc.CreateTime
+}
+
+// UpdateTime returns when the secret was last rotated.
+func (s *Secret) UpdateTime() time.Time {
+	return s.doc.UpdateTime
+}
+
+// CanRead reports whether the named application may read the secret.
+func (s *Secret) CanRead(application string) bool {
+	if application == s.doc.Owner {
+		return true
+	}
+	for _, grant := range s.doc.Grants {
+		if grant.Application == application {
+			return true
+		}
+	}
+	return false
+}
+
+// Refresh refreshes the contents of the secret from the underlying
+// state. It returns an error that satisfies errors.IsNotFound if the
+// secret has been removed.
+func (s *Secret) Refresh() error {
+	coll, closer := s.st.db().GetCollection(secretsC)
+	defer closer()
+	err := coll.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("secret %q", s.Id())
+	}
+	return errors.Annotatef(err, "cannot refresh secret %q", s.Id())
+}
+
+// Value returns the decrypted values of the given revision of the
+// secret. A revision of zero returns the latest values.
+func (s *Secret) Value(revision int) (map[string]string, error) {
+	if revision == 0 {
+		revision = s.doc.Revision
+	}
+	coll, closer := s.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+	var doc secretRevisionDoc
+	err := coll.FindId(secretRevisionID(s.Id(), revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q revision %d", s.Id(), revision)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", s.Id())
+	}
+	key, err := s.st.secretKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := decryptSecretData(key, doc.Data)
+	return data, errors.Annotatef(err, "cannot decrypt secret %q", s.Id())
+}
+
+// Revisions returns the secret's revision history, oldest first.
+func (s *Secret) Revisions() ([]SecretRevision, error) {
+	coll, closer := s.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+	var docs []secretRevisionDoc
+	err := coll.Find(bson.D{{"secret-id", s.Id()}}).Sort("revision").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get revisions of secret %q", s.Id())
+	}
+	result := make([]SecretRevision, len(docs))
+	for i, doc := range docs {
+		result[i] = SecretRevision{
+			Revision:   doc.Revision,
+			CreateTime: doc.CreateTime,
+		}
+	}
+	return result, nil
+}
+
+// Rotate records a new revision of the secret with the given values,
+// and resets the time at which it is next due to be rotated.
+func (s *Secret) Rotate(data map[string]string) error {
+	if len(data) == 0 {
+		return errors.NotValidf("empty secret value")
+	}
+	key, err := s.st.secretKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	encrypted, err := encryptSecretData(key, data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		now := s.st.clock().Now()
+		revision := s.doc.Revision + 1
+		update := bson.D{
+			{"revision", revision},
+			{"update-time", now},
+		}
+		if s.doc.RotateInterval > 0 {
+			update = append(update, bson.DocElem{"next-rotate-time", now.Add(s.doc.RotateInterval)})
+		}
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     s.doc.DocID,
+			Assert: bson.D{{"revision", s.doc.Revision}},
+			Update: bson.D{{"$set", update}},
+		}, newSecretRevisionOp(s.st, s.Id(), revision, now, encrypted)}, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot rotate secret %q", s.Id())
+	}
+	return s.Refresh()
+}
+
+// Grant allows the application at the other end of the given relation
+// from the secret's owner to read the secret, until the relation is
+// removed or the grant revoked.
+func (s *Secret) Grant(rel *Relation) error {
+	related, err := rel.RelatedEndpoints(s.doc.Owner)
+	if err != nil {
+		return errors.Annotatef(err, "cannot grant secret %q", s.Id())
+	}
+	application := related[0].ApplicationName
+	if application == s.doc.Owner {
+		return errors.NotValidf("granting secret over a peer relation")
+	}
+	grant := secretGrantDoc{
+		Application: application,
+		Relation:    rel.doc.Key,
+	}
+	ops := []txn.Op{{
+		C:      relationsC,
+		Id:     rel.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      applicationsC,
+		Id:     s.st.docID(application),
+		Assert: isAliveDoc,
+	}, {
+		C:      secretsC,
+		Id:     s.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"grants", grant}}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		if err := s.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("cannot grant secret %q over relation %q: relation or application not found or not alive", s.Id(), rel)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot grant secret %q over relation %q", s.Id(), rel)
+	}
+	return s.Refresh()
+}
+
+// Revoke removes any grant of the secret made over the given relation.
+func (s *Secret) Revoke(rel *Relation) error {
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     s.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pull", bson.D{{"grants", bson.D{{"relation", rel.doc.Key}}}}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("secret %q", s.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot revoke secret %q over relation %q", s.Id(), rel)
+	}
+	return s.Refresh()
+}
+
+// CreateSecret creates a new secret owned by an application.
+func (st *State) CreateSecret(args CreateSecretParams) (*Secret, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cannot create secret")
+	}
+	uuid, err := NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := st.secretKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	encrypted, err := encryptSecretData(key, args.Data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := uuid.String()
+	now := st.clock().Now()
+	doc := secretDoc{
+		DocID:          st.docID(id),
+		ModelUUID:      st.ModelUUID(),
+		Owner:          args.Owner,
+		Description:    args.Description,
+		Revision:       1,
+		RotateInterval: args.RotateInterval,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+	if args.RotateInterval > 0 {
+		doc.NextRotateTime = now.Add(args.RotateInterval)
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     st.docID(args.Owner),
+		Assert: isAliveDoc,
+	}, {
+		C:      secretsC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, newSecretRevisionOp(st, id, 1, now, encrypted)}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Errorf("cannot create secret: application %q not found or not alive", args.Owner)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot create secret")
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// Secret returns the secret with the given id.
+func (st *State) Secret(id string) (*Secret, error) {
+	coll, closer := st.db().GetCollection(secretsC)
+	defer closer()
+	var doc secretDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// AllSecrets returns all the secrets in the model.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	return st.secrets(nil)
+}
+
+// ApplicationSecretsToRotate returns the secrets owned by the named
+// application that are due to be rotated.
+func (st *State) ApplicationSecretsToRotate(application string) ([]*Secret, error) {
+	return st.secrets(bson.D{
+		{"owner", application},
+		{"rotate-interval", bson.D{{"$gt", 0}}},
+		{"next-rotate-time", bson.D{{"$lte", st.clock().Now()}}},
+	})
+}
+
+func (st *State) secrets(query bson.D) ([]*Secret, error) {
+	coll, closer := st.db().GetCollection(secretsC)
+	defer closer()
+	var docs []secretDoc
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = &Secret{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// secretKey returns the key used to encrypt the model's secret
+// values, creating it if necessary. The key is stored wrapped with
+// the controller's secrets master key.
+func (st *State) secretKey() ([]byte, error) {
+	if len(st.secretsMasterKey) == 0 {
+		return nil, errors.NotSupportedf("secrets on a controller without a secrets master key")
+	}
+	coll, closer := st.db().GetCollection(secretKeysC)
+	defer closer()
+	var doc secretKeyDoc
+	err := coll.FindId(secretKeyLocalID).One(&doc)
+	if err == nil {
+		return st.unwrapSecretKey(doc.WrappedKey)
+	} else if err != mgo.ErrNotFound {
+		return nil, errors.Annotate(err, "cannot get secret key")
+	}
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Annotate(err, "cannot generate secret key")
+	}
+	wrapped, err := sealSecret(st.secretsMasterKey, key, []byte(st.ModelUUID()))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot wrap secret key")
+	}
+	ops := []txn.Op{{
+		C:      secretKeysC,
+		Id:     st.docID(secretKeyLocalID),
+		Assert: txn.DocMissing,
+		Insert: &secretKeyDoc{
+			DocID:      st.docID(secretKeyLocalID),
+			ModelUUID:  st.ModelUUID(),
+			WrappedKey: wrapped,
+		},
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// Another client created the key first; use theirs.
+		if err := coll.FindId(secretKeyLocalID).One(&doc); err != nil {
+			return nil, errors.Annotate(err, "cannot get secret key")
+		}
+		return st.unwrapSecretKey(doc.WrappedKey)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot create secret key")
+	}
+	return key, nil
+}
+
+// unwrapSecretKey decrypts a model's secret key with the controller's
+// secrets master key. The key is bound to the model by its UUID.
+func (st *State) unwrapSecretKey(wrapped []byte) ([]byte, error) {
+	key, err := openSecret(st.secretsMasterKey, wrapped, []byte(st.ModelUUID()))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot unwrap secret key")
+	}
+	return key, nil
+}
+
+// SecretsMasterKey returns the controller's secrets master key, so that
+// it can be handed to other controller agents.
+func (st *State) SecretsMasterKey() []byte {
+	return st.secretsMasterKey
+}
+
+func secretRevisionID(id string, revision int) string {
+	return fmt.Sprintf("%s/%d", id, revision)
+}
+
+func newSecretRevisionOp(st *State, id string, revision int, created time.Time, data []byte) txn.Op {
+	docID := st.docID(secretRevisionID(id, revision))
+	return txn.Op{
+		C:      secretRevisionsC,
+		Id:     docID,
+		Assert: txn.DocMissing,
+		Insert: &secretRevisionDoc{
+			DocID:      docID,
+			ModelUUID:  st.ModelUUID(),
+			SecretID:   id,
+			Revision:   revision,
+			CreateTime: created,
+			Data:       data,
+		},
+	}
+}
+
+// removeApplicationSecretsOps returns the operations needed to remove
+// the secrets owned by the named application, and any grants made to it.
+func removeApplicationSecretsOps(st *State, application string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(secretsC)
+	defer closer()
+	var docs []secretDoc
+	query := bson.D{{"$or", []bson.D{
+		{{"owner", application}},
+		{{"grants.application", application}},
+	}}}
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading application %q secrets", application)
+	}
+	revisions, revisionsCloser := st.db().GetCollection(secretRevisionsC)
+	defer revisionsCloser()
+
+	var ops []txn.Op
+	for _, doc := range docs {
+		if doc.Owner != application {
+			ops = append(ops, txn.Op{
+				C:      secretsC,
+				Id:     doc.DocID,
+				Update: bson.D{{"$pull", bson.D{{"grants", bson.D{{"application", application}}}}}},
+			})
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Remove: true,
+		})
+		var revisionDocs []secretRevisionDoc
+		err := revisions.Find(bson.D{{"secret-id", st.localID(doc.DocID)}}).All(&revisionDocs)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading secret %q revisions", st.localID(doc.DocID))
+		}
+		for _, revisionDoc := range revisionDocs {
+			ops = append(ops, txn.Op{
+				C:      secretRevisionsC,
+				Id:     revisionDoc.DocID,
+				Remove: true,
+			})
+		}
+	}
+	return ops, nil
+}
+
+// removeRelationSecretGrantsOps returns the operations needed to revoke
+// the secret grants made over the relation with the given key.
+func removeRelationSecretGrantsOps(st *State, relationKey string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(secretsC)
+	defer closer()
+	var docs []secretDoc
+	if err := coll.Find(bson.D{{"grants.relation", relationKey}}).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading secrets granted over relation %q", relationKey)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Update: bson.D{{"$pull", bson.D{{"grants", bson.D{{"relation", relationKey}}}}}},
+		}
+	}
+	return ops, nil
+}
+
+// encryptSecretData encodes and encrypts secret values.
+func encryptSecretData(key []byte, data map[string]string) ([]byte, error) {
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sealSecret(key, plaintext, nil)
+}
+
+// decryptSecretData reverses encryptSecretData.
+func decryptSecretData(key, encrypted []byte) (map[string]string, error) {
+	plaintext, err := openSecret(key, encrypted, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var data map[string]string
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+// sealSecret encrypts and authenticates plaintext, and authenticates
+// additionalData, with AES-GCM. The random nonce is prepended to the
+// result.
+func sealSecret(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// openSecret reverses sealSecret.
+func openSecret(key, sealed, additionalData []byte) ([]byte, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("encrypted data too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return plaintext, nil
+}
+
+func newSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,222 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	owner    *state.Application
+	relation *state.Relation
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	f := factory.NewFactory(s.State)
+	s.owner = f.MakeApplication(c, nil)
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) createSecret(c *gc.C, interval time.Duration) *state.Secret {
+	secret, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner:          s.owner.Name(),
+		Description:    "db password",
+		RotateInterval: interval,
+		Data:           map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *SecretsSuite) TestCreateSecret(c *gc.C) {
+	secret := s.createSecret(c, 0)
+	c.Assert(secret.Id(), gc.Not(gc.Equals), "")
+	c.Assert(secret.Owner(), gc.Equals, "wordpress")
+	c.Assert(secret.Description(), gc.Equals, "db password")
+	c.Assert(secret.Revision(), gc.Equals, 1)
+	c.Assert(secret.Grants(), gc.HasLen, 0)
+
+	secret, err := s.State.Secret(secret.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := secret.Value(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestCreateSecretInvalid(c *gc.C) {
+	_, err := s.State.CreateSecret(state.CreateSecretParams{Owner: "wordpress"})
+	c.Assert(err, gc.ErrorMatches, "cannot create secret: empty secret value not valid")
+
+	_, err = s.State.CreateSecret(state.CreateSecretParams{
+		Owner: "nope",
+		Data:  map[string]string{"a": "b"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot create secret: application "nope" not found or not alive`)
+}
+
+func (s *SecretsSuite) TestSecretNotFound(c *gc.C) {
+	_, err := s.State.Secret("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestValuesEncrypted(c *gc.C) {
+	secret := s.createSecret(c, 0)
+	var doc struct {
+		Data []byte `bson:"data"`
+	}
+	coll := s.Session.DB("juju").C("secretrevisions")
+	err := coll.FindId(s.State.ModelUUID() + ":" + secret.Id() + "/1").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Contains(string(doc.Data), "s3cret"), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestRotate(c *gc.C) {
+	secret := s.createSecret(c, time.Hour)
+	c.Assert(secret.NextRotateTime(), gc.Equals, s.Clock.Now().Add(time.Hour))
+
+	s.Clock.Advance(time.Minute)
+	err := secret.Rotate(map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 2)
+	c.Assert(secret.NextRotateTime(), gc.Equals, s.Clock.Now().Add(time.Hour))
+
+	value, err := secret.Value(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "n3w"})
+	value, err = secret.Value(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+	_, err = secret.Value(3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	revisions, err := secret.Revisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 2)
+	c.Assert(revisions[0].Revision, gc.Equals, 1)
+	c.Assert(revisions[1].Revision, gc.Equals, 2)
+}
+
+func (s *SecretsSuite) TestApplicationSecretsToRotate(c *gc.C) {
+	secret := s.createSecret(c, time.Hour)
+	s.createSecret(c, 0)
+
+	due, err := s.State.ApplicationSecretsToRotate("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+
+	s.Clock.Advance(time.Hour)
+	due, err = s.State.ApplicationSecretsToRotate("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 1)
+	c.Assert(due[0].Id(), gc.Equals, secret.Id())
+
+	err = secret.Rotate(map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	due, err = s.State.ApplicationSecretsToRotate("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestGrantRevoke(c *gc.C) {
+	secret := s.createSecret(c, 0)
+	c.Assert(secret.CanRead("wordpress"), jc.IsTrue)
+	c.Assert(secret.CanRead("mysql"), jc.IsFalse)
+
+	err := secret.Grant(s.relation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), jc.DeepEquals, []string{"mysql"})
+	c.Assert(secret.CanRead("mysql"), jc.IsTrue)
+
+	err = secret.Revoke(s.relation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), gc.HasLen, 0)
+	c.Assert(secret.CanRead("mysql"), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestGrantRemovedRelation(c *gc.C) {
+	secret := s.createSecret(c, 0)
+	err := s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = secret.Grant(s.relation)
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret ".*" over relation "wordpress:db mysql:server": relation or application not found or not alive`)
+}
+
+func (s *SecretsSuite) TestRemoveRelationRevokesGrants(c *gc.C) {
+	secret := s.createSecret(c, 0)
+	err := secret.Grant(s.relation)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), gc.HasLen, 0)
+	c.Assert(secret.CanRead("mysql"), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	secret := s.createSecret(c, 0)
+	err := s.owner.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(secret.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = secret.Value(1)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesGrants(c *gc.C) {
+	secret := s.createSecret(c, 0)
+	err := secret.Grant(s.relation)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestSecretKeyWrapped(c *gc.C) {
+	s.createSecret(c, 0)
+	var doc map[string]interface{}
+	coll := s.Session.DB("juju").C("secretkeys")
+	err := coll.FindId(s.State.ModelUUID() + ":secret-key").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc["key"], gc.IsNil)
+	c.Assert(doc["wrapped-key"], gc.NotNil)
+}
+
+func (s *SecretsSuite) TestSecretsWithoutMasterKey(c *gc.C) {
+	state.SetSecretsMasterKey(s.State, nil)
+	_, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: s.owner.Name(),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
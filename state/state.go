@@ -70,6 +70,10 @@ const (
 	// singularControllerNamespace is the name of the lease.Store namespace
 	// used by the singular manager
 	singularControllerNamespace = "singular-controller"
+
+	// applicationOperatorNamespace is the name of the lease.Store namespace
+	// used by the operator lease manager.
+	applicationOperatorNamespace = "application-operator"
 )
 
 type providerIdDoc struct {
@@ -89,6 +93,10 @@ type State struct {
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
 
+	// secretsMasterKey wraps the keys used to encrypt the
+	// model's secret values; see OpenParams.
+	secretsMasterKey []byte
+
 	// cloudName is the name of the cloud on which the model
 	// represented by this state runs.
 	cloudName string
@@ -439,6 +447,10 @@ func (st *State) getSingularLeaseStore() (lease.Store, error) {
 	return st.getLeaseStore(singularControllerNamespace)
 }
 
+func (st *State) getOperatorLeaseStore() (lease.Store, error) {
+	return st.getLeaseStore(applicationOperatorNamespace)
+}
+
 func (st *State) getLeaseStore(namespace string) (lease.Store, error) {
 	globalClock, err := st.globalClockReader()
 	if err != nil {
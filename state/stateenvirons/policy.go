@@ -59,7 +59,26 @@ func (p environStatePolicy) ConfigValidator() (config.Validator, error) {
 		// config validation.
 		return nil, errors.NotImplementedf("ConfigValidator")
 	}
-	return environProvider(p.st)
+	provider, err := environProvider(p.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return configValidator{provider}, nil
+}
+
+// configValidator implements config.Validator by checking that
+// the provider can honour the model-wide settings which are
+// implemented by providers, before delegating to the provider.
+type configValidator struct {
+	environs.EnvironProvider
+}
+
+// Validate implements config.Validator.
+func (v configValidator) Validate(cfg, old *config.Config) (*config.Config, error) {
+	if err := environs.ValidateRootDiskEncryption(v.EnvironProvider, cfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return v.EnvironProvider.Validate(cfg, old)
 }
 
 // ProviderConfigSchemaSource implements state.Policy.
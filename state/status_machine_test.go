@@ -63,6 +63,16 @@ func (s *MachineStatusSuite) TestSetDownStatus(c *gc.C) {
 	s.checkInitialStatus(c)
 }
 
+func (s *MachineStatusSuite) TestSetDown(c *gc.C) {
+	err := s.machine.SetDown("instance terminated by the provider")
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Down)
+	c.Check(statusInfo.Message, gc.Equals, "instance terminated by the provider")
+}
+
 func (s *MachineStatusSuite) TestSetUnknownStatus(c *gc.C) {
 	now := testing.ZeroTime()
 	sInfo := status.StatusInfo{
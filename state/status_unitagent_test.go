@@ -84,7 +84,7 @@ func (s *StatusUnitAgentSuite) TestSetStatusUnassigned(c *gc.C) {
 	u, err := app.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
 	agent := u.Agent()
-	for _, value := range []status.Status{status.Idle, status.Executing, status.Rebooting, status.Failed} {
+	for _, value := range []status.Status{status.Idle, status.Executing, status.Rebooting, status.Failed, status.Paused} {
 		now := testing.ZeroTime()
 		sInfo := status.StatusInfo{
 			Status:  value,
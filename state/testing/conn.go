@@ -109,9 +109,10 @@ func InitializeWithArgs(c *gc.C, args InitializeArgs) (*state.Controller, *state
 			},
 			RegionConfig: args.RegionConfig,
 		},
-		MongoSession:  session,
-		NewPolicy:     args.NewPolicy,
-		AdminPassword: "admin-secret",
+		MongoSession:     session,
+		NewPolicy:        args.NewPolicy,
+		AdminPassword:    "admin-secret",
+		SecretsMasterKey: testing.SecretsMasterKey,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	return ctlr, st
@@ -91,6 +91,7 @@ type unitDoc struct {
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
 	MachineId              string
 	Resolved               ResolvedMode
+	Paused                 bool         `bson:"paused,omitempty"`
 	Tools                  *tools.Tools `bson:",omitempty"`
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
@@ -486,6 +487,9 @@ func (u *Unit) eraseHistory() error {
 	if err := eraseStatusHistory(u.st, u.globalWorkloadVersionKey()); err != nil {
 		return errors.Annotate(err, "version")
 	}
+	if err := u.eraseHookHistory(); err != nil {
+		return errors.Annotate(err, "hooks")
+	}
 	return nil
 }
 
@@ -813,6 +817,12 @@ func (u *Unit) Resolved() ResolvedMode {
 	return u.doc.Resolved
 }
 
+// Paused returns whether the unit's agent has been asked to stop
+// running hooks.
+func (u *Unit) Paused() bool {
+	return u.doc.Paused
+}
+
 // IsPrincipal returns whether the unit is deployed in its own container,
 // and can therefore have subordinate applications deployed alongside it.
 func (u *Unit) IsPrincipal() bool {
@@ -2756,6 +2766,24 @@ func (u *Unit) ClearResolved() error {
 	return nil
 }
 
+// SetPaused records whether the unit's agent should stop running hooks.
+// While a unit is paused, its agent queues events, and runs hooks for
+// them once the unit is resumed.
+func (u *Unit) SetPaused(paused bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set paused for unit %q", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"paused", paused}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err != nil {
+		return onAbort(err, ErrDead)
+	}
+	u.doc.Paused = paused
+	return nil
+}
+
 // StorageConstraints returns the unit's storage constraints.
 func (u *Unit) StorageConstraints() (map[string]StorageConstraints, error) {
 	if u.doc.CharmURL == nil {
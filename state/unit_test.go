@@ -1145,6 +1145,30 @@ func (s *UnitSuite) TestGetSetClearResolved(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot set resolved mode for unit "wordpress/0": invalid error resolution mode: "foo"`)
 }
 
+func (s *UnitSuite) TestSetPaused(c *gc.C) {
+	c.Assert(s.unit.Paused(), jc.IsFalse)
+
+	err := s.unit.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Paused(), jc.IsTrue)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Paused(), jc.IsTrue)
+
+	err = s.unit.SetPaused(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Paused(), jc.IsFalse)
+}
+
+func (s *UnitSuite) TestSetPausedWhenDead(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetPaused(true)
+	c.Assert(err, gc.ErrorMatches, deadErr)
+}
+
 func (s *UnitSuite) TestOpenedPortsOnInvalidSubnet(c *gc.C) {
 	s.testOpenedPorts(c, "bad CIDR", `invalid subnet ID "bad CIDR"`)
 }
@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/mongo/utils"
+)
+
+// unitCharmStateDoc records the key-value state persisted by a unit's
+// charm, using the state-set and state-delete hook tools.
+type unitCharmStateDoc struct {
+	DocID     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	State     map[string]string `bson:"state"`
+}
+
+// CharmState returns the key-value state persisted by the unit's charm.
+func (u *Unit) CharmState() (map[string]string, error) {
+	coll, closer := u.st.db().GetCollection(unitCharmStatesC)
+	defer closer()
+
+	var doc unitCharmStateDoc
+	err := coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm state for unit %q", u.Name())
+	}
+	result := make(map[string]string, len(doc.State))
+	for key, value := range doc.State {
+		result[utils.UnescapeKey(key)] = value
+	}
+	return result, nil
+}
+
+// SetCharmState replaces the key-value state persisted by the unit's
+// charm. Setting an empty state removes any state previously set.
+func (u *Unit) SetCharmState(state map[string]string) error {
+	escaped := make(map[string]string, len(state))
+	for key, value := range state {
+		escaped[utils.EscapeKey(key)] = value
+	}
+	docID := u.st.docID(u.globalKey())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, errors.Errorf("unit is dead")
+		}
+		coll, closer := u.st.db().GetCollection(unitCharmStatesC)
+		defer closer()
+		count, err := coll.FindId(docID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		switch {
+		case count == 0 && len(escaped) == 0:
+			return nil, jujutxn.ErrNoOperations
+		case count == 0:
+			ops = append(ops, txn.Op{
+				C:      unitCharmStatesC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &unitCharmStateDoc{
+					DocID:     docID,
+					ModelUUID: u.st.ModelUUID(),
+					State:     escaped,
+				},
+			})
+		case len(escaped) == 0:
+			ops = append(ops, removeUnitCharmStateOp(u.st, u.globalKey()))
+		default:
+			ops = append(ops, txn.Op{
+				C:      unitCharmStatesC,
+				Id:     docID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"state", escaped}}}},
+			})
+		}
+		return ops, nil
+	}
+	return errors.Annotatef(u.st.db().Run(buildTxn), "cannot set charm state for unit %q", u.Name())
+}
+
+// removeUnitCharmStateOp returns the operation needed to remove the
+// charm state document associated with the given unit global key.
+func removeUnitCharmStateOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitCharmStatesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
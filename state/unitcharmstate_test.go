@@ -0,0 +1,90 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitCharmStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitCharmStateSuite{})
+
+func (s *UnitCharmStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *UnitCharmStateSuite) TestCharmStateInitiallyEmpty(c *gc.C) {
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *UnitCharmStateSuite) TestSetCharmState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{
+		"initialised": "true",
+		"db.host":     "10.0.0.1",
+		"$weird":      "value",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{
+		"initialised": "true",
+		"db.host":     "10.0.0.1",
+		"$weird":      "value",
+	})
+
+	// Setting again replaces the whole state.
+	err = s.unit.SetCharmState(map[string]string{"initialised": "false"})
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"initialised": "false"})
+}
+
+func (s *UnitCharmStateSuite) TestSetEmptyCharmStateRemovesState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"a": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmState(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+
+	// Clearing state that was never set is a no-op.
+	err = s.unit.SetCharmState(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitCharmStateSuite) TestSetCharmStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmState(map[string]string{"a": "b"})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm state for unit ".*": unit is dead`)
+}
+
+func (s *UnitCharmStateSuite) TestRemoveUnitRemovesCharmState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"a": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll := s.Session.DB("juju").C("unitcharmstates")
+	count, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
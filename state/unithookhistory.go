@@ -0,0 +1,192 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/status"
+)
+
+// maxHookExecutions is the number of hook executions recorded for
+// each unit; older executions are discarded as new ones are recorded.
+const maxHookExecutions = 100
+
+// HookExecution describes a single execution of a charm hook by a unit.
+type HookExecution struct {
+	// Hook is the name of the hook that was run.
+	Hook string
+
+	// Relation identifies the relation the hook was run for, if any.
+	Relation string
+
+	// Started is when the hook started running.
+	Started time.Time
+
+	// Duration is how long the hook took to run.
+	Duration time.Duration
+
+	// ExitCode is the exit code of the hook process.
+	ExitCode int
+}
+
+// unitHookHistoryDoc records the most recent hook executions of a unit.
+// Documents in this collection are written without transactions, as
+// they are purely diagnostic and written after every hook.
+type unitHookHistoryDoc struct {
+	DocID      string             `bson:"_id"`
+	ModelUUID  string             `bson:"model-uuid"`
+	Executions []hookExecutionDoc `bson:"executions"`
+}
+
+type hookExecutionDoc struct {
+	Hook     string `bson:"hook"`
+	Relation string `bson:"relation,omitempty"`
+	Started  int64  `bson:"started"`
+	Duration int64  `bson:"duration"`
+	ExitCode int    `bson:"exit-code"`
+}
+
+// RecordHookExecution adds the given hook execution to the unit's hook
+// history, discarding the oldest recorded execution if the history is
+// full.
+func (u *Unit) RecordHookExecution(execution HookExecution) error {
+	if execution.Hook == "" {
+		return errors.NotValidf("empty hook name")
+	}
+	history, closer := u.st.db().GetCollection(unitHookHistoryC)
+	defer closer()
+
+	doc := hookExecutionDoc{
+		Hook:     execution.Hook,
+		Relation: execution.Relation,
+		Started:  execution.Started.UnixNano(),
+		Duration: int64(execution.Duration),
+		ExitCode: execution.ExitCode,
+	}
+	_, err := history.Writeable().UpsertId(u.st.docID(u.globalKey()), bson.D{
+		{"$set", bson.D{{"model-uuid", u.st.ModelUUID()}}},
+		{"$push", bson.D{{"executions", bson.D{
+			{"$each", []hookExecutionDoc{doc}},
+			{"$slice", -maxHookExecutions},
+		}}}},
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot record hook execution for unit %q", u.Name())
+	}
+	return nil
+}
+
+// HookExecutions returns the unit's recorded hook executions, oldest
+// first.
+func (u *Unit) HookExecutions() ([]HookExecution, error) {
+	history, closer := u.st.db().GetCollection(unitHookHistoryC)
+	defer closer()
+
+	var doc unitHookHistoryDoc
+	err := history.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u.Name())
+	}
+	result := make([]HookExecution, len(doc.Executions))
+	for i, execution := range doc.Executions {
+		result[i] = HookExecution{
+			Hook:     execution.Hook,
+			Relation: execution.Relation,
+			Started:  unixNanoToTime(execution.Started).UTC(),
+			Duration: time.Duration(execution.Duration),
+			ExitCode: execution.ExitCode,
+		}
+	}
+	return result, nil
+}
+
+// HookHistory returns a status.StatusHistoryGetter that presents the
+// unit's hook executions as status history.
+func (u *Unit) HookHistory() status.StatusHistoryGetter {
+	return &hookHistory{unit: u}
+}
+
+type hookHistory struct {
+	unit *Unit
+}
+
+// StatusHistory is part of the status.StatusHistoryGetter interface.
+// Each hook execution is reported as an idle status if the hook
+// succeeded, and an error status if it failed.
+func (h *hookHistory) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating arguments")
+	}
+	executions, err := h.unit.HookExecutions()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var from time.Time
+	switch {
+	case filter.FromDate != nil:
+		from = *filter.FromDate
+	case filter.Delta != nil:
+		from = time.Now().Add(-*filter.Delta)
+	}
+	// Results are returned newest first, as for other status history.
+	var result []status.StatusInfo
+	for i := len(executions) - 1; i >= 0; i-- {
+		execution := executions[i]
+		if !execution.Started.After(from) {
+			break
+		}
+		info := hookExecutionStatus(execution)
+		if filter.Exclude.Contains(info.Message) {
+			continue
+		}
+		result = append(result, info)
+		if filter.Size > 0 && len(result) == filter.Size {
+			break
+		}
+	}
+	return result, nil
+}
+
+func hookExecutionStatus(execution HookExecution) status.StatusInfo {
+	started := execution.Started
+	info := status.StatusInfo{
+		Status:  status.Idle,
+		Message: fmt.Sprintf("ran %q hook (took %v)", execution.Hook, execution.Duration),
+		Data: map[string]interface{}{
+			"hook":      execution.Hook,
+			"duration":  execution.Duration.String(),
+			"exit-code": execution.ExitCode,
+		},
+		Since: &started,
+	}
+	if execution.ExitCode != 0 {
+		info.Status = status.Error
+		info.Message = fmt.Sprintf("%q hook failed with exit code %d (took %v)",
+			execution.Hook, execution.ExitCode, execution.Duration)
+	}
+	if execution.Relation != "" {
+		info.Data["relation"] = execution.Relation
+	}
+	return info
+}
+
+// eraseHookHistory removes the unit's recorded hook executions.
+func (u *Unit) eraseHookHistory() error {
+	history, closer := u.st.db().GetCollection(unitHookHistoryC)
+	defer closer()
+
+	err := history.Writeable().RemoveId(u.st.docID(u.globalKey()))
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Trace(err)
+	}
+	return nil
+}
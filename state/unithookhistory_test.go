@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitHookHistorySuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitHookHistorySuite{})
+
+func (s *UnitHookHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *UnitHookHistorySuite) TestHookExecutionsInitiallyEmpty(c *gc.C) {
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+}
+
+func (s *UnitHookHistorySuite) TestRecordHookExecution(c *gc.C) {
+	started := time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC)
+	first := state.HookExecution{
+		Hook:     "install",
+		Started:  started,
+		Duration: 90 * time.Second,
+	}
+	second := state.HookExecution{
+		Hook:     "db-relation-changed",
+		Relation: "wordpress:db mysql:server",
+		Started:  started.Add(2 * time.Minute),
+		Duration: time.Second,
+		ExitCode: 1,
+	}
+	err := s.unit.RecordHookExecution(first)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookExecution(second)
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{first, second})
+}
+
+func (s *UnitHookHistorySuite) TestRecordHookExecutionEmptyHook(c *gc.C) {
+	err := s.unit.RecordHookExecution(state.HookExecution{})
+	c.Assert(err, gc.ErrorMatches, "empty hook name not valid")
+}
+
+func (s *UnitHookHistorySuite) TestRecordHookExecutionBounded(c *gc.C) {
+	started := time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < state.MaxHookExecutions+5; i++ {
+		err := s.unit.RecordHookExecution(state.HookExecution{
+			Hook:    fmt.Sprintf("hook-%d", i),
+			Started: started.Add(time.Duration(i) * time.Minute),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, state.MaxHookExecutions)
+	c.Assert(executions[0].Hook, gc.Equals, "hook-5")
+}
+
+func (s *UnitHookHistorySuite) TestHookHistory(c *gc.C) {
+	now := time.Now().UTC().Truncate(time.Second)
+	err := s.unit.RecordHookExecution(state.HookExecution{
+		Hook:     "start",
+		Started:  now.Add(-time.Minute),
+		Duration: 2 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookExecution(state.HookExecution{
+		Hook:     "db-relation-joined",
+		Relation: "wordpress:db mysql:server",
+		Started:  now,
+		Duration: time.Second,
+		ExitCode: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Status, gc.Equals, status.Error)
+	c.Check(history[0].Message, gc.Equals, `"db-relation-joined" hook failed with exit code 2 (took 1s)`)
+	c.Check(history[0].Data, jc.DeepEquals, map[string]interface{}{
+		"hook":      "db-relation-joined",
+		"relation":  "wordpress:db mysql:server",
+		"duration":  "1s",
+		"exit-code": 2,
+	})
+	c.Check(history[1].Status, gc.Equals, status.Idle)
+	c.Check(history[1].Message, gc.Equals, `ran "start" hook (took 2s)`)
+
+	history, err = s.unit.HookHistory().StatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Data["hook"], gc.Equals, "db-relation-joined")
+
+	delta := 30 * time.Second
+	history, err = s.unit.HookHistory().StatusHistory(status.StatusHistoryFilter{Delta: &delta})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+}
+
+func (s *UnitHookHistorySuite) TestHookHistoryErasedWithUnit(c *gc.C) {
+	err := s.unit.RecordHookExecution(state.HookExecution{
+		Hook:    "install",
+		Started: time.Now(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+}
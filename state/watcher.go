@@ -1513,6 +1513,12 @@ func (a *Application) Watch() NotifyWatcher {
 	return newEntityWatcher(a.st, applicationsC, a.doc.DocID)
 }
 
+// WatchApplicationConfig returns a watcher for observing changes
+// to an application's own configuration (not its charm config).
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchLeaderSettings returns a watcher for observing changed to an application's
 // leader settings.
 func (a *Application) WatchLeaderSettings() NotifyWatcher {
@@ -2787,6 +2793,24 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchApplicationsConfig returns a NotifyWatcher which triggers
+// whenever the application config of any application in the model
+// changes.
+func (st *State) WatchApplicationsConfig() NotifyWatcher {
+	filter := func(id interface{}) bool {
+		key, ok := id.(string)
+		if !ok {
+			return false
+		}
+		localID, err := st.strictLocalID(key)
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(localID, "a#") && strings.HasSuffix(localID, "#application")
+	}
+	return newNotifyCollWatcher(st, settingsC, filter)
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
 // change is seen in a specific collection matching the provided
 // filter function.
@@ -2840,6 +2864,159 @@ func (w *notifyCollWatcher) loop() error {
 	}
 }
 
+// WatchGoalState returns a NotifyWatcher that triggers when the goal
+// state of the application changes; that is, when relations involving
+// the application are added or removed, or when units are added to or
+// removed from the application or any application related to it.
+func (a *Application) WatchGoalState() NotifyWatcher {
+	return newGoalStateWatcher(a.st, a.doc.Name)
+}
+
+// goalStateWatcher implements NotifyWatcher, triggering when the set of
+// relations and units making up an application's goal state changes.
+type goalStateWatcher struct {
+	commonWatcher
+	st          *State
+	application string
+	sink        chan struct{}
+
+	// applications holds the names of the application and those
+	// related to it, whose units affect the goal state.
+	applications set.Strings
+}
+
+func newGoalStateWatcher(st *State, application string) NotifyWatcher {
+	w := &goalStateWatcher{
+		commonWatcher: newCommonWatcher(st),
+		st:            st,
+		application:   application,
+		sink:          make(chan struct{}),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.sink)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *goalStateWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+func (w *goalStateWatcher) loop() error {
+	unitsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(unitsC, unitsCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(unitsC, unitsCh)
+	relationsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(relationsC, relationsCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(relationsC, relationsCh)
+
+	current, err := w.members()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.sink // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-unitsCh:
+			ids, ok := collect(change, unitsCh, w.tomb.Dying())
+			if !ok {
+				return tomb.ErrDying
+			}
+			if !w.anyRelevant(ids, w.unitRelevant) {
+				continue
+			}
+		case change := <-relationsCh:
+			ids, ok := collect(change, relationsCh, w.tomb.Dying())
+			if !ok {
+				return tomb.ErrDying
+			}
+			if !w.anyRelevant(ids, w.relationRelevant) {
+				continue
+			}
+		case out <- struct{}{}:
+			out = nil
+			continue
+		}
+		latest, err := w.members()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Only additions and removals change the goal state; other
+		// changes to the documents are ignored.
+		if !latest.Difference(current).IsEmpty() || !current.Difference(latest).IsEmpty() {
+			current = latest
+			out = w.sink
+		}
+	}
+}
+
+// members returns the keys of the relations involving the application,
+// and the names of the units of the application and of the applications
+// related to it.
+func (w *goalStateWatcher) members() (set.Strings, error) {
+	relations, err := applicationRelations(w.st, w.application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	members := set.NewStrings()
+	applications := set.NewStrings(w.application)
+	for _, rel := range relations {
+		members.Add(rel.String())
+		for _, ep := range rel.Endpoints() {
+			applications.Add(ep.ApplicationName)
+		}
+	}
+
+	units, closer := w.db.GetCollection(unitsC)
+	defer closer()
+	var docs []struct {
+		Name string `bson:"name"`
+	}
+	err = units.Find(bson.D{{"application", bson.D{{"$in", applications.Values()}}}}).Select(bson.D{{"name", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, doc := range docs {
+		members.Add(doc.Name)
+	}
+	w.applications = applications
+	return members, nil
+}
+
+// anyRelevant reports whether any of the changed documents, identified
+// by their local ids, could affect the goal state.
+func (w *goalStateWatcher) anyRelevant(ids map[interface{}]bool, relevant func(localID string) bool) bool {
+	for id := range ids {
+		if relevant(w.st.localID(id.(string))) {
+			return true
+		}
+	}
+	return false
+}
+
+// unitRelevant reports whether the named unit belongs to the
+// application or one related to it.
+func (w *goalStateWatcher) unitRelevant(name string) bool {
+	return w.applications.Contains(unitAppName(name))
+}
+
+// relationRelevant reports whether the relation with the given key
+// involves the application.
+func (w *goalStateWatcher) relationRelevant(key string) bool {
+	for _, ep := range strings.Fields(key) {
+		if strings.SplitN(ep, ":", 2)[0] == w.application {
+			return true
+		}
+	}
+	return false
+}
+
 // WatchRemoteRelations returns a StringsWatcher that notifies of changes to
 // the lifecycles of the remote relations in the model.
 func (st *State) WatchRemoteRelations() StringsWatcher {
@@ -2956,6 +3133,31 @@ func (r *Relation) WatchRelationEgressNetworks() StringsWatcher {
 	return newrelationNetworksWatcher(r.st, r.Tag().Id(), egress)
 }
 
+// WatchRelationIngressNetworks returns a NotifyWatcher which triggers
+// whenever the ingress networks of any of the application's relations
+// change.
+func (a *Application) WatchRelationIngressNetworks() NotifyWatcher {
+	filter := func(id interface{}) bool {
+		k, err := a.st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		// The document ids are of the form <relation key>:<direction>:<label>.
+		parts := strings.Split(k, ":")
+		if len(parts) < 3 || parts[len(parts)-2] != ingress {
+			return false
+		}
+		relationKey := strings.Join(parts[:len(parts)-2], ":")
+		for _, endpoint := range strings.Fields(relationKey) {
+			if strings.HasPrefix(endpoint, a.doc.Name+":") {
+				return true
+			}
+		}
+		return false
+	}
+	return newNotifyCollWatcher(a.st, relationNetworksC, filter)
+}
+
 func newrelationNetworksWatcher(st modelBackend, relationKey, direction string) StringsWatcher {
 	filter := func(id interface{}) bool {
 		k, err := st.strictLocalID(id.(string))
@@ -23,6 +23,7 @@ const (
 	presenceWorker        = "presence"
 	leadershipWorker      = "leadership"
 	singularWorker        = "singular"
+	operatorLeaseWorker   = "operatorlease"
 	allManagerWorker      = "allmanager"
 	allModelManagerWorker = "allmodelmanager"
 	pingBatcherWorker     = "pingbatcher"
@@ -85,6 +86,13 @@ func newWorkers(st *State, hub *pubsub.SimpleHub) (*workers, error) {
 		}
 		return manager, nil
 	})
+	ws.StartWorker(operatorLeaseWorker, func() (worker.Worker, error) {
+		manager, err := st.newLeaseManager(st.getOperatorLeaseStore, operatorSecretary{}, st.ModelUUID())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return manager, nil
+	})
 	return ws, nil
 }
 
@@ -152,6 +160,14 @@ func (ws *workers) singularManager() *lease.Manager {
 	return w.(*lease.Manager)
 }
 
+func (ws *workers) operatorLeaseManager() *lease.Manager {
+	w, err := ws.Worker(operatorLeaseWorker, nil)
+	if err != nil {
+		return lease.NewDeadManager(errors.Trace(err))
+	}
+	return w.(*lease.Manager)
+}
+
 func (ws *workers) allManager(params WatchParams) *storeManager {
 	w, err := ws.Worker(allManagerWorker, nil)
 	if err == nil {
@@ -111,6 +111,11 @@ const (
 	// The juju agent has has not communicated with the juju server for an unexpectedly long time;
 	// the unit agent ought to be signalling activity, but none has been detected.
 	Lost Status = "lost"
+
+	// Paused is set when:
+	// An operator has paused the unit. The agent runs no hooks or actions,
+	// and queues events until the unit is resumed.
+	Paused Status = "paused"
 )
 
 const (
@@ -253,7 +258,8 @@ func (status Status) KnownAgentStatus() bool {
 		Failed,
 		Rebooting,
 		Executing,
-		Idle:
+		Idle,
+		Paused:
 		return true
 	}
 	return false
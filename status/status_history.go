@@ -85,6 +85,8 @@ const (
 	KindUnitAgent HistoryKind = "juju-unit"
 	// KindWorkload represents a charm workload status history entry.
 	KindWorkload HistoryKind = "workload"
+	// KindHook represents a charm hook execution by a unit.
+	KindHook HistoryKind = "hook"
 	// KindMachineInstance represents an entry for a machine instance.
 	KindMachineInstance HistoryKind = "machine"
 	// KindMachine represents an entry for a machine agent.
@@ -103,7 +105,7 @@ func (k HistoryKind) String() string {
 // Valid will return true if the current kind is a valid one.
 func (k HistoryKind) Valid() bool {
 	switch k {
-	case KindUnit, KindUnitAgent, KindWorkload,
+	case KindUnit, KindUnitAgent, KindWorkload, KindHook,
 		KindMachineInstance, KindMachine,
 		KindContainerInstance, KindContainer:
 		return true
@@ -117,6 +119,7 @@ func AllHistoryKind() map[HistoryKind]string {
 		KindUnit:              "statuses for specified unit and its workload",
 		KindUnitAgent:         "statuses from the agent that is managing a unit",
 		KindWorkload:          "statuses for unit's workload",
+		KindHook:              "hooks run by a unit, with their duration and exit code",
 		KindMachineInstance:   "statuses that occur due to provisioning of a machine",
 		KindMachine:           "status of the agent that is managing a machine",
 		KindContainerInstance: "statuses from the agent that is managing containers",
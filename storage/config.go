@@ -19,6 +19,16 @@ const (
 	// should not be relied upon until a storage source is
 	// constructed.
 	ConfigStorageDir = "storage-dir"
+
+	// ConfigEncrypted is the name of the common attribute requesting
+	// that volumes created from a pool be encrypted at rest.
+	ConfigEncrypted = "encrypted"
+
+	// ConfigKMSKey is the name of the common attribute identifying
+	// the provider's key management service key with which volumes
+	// created from a pool are encrypted. Specifying a key implies
+	// encryption.
+	ConfigKMSKey = "kms-key"
 )
 
 // Config defines the configuration for a storage source.
@@ -28,19 +38,31 @@ type Config struct {
 	attrs    map[string]interface{}
 }
 
-var fields = schema.Fields{}
+var fields = schema.Fields{
+	ConfigEncrypted: schema.Bool(),
+	ConfigKMSKey:    schema.String(),
+}
 
 var configChecker = schema.FieldMap(
 	fields,
-	schema.Defaults{},
+	schema.Defaults{
+		ConfigEncrypted: schema.Omit,
+		ConfigKMSKey:    schema.Omit,
+	},
 )
 
 // NewConfig creates a new Config for instantiating a storage source.
 func NewConfig(name string, provider ProviderType, attrs map[string]interface{}) (*Config, error) {
-	_, err := configChecker.Coerce(attrs, nil)
+	coerced, err := configChecker.Coerce(attrs, nil)
 	if err != nil {
 		return nil, errors.Annotate(err, "validating common storage config")
 	}
+	coercedAttrs := coerced.(map[string]interface{})
+	if encrypted, ok := coercedAttrs[ConfigEncrypted].(bool); ok && !encrypted {
+		if coercedAttrs[ConfigKMSKey] != nil {
+			return nil, errors.NotValidf("%s with %s false", ConfigKMSKey, ConfigEncrypted)
+		}
+	}
 	return &Config{
 		name:     name,
 		provider: provider,
@@ -76,3 +98,18 @@ func (c *Config) ValueString(name string) (string, bool) {
 	v, ok := c.attrs[name].(string)
 	return v, ok
 }
+
+// Encryption returns whether volumes created from the pool are to be
+// encrypted at rest, and the key management service key to encrypt
+// them with, if any.
+func (c *Config) Encryption() (encrypted bool, kmsKey string) {
+	coerced, err := configChecker.Coerce(c.attrs, nil)
+	if err != nil {
+		// The attributes were validated by NewConfig.
+		return false, ""
+	}
+	coercedAttrs := coerced.(map[string]interface{})
+	encrypted, _ = coercedAttrs[ConfigEncrypted].(bool)
+	kmsKey, _ = coercedAttrs[ConfigKMSKey].(string)
+	return encrypted || kmsKey != "", kmsKey
+}
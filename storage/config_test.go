@@ -0,0 +1,58 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+)
+
+type ConfigSuite struct{}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestEncryptionDefault(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "whatever", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted, kmsKey := cfg.Encryption()
+	c.Check(encrypted, jc.IsFalse)
+	c.Check(kmsKey, gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestEncryptionEncrypted(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "whatever", map[string]interface{}{
+		"encrypted": "true",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted, kmsKey := cfg.Encryption()
+	c.Check(encrypted, jc.IsTrue)
+	c.Check(kmsKey, gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestEncryptionKMSKeyImpliesEncrypted(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "whatever", map[string]interface{}{
+		"kms-key": "my-key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted, kmsKey := cfg.Encryption()
+	c.Check(encrypted, jc.IsTrue)
+	c.Check(kmsKey, gc.Equals, "my-key")
+}
+
+func (s *ConfigSuite) TestEncryptionKMSKeyNotEncrypted(c *gc.C) {
+	_, err := storage.NewConfig("pool", "whatever", map[string]interface{}{
+		"encrypted": false,
+		"kms-key":   "my-key",
+	})
+	c.Assert(err, gc.ErrorMatches, "kms-key with encrypted false not valid")
+}
+
+func (s *ConfigSuite) TestEncryptionInvalid(c *gc.C) {
+	_, err := storage.NewConfig("pool", "whatever", map[string]interface{}{
+		"encrypted": "maybe",
+	})
+	c.Assert(err, gc.ErrorMatches, `validating common storage config: encrypted: expected bool, got string\("maybe"\)`)
+}
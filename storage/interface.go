@@ -87,6 +87,19 @@ type Provider interface {
 	ValidateConfig(*Config) error
 }
 
+// EncryptingProvider is an optional interface that a Provider may
+// implement if the volumes it creates can be encrypted at rest. Pools
+// of providers that do not implement it may not request encryption.
+type EncryptingProvider interface {
+	Provider
+
+	// ValidateEncryption returns an error if the provider cannot
+	// honour the requested encryption: whether volumes are to be
+	// encrypted, and the key management service key with which to
+	// encrypt them, if any.
+	ValidateEncryption(encrypted bool, kmsKey string) error
+}
+
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -137,6 +150,17 @@ type VolumeSource interface {
 	DetachVolumes(ctx context.ProviderCallContext, params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeTagger is an optional interface that may be implemented by a
+// VolumeSource that supports updating the tags of existing volumes.
+type VolumeTagger interface {
+	// TagVolume tags the volume with the specified provider volume ID
+	// with the specified tags.
+	//
+	// The specified tags will replace any existing ones with the
+	// same names, but other existing tags will be left alone.
+	TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
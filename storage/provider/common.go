@@ -28,5 +28,14 @@ func CommonStorageProviders() storage.ProviderRegistry {
 // ValidateConfig performs storage provider config validation, including
 // any common validation.
 func ValidateConfig(p storage.Provider, cfg *storage.Config) error {
+	if encrypted, kmsKey := cfg.Encryption(); encrypted {
+		ep, ok := p.(storage.EncryptingProvider)
+		if !ok {
+			return errors.NotSupportedf("encryption with %q storage provider", cfg.Provider())
+		}
+		if err := ep.ValidateEncryption(encrypted, kmsKey); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return p.ValidateConfig(cfg)
 }
@@ -6,6 +6,7 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -37,6 +38,18 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 	})
 }
 
+func (s *providerCommonSuite) TestValidateConfigEncryptionNotSupported(c *gc.C) {
+	p, err := provider.CommonStorageProviders().StorageProvider(provider.LoopProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := storage.NewConfig("pool", provider.LoopProviderType, map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(p, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `encryption with "loop" storage provider not supported`)
+}
+
 // testDetachFilesystems is a test-case for detaching filesystems that use
 // the common "maybeUnmount" method.
 func testDetachFilesystems(c *gc.C, commands *mockRunCommand, source storage.FilesystemSource, callCtx context.ProviderCallContext, mounted bool) {
@@ -26,3 +26,7 @@ var LongAttempt = &utils.AttemptStrategy{
 	Total: LongWait,
 	Delay: ShortWait,
 }
+
+// SecretsMasterKey is the key used by test controllers to wrap the
+// keys which encrypt models' secret values.
+var SecretsMasterKey = []byte("0123456789abcdef0123456789abcdef")
@@ -3,6 +3,13 @@
 
 package upgrades
 
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/agent"
+	apiagent "github.com/juju/juju/api/agent"
+)
+
 // stateStepsFor25 returns upgrade steps for Juju 2.5.0 that manipulate state directly.
 func stateStepsFor25() []Step {
 	return []Step{
@@ -15,3 +22,70 @@ func stateStepsFor25() []Step {
 		},
 	}
 }
+
+// stepsFor25 returns upgrade steps for Juju 2.5.0.
+func stepsFor25() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "generate secrets master key",
+			targets:     []Target{DatabaseMaster},
+			run:         generateSecretsMasterKey,
+		},
+		&upgradeStep{
+			description: "copy secrets master key from the controller",
+			targets:     []Target{Controller},
+			run:         copySecretsMasterKey,
+		},
+	}
+}
+
+// generateSecretsMasterKey adds a secrets master key to the state
+// serving info of a controller which was bootstrapped before model
+// secrets were supported. The other controllers copy it in the
+// following step.
+func generateSecretsMasterKey(context Context) error {
+	config := context.AgentConfig()
+	info, ok := config.StateServingInfo()
+	if !ok {
+		return errors.New("no state serving info in agent config")
+	}
+	if info.SecretsMasterKey != "" {
+		return nil
+	}
+	key, err := agent.GenerateSecretsMasterKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info.SecretsMasterKey = key
+	config.SetStateServingInfo(info)
+	return nil
+}
+
+// copySecretsMasterKey adds the secrets master key generated by the
+// database master to the state serving info of the other controllers.
+// If it can't be had yet, it is copied when the agent next starts.
+func copySecretsMasterKey(context Context) error {
+	config := context.AgentConfig()
+	info, ok := config.StateServingInfo()
+	if !ok {
+		return errors.New("no state serving info in agent config")
+	}
+	if info.SecretsMasterKey != "" {
+		return nil
+	}
+	st, err := apiagent.NewState(context.APIState())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	apiInfo, err := st.StateServingInfo()
+	if err != nil {
+		return errors.Annotate(err, "getting state serving info")
+	}
+	if apiInfo.SecretsMasterKey == "" {
+		logger.Warningf("secrets master key not available yet, it will be copied when the agent restarts")
+		return nil
+	}
+	info.SecretsMasterKey = apiInfo.SecretsMasterKey
+	config.SetStateServingInfo(info)
+	return nil
+}
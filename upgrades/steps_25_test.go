@@ -8,6 +8,8 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/upgrades"
 )
@@ -25,3 +27,45 @@ func (s *steps25Suite) TestMigrateMachineIdField(c *gc.C) {
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+type steps25AgentSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&steps25AgentSuite{})
+
+func (s *steps25AgentSuite) TestGenerateSecretsMasterKey(c *gc.C) {
+	step := findStep(c, v25, "generate secrets master key")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+
+	ctx := &mockContext{agentConfig: &mockAgentConfig{
+		servingInfo: params.StateServingInfo{APIPort: 17070},
+	}}
+	err := step.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	info, _ := ctx.agentConfig.StateServingInfo()
+	c.Assert(info.APIPort, gc.Equals, 17070)
+	key, err := agent.SecretsMasterKey(ctx.agentConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.HasLen, 32)
+
+	// An existing key is kept.
+	err = step.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	again, _ := ctx.agentConfig.StateServingInfo()
+	c.Assert(again.SecretsMasterKey, gc.Equals, info.SecretsMasterKey)
+}
+
+func (s *steps25AgentSuite) TestCopySecretsMasterKeyKeepsExisting(c *gc.C) {
+	step := findStep(c, v25, "copy secrets master key from the controller")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.Controller})
+
+	// With a key already held, the API isn't consulted.
+	ctx := &mockContext{agentConfig: &mockAgentConfig{
+		servingInfo: params.StateServingInfo{SecretsMasterKey: "a2V5"},
+	}}
+	err := step.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	info, _ := ctx.agentConfig.StateServingInfo()
+	c.Assert(info.SecretsMasterKey, gc.Equals, "a2V5")
+}
@@ -9,14 +9,16 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/worker/catacomb"
 )
 
 type applicationWorker struct {
-	catacomb          catacomb.Catacomb
-	application       string
-	applicationGetter ApplicationGetter
-	serviceExposer    ServiceExposer
+	catacomb             catacomb.Catacomb
+	application          string
+	applicationGetter    ApplicationGetter
+	serviceExposer       ServiceExposer
+	networkPolicyManager NetworkPolicyManager
 
 	lifeGetter LifeGetter
 
@@ -28,14 +30,16 @@ func newApplicationWorker(
 	application string,
 	applicationGetter ApplicationGetter,
 	applicationExposer ServiceExposer,
+	networkPolicyManager NetworkPolicyManager,
 	lifeGetter LifeGetter,
 ) (worker.Worker, error) {
 	w := &applicationWorker{
-		application:       application,
-		applicationGetter: applicationGetter,
-		serviceExposer:    applicationExposer,
-		lifeGetter:        lifeGetter,
-		initial:           true,
+		application:          application,
+		applicationGetter:    applicationGetter,
+		serviceExposer:       applicationExposer,
+		networkPolicyManager: networkPolicyManager,
+		lifeGetter:           lifeGetter,
+		initial:              true,
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -68,6 +72,29 @@ func (w *applicationWorker) loop() (err error) {
 	if err := w.catacomb.Add(appWatcher); err != nil {
 		return errors.Trace(err)
 	}
+	relationsWatcher, err := w.applicationGetter.WatchRelations(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(relationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	// The network policy is enabled in the application config, and
+	// admits consumers of cross model relations by their networks.
+	configWatcher, err := w.applicationGetter.WatchApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	ingressWatcher, err := w.applicationGetter.WatchIngressNetworks(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(ingressWatcher); err != nil {
+		return errors.Trace(err)
+	}
 
 	for {
 		select {
@@ -83,6 +110,30 @@ func (w *applicationWorker) loop() (err error) {
 				}
 				return errors.Trace(err)
 			}
+			if err := w.updateNetworkPolicy(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-relationsWatcher.Changes():
+			if !ok {
+				return errors.New("relations watcher closed")
+			}
+			if err := w.updateNetworkPolicy(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("application config watcher closed")
+			}
+			if err := w.updateNetworkPolicy(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-ingressWatcher.Changes():
+			if !ok {
+				return errors.New("ingress networks watcher closed")
+			}
+			if err := w.updateNetworkPolicy(); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
@@ -113,3 +164,30 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	}
 	return nil
 }
+
+// updateNetworkPolicy ensures the application's network policy
+// reflects the applications it is currently related to.
+func (w *applicationWorker) updateNetworkPolicy() error {
+	related, err := w.applicationGetter.RelatedApplications(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ingressCIDRs, err := w.applicationGetter.IngressNetworks(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	exposed, err := w.applicationGetter.IsExposed(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	params := &caas.NetworkPolicyParams{
+		RelatedApplications: related,
+		IngressCIDRs:        ingressCIDRs,
+		Exposed:             exposed,
+	}
+	return w.networkPolicyManager.EnsureNetworkPolicy(w.application, params, appConfig)
+}
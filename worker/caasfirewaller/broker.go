@@ -3,9 +3,18 @@
 
 package caasfirewaller
 
-import "github.com/juju/juju/core/application"
+import (
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
 
 type ServiceExposer interface {
 	ExposeService(appName string, config application.ConfigAttributes) error
 	UnexposeService(appName string) error
 }
+
+// NetworkPolicyManager provides an interface for maintaining the
+// network policy restricting the traffic accepted by an application.
+type NetworkPolicyManager interface {
+	EnsureNetworkPolicy(appName string, params *caas.NetworkPolicyParams, config application.ConfigAttributes) error
+}
@@ -26,6 +26,11 @@ type ApplicationGetter interface {
 	WatchApplication(string) (watcher.NotifyWatcher, error)
 	IsExposed(string) (bool, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	WatchRelations(string) (watcher.StringsWatcher, error)
+	RelatedApplications(string) ([]string, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
+	WatchIngressNetworks(string) (watcher.NotifyWatcher, error)
+	IngressNetworks(string) ([]string, error)
 }
 
 // LifeGetter provides an interface for getting the
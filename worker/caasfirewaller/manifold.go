@@ -67,9 +67,10 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 
 	client := config.NewClient(apiCaller)
 	w, err := config.NewWorker(Config{
-		ApplicationGetter: client,
-		LifeGetter:        client,
-		ServiceExposer:    broker,
+		ApplicationGetter:    client,
+		LifeGetter:           client,
+		ServiceExposer:       broker,
+		NetworkPolicyManager: broker,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
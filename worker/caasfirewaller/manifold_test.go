@@ -127,8 +127,9 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	config := args[0].(caasfirewaller.Config)
 
 	c.Assert(config, jc.DeepEquals, caasfirewaller.Config{
-		ApplicationGetter: &s.client,
-		ServiceExposer:    &s.broker,
-		LifeGetter:        &s.client,
+		ApplicationGetter:    &s.client,
+		ServiceExposer:       &s.broker,
+		NetworkPolicyManager: &s.broker,
+		LifeGetter:           &s.client,
 	})
 }
@@ -45,11 +45,29 @@ func (m *mockServiceExposer) UnexposeService(appName string) error {
 	return m.NextErr()
 }
 
+type mockNetworkPolicyManager struct {
+	testing.Stub
+	ensured chan<- struct{}
+}
+
+func (m *mockNetworkPolicyManager) EnsureNetworkPolicy(
+	appName string, params *caas.NetworkPolicyParams, config application.ConfigAttributes,
+) error {
+	m.MethodCall(m, "EnsureNetworkPolicy", appName, params, config)
+	m.ensured <- struct{}{}
+	return m.NextErr()
+}
+
 type mockApplicationGetter struct {
 	testing.Stub
-	allWatcher *watchertest.MockStringsWatcher
-	appWatcher *watchertest.MockNotifyWatcher
-	exposed    bool
+	allWatcher       *watchertest.MockStringsWatcher
+	appWatcher       *watchertest.MockNotifyWatcher
+	relationsWatcher *watchertest.MockStringsWatcher
+	configWatcher    *watchertest.MockNotifyWatcher
+	ingressWatcher   *watchertest.MockNotifyWatcher
+	exposed          bool
+	related          []string
+	ingressCIDRs     []string
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -81,6 +99,46 @@ func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.C
 	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
 }
 
+func (m *mockApplicationGetter) WatchRelations(appName string) (watcher.StringsWatcher, error) {
+	m.MethodCall(m, "WatchRelations", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.relationsWatcher, nil
+}
+
+func (m *mockApplicationGetter) RelatedApplications(appName string) ([]string, error) {
+	m.MethodCall(m, "RelatedApplications", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.related, nil
+}
+
+func (m *mockApplicationGetter) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchApplicationConfig", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.configWatcher, nil
+}
+
+func (m *mockApplicationGetter) WatchIngressNetworks(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchIngressNetworks", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.ingressWatcher, nil
+}
+
+func (m *mockApplicationGetter) IngressNetworks(appName string) ([]string, error) {
+	m.MethodCall(m, "IngressNetworks", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.ingressCIDRs, nil
+}
+
 type mockLifeGetter struct {
 	testing.Stub
 	life life.Value
@@ -16,9 +16,10 @@ var logger = loggo.GetLogger("juju.workers.caasfirewaller")
 
 // Config holds configuration for the CAAS unit firewaller worker.
 type Config struct {
-	ApplicationGetter ApplicationGetter
-	LifeGetter        LifeGetter
-	ServiceExposer    ServiceExposer
+	ApplicationGetter    ApplicationGetter
+	LifeGetter           LifeGetter
+	ServiceExposer       ServiceExposer
+	NetworkPolicyManager NetworkPolicyManager
 }
 
 // Validate validates the worker configuration.
@@ -32,6 +33,9 @@ func (config Config) Validate() error {
 	if config.LifeGetter == nil {
 		return errors.NotValidf("missing LifeGetter")
 	}
+	if config.NetworkPolicyManager == nil {
+		return errors.NotValidf("missing NetworkPolicyManager")
+	}
 	return nil
 }
 
@@ -105,6 +109,7 @@ func (p *firewaller) loop() error {
 					appId,
 					p.config.ApplicationGetter,
 					p.config.ServiceExposer,
+					p.config.NetworkPolicyManager,
 					p.config.LifeGetter,
 				)
 				if err != nil {
@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	coretesting "github.com/juju/juju/testing"
@@ -25,12 +26,17 @@ type WorkerSuite struct {
 	config            caasfirewaller.Config
 	applicationGetter mockApplicationGetter
 	serviceExposer    mockServiceExposer
+	policyManager     mockNetworkPolicyManager
 	lifeGetter        mockLifeGetter
 
 	applicationChanges chan []string
 	appExposedChange   chan struct{}
+	relationsChanges   chan []string
+	configChanges      chan struct{}
+	ingressChanges     chan struct{}
 	serviceExposed     chan struct{}
 	serviceUnexposed   chan struct{}
+	policyEnsured      chan struct{}
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -42,12 +48,24 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.appExposedChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
+	s.relationsChanges = make(chan []string)
+	s.configChanges = make(chan struct{})
+	s.ingressChanges = make(chan struct{})
+	// The network policy is updated on every application change,
+	// so leave room for tests which don't care about it.
+	s.policyEnsured = make(chan struct{}, 10)
 
 	s.applicationGetter = mockApplicationGetter{
-		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
-		appWatcher: watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		allWatcher:       watchertest.NewMockStringsWatcher(s.applicationChanges),
+		appWatcher:       watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		relationsWatcher: watchertest.NewMockStringsWatcher(s.relationsChanges),
+		configWatcher:    watchertest.NewMockNotifyWatcher(s.configChanges),
+		ingressWatcher:   watchertest.NewMockNotifyWatcher(s.ingressChanges),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.allWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.relationsWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.configWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.ingressWatcher) })
 
 	s.lifeGetter = mockLifeGetter{
 		life: life.Alive,
@@ -56,11 +74,15 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		exposed:   s.serviceExposed,
 		unexposed: s.serviceUnexposed,
 	}
+	s.policyManager = mockNetworkPolicyManager{
+		ensured: s.policyEnsured,
+	}
 
 	s.config = caasfirewaller.Config{
-		ApplicationGetter: &s.applicationGetter,
-		ServiceExposer:    &s.serviceExposer,
-		LifeGetter:        &s.lifeGetter,
+		ApplicationGetter:    &s.applicationGetter,
+		ServiceExposer:       &s.serviceExposer,
+		NetworkPolicyManager: &s.policyManager,
+		LifeGetter:           &s.lifeGetter,
 	}
 }
 
@@ -84,6 +106,10 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.LifeGetter = nil
 	}, `missing LifeGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.NetworkPolicyManager = nil
+	}, `missing NetworkPolicyManager not valid`)
 }
 
 func (s *WorkerSuite) testValidateConfig(c *gc.C, f func(*caasfirewaller.Config), expect string) {
@@ -175,6 +201,53 @@ func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestRelationsChangeUpdatesNetworkPolicy(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.related = []string{"mysql"}
+	select {
+	case s.relationsChanges <- []string{"gitlab:db mysql:server"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending relations change")
+	}
+	select {
+	case <-s.policyEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy to be updated")
+	}
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+	select {
+	case <-s.policyEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy to be updated")
+	}
+
+	appConfig := application.ConfigAttributes{"juju-external-hostname": "exthost"}
+	s.policyManager.CheckCallNames(c, "EnsureNetworkPolicy", "EnsureNetworkPolicy")
+	s.policyManager.CheckCall(c, 0, "EnsureNetworkPolicy", "gitlab", &caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mysql"},
+	}, appConfig)
+	s.policyManager.CheckCall(c, 1, "EnsureNetworkPolicy", "gitlab", &caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mysql"},
+		Exposed:             true,
+	}, appConfig)
+}
+
 func (s *WorkerSuite) TestWatchApplicationDead(c *gc.C) {
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -235,3 +308,45 @@ func (s *WorkerSuite) TestWatcherErrorStopsWorker(c *gc.C) {
 	err = workertest.CheckKilled(c, w)
 	c.Assert(err, gc.ErrorMatches, "splat")
 }
+
+func (s *WorkerSuite) TestConfigAndIngressChangesUpdateNetworkPolicy(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	select {
+	case s.configChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application config change")
+	}
+	select {
+	case <-s.policyEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy to be updated")
+	}
+
+	s.applicationGetter.ingressCIDRs = []string{"10.0.0.0/24"}
+	select {
+	case s.ingressChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending ingress networks change")
+	}
+	select {
+	case <-s.policyEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy to be updated")
+	}
+
+	appConfig := application.ConfigAttributes{"juju-external-hostname": "exthost"}
+	s.policyManager.CheckCallNames(c, "EnsureNetworkPolicy", "EnsureNetworkPolicy")
+	s.policyManager.CheckCall(c, 0, "EnsureNetworkPolicy", "gitlab", &caas.NetworkPolicyParams{}, appConfig)
+	s.policyManager.CheckCall(c, 1, "EnsureNetworkPolicy", "gitlab", &caas.NetworkPolicyParams{
+		IngressCIDRs: []string{"10.0.0.0/24"},
+	}, appConfig)
+}
@@ -4,6 +4,9 @@
 package caasoperator
 
 import (
+	"time"
+
+	"github.com/juju/utils/exec"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
 
@@ -24,6 +27,7 @@ type Client interface {
 	PodSpecSetter
 	StatusSetter
 	VersionSetter
+	WorkloadExecutor
 	Model() (*model.Model, error)
 }
 
@@ -80,6 +84,12 @@ type CharmConfigGetter interface {
 	WatchCharmConfig(string) (watcher.NotifyWatcher, error)
 }
 
+// WorkloadExecutor provides an interface for running
+// commands in the workload containers of units.
+type WorkloadExecutor interface {
+	ExecInWorkload(unitName, commands string, timeout time.Duration) (*exec.ExecResponse, error)
+}
+
 // VersionSetter provides an interface for setting
 // the operator agent version.
 type VersionSetter interface {
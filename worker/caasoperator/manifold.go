@@ -165,6 +165,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 					UpdateStatusSignal:   uniter.NewUpdateStatusTimer(),
 					HookRetryStrategy:    hookRetryStrategy,
 					TranslateResolverErr: config.TranslateResolverErr,
+					RemoteExec:           client.ExecInWorkload,
 				},
 			})
 			if err != nil {
@@ -137,11 +137,13 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	c.Assert(config.StartUniterFunc, gc.NotNil)
 	c.Assert(config.UniterParams.UpdateStatusSignal, gc.NotNil)
 	c.Assert(config.UniterParams.NewOperationExecutor, gc.NotNil)
+	c.Assert(config.UniterParams.RemoteExec, gc.NotNil)
 	config.LeadershipTrackerFunc = nil
 	config.StartUniterFunc = nil
 	config.UniterFacadeFunc = nil
 	config.UniterParams.UpdateStatusSignal = nil
 	config.UniterParams.NewOperationExecutor = nil
+	config.UniterParams.RemoteExec = nil
 
 	c.Assert(config, jc.DeepEquals, caasoperator.Config{
 		ModelUUID:          coretesting.ModelTag.Id(),
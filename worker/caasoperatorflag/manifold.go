@@ -0,0 +1,117 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasoperatorflag
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/singular"
+)
+
+// ManifoldConfig holds the information necessary to run a flag worker
+// which is set while this operator instance holds the operator lease
+// for its application.
+type ManifoldConfig struct {
+	AgentName     string
+	ClockName     string
+	APICallerName string
+	Holder        string
+	Duration      time.Duration
+
+	NewFacade func(caller base.APICaller, application, holder string) (singular.Facade, error)
+	NewWorker func(singular.FlagConfig) (worker.Worker, error)
+}
+
+// validate is called by start to check for bad configuration.
+func (config ManifoldConfig) validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.Holder == "" {
+		return errors.NotValidf("empty Holder")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// start is a StartFunc for a flag worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag, ok := agent.CurrentConfig().Tag().(names.ApplicationTag)
+	if !ok {
+		return nil, errors.Errorf("expected an application tag, got %v", agent.CurrentConfig().Tag())
+	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := config.NewFacade(apiCaller, tag.Id(), config.Holder)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	flag, err := config.NewWorker(singular.FlagConfig{
+		Clock:    clock,
+		Facade:   facade,
+		Duration: config.Duration,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return flag, nil
+}
+
+// Manifold returns a dependency.Manifold that runs a flag worker which is
+// set only while this operator instance holds the operator lease for its
+// application. Only one of an application's operator instances holds the
+// lease at a time; the others wait for it to expire before claiming it.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.ClockName,
+			config.APICallerName,
+		},
+		Start:  config.start,
+		Output: engine.FlagOutput,
+		Filter: bounceErrRefresh,
+	}
+}
+
+// bounceErrRefresh converts singular.ErrRefresh to dependency.ErrBounce.
+func bounceErrRefresh(err error) error {
+	if errors.Cause(err) == singular.ErrRefresh {
+		return dependency.ErrBounce
+	}
+	return err
+}
@@ -0,0 +1,160 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasoperatorflag_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/caasoperatorflag"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/singular"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func validManifoldConfig() caasoperatorflag.ManifoldConfig {
+	return caasoperatorflag.ManifoldConfig{
+		AgentName:     "agent",
+		ClockName:     "clock",
+		APICallerName: "api-caller",
+		Holder:        "juju-operator-gitlab-1",
+		Duration:      30 * time.Second,
+		NewFacade: func(base.APICaller, string, string) (singular.Facade, error) {
+			return nil, errors.New("unexpected NewFacade call")
+		},
+		NewWorker: func(singular.FlagConfig) (worker.Worker, error) {
+			return nil, errors.New("unexpected NewWorker call")
+		},
+	}
+}
+
+func (*ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := caasoperatorflag.Manifold(validManifoldConfig())
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"agent", "clock", "api-caller"})
+}
+
+func (*ManifoldSuite) TestFilterErrRefresh(c *gc.C) {
+	manifold := caasoperatorflag.Manifold(validManifoldConfig())
+	err := manifold.Filter(singular.ErrRefresh)
+	c.Check(err, gc.Equals, dependency.ErrBounce)
+}
+
+func (*ManifoldSuite) TestFilterOther(c *gc.C) {
+	manifold := caasoperatorflag.Manifold(validManifoldConfig())
+	expect := errors.New("whatever")
+	actual := manifold.Filter(expect)
+	c.Check(actual, gc.Equals, expect)
+}
+
+func (*ManifoldSuite) TestStartMissingAgentName(c *gc.C) {
+	config := validManifoldConfig()
+	config.AgentName = ""
+	checkManifoldNotValid(c, config, "empty AgentName not valid")
+}
+
+func (*ManifoldSuite) TestStartMissingHolder(c *gc.C) {
+	config := validManifoldConfig()
+	config.Holder = ""
+	checkManifoldNotValid(c, config, "empty Holder not valid")
+}
+
+func (*ManifoldSuite) TestStartMissingNewFacade(c *gc.C) {
+	config := validManifoldConfig()
+	config.NewFacade = nil
+	checkManifoldNotValid(c, config, "nil NewFacade not valid")
+}
+
+func (*ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewApplicationTag("gitlab")},
+		"clock":      clock.WallClock,
+		"api-caller": dependency.ErrMissing,
+	})
+	manifold := caasoperatorflag.Manifold(validManifoldConfig())
+
+	worker, err := manifold.Start(context)
+	c.Check(worker, gc.IsNil)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (*ManifoldSuite) TestStartSuccess(c *gc.C) {
+	expectCaller := &struct{ base.APICaller }{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewApplicationTag("gitlab")},
+		"clock":      clock.WallClock,
+		"api-caller": expectCaller,
+	})
+	expectFacade := &struct{ singular.Facade }{}
+	expectWorker := &struct{ worker.Worker }{}
+	config := validManifoldConfig()
+	config.NewFacade = func(caller base.APICaller, application, holder string) (singular.Facade, error) {
+		c.Check(caller, gc.Equals, expectCaller)
+		c.Check(application, gc.Equals, "gitlab")
+		c.Check(holder, gc.Equals, "juju-operator-gitlab-1")
+		return expectFacade, nil
+	}
+	config.NewWorker = func(flagConfig singular.FlagConfig) (worker.Worker, error) {
+		c.Check(flagConfig.Facade, gc.Equals, expectFacade)
+		c.Check(flagConfig.Clock, gc.Equals, clock.WallClock)
+		c.Check(flagConfig.Duration, gc.Equals, 30*time.Second)
+		return expectWorker, nil
+	}
+	manifold := caasoperatorflag.Manifold(config)
+
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+func (*ManifoldSuite) TestStartNotApplicationAgent(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent": &fakeAgent{tag: names.NewMachineTag("0")},
+	})
+	manifold := caasoperatorflag.Manifold(validManifoldConfig())
+
+	worker, err := manifold.Start(context)
+	c.Check(worker, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "expected an application tag, got machine-0")
+}
+
+func checkManifoldNotValid(c *gc.C, config caasoperatorflag.ManifoldConfig, expect string) {
+	manifold := caasoperatorflag.Manifold(config)
+	worker, err := manifold.Start(dt.StubContext(nil, nil))
+	c.Check(worker, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+type fakeAgent struct {
+	agent.Agent
+	tag names.Tag
+}
+
+func (a *fakeAgent) CurrentConfig() agent.Config {
+	return &fakeConfig{tag: a.tag}
+}
+
+type fakeConfig struct {
+	agent.Config
+	tag names.Tag
+}
+
+func (c *fakeConfig) Tag() names.Tag {
+	return c.tag
+}
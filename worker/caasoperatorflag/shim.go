@@ -0,0 +1,45 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasoperatorflag
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/caasoperator"
+	"github.com/juju/juju/worker/singular"
+)
+
+// NewFacade returns a singular.Facade which claims the operator lease for
+// the application on behalf of the named holder. It's a suitable default
+// value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller, application, holder string) (singular.Facade, error) {
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	return &facade{
+		client:      caasoperator.NewClient(apiCaller),
+		application: application,
+		holder:      holder,
+	}, nil
+}
+
+type facade struct {
+	client      *caasoperator.Client
+	application string
+	holder      string
+}
+
+// Claim is part of the singular.Facade interface.
+func (f *facade) Claim(duration time.Duration) error {
+	return f.client.ClaimOperatorLease(f.application, f.holder, duration)
+}
+
+// Wait is part of the singular.Facade interface.
+func (f *facade) Wait() error {
+	return f.client.WaitOperatorLeaseExpired(f.application)
+}
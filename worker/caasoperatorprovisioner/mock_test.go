@@ -27,7 +27,9 @@ type mockProvisionerFacade struct {
 	caasoperatorprovisioner.CAASProvisionerFacade
 	applicationsWatcher *mockStringsWatcher
 	apiWatcher          *mockNotifyWatcher
+	appConfigWatcher    *mockNotifyWatcher
 	life                life.Value
+	replicas            int
 }
 
 func newMockProvisionerFacade(stub *testing.Stub) *mockProvisionerFacade {
@@ -35,6 +37,8 @@ func newMockProvisionerFacade(stub *testing.Stub) *mockProvisionerFacade {
 		stub:                stub,
 		applicationsWatcher: newMockStringsWatcher(),
 		apiWatcher:          newMockNotifyWatcher(),
+		appConfigWatcher:    newMockNotifyWatcher(),
+		replicas:            1,
 	}
 }
 
@@ -48,6 +52,16 @@ func (m *mockProvisionerFacade) WatchApplications() (watcher.StringsWatcher, err
 	return m.applicationsWatcher, nil
 }
 
+func (m *mockProvisionerFacade) WatchApplicationsConfig() (watcher.NotifyWatcher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stub.MethodCall(m, "WatchApplicationsConfig")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.appConfigWatcher, nil
+}
+
 func (m *mockProvisionerFacade) OperatorProvisioningInfo() (apicaasprovisioner.OperatorProvisioningInfo, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -61,6 +75,16 @@ func (m *mockProvisionerFacade) OperatorProvisioningInfo() (apicaasprovisioner.O
 	}, nil
 }
 
+func (m *mockProvisionerFacade) OperatorReplicas(appName string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stub.MethodCall(m, "OperatorReplicas", appName)
+	if err := m.stub.NextErr(); err != nil {
+		return 0, err
+	}
+	return m.replicas, nil
+}
+
 func (m *mockProvisionerFacade) Life(entityName string) (life.Value, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
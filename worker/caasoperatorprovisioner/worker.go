@@ -26,7 +26,9 @@ var logger = loggo.GetLogger("juju.workers.caasprovisioner")
 // CAASProvisionerFacade exposes CAAS provisioning functionality to a worker.
 type CAASProvisionerFacade interface {
 	OperatorProvisioningInfo() (apicaasprovisioner.OperatorProvisioningInfo, error)
+	OperatorReplicas(string) (int, error)
 	WatchApplications() (watcher.StringsWatcher, error)
+	WatchApplicationsConfig() (watcher.NotifyWatcher, error)
 	SetPasswords([]apicaasprovisioner.ApplicationPassword) (params.ErrorResults, error)
 	Life(string) (life.Value, error)
 	WatchAPIHostPorts() (watcher.NotifyWatcher, error)
@@ -49,6 +51,7 @@ func NewProvisionerWorker(config Config) (worker.Worker, error) {
 		modelTag:          config.ModelTag,
 		agentConfig:       config.AgentConfig,
 		appPasswords:      make(map[string]string),
+		appReplicas:       make(map[string]int),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &p.catacomb,
@@ -66,6 +69,10 @@ type provisioner struct {
 	agentConfig agent.Config
 
 	appPasswords map[string]string
+
+	// appReplicas holds the number of operator replicas
+	// most recently ensured for each application.
+	appReplicas map[string]int
 }
 
 // Kill is part of the worker.Worker interface.
@@ -100,7 +107,18 @@ func (p *provisioner) loop() error {
 		return errors.Trace(err)
 	}
 
-	var apiAddressChanged watcher.NotifyChannel
+	appConfigWatcher, err := p.provisionerFacade.WatchApplicationsConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.catacomb.Add(appConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		apiAddressChanged watcher.NotifyChannel
+		appConfigChanged  watcher.NotifyChannel
+	)
 	for {
 		select {
 		case <-p.catacomb.Dying():
@@ -114,10 +132,20 @@ func (p *provisioner) loop() error {
 			}
 			for app, password := range p.appPasswords {
 				if err := p.ensureOperator(app, password); err != nil {
-					return errors.Annotatef(err, "updating operator for q with new api addresses", app)
+					return errors.Annotatef(err, "updating operator for %q with new api addresses", app)
 				}
 			}
 
+		// Application config has changed so we need to update
+		// any operators whose number of replicas has changed.
+		case _, ok := <-appConfigChanged:
+			if !ok {
+				return errors.New("application config watcher closed channel")
+			}
+			if err := p.updateOperatorReplicas(); err != nil {
+				return errors.Trace(err)
+			}
+
 		// CAAS applications changed so either create or remove pods as appropriate.
 		case apps, ok := <-appWatcher.Changes():
 			if !ok {
@@ -132,6 +160,7 @@ func (p *provisioner) loop() error {
 						return errors.Annotatef(err, "failed to stop operator for %q", app)
 					}
 					delete(p.appPasswords, app)
+					delete(p.appReplicas, app)
 					continue
 				}
 				if appLife != life.Alive {
@@ -156,8 +185,9 @@ func (p *provisioner) loop() error {
 			}
 
 			// Now we have been through all the applications at least once, we can
-			// listen for api address changes.
+			// listen for api address and application config changes.
 			apiAddressChanged = apiAddressWatcher.Changes()
+			appConfigChanged = appConfigWatcher.Changes()
 		}
 	}
 }
@@ -193,10 +223,34 @@ func (p *provisioner) ensureOperator(app, password string) error {
 	if err := p.broker.EnsureOperator(app, p.agentConfig.DataDir(), config); err != nil {
 		return errors.Annotatef(err, "failed to start operator for %q", app)
 	}
+	p.appReplicas[app] = config.Replicas
 	logger.Infof("started operator for application %q", app)
 	return nil
 }
 
+// updateOperatorReplicas ensures the operator of each application
+// whose configured number of operator replicas has changed.
+func (p *provisioner) updateOperatorReplicas() error {
+	for app, password := range p.appPasswords {
+		replicas, err := p.provisionerFacade.OperatorReplicas(app)
+		if errors.IsNotFound(err) {
+			// The application has been removed, which
+			// the application watcher will report.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if replicas == p.appReplicas[app] {
+			continue
+		}
+		logger.Debugf("updating operator for %q to run %d replicas", app, replicas)
+		if err := p.ensureOperator(app, password); err != nil {
+			return errors.Annotatef(err, "updating operator for %q with new replicas", app)
+		}
+	}
+	return nil
+}
+
 func (p *provisioner) newOperatorConfig(appName string, password string) (*caas.OperatorConfig, error) {
 	appTag := names.NewApplicationTag(appName)
 	apiAddrs, err := p.provisionerFacade.APIAddresses()
@@ -208,6 +262,10 @@ func (p *provisioner) newOperatorConfig(appName string, password string) (*caas.
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	replicas, err := p.provisionerFacade.OperatorReplicas(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	conf, err := agent.NewAgentConfig(
 		agent.AgentConfigParams{
 			Paths: agent.Paths{
@@ -237,5 +295,6 @@ func (p *provisioner) newOperatorConfig(appName string, password string) (*caas.
 		AgentConf:         confBytes,
 		OperatorImagePath: info.ImagePath,
 		Version:           info.Version,
+		Replicas:          replicas,
 	}, nil
 }
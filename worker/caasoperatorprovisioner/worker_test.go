@@ -72,6 +72,7 @@ func (s *CAASProvisionerSuite) assertWorker(c *gc.C) worker.Worker {
 	expected := []jujutesting.StubCall{
 		{"WatchApplications", nil},
 		{"WatchAPIHostPorts", nil},
+		{"WatchApplicationsConfig", nil},
 	}
 	s.waitForWorkerStubCalls(c, expected)
 	s.stub.ResetCalls()
@@ -102,6 +103,7 @@ func (s *CAASProvisionerSuite) assertOperatorCreated(c *gc.C) {
 	config := args[2].(*caas.OperatorConfig)
 	c.Assert(config.OperatorImagePath, gc.Equals, "juju-operator-image")
 	c.Assert(config.Version, gc.Equals, version.MustParse("2.99.0"))
+	c.Assert(config.Replicas, gc.Equals, s.provisionerFacade.replicas)
 
 	agentFile := filepath.Join(c.MkDir(), "agent.config")
 	err := ioutil.WriteFile(agentFile, []byte(config.AgentConf), 0644)
@@ -118,7 +120,7 @@ func (s *CAASProvisionerSuite) assertOperatorCreated(c *gc.C) {
 			break
 		}
 	}
-	s.provisionerFacade.stub.CheckCallNames(c, "Life", "SetPasswords", "APIAddresses", "OperatorProvisioningInfo")
+	s.provisionerFacade.stub.CheckCallNames(c, "Life", "SetPasswords", "APIAddresses", "OperatorProvisioningInfo", "OperatorReplicas")
 	c.Assert(s.provisionerFacade.stub.Calls()[0].Args[0], gc.Equals, "myapp")
 	passwords := s.provisionerFacade.stub.Calls()[1].Args[0].([]apicaasprovisioner.ApplicationPassword)
 
@@ -134,6 +136,14 @@ func (s *CAASProvisionerSuite) TestNewApplicationCreatesOperator(c *gc.C) {
 	s.assertOperatorCreated(c)
 }
 
+func (s *CAASProvisionerSuite) TestNewApplicationCreatesStandbyOperators(c *gc.C) {
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.provisionerFacade.replicas = 3
+	s.assertOperatorCreated(c)
+}
+
 func (s *CAASProvisionerSuite) TestApplicationDeletedRemovesOperator(c *gc.C) {
 	w := s.assertWorker(c)
 	defer workertest.CleanKill(c, w)
@@ -167,6 +177,48 @@ func (s *CAASProvisionerSuite) TestAddressChanges(c *gc.C) {
 			break
 		}
 	}
-	s.stub.CheckCallNames(c, "APIAddresses", "OperatorProvisioningInfo")
+	s.stub.CheckCallNames(c, "APIAddresses", "OperatorProvisioningInfo", "OperatorReplicas")
 	s.caasClient.CheckCallNames(c, "EnsureOperator")
 }
+
+func (s *CAASProvisionerSuite) TestReplicasChange(c *gc.C) {
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertOperatorCreated(c)
+	s.stub.ResetCalls()
+	s.caasClient.ResetCalls()
+
+	s.provisionerFacade.mu.Lock()
+	s.provisionerFacade.replicas = 3
+	s.provisionerFacade.mu.Unlock()
+	s.provisionerFacade.appConfigWatcher.changes <- struct{}{}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.caasClient.Calls()) > 0 {
+			break
+		}
+	}
+	s.stub.CheckCallNames(c, "OperatorReplicas", "APIAddresses", "OperatorProvisioningInfo", "OperatorReplicas")
+	s.caasClient.CheckCallNames(c, "EnsureOperator")
+	config := s.caasClient.Calls()[0].Args[2].(*caas.OperatorConfig)
+	c.Assert(config.Replicas, gc.Equals, 3)
+}
+
+func (s *CAASProvisionerSuite) TestReplicasUnchanged(c *gc.C) {
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertOperatorCreated(c)
+	s.stub.ResetCalls()
+	s.caasClient.ResetCalls()
+
+	s.provisionerFacade.appConfigWatcher.changes <- struct{}{}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.stub.Calls()) > 0 {
+			break
+		}
+	}
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c, "OperatorReplicas")
+	s.caasClient.CheckNoCalls(c)
+}
@@ -112,6 +112,7 @@ func (aw *applicationWorker) loop() error {
 	// Cache the last reported status information
 	// so we only report true changes.
 	lastReportedStatus := make(map[string]status.StatusInfo)
+	var lastRolloutStatus status.StatusInfo
 
 	for {
 		// The caas watcher can just die from underneath us so recreate if needed.
@@ -207,6 +208,9 @@ func (aw *applicationWorker) loop() error {
 					return errors.Trace(err)
 				}
 			}
+			if lastRolloutStatus, err = aw.updateRolloutStatus(lastRolloutStatus); err != nil {
+				return errors.Trace(err)
+			}
 		case units, ok := <-jujuUnitsWatcher.Changes():
 			if !ok {
 				return errors.New("watcher closed channel")
@@ -226,3 +230,38 @@ func (aw *applicationWorker) loop() error {
 		}
 	}
 }
+
+// updateRolloutStatus reports the progress of any rolling update
+// of the application's pods as the status of its service, if it has
+// changed since last reported.
+// The status which has most recently been reported is returned.
+func (aw *applicationWorker) updateRolloutStatus(last status.StatusInfo) (status.StatusInfo, error) {
+	service, err := aw.serviceBroker.Service(aw.application)
+	if errors.IsNotFound(err) {
+		return last, nil
+	} else if err != nil {
+		return last, errors.Annotate(err, "cannot get service details")
+	}
+	current := service.Status
+	if current.Status == last.Status && current.Message == last.Message {
+		return last, nil
+	}
+	arg := params.UpdateApplicationServiceArg{
+		ApplicationTag: names.NewApplicationTag(aw.application).String(),
+		ProviderId:     service.Id,
+		Addresses:      params.FromNetworkAddresses(service.Addresses...),
+		Status:         current.Status.String(),
+		Info:           current.Message,
+		Data:           current.Data,
+	}
+	if current.Status == "" {
+		// The rolling update has completed, so the service
+		// is fully available again.
+		arg.Status = status.Active.String()
+	}
+	err = aw.applicationUpdater.UpdateApplicationService(arg)
+	if err != nil && !errors.IsNotFound(err) {
+		return last, errors.Trace(err)
+	}
+	return current, nil
+}
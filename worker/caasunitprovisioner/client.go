@@ -30,6 +30,7 @@ type Client interface {
 type ApplicationGetter interface {
 	WatchApplications() (watcher.StringsWatcher, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
 }
 
 // ApplicationUpdater provides an interface for updating
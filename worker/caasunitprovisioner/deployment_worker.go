@@ -4,12 +4,15 @@
 package caasunitprovisioner
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
@@ -68,9 +71,12 @@ func (w *deploymentWorker) loop() error {
 		aliveUnits []string
 		cw         watcher.NotifyWatcher
 		specChan   watcher.NotifyChannel
+		configw    watcher.NotifyWatcher
+		configChan watcher.NotifyChannel
 
 		currentAliveCount int
 		currentSpec       string
+		currentConfig     application.ConfigAttributes
 	)
 
 	gotSpecNotify := false
@@ -88,18 +94,35 @@ func (w *deploymentWorker) loop() error {
 				}
 				w.catacomb.Add(cw)
 				specChan = cw.Changes()
+
+				// Changes to the application config may alter
+				// how the pods are rolled out, so watch that too.
+				configw, err = w.applicationGetter.WatchApplicationConfig(w.application)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				w.catacomb.Add(configw)
+				configChan = configw.Changes()
 			}
 		case _, ok := <-specChan:
 			if !ok {
 				return errors.New("watcher closed channel")
 			}
 			gotSpecNotify = true
+		case _, ok := <-configChan:
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
 		}
 		if len(aliveUnits) == 0 {
 			if cw != nil {
 				worker.Stop(cw)
 				specChan = nil
 			}
+			if configw != nil {
+				worker.Stop(configw)
+				configChan = nil
+			}
 			continue
 		}
 
@@ -116,18 +139,20 @@ func (w *deploymentWorker) loop() error {
 		}
 		specStr := info.PodSpec
 
+		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+
 		numUnits := len(aliveUnits)
-		if numUnits == currentAliveCount && specStr == currentSpec {
+		if numUnits == currentAliveCount && specStr == currentSpec && reflect.DeepEqual(appConfig, currentConfig) {
 			continue
 		}
 
 		currentAliveCount = numUnits
 		currentSpec = specStr
+		currentConfig = appConfig
 
-		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
-		if err != nil {
-			return errors.Trace(err)
-		}
 		spec, err := w.broker.Provider().ParsePodSpec(specStr)
 		if err != nil {
 			return errors.Annotate(err, "cannot parse pod spec")
@@ -122,7 +122,17 @@ func (m *mockContainerBroker) Units(appName string) ([]caas.Unit, error) {
 
 type mockApplicationGetter struct {
 	testing.Stub
-	watcher *watchertest.MockStringsWatcher
+	watcher       *watchertest.MockStringsWatcher
+	configWatcher *watchertest.MockNotifyWatcher
+
+	mu     sync.Mutex
+	config application.ConfigAttributes
+}
+
+func (a *mockApplicationGetter) setConfig(config application.ConfigAttributes) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.config = config
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -133,8 +143,21 @@ func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, err
 	return m.watcher, nil
 }
 
+func (m *mockApplicationGetter) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchApplicationConfig", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.configWatcher, nil
+}
+
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.config != nil {
+		return a.config, a.NextErr()
+	}
 	return application.ConfigAttributes{
 		"juju-external-hostname": "exthost",
 	}, a.NextErr()
@@ -45,6 +45,7 @@ type WorkerSuite struct {
 	jujuUnitChanges      chan []string
 	caasUnitsChanges     chan struct{}
 	containerSpecChanges chan struct{}
+	appConfigChanges     chan struct{}
 	serviceDeleted       chan struct{}
 	serviceEnsured       chan struct{}
 	serviceUpdated       chan struct{}
@@ -99,12 +100,14 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.jujuUnitChanges = make(chan []string)
 	s.caasUnitsChanges = make(chan struct{})
 	s.containerSpecChanges = make(chan struct{}, 1)
+	s.appConfigChanges = make(chan struct{}, 1)
 	s.serviceDeleted = make(chan struct{})
 	s.serviceEnsured = make(chan struct{})
 	s.serviceUpdated = make(chan struct{})
 
 	s.applicationGetter = mockApplicationGetter{
-		watcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
+		watcher:       watchertest.NewMockStringsWatcher(s.applicationChanges),
+		configWatcher: watchertest.NewMockNotifyWatcher(s.appConfigChanges),
 	}
 	s.applicationUpdater = mockApplicationUpdater{
 		updated: s.serviceUpdated,
@@ -254,7 +257,7 @@ func (s *WorkerSuite) TestUnitChanged(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
 
-	s.applicationGetter.CheckCallNames(c, "WatchApplications", "ApplicationConfig")
+	s.applicationGetter.CheckCallNames(c, "WatchApplications", "WatchApplicationConfig", "ApplicationConfig")
 	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "ProvisioningInfo", "ProvisioningInfo")
 	s.podSpecGetter.CheckCall(c, 0, "WatchPodSpec", "gitlab")
 	s.podSpecGetter.CheckCall(c, 1, "ProvisioningInfo", "gitlab") // not found
@@ -360,6 +363,31 @@ containers:
 		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestApplicationConfigChanged(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+	newConfig := application.ConfigAttributes{
+		"juju-external-hostname":   "exthost",
+		"kubernetes-update-paused": true,
+	}
+	s.applicationGetter.setConfig(newConfig)
+	select {
+	case s.appConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application config change")
+	}
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService", "gitlab", expectedServiceParams, 1, newConfig)
+}
+
 func (s *WorkerSuite) TestUnitAllRemoved(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
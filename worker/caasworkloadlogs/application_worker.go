@@ -0,0 +1,136 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// resyncPeriod is how often an application's units are checked, so
+// that units which are assigned a pod after the last change to the
+// application's pods are picked up.
+const resyncPeriod = time.Minute
+
+type applicationWorker struct {
+	catacomb    catacomb.Catacomb
+	application string
+	config      Config
+
+	units map[string]*unitWorker
+}
+
+func newApplicationWorker(application string, config Config) (worker.Worker, error) {
+	w := &applicationWorker{
+		application: application,
+		config:      config,
+		units:       make(map[string]*unitWorker),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *applicationWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *applicationWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *applicationWorker) loop() (err error) {
+	defer func() {
+		// If the application has been deleted, we can return nil.
+		if errors.IsNotFound(err) {
+			logger.Debugf("caas workload logs application %v has been removed", w.application)
+			err = nil
+		}
+	}()
+	configWatcher, err := w.config.ApplicationGetter.WatchApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	podsWatcher, err := w.config.LogStreamer.WatchUnits(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(podsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("application config watcher closed")
+			}
+		case _, ok := <-podsWatcher.Changes():
+			if !ok {
+				return errors.New("pods watcher closed")
+			}
+		case <-w.config.Clock.After(resyncPeriod):
+		}
+		if err := w.updateUnits(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// updateUnits starts following the workload logs of the
+// application's units, and stops following those of units
+// which have gone, or all units if forwarding is disabled.
+func (w *applicationWorker) updateUnits() error {
+	appConfig, err := w.config.ApplicationGetter.ApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var providerIds map[string]string
+	if appConfig.GetBool(caas.JujuWorkloadLogsKey, false) {
+		providerIds, err = w.config.UnitGetter.UnitProviderIds(w.application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for unit, uw := range w.units {
+		if providerIds[unit] == uw.providerId {
+			continue
+		}
+		if err := worker.Stop(uw); err != nil {
+			logger.Errorf("error stopping workload logs of %v: %v", unit, err)
+		}
+		delete(w.units, unit)
+	}
+	for unit, providerId := range providerIds {
+		if _, ok := w.units[unit]; ok {
+			continue
+		}
+		uw, err := newUnitWorker(w.application, unit, providerId, w.config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.units[unit] = uw
+		if err := w.catacomb.Add(uw); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/juju/watcher"
+)
+
+// LogStreamer provides an interface for following the
+// output of the workload containers of an application.
+type LogStreamer interface {
+	WatchUnits(appName string) (watcher.NotifyWatcher, error)
+	WorkloadLogs(appName, unitID string, since time.Time) (map[string]io.ReadCloser, error)
+}
@@ -0,0 +1,41 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/watcher"
+)
+
+// Client provides an interface for interacting with the
+// CAASUnitProvisioner API. Subsets of this should be passed
+// to the CAAS workload logs worker.
+type Client interface {
+	ApplicationGetter
+	LifeGetter
+	UnitGetter
+}
+
+// ApplicationGetter provides an interface for
+// watching for the lifecycle state changes
+// (including addition) of applications in the
+// model, and fetching their config.
+type ApplicationGetter interface {
+	WatchApplications() (watcher.StringsWatcher, error)
+	ApplicationConfig(string) (application.ConfigAttributes, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
+}
+
+// LifeGetter provides an interface for getting the
+// lifecycle state value for an application.
+type LifeGetter interface {
+	Life(string) (life.Value, error)
+}
+
+// UnitGetter provides an interface for getting the
+// provider ids of an application's units.
+type UnitGetter interface {
+	UnitProviderIds(string) (map[string]string, error)
+}
@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/logsender"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/worker/common"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig describes the resources used by the workload logs worker.
+type ManifoldConfig struct {
+	APICallerName string
+	BrokerName    string
+	ClockName     string
+
+	NewClient    func(base.APICaller) Client
+	NewLogWriter func(base.APICaller) (logsender.LogWriter, error)
+	NewWorker    func(Config) (worker.Worker, error)
+}
+
+// Manifold returns a Manifold that encapsulates the workload logs worker.
+func Manifold(cfg ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			cfg.APICallerName,
+			cfg.BrokerName,
+			cfg.ClockName,
+		},
+		Start: cfg.start,
+	}
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.BrokerName == "" {
+		return errors.NotValidf("empty BrokerName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.NewClient == nil {
+		return errors.NotValidf("nil NewClient")
+	}
+	if config.NewLogWriter == nil {
+		return errors.NotValidf("nil NewLogWriter")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var broker caas.Broker
+	if err := context.Get(config.BrokerName, &broker); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	logWriter, err := config.NewLogWriter(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := config.NewClient(apiCaller)
+	w, err := config.NewWorker(Config{
+		ApplicationGetter: client,
+		LifeGetter:        client,
+		UnitGetter:        client,
+		LogStreamer:       broker,
+		LogWriter:         logWriter,
+		Clock:             clock,
+	})
+	if err != nil {
+		logWriter.Close()
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { logWriter.Close() }), nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs_test
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/caasworkloadlogs"
+)
+
+type fakeAPICaller struct {
+	base.APICaller
+}
+
+type fakeBroker struct {
+	caas.Broker
+}
+
+type fakeClient struct {
+	caasworkloadlogs.Client
+}
+
+type mockApplicationGetter struct {
+	testing.Stub
+	allWatcher    *watchertest.MockStringsWatcher
+	configWatcher *watchertest.MockNotifyWatcher
+	config        application.ConfigAttributes
+}
+
+func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
+	m.MethodCall(m, "WatchApplications")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.allWatcher, nil
+}
+
+func (m *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
+	m.MethodCall(m, "ApplicationConfig", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.config, nil
+}
+
+func (m *mockApplicationGetter) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchApplicationConfig", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.configWatcher, nil
+}
+
+type mockLifeGetter struct {
+	testing.Stub
+	life life.Value
+}
+
+func (m *mockLifeGetter) Life(entityName string) (life.Value, error) {
+	m.MethodCall(m, "Life", entityName)
+	if err := m.NextErr(); err != nil {
+		return "", err
+	}
+	return m.life, nil
+}
+
+type mockUnitGetter struct {
+	testing.Stub
+	providerIds map[string]string
+}
+
+func (m *mockUnitGetter) UnitProviderIds(appName string) (map[string]string, error) {
+	m.MethodCall(m, "UnitProviderIds", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.providerIds, nil
+}
+
+type mockLogStreamer struct {
+	testing.Stub
+	podsWatcher *watchertest.MockNotifyWatcher
+	output      map[string]string
+}
+
+func (m *mockLogStreamer) WatchUnits(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchUnits", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.podsWatcher, nil
+}
+
+func (m *mockLogStreamer) WorkloadLogs(appName, unitID string, since time.Time) (map[string]io.ReadCloser, error) {
+	m.MethodCall(m, "WorkloadLogs", appName, unitID, since)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	streams := make(map[string]io.ReadCloser)
+	for container, output := range m.output {
+		streams[container] = ioutil.NopCloser(strings.NewReader(output))
+	}
+	return streams, nil
+}
+
+type mockLogWriter struct {
+	testing.Stub
+	records chan<- params.LogRecord
+}
+
+func (m *mockLogWriter) WriteLog(record *params.LogRecord) error {
+	m.MethodCall(m, "WriteLog", record)
+	m.records <- *record
+	return m.NextErr()
+}
+
+func (m *mockLogWriter) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -0,0 +1,188 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// retryDelay is how long to wait before following a unit's
+// workload logs again after the streams end or fail, as they
+// do when the unit's containers are restarted.
+const retryDelay = 5 * time.Second
+
+// workloadModule is the logging module of forwarded workload output.
+const workloadModule = "workload"
+
+// maxLineLength is the length of the longest line of workload output
+// that can be forwarded. Reading a longer line stops the container's
+// output being forwarded until the streams are reopened.
+const maxLineLength = 1024 * 1024
+
+type unitWorker struct {
+	catacomb    catacomb.Catacomb
+	application string
+	unit        string
+	providerId  string
+	config      Config
+
+	// since is the time from which the output of the containers
+	// is followed, and lastSeen the time of the last line written
+	// by each container, so that output is not repeated when the
+	// streams are reopened.
+	since    time.Time
+	lastSeen map[string]time.Time
+}
+
+func newUnitWorker(application, unit, providerId string, config Config) (*unitWorker, error) {
+	w := &unitWorker{
+		application: application,
+		unit:        unit,
+		providerId:  providerId,
+		config:      config,
+		since:       config.Clock.Now(),
+		lastSeen:    make(map[string]time.Time),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *unitWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *unitWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *unitWorker) loop() error {
+	for {
+		if err := w.follow(); err != nil {
+			logger.Warningf("following workload logs of unit %v: %v", w.unit, err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(retryDelay):
+		}
+	}
+}
+
+// follow forwards the output of the unit's workload containers
+// until the streams end or the worker is stopped.
+func (w *unitWorker) follow() error {
+	streams, err := w.config.LogStreamer.WorkloadLogs(w.application, w.providerId, w.since)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// Closing the streams unblocks any pending reads.
+		select {
+		case <-w.catacomb.Dying():
+		case <-done:
+		}
+		for _, stream := range streams {
+			stream.Close()
+		}
+	}()
+
+	records := make(chan params.LogRecord)
+	var wg sync.WaitGroup
+	for container, stream := range streams {
+		wg.Add(1)
+		go func(container string, stream io.Reader) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), maxLineLength)
+			for scanner.Scan() {
+				record := w.logRecord(container, scanner.Text())
+				select {
+				case records <- record:
+				case <-done:
+					return
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				select {
+				case <-w.catacomb.Dying():
+					// The stream was closed to stop the worker.
+				default:
+					logger.Warningf("reading workload logs of unit %v container %v: %v", w.unit, container, err)
+				}
+			}
+		}(container, stream)
+	}
+	go func() {
+		wg.Wait()
+		close(records)
+	}()
+
+	for record := range records {
+		container := record.Location
+		if !record.Time.After(w.lastSeen[container]) {
+			continue
+		}
+		if err := w.config.LogWriter.WriteLog(&record); err != nil {
+			return errors.Trace(err)
+		}
+		w.lastSeen[container] = record.Time
+	}
+	w.updateSince()
+	return nil
+}
+
+// updateSince sets the time from which to follow the containers'
+// output when the streams are next opened, so as to include any
+// output not yet seen from each of them.
+func (w *unitWorker) updateSince() {
+	var since time.Time
+	for _, t := range w.lastSeen {
+		if since.IsZero() || t.Before(since) {
+			since = t
+		}
+	}
+	if !since.IsZero() {
+		w.since = since
+	}
+}
+
+// logRecord returns the log record for a line of output
+// from the specified container. The line is expected to be
+// prefixed with the time at which it was written.
+func (w *unitWorker) logRecord(container, line string) params.LogRecord {
+	t := w.config.Clock.Now()
+	if parts := strings.SplitN(line, " ", 2); len(parts) == 2 {
+		if parsed, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+			t, line = parsed, parts[1]
+		}
+	}
+	return params.LogRecord{
+		Time:     t,
+		Module:   workloadModule,
+		Location: container,
+		Level:    loggo.INFO.String(),
+		Message:  line,
+		Entity:   names.NewUnitTag(w.unit).String(),
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/logsender"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.workers.caasworkloadlogs")
+
+// Config holds configuration for the CAAS workload logs worker.
+type Config struct {
+	ApplicationGetter ApplicationGetter
+	LifeGetter        LifeGetter
+	UnitGetter        UnitGetter
+	LogStreamer       LogStreamer
+	LogWriter         logsender.LogWriter
+	Clock             clock.Clock
+}
+
+// Validate validates the worker configuration.
+func (config Config) Validate() error {
+	if config.ApplicationGetter == nil {
+		return errors.NotValidf("missing ApplicationGetter")
+	}
+	if config.LifeGetter == nil {
+		return errors.NotValidf("missing LifeGetter")
+	}
+	if config.UnitGetter == nil {
+		return errors.NotValidf("missing UnitGetter")
+	}
+	if config.LogStreamer == nil {
+		return errors.NotValidf("missing LogStreamer")
+	}
+	if config.LogWriter == nil {
+		return errors.NotValidf("missing LogWriter")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
+	return nil
+}
+
+// NewWorker starts and returns a new CAAS workload logs worker,
+// which forwards the output of the workload containers of
+// applications which have juju-workload-logs enabled to the
+// model's log, attributed to the units running them.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The log writer is shared by all the units' workers.
+	config.LogWriter = &lockedLogWriter{LogWriter: config.LogWriter}
+	w := &workloadLogs{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, err
+}
+
+type workloadLogs struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *workloadLogs) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *workloadLogs) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *workloadLogs) loop() error {
+	appWatcher, err := w.config.ApplicationGetter.WatchApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(appWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	appWorkers := make(map[string]worker.Worker)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case apps, ok := <-appWatcher.Changes():
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			for _, appId := range apps {
+				appLife, err := w.config.LifeGetter.Life(appId)
+				if errors.IsNotFound(err) || appLife == life.Dead {
+					if aw, ok := appWorkers[appId]; ok {
+						if err := worker.Stop(aw); err != nil {
+							logger.Errorf("error stopping workload logs of %v: %v", appId, err)
+						}
+						delete(appWorkers, appId)
+					}
+					continue
+				}
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if _, ok := appWorkers[appId]; ok {
+					continue
+				}
+				aw, err := newApplicationWorker(appId, w.config)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				appWorkers[appId] = aw
+				if err := w.catacomb.Add(aw); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+}
+
+// lockedLogWriter serialises writes to a log writer.
+type lockedLogWriter struct {
+	mu sync.Mutex
+	logsender.LogWriter
+}
+
+// WriteLog is part of the logsender.LogWriter interface.
+func (w *lockedLogWriter) WriteLog(m *params.LogRecord) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.LogWriter.WriteLog(m)
+}
@@ -0,0 +1,226 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasworkloadlogs_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/life"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/caasworkloadlogs"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	config            caasworkloadlogs.Config
+	applicationGetter mockApplicationGetter
+	lifeGetter        mockLifeGetter
+	unitGetter        mockUnitGetter
+	logStreamer       mockLogStreamer
+	logWriter         mockLogWriter
+	clock             *testing.Clock
+
+	applicationChanges chan []string
+	configChanges      chan struct{}
+	podsChanges        chan struct{}
+	records            chan params.LogRecord
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.applicationChanges = make(chan []string)
+	s.configChanges = make(chan struct{})
+	s.podsChanges = make(chan struct{})
+	s.records = make(chan params.LogRecord, 10)
+
+	s.applicationGetter = mockApplicationGetter{
+		allWatcher:    watchertest.NewMockStringsWatcher(s.applicationChanges),
+		configWatcher: watchertest.NewMockNotifyWatcher(s.configChanges),
+		config:        application.ConfigAttributes{"juju-workload-logs": true},
+	}
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.allWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.configWatcher) })
+
+	s.lifeGetter = mockLifeGetter{
+		life: life.Alive,
+	}
+	s.unitGetter = mockUnitGetter{
+		providerIds: map[string]string{"gitlab/0": "gitlab-uuid"},
+	}
+	s.logStreamer = mockLogStreamer{
+		podsWatcher: watchertest.NewMockNotifyWatcher(s.podsChanges),
+		output: map[string]string{
+			"gitlab": "2018-06-01T10:20:30.123456789Z listening on :80\n",
+		},
+	}
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.logStreamer.podsWatcher) })
+	s.logWriter = mockLogWriter{
+		records: s.records,
+	}
+	s.clock = testing.NewClock(time.Time{})
+
+	s.config = caasworkloadlogs.Config{
+		ApplicationGetter: &s.applicationGetter,
+		LifeGetter:        &s.lifeGetter,
+		UnitGetter:        &s.unitGetter,
+		LogStreamer:       &s.logStreamer,
+		LogWriter:         &s.logWriter,
+		Clock:             s.clock,
+	}
+}
+
+func (s *WorkerSuite) sendApplicationChange(c *gc.C) {
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+}
+
+func (s *WorkerSuite) sendConfigChange(c *gc.C) {
+	select {
+	case s.configChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application config change")
+	}
+}
+
+func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.ApplicationGetter = nil
+	}, `missing ApplicationGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.LifeGetter = nil
+	}, `missing LifeGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.UnitGetter = nil
+	}, `missing UnitGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.LogStreamer = nil
+	}, `missing LogStreamer not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.LogWriter = nil
+	}, `missing LogWriter not valid`)
+
+	s.testValidateConfig(c, func(config *caasworkloadlogs.Config) {
+		config.Clock = nil
+	}, `missing Clock not valid`)
+}
+
+func (s *WorkerSuite) testValidateConfig(c *gc.C, f func(*caasworkloadlogs.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := caasworkloadlogs.NewWorker(config)
+	if err == nil {
+		workertest.DirtyKill(c, w)
+	}
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestStartStop(c *gc.C) {
+	w, err := caasworkloadlogs.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestForwardsWorkloadLogs(c *gc.C) {
+	w, err := caasworkloadlogs.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendApplicationChange(c)
+	s.sendConfigChange(c)
+
+	select {
+	case record := <-s.records:
+		c.Assert(record, jc.DeepEquals, params.LogRecord{
+			Time:     time.Date(2018, 6, 1, 10, 20, 30, 123456789, time.UTC),
+			Module:   "workload",
+			Location: "gitlab",
+			Level:    "INFO",
+			Message:  "listening on :80",
+			Entity:   "unit-gitlab-0",
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for workload log record")
+	}
+	s.unitGetter.CheckCall(c, 0, "UnitProviderIds", "gitlab")
+	s.logStreamer.CheckCall(c, 1, "WorkloadLogs", "gitlab", "gitlab-uuid", time.Time{})
+}
+
+func (s *WorkerSuite) TestForwardsLongLines(c *gc.C) {
+	long := strings.Repeat("x", 100*1024)
+	s.logStreamer.output = map[string]string{
+		"gitlab": "2018-06-01T10:20:30Z " + long + "\n2018-06-01T10:20:31Z done\n",
+	}
+	w, err := caasworkloadlogs.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendApplicationChange(c)
+	s.sendConfigChange(c)
+
+	for _, expect := range []string{long, "done"} {
+		select {
+		case record := <-s.records:
+			c.Assert(record.Message, gc.Equals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for workload log record")
+		}
+	}
+}
+
+func (s *WorkerSuite) TestForwardingDisabled(c *gc.C) {
+	s.applicationGetter.config = application.ConfigAttributes{}
+	w, err := caasworkloadlogs.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendApplicationChange(c)
+	// The second change is only accepted once the first is handled.
+	s.sendConfigChange(c)
+	s.sendConfigChange(c)
+
+	select {
+	case <-s.records:
+		c.Fatal("workload logs forwarded unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.unitGetter.CheckNoCalls(c)
+	s.logStreamer.CheckCallNames(c, "WatchUnits")
+}
+
+func (s *WorkerSuite) TestWatchApplicationDead(c *gc.C) {
+	w, err := caasworkloadlogs.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.lifeGetter.life = life.Dead
+	s.sendApplicationChange(c)
+
+	select {
+	case s.configChanges <- struct{}{}:
+		c.Fatal("application worker started unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.applicationGetter.CheckCallNames(c, "WatchApplications")
+}
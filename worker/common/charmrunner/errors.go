@@ -5,6 +5,7 @@ package charmrunner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -47,3 +48,21 @@ func IsMissingHookError(err error) bool {
 func NewMissingHookError(hookName string) error {
 	return &missingHookError{hookName}
 }
+
+type hookTimeoutError struct {
+	hookName string
+	timeout  time.Duration
+}
+
+func (e *hookTimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %v", e.hookName, e.timeout)
+}
+
+func IsHookTimeoutError(err error) bool {
+	_, ok := err.(*hookTimeoutError)
+	return ok
+}
+
+func NewHookTimeoutError(hookName string, timeout time.Duration) error {
+	return &hookTimeoutError{hookName, timeout}
+}
@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -45,6 +46,8 @@ type FirewallerAPI interface {
 	MacaroonForRelation(relationKey string) (*macaroon.Macaroon, error)
 	SetRelationStatus(relationKey string, status relation.Status, message string) error
 	FirewallRules(applicationNames ...string) ([]params.FirewallRule, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
 }
 
 // CrossModelFirewallerFacade exposes firewaller functionality on the
@@ -67,6 +70,19 @@ type EnvironFirewaller interface {
 	environs.Firewaller
 }
 
+// EnvironApplicationFirewaller defines methods to allow the worker to
+// manage per-application firewall groups on a Juju cloud environment.
+type EnvironApplicationFirewaller interface {
+	environs.ApplicationFirewaller
+}
+
+// EnvironLoadBalancer defines methods to allow the worker to manage
+// load balancers in front of exposed applications on a Juju cloud
+// environment.
+type EnvironLoadBalancer interface {
+	environs.LoadBalancer
+}
+
 // EnvironInstances defines methods to allow the worker to perform
 // operations on instances in a Juju cloud environment.
 type EnvironInstances interface {
@@ -84,6 +100,12 @@ type Config struct {
 	EnvironFirewaller  EnvironFirewaller
 	EnvironInstances   EnvironInstances
 
+	EnvironApplicationFirewaller EnvironApplicationFirewaller
+
+	// EnvironLoadBalancer is optional. If set, exposed applications
+	// are given a load balancer when the model config asks for them.
+	EnvironLoadBalancer EnvironLoadBalancer
+
 	NewCrossModelFacadeFunc newCrossModelFacadeFunc
 
 	Clock clock.Clock
@@ -105,6 +127,9 @@ func (cfg Config) Validate() error {
 	if cfg.Mode == config.FwGlobal && cfg.EnvironFirewaller == nil {
 		return errors.NotValidf("nil EnvironFirewaller")
 	}
+	if cfg.Mode == config.FwApplication && cfg.EnvironApplicationFirewaller == nil {
+		return errors.NotValidf("nil EnvironApplicationFirewaller")
+	}
 	if cfg.EnvironInstances == nil {
 		return errors.NotValidf("nil EnvironInstances")
 	}
@@ -119,6 +144,11 @@ func (cfg Config) Validate() error {
 
 type portRanges map[network.PortRange]bool
 
+// membershipRetryDelay is how long to wait before trying again to set
+// the application firewall groups of machines that were not yet
+// provisioned.
+const membershipRetryDelay = 10 * time.Second
+
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
 // Uses Firewaller API V1.
@@ -129,6 +159,15 @@ type Firewaller struct {
 	environFirewaller  EnvironFirewaller
 	environInstances   EnvironInstances
 
+	environApplicationFirewaller EnvironApplicationFirewaller
+
+	// environLoadBalancer is nil if the environ does not support
+	// load balancers. Otherwise, the model config is watched for
+	// whether exposed applications should be given one.
+	environLoadBalancer  EnvironLoadBalancer
+	modelConfigWatcher   watcher.NotifyWatcher
+	loadBalancersEnabled bool
+
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
@@ -139,6 +178,15 @@ type Firewaller struct {
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
 
+	// applicationMode is true when firewalling with a group per
+	// application. Machines that could not yet be made members of
+	// their applications' groups are recorded in pendingMembership,
+	// and retried when membershipRetry fires.
+	applicationMode   bool
+	relatedChange     chan *relatedChange
+	pendingMembership map[names.MachineTag]bool
+	membershipRetry   <-chan time.Time
+
 	modelUUID                  string
 	newRemoteFirewallerAPIFunc newCrossModelFacadeFunc
 	remoteRelationsWatcher     watcher.StringsWatcher
@@ -161,20 +209,23 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 	}
 
 	fw := &Firewaller{
-		firewallerApi:              cfg.FirewallerAPI,
-		remoteRelationsApi:         cfg.RemoteRelationsApi,
-		environFirewaller:          cfg.EnvironFirewaller,
-		environInstances:           cfg.EnvironInstances,
-		newRemoteFirewallerAPIFunc: cfg.NewCrossModelFacadeFunc,
-		modelUUID:                  cfg.ModelUUID,
-		machineds:                  make(map[names.MachineTag]*machineData),
-		unitsChange:                make(chan *unitsChange),
-		unitds:                     make(map[names.UnitTag]*unitData),
-		applicationids:             make(map[names.ApplicationTag]*applicationData),
-		exposedChange:              make(chan *exposedChange),
-		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
-		localRelationsChange:       make(chan *remoteRelationNetworkChange),
-		pollClock:                  clk,
+		firewallerApi:                cfg.FirewallerAPI,
+		remoteRelationsApi:           cfg.RemoteRelationsApi,
+		environFirewaller:            cfg.EnvironFirewaller,
+		environInstances:             cfg.EnvironInstances,
+		environApplicationFirewaller: cfg.EnvironApplicationFirewaller,
+		environLoadBalancer:          cfg.EnvironLoadBalancer,
+		newRemoteFirewallerAPIFunc:   cfg.NewCrossModelFacadeFunc,
+		modelUUID:                    cfg.ModelUUID,
+		machineds:                    make(map[names.MachineTag]*machineData),
+		unitsChange:                  make(chan *unitsChange),
+		unitds:                       make(map[names.UnitTag]*unitData),
+		applicationids:               make(map[names.ApplicationTag]*applicationData),
+		exposedChange:                make(chan *exposedChange),
+		relatedChange:                make(chan *relatedChange),
+		relationIngress:              make(map[names.RelationTag]*remoteRelationData),
+		localRelationsChange:         make(chan *remoteRelationNetworkChange),
+		pollClock:                    clk,
 		relationWorkerRunner: worker.NewRunner(worker.RunnerParams{
 			Clock: clk,
 
@@ -193,6 +244,9 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 	case config.FwGlobal:
 		fw.globalMode = true
 		fw.globalIngressRuleRef = make(map[string]int)
+	case config.FwApplication:
+		fw.applicationMode = true
+		fw.pendingMembership = make(map[names.MachineTag]bool)
 	default:
 		return nil, errors.Errorf("invalid firewall-mode %q", cfg.Mode)
 	}
@@ -234,6 +288,24 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	if fw.environLoadBalancer != nil {
+		// Read the model config before any application is started,
+		// so that existing load balancers are not removed and then
+		// recreated when the first config change is seen.
+		modelConfig, err := fw.firewallerApi.ModelConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		fw.loadBalancersEnabled = modelConfig.ExposeLoadBalancers()
+		fw.modelConfigWatcher, err = fw.firewallerApi.WatchForModelConfigChanges()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := fw.catacomb.Add(fw.modelConfigWatcher); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	logger.Debugf("started watching opened port ranges for the model")
 	return nil
 }
@@ -244,6 +316,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var modelConfigChange watcher.NotifyChannel
+	if fw.modelConfigWatcher != nil {
+		modelConfigChange = fw.modelConfigWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -262,6 +338,8 @@ func (fw *Firewaller) loop() error {
 				var err error
 				if fw.globalMode {
 					err = fw.reconcileGlobal()
+				} else if fw.applicationMode {
+					err = fw.reconcileApplications()
 				} else {
 					err = fw.reconcileInstances()
 				}
@@ -291,6 +369,13 @@ func (fw *Firewaller) loop() error {
 					return err
 				}
 			}
+		case _, ok := <-modelConfigChange:
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := fw.modelConfigChanged(); err != nil {
+				return errors.Annotate(err, "cannot change load balancers")
+			}
 		case change := <-fw.localRelationsChange:
 			// We have a notification that the remote (consuming) model
 			// has changed egress networks so need to update the local
@@ -311,6 +396,22 @@ func (fw *Firewaller) loop() error {
 			if err := fw.flushUnits(unitds); err != nil {
 				return errors.Annotate(err, "cannot change firewall ports")
 			}
+		case change := <-fw.relatedChange:
+			change.applicationd.relatedApplications = change.applications
+			if err := fw.flushApplication(change.applicationd); err != nil {
+				return errors.Annotate(err, "cannot change firewall ports")
+			}
+		case <-fw.membershipRetry:
+			fw.membershipRetry = nil
+			pending := fw.pendingMembership
+			fw.pendingMembership = make(map[names.MachineTag]bool)
+			for tag := range pending {
+				if machined, ok := fw.machineds[tag]; ok {
+					if err := fw.flushMembership(machined); err != nil {
+						return errors.Annotate(err, "cannot change application firewall groups")
+					}
+				}
+			}
 		}
 	}
 }
@@ -478,12 +579,25 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 		exposed:     exposed,
 		unitds:      make(map[names.UnitTag]*unitData),
 	}
+	if fw.applicationMode {
+		applicationd.relatedApplications, err = app.RelatedApplications()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The application's group may already have rules from before
+		// the firewaller was restarted; start from those.
+		applicationd.ingressRules, err = fw.environApplicationFirewaller.ApplicationIngressRules(fw.cloudCallContext, app.Name())
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	fw.applicationids[app.Tag()] = applicationd
 
+	related := applicationd.relatedApplications
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, related)
 		},
 	})
 	if err != nil {
@@ -526,6 +640,18 @@ func (fw *Firewaller) reconcileGlobal() error {
 	return nil
 }
 
+// reconcileApplications sets the application firewall groups of all
+// the initially started machines. The ports of each application's group
+// are reconciled when the application is started.
+func (fw *Firewaller) reconcileApplications() error {
+	for _, machined := range fw.machineds {
+		if err := fw.flushMembership(machined); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // reconcileInstances compares the initially started watcher for machines,
 // units and appications with the opened and closed ports of the instances and
 // opens and closes the appropriate ports for each instance.
@@ -674,7 +800,14 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
 		machined.definedPorts = newPortRanges
-		return fw.flushMachine(machined)
+		if err := fw.flushMachine(machined); err != nil {
+			return err
+		}
+		var unitds []*unitData
+		for _, unitd := range machined.unitds {
+			unitds = append(unitds, unitd)
+		}
+		return fw.flushLoadBalancers(unitds)
 	}
 	return nil
 }
@@ -717,16 +850,34 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	for _, unitd := range unitds {
 		machineds[unitd.machined.tag] = unitd.machined
 	}
-	for _, machined := range machineds {
-		if err := fw.flushMachine(machined); err != nil {
-			return err
+	if fw.applicationMode {
+		if err := fw.flushApplications(unitds); err != nil {
+			return errors.Trace(err)
+		}
+		for _, machined := range machineds {
+			if err := fw.flushMembership(machined); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	} else {
+		for _, machined := range machineds {
+			if err := fw.flushMachine(machined); err != nil {
+				return err
+			}
 		}
 	}
-	return nil
+	return fw.flushLoadBalancers(unitds)
 }
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
+	if fw.applicationMode {
+		var unitds []*unitData
+		for _, unitd := range machined.unitds {
+			unitds = append(unitds, unitd)
+		}
+		return fw.flushApplications(unitds)
+	}
 	want, err := fw.gatherIngressRules(machined)
 	if err != nil {
 		return errors.Trace(err)
@@ -777,6 +928,363 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 	return want, nil
 }
 
+// flushApplications opens and closes ports in the firewall groups of the
+// applications of the passed unit data.
+func (fw *Firewaller) flushApplications(unitds []*unitData) error {
+	applicationds := map[names.ApplicationTag]*applicationData{}
+	for _, unitd := range unitds {
+		applicationds[unitd.applicationd.application.Tag()] = unitd.applicationd
+	}
+	for _, applicationd := range applicationds {
+		if err := fw.flushApplication(applicationd); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// flushApplication opens and closes ports in the firewall group of the
+// passed application.
+func (fw *Firewaller) flushApplication(applicationd *applicationData) error {
+	want, err := fw.gatherApplicationIngressRules(applicationd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var wantCIDRs, wantSources []network.IngressRule
+	for _, rule := range want {
+		if len(rule.SourceApplications) > 0 {
+			wantSources = append(wantSources, rule)
+		} else {
+			wantCIDRs = append(wantCIDRs, rule)
+		}
+	}
+	toOpen, toClose := diffRanges(applicationd.ingressRules, wantCIDRs)
+	applicationd.ingressRules = wantCIDRs
+
+	appName := applicationd.application.Name()
+	if !applicationd.sourceRulesSet || !ingressRulesEqual(applicationd.sourceRules, wantSources) {
+		if err := fw.environApplicationFirewaller.SetApplicationSources(fw.cloudCallContext, appName, wantSources); err != nil {
+			return err
+		}
+		applicationd.sourceRules = wantSources
+		applicationd.sourceRulesSet = true
+		logger.Infof("set port ranges %v from related applications for application %q", wantSources, appName)
+	}
+	if len(toOpen) > 0 {
+		if err := fw.environApplicationFirewaller.OpenApplicationPorts(fw.cloudCallContext, appName, toOpen); err != nil {
+			return err
+		}
+		logger.Infof("opened port ranges %v for application %q", toOpen, appName)
+	}
+	if len(toClose) > 0 {
+		if err := fw.environApplicationFirewaller.CloseApplicationPorts(fw.cloudCallContext, appName, toClose); err != nil {
+			return err
+		}
+		logger.Infof("closed port ranges %v for application %q", toClose, appName)
+	}
+	return nil
+}
+
+// gatherApplicationIngressRules returns the ingress rules wanted in the
+// firewall group of the specified application. The rules cover the
+// ports opened by any of the application's units; traffic to them is
+// allowed from everywhere if the application is exposed, and otherwise
+// from the groups of related applications and the networks of remote
+// relations.
+func (fw *Firewaller) gatherApplicationIngressRules(applicationd *applicationData) ([]network.IngressRule, error) {
+	ports := make(portRanges)
+	for unitTag, unitd := range applicationd.unitds {
+		for portRange := range unitd.machined.definedPorts[unitTag] {
+			ports[portRange] = true
+		}
+	}
+	if len(ports) == 0 {
+		return nil, nil
+	}
+
+	cidrs := set.NewStrings()
+	var related []string
+	if applicationd.exposed {
+		cidrs.Add("0.0.0.0/0")
+	} else {
+		if err := fw.updateForRemoteRelationIngress(applicationd.application.Tag(), cidrs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		related = applicationd.relatedApplications
+	}
+
+	var want []network.IngressRule
+	for portRange := range ports {
+		if cidrs.Size() > 0 {
+			rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs.SortedValues()...)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			want = append(want, rule)
+		}
+		if len(related) > 0 {
+			want = append(want, network.NewApplicationIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, related...))
+		}
+	}
+	network.SortIngressRules(want)
+	return want, nil
+}
+
+// flushMembership makes the instance of the passed machine a member of
+// the firewall groups of the applications of its units. If the machine
+// is not yet provisioned, the change is retried later.
+func (fw *Firewaller) flushMembership(machined *machineData) error {
+	appNames := set.NewStrings()
+	for _, unitd := range machined.unitds {
+		appNames.Add(unitd.applicationd.application.Name())
+	}
+	want := appNames.SortedValues()
+	if machined.applicationsSet && stringsEqual(machined.applications, want) {
+		return nil
+	}
+
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		if len(want) == 0 && !machined.applicationsSet {
+			// A new instance starts out in no application groups.
+			return nil
+		}
+		fw.pendingMembership[machined.tag] = true
+		if fw.membershipRetry == nil {
+			fw.membershipRetry = fw.pollClock.After(membershipRetryDelay)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := fw.environApplicationFirewaller.SetInstanceApplications(fw.cloudCallContext, instanceId, want); err != nil {
+		return err
+	}
+	machined.applications = want
+	machined.applicationsSet = true
+	logger.Infof("set application firewall groups of %q to %v", machined.tag, want)
+	return nil
+}
+
+// modelConfigChanged responds to changes to the model config, adding
+// or removing the load balancers of exposed applications if they have
+// been turned on or off.
+func (fw *Firewaller) modelConfigChanged() error {
+	modelConfig, err := fw.firewallerApi.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	enabled := modelConfig.ExposeLoadBalancers()
+	if enabled == fw.loadBalancersEnabled {
+		return nil
+	}
+	fw.loadBalancersEnabled = enabled
+	for _, applicationd := range fw.applicationids {
+		if err := fw.flushLoadBalancer(applicationd); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// flushLoadBalancers adds, updates or removes the load balancers of
+// the applications of the passed unit data.
+func (fw *Firewaller) flushLoadBalancers(unitds []*unitData) error {
+	if fw.environLoadBalancer == nil {
+		return nil
+	}
+	applicationds := map[*applicationData]bool{}
+	for _, unitd := range unitds {
+		applicationds[unitd.applicationd] = true
+	}
+	for applicationd := range applicationds {
+		if err := fw.flushLoadBalancer(applicationd); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// flushLoadBalancer ensures that each exposed endpoint of the passed
+// application has a load balancer forwarding it to the instances of
+// the units that open it, if the application is exposed and load
+// balancers are enabled, and that the application has no other load
+// balancers. The load balancers' addresses are recorded against the
+// application.
+func (fw *Firewaller) flushLoadBalancer(applicationd *applicationData) error {
+	if fw.environLoadBalancer == nil {
+		return nil
+	}
+	want, err := fw.gatherLoadBalancers(applicationd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appName := applicationd.application.Name()
+	if applicationd.loadBalancers == nil {
+		// The load balancers are not known until the first flush,
+		// so start from the ones the addresses were recorded for
+		// to remove any that are no longer wanted.
+		if err := fw.loadRecordedLoadBalancers(applicationd); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	changed := false
+	for endpoint, lb := range applicationd.loadBalancers {
+		if _, ok := want[endpoint]; ok {
+			continue
+		}
+		if err := fw.environLoadBalancer.RemoveLoadBalancer(fw.cloudCallContext, appName, endpoint); err != nil {
+			return err
+		}
+		logger.Infof("removed load balancer for endpoint %v of application %q at %v", endpoint, appName, lb.addresses)
+		delete(applicationd.loadBalancers, endpoint)
+		changed = true
+	}
+	for endpoint, ids := range want {
+		lb, ok := applicationd.loadBalancers[endpoint]
+		if ok && lb.ids != nil && instanceIdsEqual(lb.ids, ids) {
+			continue
+		}
+		addrs, err := fw.environLoadBalancer.EnsureLoadBalancer(fw.cloudCallContext, appName, endpoint, ids)
+		if errors.IsNotSupported(err) {
+			logger.Warningf("cannot add load balancer for endpoint %v of application %q: %v", endpoint, appName, err)
+			continue
+		} else if err != nil {
+			return err
+		}
+		var addresses []string
+		for _, addr := range addrs {
+			addresses = append(addresses, addr.Value)
+		}
+		logger.Infof("load balancer for endpoint %v of application %q at %v forwards to %v", endpoint, appName, addresses, ids)
+		applicationd.loadBalancers[endpoint] = loadBalancerData{ids: ids, addresses: addresses}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	recorded := make(map[string][]string)
+	for endpoint, lb := range applicationd.loadBalancers {
+		recorded[endpoint.String()] = lb.addresses
+	}
+	err = applicationd.application.SetLoadBalancerAddresses(recorded)
+	if err != nil && !params.IsCodeNotFound(err) {
+		return errors.Annotatef(err, "cannot record load balancer addresses of %q", appName)
+	}
+	return nil
+}
+
+// loadRecordedLoadBalancers initialises the load balancers of the
+// passed application from the endpoints whose load balancer addresses
+// were recorded against it. The instances of the load balancers are
+// unknown, so they are updated by the next flush if still wanted.
+func (fw *Firewaller) loadRecordedLoadBalancers(applicationd *applicationData) error {
+	applicationd.loadBalancers = make(map[network.PortRange]loadBalancerData)
+	recorded, err := applicationd.application.LoadBalancerAddresses()
+	if params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot get load balancer addresses of %q", applicationd.application.Name())
+	}
+	for endpoint, addresses := range recorded {
+		portRange, err := network.ParsePortRange(endpoint)
+		if err != nil {
+			logger.Warningf("ignoring load balancer of application %q: %v", applicationd.application.Name(), err)
+			continue
+		}
+		applicationd.loadBalancers[portRange] = loadBalancerData{addresses: addresses}
+	}
+	return nil
+}
+
+// gatherLoadBalancers returns the exposed endpoints of the specified
+// application which should have load balancers, and the instances of
+// the units opening each of them. It is empty if the application
+// should have no load balancers. ICMP cannot be load balanced, so
+// endpoints opening it are left out.
+func (fw *Firewaller) gatherLoadBalancers(applicationd *applicationData) (map[network.PortRange][]instance.Id, error) {
+	want := make(map[network.PortRange][]instance.Id)
+	if !fw.loadBalancersEnabled || !applicationd.exposed {
+		return want, nil
+	}
+	for unitTag, unitd := range applicationd.unitds {
+		unitPorts := unitd.machined.definedPorts[unitTag]
+		if len(unitPorts) == 0 {
+			continue
+		}
+		m, err := unitd.machined.machine()
+		if params.IsCodeNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		instanceId, err := m.InstanceId()
+		if params.IsCodeNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for portRange := range unitPorts {
+			if portRange.Protocol == "icmp" {
+				continue
+			}
+			want[portRange] = append(want[portRange], instanceId)
+		}
+	}
+	for _, ids := range want {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+	return want, nil
+}
+
+func instanceIdsEqual(a, b []instance.Id) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ingressRulesEqual reports whether a and b hold the same rules, which
+// are expected to be sorted.
+func ingressRulesEqual(a, b []network.IngressRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // TODO(wallyworld) - consider making this configurable.
 const maxAllowedCIDRS = 20
 
@@ -956,10 +1464,22 @@ func (fw *Firewaller) machineLifeChanged(tag names.MachineTag) error {
 
 // forgetMachine cleans the machine data after the machine is removed.
 func (fw *Firewaller) forgetMachine(machined *machineData) error {
+	var unitds []*unitData
 	for _, unitd := range machined.unitds {
 		fw.forgetUnit(unitd)
+		unitds = append(unitds, unitd)
+	}
+	if fw.applicationMode {
+		// The machine is going away, so there's no need to change
+		// its groups; but its applications may need ports closed.
+		delete(fw.pendingMembership, machined.tag)
+		if err := fw.flushApplications(unitds); err != nil {
+			return errors.Trace(err)
+		}
+	} else if err := fw.flushMachine(machined); err != nil {
+		return errors.Trace(err)
 	}
-	if err := fw.flushMachine(machined); err != nil {
+	if err := fw.flushLoadBalancers(unitds); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -1027,6 +1547,11 @@ type machineData struct {
 	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
+	// applications holds the names of the applications whose firewall
+	// groups the machine's instance was last made a member of, if
+	// applicationsSet is true.
+	applications    []string
+	applicationsSet bool
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -1080,6 +1605,13 @@ type exposedChange struct {
 	exposed      bool
 }
 
+// relatedChange contains the changed related applications for one
+// specific application.
+type relatedChange struct {
+	applicationd *applicationData
+	applications []string
+}
+
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
 	catacomb    catacomb.Catacomb
@@ -1087,10 +1619,33 @@ type applicationData struct {
 	application *firewaller.Application
 	exposed     bool
 	unitds      map[names.UnitTag]*unitData
+
+	// The remaining fields are only used in the application firewall
+	// mode. They hold the applications related to this one, the rules
+	// from source CIDRs in the application's firewall group, and the
+	// rules from the groups of related applications, which are only
+	// known once they have been set.
+	relatedApplications []string
+	ingressRules        []network.IngressRule
+	sourceRules         []network.IngressRule
+	sourceRulesSet      bool
+
+	// loadBalancers is only used if the environ supports load
+	// balancers. It holds the load balancers of the application's
+	// endpoints, and is nil until they are first flushed.
+	loadBalancers map[network.PortRange]loadBalancerData
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// loadBalancerData holds the instances last given to the load balancer
+// of an application endpoint, and its addresses.
+type loadBalancerData struct {
+	ids       []instance.Id
+	addresses []string
+}
+
+// watchLoop watches the application's exposed flag for changes and, in
+// the application firewall mode, its related applications.
+func (ad *applicationData) watchLoop(exposed bool, related []string) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1115,6 +1670,20 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
+			if ad.fw.applicationMode {
+				change, err := ad.application.RelatedApplications()
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if !stringsEqual(change, related) {
+					related = change
+					select {
+					case <-ad.catacomb.Dying():
+						return ad.catacomb.ErrDying()
+					case ad.fw.relatedChange <- &relatedChange{ad, change}:
+					}
+				}
+			}
 			change, err := ad.application.IsExposed()
 			if err != nil {
 				return errors.Trace(err)
@@ -1503,6 +1503,374 @@ func (s *GlobalModeSuite) TestRestartPortCount(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+type ApplicationModeSuite struct {
+	firewallerBaseSuite
+}
+
+var _ = gc.Suite(&ApplicationModeSuite{})
+
+func (s *ApplicationModeSuite) SetUpTest(c *gc.C) {
+	s.firewallerBaseSuite.setUpTest(c, config.FwApplication)
+}
+
+func (s *ApplicationModeSuite) TearDownTest(c *gc.C) {
+	s.firewallerBaseSuite.JujuConnSuite.TearDownTest(c)
+}
+
+func (s *ApplicationModeSuite) newFirewaller(c *gc.C, clk clock.Clock) worker.Worker {
+	fwEnv, ok := s.Environ.(environs.ApplicationFirewaller)
+	c.Assert(ok, gc.Equals, true)
+
+	cfg := firewaller.Config{
+		ModelUUID:                    s.State.ModelUUID(),
+		Mode:                         config.FwApplication,
+		EnvironApplicationFirewaller: fwEnv,
+		EnvironInstances:             s.Environ,
+		FirewallerAPI:                s.firewaller,
+		RemoteRelationsApi:           s.remoteRelations,
+		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
+			return s.crossmodelFirewaller, nil
+		},
+		Clock:         clk,
+		CredentialAPI: s.credentialsFacade,
+	}
+	fw, err := firewaller.NewFirewaller(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return fw
+}
+
+// assertApplicationPorts retrieves the ingress rules from source CIDRs of
+// the application's firewall group and compares them to the expected.
+func (s *ApplicationModeSuite) assertApplicationPorts(c *gc.C, appName string, expected []network.IngressRule) {
+	fwEnv, ok := s.Environ.(environs.ApplicationFirewaller)
+	c.Assert(ok, gc.Equals, true)
+	s.assertRules(c, expected, func() ([]network.IngressRule, error) {
+		return fwEnv.ApplicationIngressRules(s.callCtx, appName)
+	})
+}
+
+// assertApplicationSources retrieves the ingress rules from the groups of
+// other applications of the application's firewall group and compares
+// them to the expected.
+func (s *ApplicationModeSuite) assertApplicationSources(c *gc.C, appName string, expected []network.IngressRule) {
+	s.assertRules(c, expected, func() ([]network.IngressRule, error) {
+		return dummy.ApplicationSources(s.Environ, appName), nil
+	})
+}
+
+func (s *ApplicationModeSuite) assertRules(c *gc.C, expected []network.IngressRule, getRules func() ([]network.IngressRule, error)) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := getRules()
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortIngressRules(got)
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// assertInstanceApplications checks that the instance becomes a member of
+// the firewall groups of the expected applications.
+func (s *ApplicationModeSuite) assertInstanceApplications(c *gc.C, inst instance.Instance, expected []string) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got := dummy.InstanceApplications(inst)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+func (s *ApplicationModeSuite) TestStartStop(c *gc.C) {
+	fw := s.newFirewaller(c, nil)
+	statetesting.AssertKillAndWait(c, fw)
+}
+
+func (s *ApplicationModeSuite) TestApplicationMode(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	u1, m1 := s.addUnit(c, wordpress)
+	inst1 := s.startInstance(c, m1)
+	u2, m2 := s.addUnit(c, mysql)
+	inst2 := s.startInstance(c, m2)
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c, nil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	s.assertInstanceApplications(c, inst1, []string{"wordpress"})
+	s.assertInstanceApplications(c, inst2, []string{"mysql"})
+
+	// Ports of unexposed applications are opened to the groups of
+	// related applications.
+	err = u2.OpenPort("tcp", 3306)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationSources(c, "mysql", []network.IngressRule{
+		network.NewApplicationIngressRule("tcp", 3306, 3306, "wordpress"),
+	})
+	s.assertApplicationPorts(c, "mysql", nil)
+
+	// Relations allow traffic between the groups in both directions.
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationSources(c, "wordpress", []network.IngressRule{
+		network.NewApplicationIngressRule("tcp", 80, 80, "mysql"),
+	})
+
+	// Exposed applications are open to everyone.
+	err = mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, "mysql", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306, "0.0.0.0/0"),
+	})
+	s.assertApplicationSources(c, "mysql", nil)
+	err = mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, "mysql", nil)
+	s.assertApplicationSources(c, "mysql", []network.IngressRule{
+		network.NewApplicationIngressRule("tcp", 3306, 3306, "wordpress"),
+	})
+
+	// Removing the relation closes the ports to the related group.
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationSources(c, "mysql", nil)
+	s.assertApplicationSources(c, "wordpress", nil)
+}
+
+func (s *ApplicationModeSuite) TestMembershipRetriedUntilProvisioned(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	_, m := s.addUnit(c, app)
+
+	clk := testing.NewClock(time.Now())
+	fw := s.newFirewaller(c, clk)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	// Wait for the retry to be scheduled before provisioning the machine.
+	err := clk.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	inst := s.startInstance(c, m)
+	clk.Advance(10 * time.Second)
+
+	s.assertInstanceApplications(c, inst, []string{"wordpress"})
+}
+
+type LoadBalancerSuite struct {
+	firewallerBaseSuite
+}
+
+var _ = gc.Suite(&LoadBalancerSuite{})
+
+func (s *LoadBalancerSuite) SetUpTest(c *gc.C) {
+	s.firewallerBaseSuite.setUpTest(c, config.FwInstance)
+	s.setExposeLoadBalancers(c, true)
+}
+
+func (s *LoadBalancerSuite) TearDownTest(c *gc.C) {
+	s.firewallerBaseSuite.JujuConnSuite.TearDownTest(c)
+}
+
+func (s *LoadBalancerSuite) setExposeLoadBalancers(c *gc.C, enabled bool) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		config.ExposeLoadBalancers: enabled,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LoadBalancerSuite) newFirewaller(c *gc.C) worker.Worker {
+	fwEnv, ok := s.Environ.(environs.Firewaller)
+	c.Assert(ok, gc.Equals, true)
+	lbEnv, ok := s.Environ.(environs.LoadBalancer)
+	c.Assert(ok, gc.Equals, true)
+
+	cfg := firewaller.Config{
+		ModelUUID:           s.State.ModelUUID(),
+		Mode:                config.FwInstance,
+		EnvironFirewaller:   fwEnv,
+		EnvironInstances:    s.Environ,
+		EnvironLoadBalancer: lbEnv,
+		FirewallerAPI:       s.firewaller,
+		RemoteRelationsApi:  s.remoteRelations,
+		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
+			return s.crossmodelFirewaller, nil
+		},
+		Clock:         &mockClock{c: c},
+		CredentialAPI: s.credentialsFacade,
+	}
+	fw, err := firewaller.NewFirewaller(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return fw
+}
+
+// assertLoadBalancers checks that the application comes to have load
+// balancers for exactly the expected endpoints, forwarding to the
+// expected instances, and that the application's load balancer
+// addresses are recorded for those endpoints.
+func (s *LoadBalancerSuite) assertLoadBalancers(
+	c *gc.C,
+	app *state.Application,
+	expected map[network.PortRange][]instance.Id,
+) {
+	if expected == nil {
+		expected = make(map[network.PortRange][]instance.Id)
+	}
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		lbs := dummy.LoadBalancers(s.Environ, app.Name())
+		err := app.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		addresses := app.LoadBalancerAddresses()
+		if reflect.DeepEqual(lbs, expected) && len(addresses) == len(expected) {
+			recorded := true
+			for endpoint := range expected {
+				if len(addresses[endpoint.String()]) == 0 {
+					recorded = false
+				}
+			}
+			if recorded {
+				return
+			}
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v (addresses %v)", expected, lbs, addresses)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+func (s *LoadBalancerSuite) TestExposedApplication(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, app)
+	inst1 := s.startInstance(c, m1)
+	u2, m2 := s.addUnit(c, app)
+	inst2 := s.startInstance(c, m2)
+
+	err = u1.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLoadBalancers(c, app, map[network.PortRange][]instance.Id{
+		{Protocol: "tcp", FromPort: 80, ToPort: 90}: {inst1.Id()},
+	})
+
+	// Each endpoint has its own load balancer, forwarding to the
+	// units that open it.
+	err = u2.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLoadBalancers(c, app, map[network.PortRange][]instance.Id{
+		{Protocol: "tcp", FromPort: 80, ToPort: 90}:     {inst1.Id(), inst2.Id()},
+		{Protocol: "tcp", FromPort: 8080, ToPort: 8080}: {inst2.Id()},
+	})
+
+	// Units are removed as they go away.
+	err = u1.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLoadBalancers(c, app, map[network.PortRange][]instance.Id{
+		{Protocol: "tcp", FromPort: 80, ToPort: 90}:     {inst2.Id()},
+		{Protocol: "tcp", FromPort: 8080, ToPort: 8080}: {inst2.Id()},
+	})
+
+	// Endpoints no unit opens lose their load balancers.
+	err = u2.ClosePort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLoadBalancers(c, app, map[network.PortRange][]instance.Id{
+		{Protocol: "tcp", FromPort: 80, ToPort: 90}: {inst2.Id()},
+	})
+
+	// Unexposing the application removes its load balancers.
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLoadBalancers(c, app, nil)
+}
+
+func (s *LoadBalancerSuite) TestExposeLoadBalancersConfigChange(c *gc.C) {
+	s.setExposeLoadBalancers(c, false)
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	// The ports are opened without a load balancer.
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+	s.assertLoadBalancers(c, app, nil)
+
+	s.setExposeLoadBalancers(c, true)
+	s.assertLoadBalancers(c, app, map[network.PortRange][]instance.Id{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}: {inst.Id()},
+	})
+
+	s.setExposeLoadBalancers(c, false)
+	s.assertLoadBalancers(c, app, nil)
+}
+
+func (s *LoadBalancerSuite) TestRestartRemovesStaleLoadBalancers(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	s.assertLoadBalancers(c, app, map[network.PortRange][]instance.Id{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}:   {inst.Id()},
+		{Protocol: "tcp", FromPort: 443, ToPort: 443}: {inst.Id()},
+	})
+	statetesting.AssertKillAndWait(c, fw)
+
+	// An endpoint closed while the firewaller is down loses its
+	// load balancer once the firewaller is back.
+	err = u.ClosePort("tcp", 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw = s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+	s.assertLoadBalancers(c, app, map[network.PortRange][]instance.Id{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}: {inst.Id()},
+	})
+}
+
 type NoneModeSuite struct {
 	firewallerBaseSuite
 }
@@ -92,10 +92,15 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		return nil, errors.Trace(err)
 	}
 
-	// Check if the env supports global firewalling.  If the
-	// configured mode is instance, we can ignore fwEnv being a
-	// nil value, as it won't be used.
+	// Check if the env supports global or application firewalling.
+	// If the configured mode doesn't need them, we can ignore fwEnv
+	// or appFwEnv being a nil value, as they won't be used.
 	fwEnv, fwEnvOK := environ.(environs.Firewaller)
+	appFwEnv, appFwEnvOK := environ.(environs.ApplicationFirewaller)
+
+	// Load balancers are optional, and only used if the model
+	// config asks for them.
+	lbEnv, _ := environ.(environs.LoadBalancer)
 
 	mode := environ.Config().FirewallMode()
 	if mode == config.FwNone {
@@ -106,6 +111,11 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 			logger.Infof("Firewall global mode set on provider with no support. stopping firewaller")
 			return nil, dependency.ErrUninstall
 		}
+	} else if mode == config.FwApplication {
+		if !appFwEnvOK {
+			logger.Infof("Firewall application mode set on provider with no support. stopping firewaller")
+			return nil, dependency.ErrUninstall
+		}
 	}
 
 	firewallerAPI, err := cfg.NewFirewallerFacade(apiConn)
@@ -123,14 +133,16 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 	}
 
 	w, err := cfg.NewFirewallerWorker(Config{
-		ModelUUID:          agent.CurrentConfig().Model().Id(),
-		RemoteRelationsApi: remoteRelationsAPI,
-		FirewallerAPI:      firewallerAPI,
-		EnvironFirewaller:  fwEnv,
-		EnvironInstances:   environ,
-		Mode:               mode,
-		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
-		CredentialAPI:           credentialAPI,
+		ModelUUID:                    agent.CurrentConfig().Model().Id(),
+		RemoteRelationsApi:           remoteRelationsAPI,
+		FirewallerAPI:                firewallerAPI,
+		EnvironFirewaller:            fwEnv,
+		EnvironInstances:             environ,
+		Mode:                         mode,
+		EnvironApplicationFirewaller: appFwEnv,
+		EnvironLoadBalancer:          lbEnv,
+		NewCrossModelFacadeFunc:      crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
+		CredentialAPI:                credentialAPI,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -41,6 +41,20 @@ func (s *ManifoldSuite) TestManifoldFirewallModeNone(c *gc.C) {
 	c.Assert(err, gc.Equals, dependency.ErrUninstall)
 }
 
+func (s *ManifoldSuite) TestManifoldFirewallModeApplicationNotSupported(c *gc.C) {
+	ctx := &mockDependencyContext{
+		env: &mockEnviron{
+			config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+				"firewall-mode": config.FwApplication,
+			}),
+		},
+	}
+
+	manifold := firewaller.Manifold(validConfig())
+	_, err := manifold.Start(ctx)
+	c.Assert(err, gc.Equals, dependency.ErrUninstall)
+}
+
 type mockDependencyContext struct {
 	dependency.Context
 	env *mockEnviron
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/clock"
@@ -18,6 +19,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -81,6 +83,79 @@ func (s *machineSuite) TestSetsInstanceInfoDeadMachineInitially(c *gc.C) {
 	c.Assert(m.instStatusInfo, gc.Equals, "deleting")
 }
 
+func (s *machineSuite) TestSetsTerminatedWhenRunningInstanceGone(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: instanceInfoGetter(c, "i1234", nil, "", errors.NotFoundf("instance i1234")),
+		dyingc:          make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		instStatus: status.Running,
+		refresh:    func() error { return nil },
+		life:       params.Alive,
+	}
+	died := make(chan machine)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, died, clock)
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.setAddressCount, gc.Equals, 0)
+	c.Assert(m.instStatus, gc.Equals, status.Terminated)
+	c.Assert(m.instStatusInfo, gc.Equals, "instance terminated by the provider")
+}
+
+func (s *machineSuite) TestIgnoresRunningInstanceWhenNoInstances(c *gc.C) {
+	// No instances at all being returned may be a provider failure,
+	// so doesn't mean the instance has gone.
+	context := &testMachineContext{
+		getInstanceInfo: instanceInfoGetter(c, "i1234", nil, "", environs.ErrNoInstances),
+		dyingc:          make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		instStatus: status.Running,
+		refresh:    func() error { return nil },
+		life:       params.Alive,
+	}
+	died := make(chan machine)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, died, clock)
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.instStatus, gc.Equals, status.Running)
+}
+
+func (s *machineSuite) TestIgnoresMissingPendingInstance(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: instanceInfoGetter(c, "i1234", nil, "", errors.NotFoundf("instance i1234")),
+		dyingc:          make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		instStatus: status.Pending,
+		refresh:    func() error { return nil },
+		life:       params.Alive,
+	}
+	died := make(chan machine)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, died, clock)
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.instStatus, gc.Equals, status.Pending)
+}
+
 func (s *machineSuite) TestShortPollIntervalWhenNoAddress(c *gc.C) {
 	s.testShortPoll(c, nil, "i1234", "running", status.Started)
 }
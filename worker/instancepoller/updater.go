@@ -264,6 +264,9 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 		if params.IsCodeNotImplemented(err) {
 			return instanceInfo{}, err
 		}
+		if isInstanceGone(err) {
+			return instanceGone(m, instId, err)
+		}
 		logger.Warningf("cannot get instance info for instance %q: %v", instId, err)
 		return instInfo, nil
 	}
@@ -303,6 +306,53 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 	return instInfo, nil
 }
 
+// isInstanceGone reports whether the given error, returned when asking
+// the provider about an instance, means the provider no longer knows
+// about the instance. That is only the case when the provider returned
+// other instances asked for at the same time; environs.ErrNoInstances
+// applies to the whole batch, and may just mean the provider failed to
+// report any instances.
+func isInstanceGone(err error) bool {
+	return errors.IsNotFound(err)
+}
+
+// instanceGone handles an instance which the provider no longer
+// reports. If the instance was running and its machine is alive, the
+// provider must have terminated it (spot instances being reclaimed,
+// for example), so its status is set to terminated; the controller
+// then marks the machine down. Instances which have not been seen
+// running yet may just not be visible yet, so are left alone.
+func instanceGone(m machine, instId instance.Id, err error) (instanceInfo, error) {
+	terminated := instanceInfo{
+		status: instance.InstanceStatus{
+			Status:  status.Terminated,
+			Message: "instance terminated by the provider",
+		},
+	}
+	instStat, statusErr := m.InstanceStatus()
+	if statusErr != nil {
+		logger.Warningf("cannot get current instance status for machine %v: %v", m.Id(), statusErr)
+		return instanceInfo{}, nil
+	}
+	switch status.Status(instStat.Status) {
+	case status.Terminated:
+		return terminated, nil
+	case status.Running:
+	default:
+		logger.Warningf("cannot get instance info for instance %q: %v", instId, err)
+		return instanceInfo{}, nil
+	}
+	if m.Life() != params.Alive {
+		return instanceInfo{}, nil
+	}
+	logger.Warningf("machine %q instance %q has been terminated by the provider", m.Id(), instId)
+	if err := m.SetInstanceStatus(terminated.status.Status, terminated.status.Message, nil); err != nil {
+		logger.Errorf("cannot set instance status on %q: %v", m, err)
+		return instanceInfo{}, err
+	}
+	return terminated, nil
+}
+
 // addressesEqual compares the addresses of the machine and the instance information.
 func addressesEqual(a0, a1 []network.Address) bool {
 	if len(a0) != len(a1) {
@@ -65,6 +65,9 @@ func (ctx *limitedContext) UnitName() string {
 // SetProcess implements runner.Context.
 func (ctx *limitedContext) SetProcess(process context.HookProcess) {}
 
+// HookTimeout implements runner.Context.
+func (ctx *limitedContext) HookTimeout() time.Duration { return 0 }
+
 // ActionData implements runner.Context.
 func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
@@ -77,6 +77,9 @@ func (ctx *hookContext) addJujuUnitsMetric() error {
 // SetProcess implements runner.Context.
 func (ctx *hookContext) SetProcess(process context.HookProcess) {}
 
+// HookTimeout implements runner.Context.
+func (ctx *hookContext) HookTimeout() time.Duration { return 0 }
+
 // ActionData implements runner.Context.
 func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
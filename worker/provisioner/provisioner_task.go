@@ -999,8 +999,8 @@ func (task *provisionerTask) setupToStartMachine(machine *apiprovisioner.Machine
 }
 
 // populateExcludedMachines, translates the results of DeriveAvailabilityZones
-// into availabilityZoneMachines.ExcludedMachineIds for machines not to be used
-// in the given zone.
+// and the zones constraint into availabilityZoneMachines.ExcludedMachineIds
+// for machines not to be used in the given zone.
 func (task *provisionerTask) populateExcludedMachines(machineId string, startInstanceParams environs.StartInstanceParams) error {
 	zonedEnv, ok := task.broker.(providercommon.ZonedEnviron)
 	if !ok {
@@ -1010,14 +1010,22 @@ func (task *provisionerTask) populateExcludedMachines(machineId string, startIns
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if len(derivedZones) == 0 {
+	cons := startInstanceParams.Constraints
+	if len(derivedZones) == 0 && !cons.HasZones() {
 		return nil
 	}
 	task.machinesMutex.Lock()
 	defer task.machinesMutex.Unlock()
 	useZones := set.NewStrings(derivedZones...)
+	var constraintZones set.Strings
+	if cons.HasZones() {
+		constraintZones = set.NewStrings(*cons.Zones...)
+	}
 	for _, zoneMachines := range task.availabilityZoneMachines {
-		if !useZones.Contains(zoneMachines.ZoneName) {
+		if len(derivedZones) > 0 && !useZones.Contains(zoneMachines.ZoneName) {
+			zoneMachines.ExcludedMachineIds.Add(machineId)
+		}
+		if cons.HasZones() && !constraintZones.Contains(zoneMachines.ZoneName) {
 			zoneMachines.ExcludedMachineIds.Add(machineId)
 		}
 	}
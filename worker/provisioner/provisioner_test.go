@@ -1770,6 +1770,30 @@ func (s *ProvisionerSuite) TestAvailabilityZoneMachinesStartMachinesAZFailuresWi
 	c.Assert(checkAvailabilityZoneMachinesDistributionGroups(c, dgFinder.groups, availabilityZoneMachines), jc.ErrorIsNil)
 }
 
+func (s *ProvisionerSuite) TestAvailabilityZoneMachinesStartMachinesZonesConstraint(c *gc.C) {
+	// Per provider dummy, there will be 3 available availability zones,
+	// only two of which are allowed by the zones constraint.
+	task := s.newProvisionerTask(c, config.HarvestDestroyed, s.Environ, s.provisioner, &mockDistributionGroupFinder{}, mockToolsFinder{})
+	defer workertest.CleanKill(c, task)
+
+	cons := constraints.MustParse(s.defaultConstraints.String(), "zones=zone1,zone4")
+	machines := make([]*state.Machine, 4)
+	for i := range machines {
+		m, err := s.addMachineWithConstraints(cons)
+		c.Assert(err, jc.ErrorIsNil)
+		machines[i] = m
+	}
+	s.checkStartInstancesCustom(c, machines, "pork", cons, nil, nil, nil, nil, nil, true)
+
+	for _, m := range machines {
+		zone, err := m.AvailabilityZone()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(zone, gc.Matches, "zone1|zone4")
+	}
+	availabilityZoneMachines := provisioner.GetCopyAvailabilityZoneMachines(task)
+	assertAvailabilityZoneMachines(c, machines, nil, availabilityZoneMachines)
+}
+
 func (s *ProvisionerSuite) TestProvisioningMachinesSingleMachineDGFailure(c *gc.C) {
 	// If a single machine fails getting the distribution group,
 	// ensure the other machines are still provisioned.
@@ -0,0 +1,194 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/common"
+)
+
+var logger = loggo.GetLogger("juju.worker.resourcetagger")
+
+// Facade defines the interface we require from the resource tagger
+// facade.
+type Facade interface {
+	WatchResourceTags() (watcher.NotifyWatcher, error)
+	ResourceTags() (*resourcetagger.ResourceTags, error)
+}
+
+// Tagger is responsible for updating the tags of the model's existing
+// provider resources when the model or application resource tags
+// change. Tags are only ever added or updated; tags that are no
+// longer specified are left in place. Resources are only tagged when
+// their tags differ from those the Tagger last applied to them.
+//
+// Only instances, volumes and shared resources whose provider
+// implements environs.InstanceTagger, storage.VolumeTagger or
+// environs.ModelResourceTagger are updated; currently that means EC2
+// instances, security groups and EBS volumes, and OpenStack instances
+// and Cinder volumes. Filesystems, and resources on other providers,
+// get the tags in effect when they are created, and are not updated
+// afterwards.
+type Tagger struct {
+	API         Facade
+	Environ     environs.Environ
+	CallContext context.ProviderCallContext
+
+	modelTags    map[string]string
+	instanceTags map[instance.Id]map[string]string
+	volumeTags   map[string]map[string]string
+}
+
+// NewWorker returns a resource tagger worker that will watch for
+// changes to the model and application resource tags, and update the
+// tags of the model's instances, volumes and shared resources on
+// providers that support it.
+func NewWorker(api Facade, env environs.Environ, credentialAPI common.CredentialAPI) (worker.Worker, error) {
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &Tagger{
+			API:         api,
+			Environ:     env,
+			CallContext: common.NewCloudCallContext(credentialAPI),
+		},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// SetUp (part of watcher.NotifyHandler) starts watching for changes
+// to the resource tags.
+func (t *Tagger) SetUp() (watcher.NotifyWatcher, error) {
+	return t.API.WatchResourceTags()
+}
+
+// Handle (part of watcher.NotifyHandler) updates the tags of the
+// model's provider resources. Failing to tag an individual instance
+// or volume is logged, but does not stop the others being tagged.
+func (t *Tagger) Handle(<-chan struct{}) error {
+	resourceTags, err := t.API.ResourceTags()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := t.tagModelResources(resourceTags.ModelTags); err != nil {
+		return errors.Annotate(err, "tagging model resources")
+	}
+	t.tagInstances(resourceTags.Instances)
+	t.tagVolumes(resourceTags.Volumes)
+	return nil
+}
+
+func (t *Tagger) tagModelResources(tags map[string]string) error {
+	tagger, ok := t.Environ.(environs.ModelResourceTagger)
+	if !ok || len(tags) == 0 || reflect.DeepEqual(tags, t.modelTags) {
+		return nil
+	}
+	if err := tagger.TagModelResources(t.CallContext, tags); err != nil {
+		return errors.Trace(err)
+	}
+	t.modelTags = tags
+	return nil
+}
+
+func (t *Tagger) tagInstances(instanceTags map[instance.Id]map[string]string) {
+	tagger, ok := t.Environ.(environs.InstanceTagger)
+	if !ok {
+		logger.Debugf("provider does not support tagging instances")
+		return
+	}
+	ids := make([]string, 0, len(instanceTags))
+	for id := range instanceTags {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		tags := instanceTags[instance.Id(id)]
+		if len(tags) == 0 || reflect.DeepEqual(tags, t.instanceTags[instance.Id(id)]) {
+			continue
+		}
+		if err := tagger.TagInstance(t.CallContext, instance.Id(id), tags); err != nil {
+			logger.Errorf("couldn't tag instance %q: %v", id, err)
+			continue
+		}
+		if t.instanceTags == nil {
+			t.instanceTags = make(map[instance.Id]map[string]string)
+		}
+		t.instanceTags[instance.Id(id)] = tags
+	}
+}
+
+func (t *Tagger) tagVolumes(volumes []resourcetagger.VolumeTags) {
+	taggers := make(map[storage.ProviderType]storage.VolumeTagger)
+	for _, v := range volumes {
+		if len(v.Tags) == 0 || reflect.DeepEqual(v.Tags, t.volumeTags[v.VolumeId]) {
+			continue
+		}
+		tagger, ok := taggers[v.Provider]
+		if !ok {
+			var err error
+			tagger, err = t.volumeTagger(v.Provider)
+			if err != nil {
+				logger.Errorf("couldn't get volume source for storage provider %q: %v", v.Provider, err)
+			}
+			taggers[v.Provider] = tagger
+		}
+		if tagger == nil {
+			continue
+		}
+		if err := tagger.TagVolume(t.CallContext, v.VolumeId, v.Tags); err != nil {
+			logger.Errorf("couldn't tag volume %q: %v", v.VolumeId, err)
+			continue
+		}
+		if t.volumeTags == nil {
+			t.volumeTags = make(map[string]map[string]string)
+		}
+		t.volumeTags[v.VolumeId] = v.Tags
+	}
+}
+
+// volumeTagger returns the volume source for the given storage provider
+// type if it is managed by the environ and supports tagging volumes,
+// and nil otherwise.
+func (t *Tagger) volumeTagger(providerType storage.ProviderType) (storage.VolumeTagger, error) {
+	provider, err := t.Environ.StorageProvider(providerType)
+	if errors.IsNotFound(err) {
+		// The volumes are not managed by the environ.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !provider.Dynamic() || !provider.Supports(storage.StorageKindBlock) {
+		return nil, nil
+	}
+	cfg, err := storage.NewConfig(string(providerType), providerType, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tagger, _ := source.(storage.VolumeTagger)
+	return tagger, nil
+}
+
+// TearDown (part of watcher.NotifyHandler) is an opportunity to stop
+// or release any resources created in SetUp other than the watcher,
+// which watcher.NotifyWorker takes care of for us.
+func (t *Tagger) TearDown() error {
+	return nil
+}
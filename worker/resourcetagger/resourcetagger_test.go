@@ -0,0 +1,230 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiresourcetagger "github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/resourcetagger"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type taggerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&taggerSuite{})
+
+func (s *taggerSuite) TestErrorWatching(c *gc.C) {
+	api := &fakeAPI{Stub: &testing.Stub{}}
+	api.SetErrors(errors.New("blam"))
+	w, err := resourcetagger.NewWorker(api, &fakeEnviron{}, &fakeCredentialAPI{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blam")
+	api.CheckCallNames(c, "WatchResourceTags")
+}
+
+func (s *taggerSuite) TestErrorGettingResourceTags(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	api := &fakeAPI{
+		Stub:    &testing.Stub{},
+		watcher: watchertest.NewMockNotifyWatcher(changes),
+	}
+	api.SetErrors(nil, errors.New("explodo"))
+	w, err := resourcetagger.NewWorker(api, &fakeEnviron{}, &fakeCredentialAPI{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "explodo")
+	api.CheckCallNames(c, "WatchResourceTags", "ResourceTags")
+}
+
+func (s *taggerSuite) TestHandle(c *gc.C) {
+	api := &fakeAPI{
+		Stub: &testing.Stub{},
+		resourceTags: &apiresourcetagger.ResourceTags{
+			ModelTags: map[string]string{"team": "ops"},
+			Instances: map[instance.Id]map[string]string{
+				"inst-1": {"team": "dba"},
+				"inst-0": {"team": "ops"},
+				"inst-2": {},
+			},
+			Volumes: []apiresourcetagger.VolumeTags{
+				{VolumeId: "vol-0", Provider: "ebs", Tags: map[string]string{"team": "dba"}},
+				{VolumeId: "vol-1", Provider: "loop", Tags: map[string]string{"team": "dba"}},
+				{VolumeId: "vol-2", Provider: "ebs", Tags: map[string]string{"team": "ops"}},
+			},
+		},
+	}
+	env := &fakeEnviron{Stub: &testing.Stub{}}
+	// Failing to tag one instance does not stop the others being tagged.
+	env.SetErrors(nil, errors.New("instance gone"))
+	tagger := resourcetagger.Tagger{API: api, Environ: env, CallContext: context.NewCloudCallContext()}
+	err := tagger.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	env.CheckCalls(c, []testing.StubCall{
+		{"TagModelResources", []interface{}{map[string]string{"team": "ops"}}},
+		{"TagInstance", []interface{}{instance.Id("inst-0"), map[string]string{"team": "ops"}}},
+		{"TagInstance", []interface{}{instance.Id("inst-1"), map[string]string{"team": "dba"}}},
+		{"StorageProvider", []interface{}{storage.ProviderType("ebs")}},
+		{"TagVolume", []interface{}{"vol-0", map[string]string{"team": "dba"}}},
+		{"StorageProvider", []interface{}{storage.ProviderType("loop")}},
+		{"TagVolume", []interface{}{"vol-2", map[string]string{"team": "ops"}}},
+	})
+}
+
+func (s *taggerSuite) TestHandleOnlyChangedTags(c *gc.C) {
+	api := &fakeAPI{
+		Stub: &testing.Stub{},
+		resourceTags: &apiresourcetagger.ResourceTags{
+			ModelTags: map[string]string{"team": "ops"},
+			Instances: map[instance.Id]map[string]string{
+				"inst-0": {"team": "ops"},
+				"inst-1": {"team": "dba"},
+			},
+			Volumes: []apiresourcetagger.VolumeTags{
+				{VolumeId: "vol-0", Provider: "ebs", Tags: map[string]string{"team": "dba"}},
+			},
+		},
+	}
+	env := &fakeEnviron{Stub: &testing.Stub{}}
+	env.SetErrors(nil, errors.New("instance gone"))
+	tagger := resourcetagger.Tagger{API: api, Environ: env, CallContext: context.NewCloudCallContext()}
+	err := tagger.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	env.ResetCalls()
+
+	// Only the resources whose tags have changed, or which could
+	// not be tagged before, are tagged again.
+	api.resourceTags = &apiresourcetagger.ResourceTags{
+		ModelTags: map[string]string{"team": "ops"},
+		Instances: map[instance.Id]map[string]string{
+			"inst-0": {"team": "ops"},
+			"inst-1": {"team": "web"},
+		},
+		Volumes: []apiresourcetagger.VolumeTags{
+			{VolumeId: "vol-0", Provider: "ebs", Tags: map[string]string{"team": "dba"}},
+		},
+	}
+	err = tagger.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	env.CheckCalls(c, []testing.StubCall{
+		{"TagInstance", []interface{}{instance.Id("inst-0"), map[string]string{"team": "ops"}}},
+		{"TagInstance", []interface{}{instance.Id("inst-1"), map[string]string{"team": "web"}}},
+	})
+}
+
+func (s *taggerSuite) TestHandleModelResourcesError(c *gc.C) {
+	api := &fakeAPI{
+		Stub: &testing.Stub{},
+		resourceTags: &apiresourcetagger.ResourceTags{
+			ModelTags: map[string]string{"team": "ops"},
+		},
+	}
+	env := &fakeEnviron{Stub: &testing.Stub{}}
+	env.SetErrors(errors.New("denied"))
+	tagger := resourcetagger.Tagger{API: api, Environ: env, CallContext: context.NewCloudCallContext()}
+	err := tagger.Handle(nil)
+	c.Assert(err, gc.ErrorMatches, "tagging model resources: denied")
+}
+
+func (s *taggerSuite) TestHandleNoTaggingSupport(c *gc.C) {
+	api := &fakeAPI{
+		Stub: &testing.Stub{},
+		resourceTags: &apiresourcetagger.ResourceTags{
+			ModelTags: map[string]string{"team": "ops"},
+			Instances: map[instance.Id]map[string]string{
+				"inst-0": {"team": "ops"},
+			},
+		},
+	}
+	tagger := resourcetagger.Tagger{API: api, Environ: &fakeNoTaggingEnviron{}, CallContext: context.NewCloudCallContext()}
+	err := tagger.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type fakeAPI struct {
+	*testing.Stub
+	watcher      watcher.NotifyWatcher
+	resourceTags *apiresourcetagger.ResourceTags
+}
+
+func (a *fakeAPI) WatchResourceTags() (watcher.NotifyWatcher, error) {
+	a.AddCall("WatchResourceTags")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.watcher, nil
+}
+
+func (a *fakeAPI) ResourceTags() (*apiresourcetagger.ResourceTags, error) {
+	a.AddCall("ResourceTags")
+	return a.resourceTags, a.NextErr()
+}
+
+type fakeNoTaggingEnviron struct {
+	environs.Environ
+}
+
+type fakeEnviron struct {
+	environs.Environ
+	*testing.Stub
+}
+
+func (e *fakeEnviron) TagModelResources(ctx context.ProviderCallContext, tags map[string]string) error {
+	e.AddCall("TagModelResources", tags)
+	return e.NextErr()
+}
+
+func (e *fakeEnviron) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	e.AddCall("TagInstance", id, tags)
+	return e.NextErr()
+}
+
+func (e *fakeEnviron) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	e.AddCall("StorageProvider", t)
+	if t != "ebs" {
+		return nil, errors.NotFoundf("storage provider %q", t)
+	}
+	return &fakeStorageProvider{e}, e.NextErr()
+}
+
+type fakeStorageProvider struct {
+	storage.Provider
+	env *fakeEnviron
+}
+
+func (p *fakeStorageProvider) Dynamic() bool {
+	return true
+}
+
+func (p *fakeStorageProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+func (p *fakeStorageProvider) VolumeSource(*storage.Config) (storage.VolumeSource, error) {
+	return &fakeVolumeSource{env: p.env}, nil
+}
+
+type fakeVolumeSource struct {
+	storage.VolumeSource
+	env *fakeEnviron
+}
+
+func (s *fakeVolumeSource) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	s.env.AddCall("TagVolume", volumeId, tags)
+	return s.env.NextErr()
+}
@@ -90,11 +90,19 @@ func (w *stateConfigWatcher) isStateServer() bool {
 	return ok
 }
 
+// secretsMasterKey returns the secrets master key held in the state
+// serving info, which the state is opened with.
+func (w *stateConfigWatcher) secretsMasterKey() string {
+	info, _ := w.agent.CurrentConfig().StateServingInfo()
+	return info.SecretsMasterKey
+}
+
 func (w *stateConfigWatcher) loop() error {
 	watch := w.agentConfigChanged.Watch()
 	defer watch.Close()
 
 	lastValue := w.isStateServer()
+	lastSecretsMasterKey := w.secretsMasterKey()
 
 	watchCh := make(chan bool)
 	go func() {
@@ -129,6 +137,13 @@ func (w *stateConfigWatcher) loop() error {
 				logger.Debugf("state serving info change in agent config")
 				return dependency.ErrBounce
 			}
+			if w.secretsMasterKey() != lastSecretsMasterKey {
+				// The secrets master key has been added by an
+				// upgrade step; restart so that state is opened
+				// with it.
+				logger.Debugf("secrets master key change in agent config")
+				return dependency.ErrBounce
+			}
 		}
 	}
 }
@@ -169,6 +169,23 @@ func (s *ManifoldSuite) TestBounceOnChange(c *gc.C) {
 	checkExitsWithError(c, w, dependency.ErrBounce)
 }
 
+func (s *ManifoldSuite) TestBounceOnSecretsMasterKeyChange(c *gc.C) {
+	w, err := s.manifold.Start(s.goodContext)
+	c.Assert(err, jc.ErrorIsNil)
+	checkNotExiting(c, w)
+
+	s.agent.conf.setSecretsMasterKey("a2V5")
+	s.agentConfigChanged.Set(0)
+	checkExitsWithError(c, w, dependency.ErrBounce)
+
+	// Restart the worker; with the key unchanged it keeps running.
+	w, err = s.manifold.Start(s.goodContext)
+	c.Assert(err, jc.ErrorIsNil)
+	defer checkStop(c, w)
+	s.agentConfigChanged.Set(0)
+	checkNotExiting(c, w)
+}
+
 func (s *ManifoldSuite) TestClosedVoyeur(c *gc.C) {
 	w, err := s.manifold.Start(s.goodContext)
 	c.Assert(err, jc.ErrorIsNil)
@@ -231,6 +248,7 @@ type mockConfig struct {
 	tag         names.Tag
 	mu          sync.Mutex
 	ssInfoIsSet bool
+	secretsKey  string
 }
 
 func (mc *mockConfig) Tag() names.Tag {
@@ -243,10 +261,19 @@ func (mc *mockConfig) setStateServingInfo(isSet bool) {
 	mc.ssInfoIsSet = isSet
 }
 
+func (mc *mockConfig) setSecretsMasterKey(key string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.secretsKey = key
+}
+
 func (mc *mockConfig) StateServingInfo() (params.StateServingInfo, bool) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
-	return params.StateServingInfo{}, mc.ssInfoIsSet
+	if !mc.ssInfoIsSet {
+		return params.StateServingInfo{}, false
+	}
+	return params.StateServingInfo{SecretsMasterKey: mc.secretsKey}, true
 }
 
 type dummyWorker struct {
@@ -16,6 +16,8 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+	SecretRotate          hooks.Kind = "secret-rotate"
+	GoalStateChanged      hooks.Kind = "goal-state-changed"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -37,6 +39,10 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// SecretId is the ID of the secret to be rotated. It is only set
+	// when Kind is SecretRotate.
+	SecretId string `yaml:"secret-id,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -58,7 +64,12 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, GoalStateChanged:
+		return nil
+	case SecretRotate:
+		if hi.SecretId == "" {
+			return fmt.Errorf("%q hook requires a secret ID", hi.Kind)
+		}
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
@@ -18,6 +18,39 @@ var (
 	ErrCannotAcceptLeadership = errors.New("cannot accept leadership")
 )
 
+// hookFailedError is returned when a charm hook exits with a non-zero
+// exit code. Its cause is ErrHookFailed.
+type hookFailedError struct {
+	exitCode int
+}
+
+func (err *hookFailedError) Error() string {
+	return ErrHookFailed.Error()
+}
+
+// Cause is part of the errors.Causer interface.
+func (err *hookFailedError) Cause() error {
+	return ErrHookFailed
+}
+
+// hookExitCode returns the exit code of the failed hook that caused
+// the supplied error, or -1 if it is not known.
+func hookExitCode(err error) int {
+	for err != nil {
+		if hookErr, ok := err.(*hookFailedError); ok {
+			return hookErr.exitCode
+		}
+		wrapper, ok := err.(interface {
+			Underlying() error
+		})
+		if !ok {
+			break
+		}
+		err = wrapper.Underlying()
+	}
+	return -1
+}
+
 type deployConflictError struct {
 	charmURL *corecharm.URL
 }
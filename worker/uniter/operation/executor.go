@@ -5,9 +5,12 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/mutex"
+
+	"github.com/juju/juju/worker/uniter/hook"
 )
 
 type executorStep struct {
@@ -25,17 +28,38 @@ var (
 	stepCommit  = executorStep{"committing", Operation.Commit}
 )
 
+// HookExecution describes a single execution of a charm hook.
+type HookExecution struct {
+	// Info identifies the hook that was run.
+	Info hook.Info
+
+	// Started is when the hook started running.
+	Started time.Time
+
+	// Duration is how long the hook took to run.
+	Duration time.Duration
+
+	// ExitCode is the exit code of the hook, or -1 if the hook
+	// failed without exiting normally.
+	ExitCode int
+}
+
+// HookRecorder is called by an Executor after every charm hook it runs.
+type HookRecorder func(HookExecution) error
+
 type executor struct {
 	file               *StateFile
 	state              *State
 	acquireMachineLock func() (mutex.Releaser, error)
+	recordHook         HookRecorder
 }
 
 // NewExecutor returns an Executor which takes its starting state from the
 // supplied path, and records state changes there. If no state file exists,
 // the executor's starting state will include a queued Install hook, for
-// the charm identified by the supplied func.
-func NewExecutor(stateFilePath string, initialState State, acquireLock func() (mutex.Releaser, error)) (Executor, error) {
+// the charm identified by the supplied func. If recordHook is not nil, it
+// is called with the details of every hook the executor runs.
+func NewExecutor(stateFilePath string, initialState State, acquireLock func() (mutex.Releaser, error), recordHook HookRecorder) (Executor, error) {
 	file := NewStateFile(stateFilePath)
 	state, err := file.Read()
 	if err == ErrNoStateFile {
@@ -47,6 +71,7 @@ func NewExecutor(stateFilePath string, initialState State, acquireLock func() (m
 		file:               file,
 		state:              state,
 		acquireMachineLock: acquireLock,
+		recordHook:         recordHook,
 	}, nil
 }
 
@@ -71,7 +96,7 @@ func (x *executor) Run(op Operation) error {
 	switch err := x.do(op, stepPrepare); errors.Cause(err) {
 	case ErrSkipExecute:
 	case nil:
-		if err := x.do(op, stepExecute); err != nil {
+		if err := x.execute(op); err != nil {
 			return err
 		}
 	default:
@@ -86,6 +111,37 @@ func (x *executor) Skip(op Operation) error {
 	return x.do(op, stepCommit)
 }
 
+// execute runs the Execute step of the operation. If the operation runs
+// a charm hook, the hook's execution is passed to the hook recorder.
+func (x *executor) execute(op Operation) error {
+	info := x.state.Hook
+	if x.recordHook == nil || info == nil || x.state.Kind != RunHook || x.state.Step != Pending {
+		return x.do(op, stepExecute)
+	}
+	step := executorStep{stepExecute.verb, func(op Operation, state State) (*State, error) {
+		started := time.Now()
+		newState, err := op.Execute(state)
+		execution := HookExecution{
+			Info:     *info,
+			Started:  started,
+			Duration: time.Since(started),
+		}
+		switch cause := errors.Cause(err); {
+		case err == nil, cause == ErrNeedsReboot:
+		case cause == ErrHookFailed:
+			execution.ExitCode = hookExitCode(err)
+		default:
+			// The hook did not get to run.
+			return newState, err
+		}
+		if recordErr := x.recordHook(execution); recordErr != nil {
+			logger.Warningf("cannot record execution of %q hook: %v", info.Kind, recordErr)
+		}
+		return newState, err
+	}}
+	return x.do(op, step)
+}
+
 func (x *executor) do(op Operation, step executorStep) (err error) {
 	message := step.message(op)
 	logger.Debugf(message)
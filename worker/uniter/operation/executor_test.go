@@ -40,14 +40,14 @@ func (s *NewExecutorSuite) path(path string) string {
 
 func (s *NewExecutorSuite) TestNewExecutorInvalidFile(c *gc.C) {
 	ft.File{"existing", "", 0666}.Create(c, s.basePath)
-	executor, err := operation.NewExecutor(s.path("existing"), operation.State{}, failAcquireLock)
+	executor, err := operation.NewExecutor(s.path("existing"), operation.State{}, failAcquireLock, nil)
 	c.Assert(executor, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, `cannot read ".*": invalid operation state: .*`)
 }
 
 func (s *NewExecutorSuite) TestNewExecutorNoFile(c *gc.C) {
 	initialState := operation.State{}
-	executor, err := operation.NewExecutor(s.path("missing"), initialState, failAcquireLock)
+	executor, err := operation.NewExecutor(s.path("missing"), initialState, failAcquireLock, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(executor.State(), gc.DeepEquals, initialState)
 	ft.Removed{"missing"}.Check(c, s.basePath)
@@ -63,7 +63,7 @@ started: true
 op: continue
 opstep: pending
 `[1:], 0666}.Create(c, s.basePath)
-	executor, err := operation.NewExecutor(s.path("existing"), operation.State{}, failAcquireLock)
+	executor, err := operation.NewExecutor(s.path("existing"), operation.State{}, failAcquireLock, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(executor.State(), gc.DeepEquals, operation.State{
 		Kind:    operation.Continue,
@@ -88,7 +88,7 @@ func newExecutor(c *gc.C, st *operation.State) (operation.Executor, string) {
 	path := filepath.Join(c.MkDir(), "state")
 	err := operation.NewStateFile(path).Write(st)
 	c.Assert(err, jc.ErrorIsNil)
-	executor, err := operation.NewExecutor(path, operation.State{}, failAcquireLock)
+	executor, err := operation.NewExecutor(path, operation.State{}, failAcquireLock, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	return executor, path
 }
@@ -151,6 +151,51 @@ func (s *ExecutorSuite) TestSucceedWithStateChanges(c *gc.C) {
 	c.Assert(executor.State(), gc.DeepEquals, *op.commit.newState)
 }
 
+func (s *ExecutorSuite) TestRecordsHookExecution(c *gc.C) {
+	initialState := justInstalledState()
+	path := filepath.Join(c.MkDir(), "state")
+	err := operation.NewStateFile(path).Write(&initialState)
+	c.Assert(err, jc.ErrorIsNil)
+	var recorded []operation.HookExecution
+	recordHook := func(execution operation.HookExecution) error {
+		recorded = append(recorded, execution)
+		return errors.New("recording is best effort")
+	}
+	executor, err := operation.NewExecutor(path, operation.State{}, failAcquireLock, recordHook)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := hook.Info{Kind: hooks.RelationChanged, RelationId: 1, RemoteUnit: "mysql/0"}
+	op := &mockOperation{
+		prepare: newStep(&operation.State{
+			Kind: operation.RunHook,
+			Step: operation.Pending,
+			Hook: &info,
+		}, nil),
+		execute: newStep(nil, operation.ErrHookFailed),
+	}
+	err = executor.Run(op)
+	c.Assert(errors.Cause(err), gc.Equals, operation.ErrHookFailed)
+
+	c.Assert(recorded, gc.HasLen, 1)
+	c.Check(recorded[0].Info, jc.DeepEquals, info)
+	c.Check(recorded[0].Started.IsZero(), jc.IsFalse)
+	c.Check(recorded[0].Duration >= 0, jc.IsTrue)
+	c.Check(recorded[0].ExitCode, gc.Equals, -1)
+
+	// Operations that do not run hooks are not recorded.
+	op = &mockOperation{
+		prepare: newStep(&operation.State{
+			Kind: operation.Continue,
+			Step: operation.Pending,
+		}, nil),
+		execute: newStep(nil, nil),
+		commit:  newStep(nil, nil),
+	}
+	err = executor.Run(op)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, gc.HasLen, 1)
+}
+
 func (s *ExecutorSuite) TestErrSkipExecute(c *gc.C) {
 	initialState := justInstalledState()
 	executor, statePath := newExecutor(c, &initialState)
@@ -313,7 +358,7 @@ func (s *ExecutorSuite) initLockTest(c *gc.C, lockFunc func() (mutex.Releaser, e
 	statePath := filepath.Join(c.MkDir(), "state")
 	err := operation.NewStateFile(statePath).Write(&initialState)
 	c.Assert(err, jc.ErrorIsNil)
-	executor, err := operation.NewExecutor(statePath, operation.State{}, lockFunc)
+	executor, err := operation.NewExecutor(statePath, operation.State{}, lockFunc, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	return executor
@@ -5,6 +5,8 @@ package operation
 
 import (
 	"fmt"
+	"os/exec"
+	"syscall"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -111,9 +113,23 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
 	case err == nil:
+	case charmrunner.IsHookTimeoutError(cause):
+		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		return stateChange{
+			Kind:         RunHook,
+			Step:         Pending,
+			Hook:         &rh.info,
+			HookTimedOut: true,
+		}.apply(state), ErrHookFailed
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		if exitErr, ok := cause.(*exec.ExitError); ok {
+			if waitStatus, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+				return nil, &hookFailedError{exitCode: waitStatus.ExitStatus()}
+			}
+		}
 		return nil, ErrHookFailed
 	}
 
@@ -4,6 +4,9 @@
 package operation_test
 
 import (
+	"os/exec"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -274,6 +277,40 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteExitError(c *gc.C) {
+	runErr := exec.Command("/bin/sh", "-c", "exit 3").Run()
+	c.Assert(runErr, gc.FitsTypeOf, &exec.ExitError{})
+	op, callbacks, _ := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, errors.Trace(runErr))
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(errors.Cause(err), gc.Equals, operation.ErrHookFailed)
+	c.Assert(err, gc.ErrorMatches, "hook failed")
+	c.Assert(operation.HookExitCode(err), gc.Equals, 3)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+}
+
+func (s *RunHookSuite) TestExecuteHookTimeoutError(c *gc.C) {
+	runErr := charmrunner.NewHookTimeoutError("some-hook-name", time.Minute)
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:         operation.RunHook,
+		Step:         operation.Pending,
+		Hook:         &hook.Info{Kind: hooks.ConfigChanged},
+		HookTimedOut: true,
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+}
+
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
 	op, callbacks, f := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.Install, nil)
 	err := f.MockNewHookRunner.runner.Context().SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no database"})
@@ -93,6 +93,10 @@ type State struct {
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
+
+	// HookTimedOut indicates that the hook of a failed RunHook operation
+	// was killed because it exceeded its hook timeout.
+	HookTimedOut bool `yaml:"hook-timed-out,omitempty"`
 }
 
 // validate returns an error if the state violates expectations.
@@ -165,6 +169,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimedOut    bool
 }
 
 func (change stateChange) apply(state State) *State {
@@ -174,6 +179,7 @@ func (change stateChange) apply(state State) *State {
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
+	state.HookTimedOut = change.HookTimedOut
 	return &state
 }
 
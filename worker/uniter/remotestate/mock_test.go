@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/juju/core/model"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -198,6 +199,7 @@ type mockUnit struct {
 	tag                              names.UnitTag
 	life                             params.Life
 	resolved                         params.ResolvedMode
+	paused                           bool
 	series                           string
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
@@ -208,6 +210,8 @@ type mockUnit struct {
 	storageWatcher                   *mockStringsWatcher
 	actionWatcher                    *mockStringsWatcher
 	relationsWatcher                 *mockStringsWatcher
+	goalStateWatcher                 *mockNotifyWatcher
+	secretsToRotate                  []string
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -222,6 +226,14 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) Paused() bool {
+	return u.paused
+}
+
+func (u *mockUnit) SecretsToRotate() ([]string, error) {
+	return u.secretsToRotate, nil
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
@@ -262,6 +274,13 @@ func (u *mockUnit) WatchRelations() (watcher.StringsWatcher, error) {
 	return u.relationsWatcher, nil
 }
 
+func (u *mockUnit) WatchGoalState() (watcher.NotifyWatcher, error) {
+	if u.goalStateWatcher == nil {
+		return nil, errors.NotImplementedf("WatchGoalState() (need V9+)")
+	}
+	return u.goalStateWatcher, nil
+}
+
 func (u *mockUnit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
 	return u.upgradeSeriesWatcher, nil
 }
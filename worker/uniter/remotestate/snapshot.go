@@ -41,6 +41,10 @@ type Snapshot struct {
 	// hook execution errors.
 	ResolvedMode params.ResolvedMode
 
+	// Paused reports whether the unit has been paused,
+	// in which case no operations should be run.
+	Paused bool
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
@@ -62,6 +66,10 @@ type Snapshot struct {
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
 
+	// GoalStateVersion increments each time the goal
+	// state of the unit's application changes.
+	GoalStateVersion int
+
 	// Actions is the list of pending actions to
 	// be performed by this unit.
 	Actions []string
@@ -70,6 +78,11 @@ type Snapshot struct {
 	// executed by this unit.
 	Commands []string
 
+	// SecretRotations is the list of IDs of secrets owned
+	// by the unit's application that are due to be rotated.
+	// It is only populated for the leader unit.
+	SecretRotations []string
+
 	// Series is the current series running on the unit
 	Series string
 
@@ -35,6 +35,7 @@ type Unit interface {
 	Life() params.Life
 	Refresh() error
 	Resolved() params.ResolvedMode
+	Paused() bool
 	Application() (Application, error)
 	Series() string
 	Tag() names.UnitTag
@@ -48,7 +49,11 @@ type Unit interface {
 	// WatchRelation returns a watcher that fires when relations
 	// relevant for this unit change.
 	WatchRelations() (watcher.StringsWatcher, error)
+	// WatchGoalState returns a watcher that fires when the goal
+	// state of the unit's application changes.
+	WatchGoalState() (watcher.NotifyWatcher, error)
 	UpgradeSeriesStatus() (string, error)
+	SecretsToRotate() ([]string, error)
 }
 
 type Application interface {
@@ -145,6 +145,8 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.Actions, w.current.Actions)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	snapshot.SecretRotations = make([]string, len(w.current.SecretRotations))
+	copy(snapshot.SecretRotations, w.current.SecretRotations)
 	return snapshot
 }
 
@@ -169,6 +171,23 @@ func (w *RemoteStateWatcher) CommandCompleted(completed string) {
 	}
 }
 
+// SecretRotated removes the secret with the given ID from the list of
+// secrets due to be rotated, once its secret-rotate hook has run.
+func (w *RemoteStateWatcher) SecretRotated(rotated string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, id := range w.current.SecretRotations {
+		if id != rotated {
+			continue
+		}
+		w.current.SecretRotations = append(
+			w.current.SecretRotations[:i],
+			w.current.SecretRotations[i+1:]...,
+		)
+		break
+	}
+}
+
 func (w *RemoteStateWatcher) setUp(unitTag names.UnitTag) error {
 	// TODO(axw) move this logic
 	var err error
@@ -250,6 +269,25 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var (
+		seenGoalStateChange bool
+		goalStateChanges    watcher.NotifyChannel
+	)
+	goalStatew, err := w.unit.WatchGoalState()
+	if errors.IsNotImplemented(err) {
+		// Controllers older than the Uniter v9 facade can't watch
+		// goal state, so goal state changes are never reported.
+		logger.Debugf("not watching goal state: %v", err)
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := w.catacomb.Add(goalStatew); err != nil {
+			return errors.Trace(err)
+		}
+		goalStateChanges = goalStatew.Changes()
+		requiredEvents++
+	}
+
 	var (
 		seenApplicationChange bool
 
@@ -357,7 +395,9 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		return w.catacomb.ErrDying()
 	case <-claimLeader.Ready():
 		isLeader := claimLeader.Wait()
-		w.leadershipChanged(isLeader)
+		if err := w.leadershipChanged(isLeader); err != nil {
+			return errors.Trace(err)
+		}
 		if isLeader {
 			waitMinion = w.leadershipTracker.WaitMinion().Ready()
 		} else {
@@ -447,6 +487,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenLeaderSettingsChange)
 
+		case _, ok := <-goalStateChanges:
+			logger.Debugf("got goal state change: ok=%t", ok)
+			if !ok {
+				return errors.New("goal state watcher closed")
+			}
+			if err := w.goalStateChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenGoalStateChange)
+
 		case actions, ok := <-actionsw.Changes():
 			logger.Debugf("got action change: %v ok=%t", actions, ok)
 			if !ok {
@@ -531,6 +581,9 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.updateStatusChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			if err := w.secretRotationsChanged(); err != nil {
+				return errors.Trace(err)
+			}
 			resetUpdateStatusTimer()
 
 		case id, ok := <-w.commandChannel:
@@ -585,6 +638,26 @@ func (w *RemoteStateWatcher) updateStatusChanged() error {
 	return nil
 }
 
+// secretRotationsChanged polls for the secrets owned by the unit's
+// application that are due to be rotated. Secrets are only rotated by
+// the leader, so minions always see an empty list.
+func (w *RemoteStateWatcher) secretRotationsChanged() error {
+	w.mu.Lock()
+	isLeader := w.current.Leader
+	w.mu.Unlock()
+	var ids []string
+	if isLeader {
+		var err error
+		if ids, err = w.unit.SecretsToRotate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.mu.Lock()
+	w.current.SecretRotations = ids
+	w.mu.Unlock()
+	return nil
+}
+
 // commandsChanged is called when a command is enqueued.
 func (w *RemoteStateWatcher) commandsChanged(id string) error {
 	w.mu.Lock()
@@ -610,6 +683,7 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	defer w.mu.Unlock()
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = w.unit.Resolved()
+	w.current.Paused = w.unit.Paused()
 	w.current.Series = w.unit.Series()
 	return nil
 }
@@ -656,11 +730,18 @@ func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	return nil
 }
 
+func (w *RemoteStateWatcher) goalStateChanged() error {
+	w.mu.Lock()
+	w.current.GoalStateVersion++
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) error {
 	w.mu.Lock()
 	w.current.Leader = isLeader
 	w.mu.Unlock()
-	return nil
+	return w.secretRotationsChanged()
 }
 
 // relationsChanged responds to application relation changes.
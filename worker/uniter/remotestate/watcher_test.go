@@ -63,6 +63,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			storageWatcher:                   newMockStringsWatcher(),
 			actionWatcher:                    newMockStringsWatcher(),
 			relationsWatcher:                 newMockStringsWatcher(),
+			goalStateWatcher:                 newMockNotifyWatcher(),
 		},
 		relations:                   make(map[names.RelationTag]*mockRelation),
 		storageAttachment:           make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -162,6 +163,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	}
 	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.relationsWatcher.changes <- []string{}
+	s.st.unit.goalStateWatcher.changes <- struct{}{}
 	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
 	s.leadership.claimTicket.ch <- struct{}{}
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -174,6 +176,9 @@ func (s *WatcherSuite) signalAll() {
 	s.st.unit.actionWatcher.changes <- []string{}
 	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.relationsWatcher.changes <- []string{}
+	if s.st.unit.goalStateWatcher != nil {
+		s.st.unit.goalStateWatcher.changes <- struct{}{}
+	}
 	s.st.unit.addressesWatcher.changes <- struct{}{}
 	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
 	s.leadership.claimTicket.ch <- struct{}{}
@@ -202,12 +207,37 @@ func (s *WatcherSuiteIAAS) TestSnapshot(c *gc.C) {
 		ResolvedMode:          s.st.unit.resolved,
 		ConfigVersion:         expectedVersion,
 		LeaderSettingsVersion: 1,
+		GoalStateVersion:      1,
 		Leader:                true,
 		Series:                "",
 		UpgradeSeriesStatus:   model.UnitStarted,
 	})
 }
 
+func (s *WatcherSuiteIAAS) TestGoalStateNotImplemented(c *gc.C) {
+	// Replace the watcher with one talking to a controller which
+	// can't watch goal state.
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+	s.st.unit.goalStateWatcher = nil
+	statusTicker := func(wait time.Duration) remotestate.Waiter {
+		return dummyWaiter{s.clock.After(wait)}
+	}
+	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
+		State:               s.st,
+		ModelType:           s.modelType,
+		LeadershipTracker:   s.leadership,
+		UnitTag:             s.st.unit.tag,
+		UpdateStatusChannel: statusTicker,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.watcher = w
+
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().GoalStateVersion, gc.Equals, 0)
+}
+
 func (s *WatcherSuiteCAAS) TestSnapshot(c *gc.C) {
 	s.signalAll()
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -226,6 +256,7 @@ func (s *WatcherSuiteCAAS) TestSnapshot(c *gc.C) {
 		ResolvedMode:          s.st.unit.resolved,
 		ConfigVersion:         expectedVersion,
 		LeaderSettingsVersion: 1,
+		GoalStateVersion:      1,
 		Leader:                true,
 		Series:                "",
 		UpgradeSeriesStatus:   "",
@@ -257,6 +288,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ResolvedMode, gc.Equals, params.ResolvedRetryHooks)
 
+	s.st.unit.paused = true
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().Paused, jc.IsTrue)
+
 	s.st.unit.addressesWatcher.changes <- struct{}{}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ConfigVersion, gc.Equals, initial.ConfigVersion+1)
@@ -276,6 +312,10 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	s.st.unit.relationsWatcher.changes <- []string{}
 	assertOneChange()
 
+	s.st.unit.goalStateWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().GoalStateVersion, gc.Equals, initial.GoalStateVersion+1)
+
 	if s.modelType == model.IAAS {
 		s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 		assertOneChange()
@@ -675,6 +715,27 @@ func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestSecretRotations(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+
+	// Secrets due to be rotated are polled for with update-status.
+	s.st.unit.secretsToRotate = []string{"secret-1", "secret-2"}
+	s.waitAlarmsStable(c)
+	s.clock.Advance(5 * time.Minute)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{"secret-1", "secret-2"})
+
+	s.watcher.SecretRotated("secret-1")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{"secret-2"})
+
+	// Minions never rotate secrets.
+	s.leadership.minionTicket.ch <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+}
+
 func (s *WatcherSuite) TestUpdateStatusIntervalChanges(c *gc.C) {
 	s.signalAll()
 	initial := s.watcher.Snapshot()
@@ -19,7 +19,7 @@ import (
 type ResolverConfig struct {
 	ModelType           model.ModelType
 	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
+	ReportHookError     func(info hook.Info, timedOut bool) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
@@ -28,6 +28,7 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+	Secrets             resolver.Resolver
 }
 
 type uniterResolver struct {
@@ -52,6 +53,12 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrTerminate
 	}
 
+	// If the unit has been paused by an operator, run nothing; the
+	// remote state changes will be acted on when it is resumed.
+	if remoteState.Paused {
+		return nil, resolver.ErrNoOperation
+	}
+
 	// If the unit has completed a pre-series-upgrade hook (as noted
 	// by its state) then the uniter should idle in the face of
 	// all remote state changes - the unit is waiting to be shutdown.
@@ -162,7 +169,7 @@ func (s *uniterResolver) nextOpHookError(
 ) (operation.Operation, error) {
 
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	if err := s.config.ReportHookError(*localState.Hook, localState.HookTimedOut); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -295,6 +302,15 @@ func (s *uniterResolver) nextOp(
 		return op, err
 	}
 
+	op, err = s.config.Secrets.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
+	if localState.Started && localState.GoalStateVersion != remoteState.GoalStateVersion {
+		return opFactory.NewRunHook(hook.Info{Kind: hook.GoalStateChanged})
+	}
+
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
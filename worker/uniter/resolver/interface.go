@@ -91,6 +91,11 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// GoalStateVersion is the version of the goal state from
+	// remotestate.Snapshot for which a goal-state-changed hook has
+	// been committed.
+	GoalStateVersion int
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
@@ -142,6 +142,11 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.GoalStateChanged:
+		v := s.RemoteState.GoalStateVersion
+		op = onCommitWrapper{op, func() {
+			s.LocalState.GoalStateVersion = v
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
@@ -152,6 +152,23 @@ func (s *ResolverOpFactorySuite) testLeaderSettingsChanged(
 	c.Assert(f.LocalState.UpdateStatusVersion, gc.Equals, 3)
 }
 
+func (s *ResolverOpFactorySuite) TestGoalStateChanged(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	f.RemoteState.GoalStateVersion = 1
+
+	op, err := f.NewRunHook(hook.Info{Kind: hook.GoalStateChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	f.RemoteState.GoalStateVersion = 2
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Local state's GoalStateVersion should be set to what
+	// RemoteState's GoalStateVersion was when the operation
+	// was constructed.
+	c.Assert(f.LocalState.GoalStateVersion, gc.Equals, 1)
+}
+
 func (s *ResolverOpFactorySuite) TestUpgrade(c *gc.C) {
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewUpgrade)
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewRevertUpgrade)
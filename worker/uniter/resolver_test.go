@@ -82,7 +82,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 
 	s.resolverConfig = uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
-		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
+		ReportHookError:     func(info hook.Info, _ bool) error { return s.reportHookError(info) },
 		StartRetryHookTimer: func() { s.stub.AddCall("StartRetryHookTimer") },
 		StopRetryHookTimer:  func() { s.stub.AddCall("StopRetryHookTimer") },
 		ShouldRetryHooks:    true,
@@ -91,6 +91,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Relations:           relation.NewRelationsResolver(&dummyRelations{}),
 		Storage:             storage.NewResolver(attachments, s.modelType),
 		Commands:            nopResolver{},
+		Secrets:             nopResolver{},
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -147,6 +148,26 @@ func (s *resolverSuite) TestSeriesChanged(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run config-changed hook")
 }
 
+func (s *resolverSuite) TestGoalStateChanged(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.GoalStateVersion = 1
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run goal-state-changed hook")
+
+	localState.GoalStateVersion = 1
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
 func (s *resolverSuite) TestUpgradeSeriesStatusChanged(c *gc.C) {
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
@@ -186,6 +207,29 @@ func (s *resolverSuite) TestUpgradeSeriesStatusIdlesUniterOnUpggradeSeriesComple
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 }
 
+func (s *resolverSuite) TestPausedQueuesOperations(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		Series:               s.charmURL.Series,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Series = "trusty"
+	s.remoteState.Paused = true
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	// The queued change is acted on once the unit is resumed.
+	s.remoteState.Paused = false
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+}
+
 func (s *resolverSuite) TestSeriesChangedBlank(c *gc.C) {
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
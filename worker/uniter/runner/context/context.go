@@ -164,6 +164,10 @@ type HookContext struct {
 	// or if it is running a relation-broken hook.
 	remoteUnitName string
 
+	// secretId identifies the secret for which a secret-rotate hook is
+	// executing. It is empty for all other hooks.
+	secretId string
+
 	// relations contains the context for every relation the unit is a member
 	// of, keyed on relation id.
 	relations map[int]*ContextRelation
@@ -197,6 +201,18 @@ type HookContext struct {
 	// like a juju-run command or a hook
 	process HookProcess
 
+	// hookTimeout is how long a charm hook run in this context may take
+	// before it is killed. A zero value means there is no timeout.
+	hookTimeout time.Duration
+
+	// charmState is the cached value of the unit's charm state, loaded
+	// on first use. It is nil until then.
+	charmState map[string]string
+
+	// charmStateDirty is true if charmState has been modified by the
+	// running hook and needs to be written back when it completes.
+	charmStateDirty bool
+
 	// rebootPriority tells us when the hook wants to reboot. If rebootPriority is hooks.RebootNow
 	// the hook will be killed and requeued
 	rebootPriority jujuc.RebootPriority
@@ -298,6 +314,12 @@ func (ctx *HookContext) SetProcess(process HookProcess) {
 	ctx.process = process
 }
 
+// HookTimeout returns how long a charm hook run in this context may
+// take before it is killed. A zero value means there is no timeout.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *HookContext) Id() string {
 	return ctx.id
 }
@@ -524,6 +546,115 @@ func (ctx *HookContext) SetPodSpec(specYaml string) error {
 	return ctx.state.SetPodSpec(entityName, specYaml)
 }
 
+// GetCharmState returns a copy of the unit's charm state.
+// Implements jujuc.HookContext.ContextCharmState, part of runner.Context.
+func (ctx *HookContext) GetCharmState() (map[string]string, error) {
+	if err := ctx.ensureCharmState(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(ctx.charmState))
+	for key, value := range ctx.charmState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// GetCharmStateValue returns the value of the given charm state key.
+// Implements jujuc.HookContext.ContextCharmState, part of runner.Context.
+func (ctx *HookContext) GetCharmStateValue(key string) (string, error) {
+	if err := ctx.ensureCharmState(); err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok := ctx.charmState[key]
+	if !ok {
+		return "", errors.NotFoundf("charm state key %q", key)
+	}
+	return value, nil
+}
+
+// SetCharmStateValue sets the value of the given charm state key. The
+// change is written to the controller when the context is flushed.
+// Implements jujuc.HookContext.ContextCharmState, part of runner.Context.
+func (ctx *HookContext) SetCharmStateValue(key, value string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return errors.Trace(err)
+	}
+	if current, ok := ctx.charmState[key]; ok && current == value {
+		return nil
+	}
+	ctx.charmState[key] = value
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// DeleteCharmStateValue removes the given charm state key. The change
+// is written to the controller when the context is flushed.
+// Implements jujuc.HookContext.ContextCharmState, part of runner.Context.
+func (ctx *HookContext) DeleteCharmStateValue(key string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := ctx.charmState[key]; !ok {
+		return nil
+	}
+	delete(ctx.charmState, key)
+	ctx.charmStateDirty = true
+	return nil
+}
+
+func (ctx *HookContext) ensureCharmState() error {
+	if ctx.charmState != nil {
+		return nil
+	}
+	charmState, err := ctx.unit.CharmState()
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	if charmState == nil {
+		charmState = make(map[string]string)
+	}
+	ctx.charmState = charmState
+	return nil
+}
+
+// CreateSecret creates a secret owned by the unit's application.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) CreateSecret(description string, rotateInterval time.Duration, data map[string]string) (string, error) {
+	return ctx.unit.CreateSecret(description, rotateInterval, data)
+}
+
+// GetSecret returns the value of the given secret revision.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) GetSecret(id string, revision int) (map[string]string, error) {
+	return ctx.unit.SecretValue(id, revision)
+}
+
+// RotateSecret records a new revision of the given secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) RotateSecret(id string, data map[string]string) error {
+	return ctx.unit.RotateSecret(id, data)
+}
+
+// GrantSecret allows the remote application of the given relation to read
+// the given secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) GrantSecret(id string, relationId int) error {
+	if _, err := ctx.Relation(relationId); err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.unit.GrantSecret(id, relationId)
+}
+
+// RevokeSecret stops the remote application of the given relation from
+// reading the given secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) RevokeSecret(id string, relationId int) error {
+	if _, err := ctx.Relation(relationId); err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.unit.RevokeSecret(id, relationId)
+}
+
 // CloudSpec return the cloud specification for the running unit's model
 func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
 	var err error
@@ -671,6 +802,9 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.secretId != "" {
+		vars = append(vars, "JUJU_SECRET_ID="+context.secretId)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -793,6 +927,16 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	if ctx.charmStateDirty && writeChanges {
+		if err := ctx.unit.SetCharmState(ctx.charmState); err != nil {
+			err = errors.Annotatef(err, "cannot write charm state")
+			logger.Errorf("%v", err)
+			if ctxErr == nil {
+				ctxErr = err
+			}
+		}
+	}
+
 	// TODO (tasdomas) 2014 09 03: context finalization needs to modified to apply all
 	//                             changes in one api call to minimize the risk
 	//                             of partial failures.
@@ -234,6 +234,12 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.SecretRotate {
+		ctx.secretId = hookInfo.SecretId
+	}
+	if ctx.hookTimeout, err = f.unit.HookTimeout(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -259,6 +265,10 @@ func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext,
 func (f *contextFactory) getContextRelations() map[int]*ContextRelation {
 	contextRelations := map[int]*ContextRelation{}
 	relationInfos := f.getRelationInfos()
+	schemas, err := readRelationSchemas(f.paths.GetCharmDir())
+	if err != nil {
+		logger.Warningf("cannot read relation schemas: %v", err)
+	}
 	relationCaches := map[int]*RelationCache{}
 	for id, info := range relationInfos {
 		relationUnit := info.RelationUnit
@@ -270,7 +280,9 @@ func (f *contextFactory) getContextRelations() map[int]*ContextRelation {
 			cache = NewRelationCache(relationUnit.ReadSettings, memberNames)
 		}
 		relationCaches[id] = cache
-		contextRelations[id] = NewContextRelation(relationUnit, cache)
+		contextRelation := NewContextRelation(relationUnit, cache)
+		contextRelation.schema = schemas[contextRelation.endpointName]
+		contextRelations[id] = contextRelation
 	}
 	f.relationCaches = relationCaches
 	return contextRelations
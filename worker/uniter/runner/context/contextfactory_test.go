@@ -4,7 +4,9 @@
 package context_test
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/errors"
@@ -133,6 +135,25 @@ func (s *ContextFactorySuite) TestNewHookContextRetrievesSLALevel(c *gc.C) {
 	c.Assert(ctx.SLALevel(), gc.Equals, "essential")
 }
 
+func (s *ContextFactorySuite) TestNewHookContextRetrievesHookTimeout(c *gc.C) {
+	err := s.Model(c).UpdateModelConfig(map[string]interface{}{"hook-timeout": "10m"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, 10*time.Minute)
+}
+
+func (s *ContextFactorySuite) TestNewHookContextSecretRotate(c *gc.C) {
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hook.SecretRotate, SecretId: "some-secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(context.ContextSecretId(ctx), gc.Equals, "some-secret")
+
+	ctx, err = s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged, SecretId: "ignored"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(context.ContextSecretId(ctx), gc.Equals, "")
+}
+
 func (s *ContextFactorySuite) TestNewHookContextLeadershipContext(c *gc.C) {
 	s.testLeadershipContextWiring(c, func() *context.HookContext {
 		ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
@@ -181,6 +202,34 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestRelationHookContextValidatesSettings(c *gc.C) {
+	s.SetCharm(c, "wordpress")
+	metadataPath := filepath.Join(s.paths.GetCharmDir(), "metadata.yaml")
+	metadata, err := ioutil.ReadFile(metadataPath)
+	c.Assert(err, jc.ErrorIsNil)
+	metadata = append(metadata, []byte(`
+relation-schemas:
+  db:
+    publishes:
+      type: object
+      required: [database]
+`)...)
+	err = ioutil.WriteFile(metadataPath, metadata, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.membership[1] = []string{"r/0"}
+	ctx, err := s.factory.HookContext(hook.Info{
+		Kind:       hooks.RelationChanged,
+		RelationId: 1,
+		RemoteUnit: "r/0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := ctx.Relation(1)
+	c.Assert(err, jc.ErrorIsNil)
+	schema := context.ContextRelationSchema(rel)
+	c.Assert(schema.Publishes["required"], jc.DeepEquals, []interface{}{"database"})
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -172,6 +173,10 @@ func ContextMachineTag(hctx *HookContext) names.MachineTag {
 	return hctx.assignedMachineTag
 }
 
+func ContextSecretId(hctx *HookContext) string {
+	return hctx.secretId
+}
+
 func UpdateCachedSettings(cf0 ContextFactory, relId int, unitName string, settings params.Settings) {
 	cf := cf0.(*contextFactory)
 	members := cf.relationCaches[relId].members
@@ -189,6 +194,14 @@ func CachedSettings(cf0 ContextFactory, relId int, unitName string) (params.Sett
 	return settings, found
 }
 
+func ContextRelationSchema(ctx jujuc.ContextRelation) relation.EndpointSchema {
+	return ctx.(*ContextRelation).schema
+}
+
+func SetContextRelationSchema(ctx *ContextRelation, schema relation.EndpointSchema) {
+	ctx.schema = schema
+}
+
 func (ctx *HookContext) SLALevel() string {
 	return ctx.slaLevel
 }
@@ -92,6 +92,54 @@ func (s *FlushContextSuite) TestRunHookRelationFlushingSuccess(c *gc.C) {
 	})
 }
 
+func (s *FlushContextSuite) TestRunHookCharmStateFlushingError(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"keep": "me"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("keep")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with a failure.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	// Check that the changes have not been written to state.
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"keep": "me"})
+}
+
+func (s *FlushContextSuite) TestRunHookCharmStateFlushingSuccess(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"keep": "me", "drop": "me"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("drop")
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := ctx.GetCharmStateValue("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "bar")
+	_, err = ctx.GetCharmStateValue("drop")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Flush the context with a success.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check that the changes have been written to state.
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{
+		"keep": "me",
+		"foo":  "bar",
+	})
+}
+
 func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingPorts(c *gc.C) {
 	// Initially, no port ranges are open on the unit or its machine.
 	unitRanges, err := s.unit.OpenedPorts()
@@ -5,6 +5,12 @@ package context
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
@@ -28,6 +34,14 @@ type ContextRelation struct {
 
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
+
+	// schema holds the relation data schemas that the charm declares
+	// for the endpoint, if any.
+	schema relation.EndpointSchema
+
+	// loadedSettings holds the unit's settings as they were when first
+	// read, so that unchanged settings are not validated.
+	loadedSettings params.Settings
 }
 
 // NewContextRelation creates a new context for the given relation unit.
@@ -68,16 +82,24 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 			return nil, err
 		}
 		ctx.settings = node
+		ctx.loadedSettings = node.Map()
 	}
 	return ctx.settings, nil
 }
 
 // WriteSettings persists all changes made to the unit's relation settings.
-func (ctx *ContextRelation) WriteSettings() (err error) {
-	if ctx.settings != nil {
-		err = ctx.settings.Write()
+// Changed settings must conform to the schema the charm declares for the
+// data it publishes on the relation.
+func (ctx *ContextRelation) WriteSettings() error {
+	if ctx.settings == nil {
+		return nil
 	}
-	return
+	if settings := ctx.settings.Map(); !reflect.DeepEqual(settings, ctx.loadedSettings) {
+		if err := ctx.schema.ValidatePublished(settings); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return ctx.settings.Write()
 }
 
 // Suspended returns true if the relation is suspended.
@@ -89,3 +111,15 @@ func (ctx *ContextRelation) Suspended() bool {
 func (ctx *ContextRelation) SetStatus(status relation.Status) error {
 	return ctx.ru.Relation().SetStatus(status)
 }
+
+// readRelationSchemas returns the relation data schemas declared in the
+// metadata of the charm in the given directory.
+func readRelationSchemas(charmDir string) (relation.Schemas, error) {
+	metadata, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return relation.ParseSchemas(metadata)
+}
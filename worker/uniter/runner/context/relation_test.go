@@ -165,6 +165,48 @@ func (s *ContextRelationSuite) TestLocalSettings(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"change": "exciting"})
 }
 
+func (s *ContextRelationSuite) TestWriteSettingsValidatesSchema(c *gc.C) {
+	ctx := context.NewContextRelation(s.apiRelUnit, nil)
+	context.SetContextRelationSchema(ctx, relation.EndpointSchema{
+		Publishes: map[string]interface{}{
+			"type":                 "object",
+			"required":             []interface{}{"host"},
+			"additionalProperties": false,
+			"properties": map[string]interface{}{
+				"host": map[string]interface{}{"type": "string"},
+			},
+		},
+	})
+
+	// Unchanged settings are not validated.
+	node, err := ctx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Settings are validated as a whole when written, not as each
+	// one is set.
+	node.Set("port", "5432")
+	err = ctx.WriteSettings()
+	c.Assert(err, gc.ErrorMatches, `relation settings \(.*\) not valid`)
+	settings, err := s.ru.ReadSettings("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	// The settings Juju manages are ignored.
+	node.Delete("port")
+	node.Set("host", "10.0.0.1")
+	node.Set("private-address", "10.0.0.1")
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = s.ru.ReadSettings("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{
+		"host":            "10.0.0.1",
+		"private-address": "10.0.0.1",
+	})
+}
+
 func convertSettings(settings params.Settings) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range settings {
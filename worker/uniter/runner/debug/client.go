@@ -11,34 +11,89 @@ import (
 )
 
 type hookArgs struct {
-	Hooks []string `yaml:"hooks,omitempty"`
+	Hooks     []string `yaml:"hooks,omitempty"`
+	DebugPort int      `yaml:"debug-port,omitempty"`
+	DebugAt   string   `yaml:"debug-at,omitempty"`
+	Wrapper   string   `yaml:"wrapper,omitempty"`
+}
+
+func (args hookArgs) debugOptions() DebugOptions {
+	return DebugOptions{
+		Port:    args.DebugPort,
+		At:      args.DebugAt,
+		Wrapper: args.Wrapper,
+	}
+}
+
+// DebugOptions holds the settings for a non-interactive debug-hooks
+// session. Rather than dropping into a tmux shell, matching hooks are
+// run by the uniter as usual, with extra environment that a charm can
+// use to wait for a remote debugger.
+type DebugOptions struct {
+	// Port, if non-zero, is passed to hooks as $JUJU_DEBUG_PORT. It is
+	// the port a debugger should listen on, and is forwarded over ssh
+	// by the debug-hooks client.
+	Port int
+
+	// At, if non-empty, is passed to hooks as $JUJU_DEBUG_AT. It names
+	// the breakpoints the charm should stop at.
+	At string
+
+	// Wrapper, if non-empty, is a shell command that hooks are run
+	// under, with the path of the hook appended to it.
+	Wrapper string
+}
+
+// IsZero reports whether no debug options are set, in which case
+// the debug-hooks session is interactive.
+func (o DebugOptions) IsZero() bool {
+	return o == DebugOptions{}
 }
 
 // ClientScript returns a bash script suitable for executing
 // on the unit system to intercept matching hooks or actions via tmux shell.
 func ClientScript(c *HooksContext, match []string) string {
+	s := strings.Replace(debugHooksClientScript, "{tmux_conf}", tmuxConf, 1)
+	return clientScript(s, c, hookArgs{Hooks: matchHooks(match)})
+}
+
+// DebugClientScript returns a bash script suitable for executing on
+// the unit system to have matching hooks or actions run with the given
+// debug options. The script holds the debug-hooks locks until it is
+// interrupted.
+func DebugClientScript(c *HooksContext, match []string, options DebugOptions) string {
+	return clientScript(debugClientScript, c, hookArgs{
+		Hooks:     matchHooks(match),
+		DebugPort: options.Port,
+		DebugAt:   options.At,
+		Wrapper:   options.Wrapper,
+	})
+}
+
+func matchHooks(match []string) []string {
 	// If any argument is "*", then the client is interested in all.
 	for _, m := range match {
 		if m == "*" {
-			match = nil
-			break
+			return nil
 		}
 	}
+	return match
+}
 
-	s := strings.Replace(debugHooksClientScript, "{unit_name}", c.Unit, -1)
-	s = strings.Replace(s, "{tmux_conf}", tmuxConf, 1)
+func clientScript(s string, c *HooksContext, args hookArgs) string {
+	s = strings.Replace(s, "{unit_name}", c.Unit, -1)
 	s = strings.Replace(s, "{entry_flock}", c.ClientFileLock(), -1)
 	s = strings.Replace(s, "{exit_flock}", c.ClientExitFileLock(), -1)
 
-	yamlArgs := encodeArgs(match)
+	yamlArgs := encodeArgs(args)
 	base64Args := base64.StdEncoding.EncodeToString(yamlArgs)
 	s = strings.Replace(s, "{hook_args}", base64Args, 1)
 	return s
 }
 
-func encodeArgs(args []string) []byte {
+func encodeArgs(args hookArgs) []byte {
 	// Marshal to YAML, then encode in base64 to avoid shell escapes.
-	yamlArgs, err := goyaml.Marshal(hookArgs{Hooks: args})
+	yamlArgs, err := goyaml.Marshal(args)
 	if err != nil {
 		// This should not happen: we're in full control.
 		panic(err)
@@ -108,6 +163,32 @@ fi
 exit $?
 `
 
+const debugClientScript = `#!/bin/bash
+(
+# Lock the juju-<unit>-debug lockfile.
+flock -n 8 || {
+	echo "Found an existing debug session for {unit_name}" >&2
+	exit 1
+}
+
+# Write out the debug-hooks args.
+echo "{hook_args}" | base64 -d > {entry_flock}
+
+(
+# Lock the juju-<unit>-debug-exit lockfile. Matching hooks are
+# run with debugging enabled for as long as the lock is held.
+flock -n 9 || exit 1
+
+echo "Hooks for {unit_name} will be run with debugging enabled."
+echo "Press Ctrl-C to end the debug session."
+while true; do
+	sleep 1
+done
+) 9>{exit_flock}
+) 8>{entry_flock}
+exit $?
+`
+
 const tmuxConf = `
 # Status bar
 set-option -g status-bg black
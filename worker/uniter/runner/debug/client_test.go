@@ -48,3 +48,30 @@ func (*DebugHooksClientSuite) TestClientScript(c *gc.C) {
 	)
 	c.Assert(debug.ClientScript(ctx, []string{"something somethingelse"}), gc.Matches, expected)
 }
+
+func (*DebugHooksClientSuite) TestDebugClientScript(c *gc.C) {
+	ctx := debug.NewHooksContext("foo/8")
+
+	result := debug.DebugClientScript(ctx, []string{"install"}, debug.DebugOptions{Port: 5678})
+	// No variables left behind.
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{unit_name}(.|\n)*")
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{entry_flock}(.|\n)*")
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{exit_flock}(.|\n)*")
+	// No tmux session is started.
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*tmux(.|\n)*")
+	c.Assert(result, gc.Matches, fmt.Sprintf("(.|\n)*\\) 9>%s(.|\n)*", regexp.QuoteMeta(ctx.ClientExitFileLock())))
+	c.Assert(result, gc.Matches, fmt.Sprintf("(.|\n)*\\) 8>%s(.|\n)*", regexp.QuoteMeta(ctx.ClientFileLock())))
+
+	// The debug options are written out with the hook names.
+	expected := fmt.Sprintf(
+		`(.|\n)*echo "aG9va3M6Ci0gaW5zdGFsbApkZWJ1Zy1wb3J0OiA1Njc4Cg==" | base64 -d > %s(.|\n)*`,
+		regexp.QuoteMeta(ctx.ClientFileLock()),
+	)
+	c.Assert(result, gc.Matches, expected)
+
+	c.Assert(
+		debug.DebugClientScript(ctx, []string{"*", "install"}, debug.DebugOptions{At: "all"}),
+		gc.Equals,
+		debug.DebugClientScript(ctx, nil, debug.DebugOptions{At: "all"}),
+	)
+}
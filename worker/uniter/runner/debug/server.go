@@ -5,6 +5,7 @@ package debug
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -21,17 +22,56 @@ import (
 // ServerSession represents a "juju debug-hooks" session.
 type ServerSession struct {
 	*HooksContext
-	hooks set.Strings
+	hooks   set.Strings
+	options DebugOptions
 
 	output io.Writer
 }
 
+// Interactive returns true if matching hooks should be run in the
+// debug-hooks client's tmux session with RunHook, and false if they
+// should be run as usual with the session's debug options applied.
+func (s *ServerSession) Interactive() bool {
+	return s.options.IsZero()
+}
+
+// DebugEnv returns the specified hook environment, with the variables
+// for the session's debug options added.
+func (s *ServerSession) DebugEnv(env []string) []string {
+	if s.options.Port != 0 {
+		env = utils.Setenv(env, fmt.Sprintf("JUJU_DEBUG_PORT=%d", s.options.Port))
+	}
+	if s.options.At != "" {
+		env = utils.Setenv(env, "JUJU_DEBUG_AT="+s.options.At)
+	}
+	return env
+}
+
+// WrapCommand returns the command line that runs the specified hook
+// command under the session's wrapper command, if any.
+func (s *ServerSession) WrapCommand(hookCmd []string) []string {
+	if s.options.Wrapper == "" {
+		return hookCmd
+	}
+	wrapped := []string{"/bin/bash", "-c", s.options.Wrapper + ` "$@"`, "juju-debug-wrapper"}
+	return append(wrapped, hookCmd...)
+}
+
 // MatchHook returns true if the specified hook name matches
 // the hook specified by the debug-hooks client.
 func (s *ServerSession) MatchHook(hookName string) bool {
 	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
 }
 
+// clientConnected returns true if the debug-hooks client holds the
+// exit lock. This is a var so it can be replaced for testing.
+var clientConnected = func(c *HooksContext) bool {
+	path := c.ClientExitFileLock()
+	err := exec.Command("flock", "-n", path, "-c", "true").Run()
+	_, locked := err.(*exec.ExitError)
+	return locked
+}
+
 // waitClientExit executes flock, waiting for the SSH client to exit.
 // This is a var so it can be replaced for testing.
 var waitClientExit = func(s *ServerSession) {
@@ -108,6 +148,13 @@ func (s *ServerSession) writeDebugFiles(debugDir string) error {
 // FindSession attempts to find a debug hooks session for the unit specified
 // in the context, and returns a new ServerSession structure for it.
 func (c *HooksContext) FindSession() (*ServerSession, error) {
+	// A non-interactive session has no tmux session; it lasts for
+	// as long as the client is connected.
+	if args, err := c.readArgs(); err == nil && !args.debugOptions().IsZero() {
+		if clientConnected(c) {
+			return c.newSession(args), nil
+		}
+	}
 	cmd := exec.Command("tmux", "has-session", "-t", c.tmuxSessionName())
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -117,19 +164,33 @@ func (c *HooksContext) FindSession() (*ServerSession, error) {
 			return nil, err
 		}
 	}
-	// Parse the debug-hooks file for an optional hook name.
-	data, err := ioutil.ReadFile(c.ClientFileLock())
+	args, err := c.readArgs()
 	if err != nil {
 		return nil, err
 	}
+	// Debug options only apply while their client is connected.
+	args = hookArgs{Hooks: args.Hooks}
+	return c.newSession(args), nil
+}
+
+// readArgs parses the debug-hooks file for optional hook
+// names and debug options.
+func (c *HooksContext) readArgs() (hookArgs, error) {
 	var args hookArgs
-	err = goyaml.Unmarshal(data, &args)
+	data, err := ioutil.ReadFile(c.ClientFileLock())
 	if err != nil {
-		return nil, err
+		return args, err
+	}
+	err = goyaml.Unmarshal(data, &args)
+	return args, err
+}
+
+func (c *HooksContext) newSession(args hookArgs) *ServerSession {
+	return &ServerSession{
+		HooksContext: c,
+		hooks:        set.NewStrings(args.Hooks...),
+		options:      args.debugOptions(),
 	}
-	hooks := set.NewStrings(args.Hooks...)
-	session := &ServerSession{HooksContext: c, hooks: hooks}
-	return session, nil
 }
 
 const debugHooksServerScript = `set -e
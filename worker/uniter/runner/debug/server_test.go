@@ -103,6 +103,50 @@ func (s *DebugHooksServerSuite) TestFindSession(c *gc.C) {
 	c.Assert(session.MatchHook("foo bar baz"), jc.IsFalse)
 }
 
+func (s *DebugHooksServerSuite) TestFindSessionNonInteractive(c *gc.C) {
+	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte(`
+hooks: [install]
+debug-port: 5678
+debug-at: all
+wrapper: python3 -m debugpy
+`), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The client holds the exit lock: no tmux session is needed.
+	s.PatchValue(&clientConnected, func(*HooksContext) bool { return true })
+	os.Setenv("EXIT_CODE", "1")
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session, gc.NotNil)
+	c.Assert(session.Interactive(), jc.IsFalse)
+	c.Assert(session.MatchHook("install"), jc.IsTrue)
+	c.Assert(session.MatchHook("start"), jc.IsFalse)
+	env := session.DebugEnv([]string{"JUJU_UNIT_NAME=foo/8", "JUJU_DEBUG_AT=stale"})
+	c.Assert(env, jc.SameContents, []string{
+		"JUJU_UNIT_NAME=foo/8",
+		"JUJU_DEBUG_PORT=5678",
+		"JUJU_DEBUG_AT=all",
+	})
+	c.Assert(session.WrapCommand([]string{"/charm/hooks/install"}), jc.DeepEquals, []string{
+		"/bin/bash", "-c", `python3 -m debugpy "$@"`, "juju-debug-wrapper", "/charm/hooks/install",
+	})
+
+	// The client has gone away, and there is no tmux session.
+	s.PatchValue(&clientConnected, func(*HooksContext) bool { return false })
+	session, err = s.ctx.FindSession()
+	c.Assert(session, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, regexp.QuoteMeta("tmux has-session -t "+s.ctx.Unit+"\n"))
+
+	// A tmux session ignores the options of a client that has gone away.
+	os.Setenv("EXIT_CODE", "")
+	session, err = s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.Interactive(), jc.IsTrue)
+	c.Assert(session.MatchHook("install"), jc.IsTrue)
+	c.Assert(session.DebugEnv(nil), gc.HasLen, 0)
+	c.Assert(session.WrapCommand([]string{"hook"}), jc.DeepEquals, []string{"hook"})
+}
+
 func (s *DebugHooksServerSuite) TestRunHookExceptional(c *gc.C) {
 	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte{}, 0777)
 	c.Assert(err, jc.ErrorIsNil)
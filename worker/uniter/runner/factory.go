@@ -34,11 +34,13 @@ type Factory interface {
 }
 
 // NewFactory returns a Factory capable of creating runners for executing
-// charm hooks, actions and commands.
+// charm hooks, actions and commands. If remoteExec is not nil, the
+// runners use it to run juju-run actions.
 func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
+	remoteExec RemoteExecFunc,
 ) (
 	Factory, error,
 ) {
@@ -46,6 +48,7 @@ func NewFactory(
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		remoteExec:     remoteExec,
 	}
 
 	return f, nil
@@ -58,7 +61,8 @@ type factory struct {
 	state *uniter.State
 
 	// Fields that shouldn't change in a factory's lifetime.
-	paths context.Paths
+	paths      context.Paths
+	remoteExec RemoteExecFunc
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -124,7 +128,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
+	runner := NewRemoteRunner(ctx, f.paths, f.remoteExec)
 	return runner, nil
 }
 
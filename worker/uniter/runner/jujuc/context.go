@@ -36,6 +36,8 @@ type HookContext interface {
 	ContextInstance
 	ContextNetworking
 	ContextLeadership
+	ContextCharmState
+	ContextSecrets
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -180,6 +182,51 @@ type ContextLeadership interface {
 	WriteLeaderSettings(map[string]string) error
 }
 
+// ContextCharmState is the part of a hook context related to the
+// charm's private unit state, which is persisted by the controller.
+type ContextCharmState interface {
+	// GetCharmState returns a copy of the unit's charm state, including
+	// any changes made during the current hook.
+	GetCharmState() (map[string]string, error)
+
+	// GetCharmStateValue returns the value of the given charm state key.
+	// A NotFound error is returned if the key is not set.
+	GetCharmStateValue(key string) (string, error)
+
+	// SetCharmStateValue sets the value of the given charm state key. The
+	// change is written to the controller when the hook completes
+	// successfully.
+	SetCharmStateValue(key, value string) error
+
+	// DeleteCharmStateValue removes the given charm state key. The change
+	// is written to the controller when the hook completes successfully.
+	DeleteCharmStateValue(key string) error
+}
+
+// ContextSecrets is the part of a hook context related to secrets
+// owned by, or shared with, the unit's application.
+type ContextSecrets interface {
+	// CreateSecret creates a secret owned by the unit's application and
+	// returns its id. Only the leader may create secrets.
+	CreateSecret(description string, rotateInterval time.Duration, data map[string]string) (string, error)
+
+	// GetSecret returns the value of the given secret revision. A zero
+	// revision means the latest.
+	GetSecret(id string, revision int) (map[string]string, error)
+
+	// RotateSecret records a new revision of the given secret with the
+	// supplied value. Only the leader may rotate secrets.
+	RotateSecret(id string, data map[string]string) error
+
+	// GrantSecret allows the remote application of the given relation to
+	// read the given secret.
+	GrantSecret(id string, relationId int) error
+
+	// RevokeSecret stops the remote application of the given relation
+	// from reading the given secret.
+	RevokeSecret(id string, relationId int) error
+}
+
 // ContextMetrics is the part of a hook context related to metrics.
 type ContextMetrics interface {
 	// AddMetric records a metric to return after hook execution.
@@ -16,6 +16,8 @@ type ContextInfo struct {
 	Instance
 	NetworkInterface
 	Leadership
+	CharmState
+	Secrets
 	Metrics
 	Storage
 	Components
@@ -56,6 +58,8 @@ type Context struct {
 	ContextInstance
 	ContextNetworking
 	ContextLeader
+	ContextCharmState
+	ContextSecrets
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -78,6 +82,10 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextNetworking.info = &info.NetworkInterface
 	ctx.ContextLeader.stub = stub
 	ctx.ContextLeader.info = &info.Leadership
+	ctx.ContextCharmState.stub = stub
+	ctx.ContextCharmState.info = &info.CharmState
+	ctx.ContextSecrets.stub = stub
+	ctx.ContextSecrets.info = &info.Secrets
 	ctx.ContextMetrics.stub = stub
 	ctx.ContextMetrics.info = &info.Metrics
 	ctx.ContextStorage.stub = stub
@@ -56,9 +56,14 @@ Options:
 Details:
 "relation-set" writes the local unit's settings for some relation.
 If no relation is specified then the current relation is used. The
-setting values are not inspected and are stored as strings. Setting
-an empty string causes the setting to be removed. Duplicate settings
-are not allowed.
+setting values are stored as strings. Setting an empty string causes
+the setting to be removed. Duplicate settings are not allowed.
+
+If the charm declares a schema for the data it publishes on the
+relation, under the "relation-schemas" key of its metadata, the
+settings are validated against it when the hook completes, and the
+hook fails if they do not conform. The settings Juju manages itself,
+such as private-address, are not validated.
 
 The --file option should be used when one or more key-value pairs are
 too long to fit within the command length limit of the shell or
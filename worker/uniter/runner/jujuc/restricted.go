@@ -101,6 +101,41 @@ func (*RestrictedContext) LeaderSettings() (map[string]string, error) {
 // WriteLeaderSettings implements hooks.Context.
 func (*RestrictedContext) WriteLeaderSettings(map[string]string) error { return ErrRestrictedContext }
 
+// GetCharmState implements hooks.Context.
+func (*RestrictedContext) GetCharmState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GetCharmStateValue implements hooks.Context.
+func (*RestrictedContext) GetCharmStateValue(string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// SetCharmStateValue implements hooks.Context.
+func (*RestrictedContext) SetCharmStateValue(string, string) error { return ErrRestrictedContext }
+
+// DeleteCharmStateValue implements hooks.Context.
+func (*RestrictedContext) DeleteCharmStateValue(string) error { return ErrRestrictedContext }
+
+// CreateSecret implements hooks.Context.
+func (*RestrictedContext) CreateSecret(string, time.Duration, map[string]string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// GetSecret implements hooks.Context.
+func (*RestrictedContext) GetSecret(string, int) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// RotateSecret implements hooks.Context.
+func (*RestrictedContext) RotateSecret(string, map[string]string) error { return ErrRestrictedContext }
+
+// GrantSecret implements hooks.Context.
+func (*RestrictedContext) GrantSecret(string, int) error { return ErrRestrictedContext }
+
+// RevokeSecret implements hooks.Context.
+func (*RestrictedContext) RevokeSecret(string, int) error { return ErrRestrictedContext }
+
 // AddMetric implements hooks.Context.
 func (*RestrictedContext) AddMetric(string, string, time.Time) error { return ErrRestrictedContext }
 
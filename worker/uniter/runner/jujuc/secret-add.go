@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+)
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx            Context
+	description    string
+	rotateInterval time.Duration
+	data           map[string]string
+}
+
+// NewSecretAddCommand returns a new secretAddCommand with the given context.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add creates a secret owned by the unit's application, holding the
+supplied key/value pairs, and prints its id. Only the leader unit may add
+secrets.
+
+The secret is readable by the application's units. Use secret-grant to
+share it with a related application.
+
+If --rotate is specified, the leader will run the secret-rotate hook, with
+JUJU_SECRET_ID set, each time the interval elapses.
+
+Examples:
+    secret-add password=s3cret
+    secret-add --description "db admin" --rotate 720h user=admin password=s3cret
+`
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<key>=<value> [...]",
+		Purpose: "add a new secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.description, "description", "", "the secret description")
+	f.DurationVar(&c.rotateInterval, "rotate", 0, "how often the secret should be rotated")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretAddCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret value specified")
+	}
+	if c.rotateInterval < 0 {
+		return errors.NotValidf("negative rotate interval")
+	}
+	c.data, err = keyvalues.Parse(args, false)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	id, err := c.ctx.CreateSecret(c.description, c.rotateInterval, c.data)
+	if err != nil {
+		return errors.Annotate(err, "cannot add secret")
+	}
+	fmt.Fprintln(ctx.Stdout, id)
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *SecretAddSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "ERROR no secret value specified\n",
+	}, {
+		args: []string{"--rotate", "-1h", "a=b"},
+		err:  "ERROR negative rotate interval not valid\n",
+	}, {
+		args: []string{"novalue"},
+		err:  `ERROR .*"novalue".*\n`,
+	}} {
+		_, com := s.createCommand(c, nil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Matches, t.err)
+	}
+}
+
+func (s *SecretAddSuite) TestAdd(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{
+		"--description", "db admin", "--rotate", "1h", "user=admin", "password=s3cret",
+	})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "secret-0\n")
+	data := map[string]string{"user": "admin", "password": "s3cret"}
+	s.Stub.CheckCall(c, 0, "CreateSecret", "db admin", time.Hour, data)
+	c.Check(hctx.info.Secrets.Secrets, jc.DeepEquals, map[string]map[string]string{
+		"secret-0": data,
+	})
+}
+
+func (s *SecretAddSuite) TestAddError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"a=b"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot add secret: zap\n")
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	id       string
+	key      string
+	revision int
+	out      cmd.Output
+}
+
+// NewSecretGetCommand returns a new secretGetCommand with the given context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of the secret with the given id. If a key is
+given, only the value of that key is printed.
+
+The secret must be owned by the unit's application, or have been granted to
+it by the owner. By default the latest revision is read; --revision reads
+an earlier one.
+
+Examples:
+    secret-get 9m4e2mr0ui3e8a215n4g
+    secret-get 9m4e2mr0ui3e8a215n4g password
+    secret-get --revision 1 9m4e2mr0ui3e8a215n4g
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<id> [<key>]",
+		Purpose: "print the value of a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.IntVar(&c.revision, "revision", 0, "the secret revision to read")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	if c.revision < 0 {
+		return errors.NotValidf("negative revision")
+	}
+	c.id, args = args[0], args[1:]
+	if len(args) > 0 {
+		c.key, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	data, err := c.ctx.GetSecret(c.id, c.revision)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read secret %q", c.id)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, data)
+	}
+	value, ok := data[c.key]
+	if !ok {
+		return errors.NotFoundf("secret %q key %q", c.id, c.key)
+	}
+	return c.out.Write(ctx, value)
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Secrets = map[string]map[string]string{
+		"secret-0": {"user": "admin", "password": "s3cret"},
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *SecretGetSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "ERROR no secret id specified\n",
+	}, {
+		args: []string{"--revision", "-1", "secret-0"},
+		err:  "ERROR negative revision not valid\n",
+	}, {
+		args: []string{"secret-0", "user", "extra"},
+		err:  `ERROR unrecognized args: \["extra"\]\n`,
+	}} {
+		com := s.createCommand(c, nil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Matches, t.err)
+	}
+}
+
+func (s *SecretGetSuite) TestGetAll(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "yaml", "--revision", "2", "secret-0"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), jc.YAMLEquals, map[string]string{
+		"user":     "admin",
+		"password": "s3cret",
+	})
+	s.Stub.CheckCall(c, 0, "GetSecret", "secret-0", 2)
+}
+
+func (s *SecretGetSuite) TestGetKey(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0", "password"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "s3cret\n")
+	s.Stub.CheckCall(c, 0, "GetSecret", "secret-0", 0)
+}
+
+func (s *SecretGetSuite) TestGetMissingKey(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0", "nope"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR secret \"secret-0\" key \"nope\" not found\n")
+}
+
+func (s *SecretGetSuite) TestGetError(c *gc.C) {
+	com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read secret \"secret-0\": zap\n")
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// secretGrantCommand implements the secret-grant and secret-revoke
+// commands.
+type secretGrantCommand struct {
+	cmd.CommandBase
+	ctx             Context
+	revoke          bool
+	id              string
+	RelationId      int
+	relationIdProxy gnuflag.Value
+}
+
+// NewSecretGrantCommand returns a new secret-grant command with the given
+// context.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	return newSecretGrantCommand(ctx, false)
+}
+
+// NewSecretRevokeCommand returns a new secret-revoke command with the
+// given context.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	return newSecretGrantCommand(ctx, true)
+}
+
+func newSecretGrantCommand(ctx Context, revoke bool) (cmd.Command, error) {
+	c := &secretGrantCommand{ctx: ctx, revoke: revoke}
+	var err error
+	c.relationIdProxy, err = NewRelationIdValue(ctx, &c.RelationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	if c.revoke {
+		doc := `
+secret-revoke stops the application at the other end of the relation from
+reading the secret with the given id. Only the leader unit of the
+application that owns the secret may revoke access to it.
+
+-r must be specified when not in the context of a relation hook.
+
+Examples:
+    secret-revoke -r db:2 9m4e2mr0ui3e8a215n4g
+`
+		return &cmd.Info{
+			Name:    "secret-revoke",
+			Args:    "<id>",
+			Purpose: "revoke access to a secret",
+			Doc:     doc,
+		}
+	}
+	doc := `
+secret-grant allows the application at the other end of the relation to
+read the secret with the given id. Only the leader unit of the application
+that owns the secret may grant access to it. Access is revoked
+automatically if the related application is removed.
+
+-r must be specified when not in the context of a relation hook.
+
+Examples:
+    secret-grant -r db:2 9m4e2mr0ui3e8a215n4g
+`
+	return &cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<id>",
+		Purpose: "grant access to a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relationIdProxy, "r", "specify a relation by id")
+	f.Var(c.relationIdProxy, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGrantCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	if c.RelationId == -1 {
+		return errors.New("no relation id specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	if c.revoke {
+		err := c.ctx.RevokeSecret(c.id, c.RelationId)
+		return errors.Annotatef(err, "cannot revoke secret %q", c.id)
+	}
+	err := c.ctx.GrantSecret(c.id, c.RelationId)
+	return errors.Annotatef(err, "cannot grant secret %q", c.id)
+}
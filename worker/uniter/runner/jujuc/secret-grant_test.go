@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) run(c *gc.C, name string, relid int, args ...string) (int, *cmd.Context) {
+	hctx, _ := s.newHookContext(relid, "")
+	com, err := jujuc.NewCommand(hctx, cmdString(name))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return code, ctx
+}
+
+func (s *SecretGrantSuite) checkLastCall(c *gc.C, name string, args ...interface{}) {
+	calls := s.Stub.Calls()
+	c.Assert(calls, gc.Not(gc.HasLen), 0)
+	last := calls[len(calls)-1]
+	c.Check(last.FuncName, gc.Equals, name)
+	c.Check(last.Args, jc.DeepEquals, args)
+}
+
+func (s *SecretGrantSuite) TestInitErrors(c *gc.C) {
+	for _, name := range []string{"secret-grant", "secret-revoke"} {
+		code, ctx := s.run(c, name, -1, "secret-0")
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no relation id specified\n")
+
+		code, ctx = s.run(c, name, 1)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no secret id specified\n")
+	}
+}
+
+func (s *SecretGrantSuite) TestGrantDefaultRelation(c *gc.C) {
+	code, ctx := s.run(c, "secret-grant", 1, "secret-0")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.checkLastCall(c, "GrantSecret", "secret-0", 1)
+}
+
+func (s *SecretGrantSuite) TestGrantExplicitRelation(c *gc.C) {
+	code, ctx := s.run(c, "secret-grant", -1, "-r", "peer0:0", "secret-0")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.checkLastCall(c, "GrantSecret", "secret-0", 0)
+}
+
+func (s *SecretGrantSuite) TestRevoke(c *gc.C) {
+	code, ctx := s.run(c, "secret-revoke", -1, "-r", "peer1:1", "secret-0")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.checkLastCall(c, "RevokeSecret", "secret-0", 1)
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// secretRotateCommand implements the secret-rotate command.
+type secretRotateCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	id   string
+	data map[string]string
+}
+
+// NewSecretRotateCommand returns a new secretRotateCommand with the given
+// context.
+func NewSecretRotateCommand(ctx Context) (cmd.Command, error) {
+	return &secretRotateCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretRotateCommand) Info() *cmd.Info {
+	doc := `
+secret-rotate records a new revision of the secret with the given id,
+replacing its value with the supplied key/value pairs. Earlier revisions
+remain readable with secret-get --revision. Only the leader unit of the
+application that owns the secret may rotate it.
+
+This is typically run from the secret-rotate hook, which sets
+JUJU_SECRET_ID to the id of the secret that is due to be rotated.
+
+Examples:
+    secret-rotate $JUJU_SECRET_ID password=n3w
+`
+	return &cmd.Info{
+		Name:    "secret-rotate",
+		Args:    "<id> <key>=<value> [...]",
+		Purpose: "set a new value for a secret",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretRotateCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	c.id, args = args[0], args[1:]
+	if len(args) == 0 {
+		return errors.New("no secret value specified")
+	}
+	c.data, err = keyvalues.Parse(args, false)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretRotateCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.RotateSecret(c.id, c.data)
+	return errors.Annotatef(err, "cannot rotate secret %q", c.id)
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretRotateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretRotateSuite{})
+
+func (s *SecretRotateSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Secrets = map[string]map[string]string{
+		"secret-0": {"password": "s3cret"},
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-rotate"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *SecretRotateSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "ERROR no secret id specified\n",
+	}, {
+		args: []string{"secret-0"},
+		err:  "ERROR no secret value specified\n",
+	}} {
+		_, com := s.createCommand(c, nil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err)
+	}
+}
+
+func (s *SecretRotateSuite) TestRotate(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0", "password=n3w"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "RotateSecret", "secret-0", map[string]string{"password": "n3w"})
+	c.Check(hctx.info.Secrets.Secrets["secret-0"], jc.DeepEquals, map[string]string{"password": "n3w"})
+}
+
+func (s *SecretRotateSuite) TestRotateError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0", "password=n3w"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot rotate secret \"secret-0\": zap\n")
+	c.Check(hctx.info.Secrets.Secrets["secret-0"], jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
@@ -66,6 +66,14 @@ var baseCommands = map[string]creator{
 	"pod-spec-set" + cmdSuffix:            NewPodSpecSetCommand,
 	"goal-state" + cmdSuffix:              NewGoalStateCommand,
 	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
+	"state-get" + cmdSuffix:               NewStateGetCommand,
+	"state-set" + cmdSuffix:               NewStateSetCommand,
+	"state-delete" + cmdSuffix:            NewStateDeleteCommand,
+	"secret-add" + cmdSuffix:              NewSecretAddCommand,
+	"secret-get" + cmdSuffix:              NewSecretGetCommand,
+	"secret-grant" + cmdSuffix:            NewSecretGrantCommand,
+	"secret-revoke" + cmdSuffix:           NewSecretRevokeCommand,
+	"secret-rotate" + cmdSuffix:           NewSecretRotateCommand,
 }
 
 var storageCommands = map[string]creator{
@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// stateDeleteCommand implements the state-delete command.
+type stateDeleteCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	keys []string
+}
+
+// NewStateDeleteCommand returns a new stateDeleteCommand with the given context.
+func NewStateDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &stateDeleteCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Info() *cmd.Info {
+	doc := `
+state-delete removes the supplied keys from the unit's charm state. The
+changes are written to the controller when the hook completes successfully.
+Deleting a key that is not set is not an error.
+`
+	return &cmd.Info{
+		Name:    "state-delete",
+		Args:    "<key> [...]",
+		Purpose: "delete charm state stored by the controller",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no charm state key specified")
+	}
+	c.keys = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Run(_ *cmd.Context) error {
+	for _, key := range c.keys {
+		if err := c.ctx.DeleteCharmStateValue(key); err != nil {
+			return errors.Annotatef(err, "cannot delete charm state %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// stateGetCommand implements the state-get command.
+type stateGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	key string
+	out cmd.Output
+}
+
+// NewStateGetCommand returns a new stateGetCommand with the given context.
+func NewStateGetCommand(ctx Context) (cmd.Command, error) {
+	return &stateGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateGetCommand) Info() *cmd.Info {
+	doc := `
+state-get prints the value of the unit's charm state specified by key. If no key
+is given, or if the key is "-", all keys and values will be printed.
+
+Charm state is private to the unit and is stored by the controller, so it
+survives the loss of the unit's local disk and is carried across model
+migrations.
+`
+	return &cmd.Info{
+		Name:    "state-get",
+		Args:    "[<key>]",
+		Purpose: "print charm state stored by the controller",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *stateGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateGetCommand) Init(args []string) error {
+	c.key = ""
+	if len(args) == 0 {
+		return nil
+	}
+	key := args[0]
+	if key == "-" {
+		key = ""
+	} else if strings.Contains(key, "=") {
+		return errors.Errorf("invalid key %q", key)
+	}
+	c.key = key
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateGetCommand) Run(ctx *cmd.Context) error {
+	if c.key == "" {
+		state, err := c.ctx.GetCharmState()
+		if err != nil {
+			return errors.Annotate(err, "cannot read charm state")
+		}
+		return c.out.Write(ctx, state)
+	}
+	value, err := c.ctx.GetCharmStateValue(c.key)
+	if errors.IsNotFound(err) {
+		return c.out.Write(ctx, nil)
+	} else if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	return c.out.Write(ctx, value)
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateGetSuite{})
+
+func (s *StateGetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{
+		"key":    "value",
+		"sample": "state",
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *StateGetSuite) TestInitError(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := com.Init([]string{"x=x"})
+	c.Assert(err, gc.ErrorMatches, `invalid key "x=x"`)
+}
+
+func (s *StateGetSuite) TestGetKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"key"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "value\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "GetCharmStateValue", "key")
+}
+
+func (s *StateGetSuite) TestGetMissingKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "json", "unknown"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), jc.JSONEquals, nil)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *StateGetSuite) TestGetAll(c *gc.C) {
+	for _, args := range [][]string{nil, {"-"}} {
+		_, com := s.createCommand(c, nil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, append([]string{"--format", "yaml"}, args...))
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stdout), jc.YAMLEquals, map[string]string{
+			"key":    "value",
+			"sample": "state",
+		})
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	}
+}
+
+func (s *StateGetSuite) TestGetError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read charm state: zap\n")
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// stateSetCommand implements the state-set command.
+type stateSetCommand struct {
+	cmd.CommandBase
+	ctx   Context
+	state map[string]string
+}
+
+// NewStateSetCommand returns a new stateSetCommand with the given context.
+func NewStateSetCommand(ctx Context) (cmd.Command, error) {
+	return &stateSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateSetCommand) Info() *cmd.Info {
+	doc := `
+state-set sets the supplied key/value pairs in the unit's charm state. The
+changes are written to the controller when the hook completes successfully;
+if the hook fails they are discarded. Setting a key to an empty value
+removes it.
+`
+	return &cmd.Info{
+		Name:    "state-set",
+		Args:    "<key>=<value> [...]",
+		Purpose: "set charm state stored by the controller",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateSetCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no charm state specified")
+	}
+	c.state, err = keyvalues.Parse(args, true)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateSetCommand) Run(_ *cmd.Context) error {
+	keys := make([]string, 0, len(c.state))
+	for key := range c.state {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var err error
+		if value := c.state[key]; value == "" {
+			err = c.ctx.DeleteCharmStateValue(key)
+		} else {
+			err = c.ctx.SetCharmStateValue(key, value)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "cannot set charm state %q", key)
+		}
+	}
+	return nil
+}
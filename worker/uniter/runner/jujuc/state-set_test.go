@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateSetSuite{})
+
+func (s *StateSetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{"old": "value"}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *StateSetSuite) TestNoArguments(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no charm state specified\n")
+}
+
+func (s *StateSetSuite) TestInvalidArgument(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"novalue"})
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Matches, `ERROR .*"novalue".*\n`)
+}
+
+func (s *StateSetSuite) TestSet(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one=1", "old=", "two=2"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.CharmState, jc.DeepEquals, map[string]string{
+		"one": "1",
+		"two": "2",
+	})
+	s.Stub.CheckCallNames(c, "SetCharmStateValue", "DeleteCharmStateValue", "SetCharmStateValue")
+}
+
+func (s *StateSetSuite) TestSetError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("zap"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one=1"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot set charm state \"one\": zap\n")
+	c.Check(hctx.info.CharmState.CharmState, jc.DeepEquals, map[string]string{"old": "value"})
+}
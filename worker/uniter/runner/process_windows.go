@@ -0,0 +1,19 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build windows
+
+package runner
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on windows, which has no process groups
+// in the unix sense.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the command's process.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -51,6 +51,7 @@ type Context interface {
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
+	HookTimeout() time.Duration
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
 
@@ -58,15 +59,27 @@ type Context interface {
 	Flush(badge string, failure error) error
 }
 
+// RemoteExecFunc runs commands on behalf of a unit somewhere other
+// than the local machine, such as in the workload container of a
+// CAAS unit.
+type RemoteExecFunc func(unitName, commands string, timeout time.Duration) (*utilexec.ExecResponse, error)
+
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return &runner{context: context, paths: paths}
+}
+
+// NewRemoteRunner returns a Runner backed by the supplied context and
+// paths, which runs juju-run actions using the supplied function.
+func NewRemoteRunner(context Context, paths context.Paths, remoteExec RemoteExecFunc) Runner {
+	return &runner{context: context, paths: paths, remoteExec: remoteExec}
 }
 
 // runner implements Runner.
 type runner struct {
-	context Context
-	paths   context.Paths
+	context    Context
+	paths      context.Paths
+	remoteExec RemoteExecFunc
 }
 
 func (runner *runner) Context() Context {
@@ -136,7 +149,14 @@ func (runner *runner) runJujuRunAction() (err error) {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	var results *utilexec.ExecResponse
+	if runner.remoteExec != nil {
+		// The commands are run alongside the unit's workload,
+		// outside of the hook context.
+		results, err = runner.remoteExec(runner.context.UnitName(), command, time.Duration(timeout))
+	} else {
+		results, err = runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	}
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -224,24 +244,36 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
-		logger.Infof("executing %s via debug-hooks", hookName)
-		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
+		if session.Interactive() {
+			logger.Infof("executing %s via debug-hooks", hookName)
+			err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
+		} else {
+			logger.Infof("executing %s with debugging enabled", hookName)
+			err = runner.runCharmHook(hookName, env, charmLocation, session)
+		}
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, nil)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+// runCharmHook runs the named hook. If a non-interactive debug-hooks
+// session is specified, the hook is run with its debug options.
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, session *debug.ServerSession) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
 		return err
 	}
 	hookCmd := hookCommand(hook)
+	if session != nil {
+		env = session.DebugEnv(env)
+		hookCmd = session.WrapCommand(hookCmd)
+	}
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	setProcessGroup(ps)
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -255,13 +287,43 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		err = ps.Wait()
+		// Block until execution finishes. A hook being debugged may
+		// wait indefinitely for a debugger, so it is not timed out.
+		if session != nil {
+			err = ps.Wait()
+		} else {
+			err = runner.waitHook(hookName, ps)
+		}
 	}
 	hookLogger.Stop()
 	return errors.Trace(err)
 }
 
+// waitHook waits for the hook process to finish. If the context has a
+// hook timeout and it expires first, the hook's process group is killed
+// and a hook timeout error is returned.
+func (runner *runner) waitHook(hookName string, ps *exec.Cmd) error {
+	timeout := runner.context.HookTimeout()
+	if timeout <= 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-clock.WallClock.After(timeout):
+	}
+	logger.Warningf("hook %q timed out after %v, killing it", hookName, timeout)
+	if err := killProcessGroup(ps); err != nil {
+		logger.Errorf("cannot kill hook %q: %v", hookName, err)
+	}
+	<-done
+	return charmrunner.NewHookTimeoutError(hookName, timeout)
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
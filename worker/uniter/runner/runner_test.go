@@ -156,6 +156,7 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	hookTimeout     time.Duration
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -177,6 +178,10 @@ func (ctx *MockContext) SetProcess(process context.HookProcess) {
 	ctx.expectPid = process.Pid()
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *MockContext) Prepare() error {
 	return nil
 }
@@ -253,6 +258,26 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: 60,
+	}, s.paths.GetCharmDir())
+	start := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(start) < 30*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(errors.Cause(ctx.flushFailure), jc.Satisfies, charmrunner.IsHookTimeoutError)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened timed out after 100ms")
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -318,6 +343,30 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
 }
 
+func (s *RunMockContextSuite) TestRunActionRemote(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command": "echo 1",
+			"timeout": float64(time.Minute.Nanoseconds()),
+		},
+		actionResults: map[string]interface{}{},
+	}
+	remoteExec := func(unitName, commands string, timeout time.Duration) (*exec.ExecResponse, error) {
+		c.Check(unitName, gc.Equals, "some-unit/999")
+		c.Check(commands, gc.Equals, "echo 1")
+		c.Check(timeout, gc.Equals, time.Minute)
+		return &exec.ExecResponse{Code: 3, Stdout: []byte("1\n")}, nil
+	}
+	err := runner.NewRemoteRunner(ctx, s.paths, remoteExec).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Code"], gc.Equals, "3")
+	c.Assert(ctx.actionResults["Stdout"], gc.Equals, "1\n")
+	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
+}
+
 func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	timeout := 1 * time.Nanosecond
 	ctx := &MockContext{
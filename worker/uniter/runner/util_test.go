@@ -116,6 +116,7 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 		s.uniter,
 		s.paths,
 		s.contextFactory,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.factory = factory
@@ -202,6 +203,8 @@ type hookSpec struct {
 	stderr string
 	// background holds a string to print in the background after 0.2s.
 	background string
+	// sleep holds the number of seconds the hook sleeps before exiting.
+	sleep int
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -246,5 +249,8 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.sleep != 0 {
+		printf("sleep %d", spec.sleep)
+	}
 	printf("exit %d", spec.code)
 }
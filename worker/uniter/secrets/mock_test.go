@@ -0,0 +1,43 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"fmt"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type mockOperations struct {
+	operation.Factory
+}
+
+func (m *mockOperations) NewRunHook(hookInfo hook.Info) (operation.Operation, error) {
+	return &mockOperation{fmt.Sprintf("run hook %v %v", hookInfo.Kind, hookInfo.SecretId)}, nil
+}
+
+type mockOperation struct {
+	name string
+}
+
+func (m *mockOperation) String() string {
+	return m.name
+}
+
+func (m *mockOperation) NeedsGlobalMachineLock() bool {
+	return false
+}
+
+func (m *mockOperation) Prepare(state operation.State) (*operation.State, error) {
+	return &state, nil
+}
+
+func (m *mockOperation) Execute(state operation.State) (*operation.State, error) {
+	return &state, nil
+}
+
+func (m *mockOperation) Commit(state operation.State) (*operation.State, error) {
+	return &state, nil
+}
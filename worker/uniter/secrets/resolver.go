@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// secretsResolver is a Resolver that returns operations to run
+// secret-rotate hooks. When a hook is committed, the "secretRotated"
+// callback is invoked to remove the secret from the remote state.
+type secretsResolver struct {
+	secretRotated func(id string)
+}
+
+// NewSecretsResolver returns a new Resolver that returns operations
+// to run secret-rotate hooks.
+//
+// The returned resolver's NextOp method will return an operation to
+// run a secret-rotate hook whenever the unit is the leader and the
+// remote state's "SecretRotations" is non-empty, taking the first ID
+// in the sequence. When the hook operation is committed, the ID of the
+// secret is passed to the "secretRotated" callback.
+func NewSecretsResolver(secretRotated func(string)) resolver.Resolver {
+	return &secretsResolver{secretRotated}
+}
+
+// NextOp is part of the resolver.Resolver interface.
+func (s *secretsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if !localState.Leader || len(remoteState.SecretRotations) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	id := remoteState.SecretRotations[0]
+	op, err := opFactory.NewRunHook(hook.Info{
+		Kind:     hook.SecretRotate,
+		SecretId: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &secretRotater{op, func() { s.secretRotated(id) }}, nil
+}
+
+type secretRotater struct {
+	operation.Operation
+	secretRotated func()
+}
+
+func (r *secretRotater) Commit(st operation.State) (*operation.State, error) {
+	result, err := r.Operation.Commit(st)
+	if err == nil {
+		r.secretRotated()
+	}
+	return result, err
+}
@@ -0,0 +1,53 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/secrets"
+)
+
+type resolverSuite struct {
+	rotated  []string
+	resolver resolver.Resolver
+}
+
+var _ = gc.Suite(&resolverSuite{})
+
+func (s *resolverSuite) SetUpTest(c *gc.C) {
+	s.rotated = nil
+	s.resolver = secrets.NewSecretsResolver(func(id string) {
+		s.rotated = append(s.rotated, id)
+	})
+}
+
+func (s *resolverSuite) TestNoSecrets(c *gc.C) {
+	localState := resolver.LocalState{State: operation.State{Leader: true}}
+	_, err := s.resolver.NextOp(localState, remotestate.Snapshot{}, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestNotLeader(c *gc.C) {
+	remoteState := remotestate.Snapshot{SecretRotations: []string{"secret-1"}}
+	_, err := s.resolver.NextOp(resolver.LocalState{}, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestRotateSecret(c *gc.C) {
+	localState := resolver.LocalState{State: operation.State{Leader: true}}
+	remoteState := remotestate.Snapshot{SecretRotations: []string{"secret-1", "secret-2"}}
+	op, err := s.resolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook secret-rotate secret-1")
+	c.Assert(s.rotated, gc.HasLen, 0)
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rotated, jc.DeepEquals, []string{"secret-1"})
+}
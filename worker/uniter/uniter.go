@@ -42,6 +42,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
 )
 
@@ -110,6 +111,10 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// remoteExec, if set, is used to run juju-run actions
+	// somewhere other than the machine running the uniter.
+	remoteExec runner.RemoteExecFunc
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -127,13 +132,14 @@ type UniterParams struct {
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
 	ApplicationChannel   watcher.NotifyChannel
+	RemoteExec           runner.RemoteExecFunc
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
 	Observer UniterExecutionObserver
 }
 
-type NewExecutorFunc func(string, operation.State, func() (mutex.Releaser, error)) (operation.Executor, error)
+type NewExecutorFunc func(string, operation.State, func() (mutex.Releaser, error), operation.HookRecorder) (operation.Executor, error)
 
 // NewUniter creates a new Uniter which will install, run, and upgrade
 // a charm on behalf of the unit with the given unitTag, by executing
@@ -173,6 +179,7 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
 		applicationChannel:   uniterParams.ApplicationChannel,
+		remoteExec:           uniterParams.RemoteExec,
 	}
 	startFunc := func() (worker.Worker, error) {
 		if err := catacomb.Invoke(catacomb.Plan{
@@ -297,6 +304,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			// error state.
 			return nil
 		}
+		if watcher.Snapshot().Paused {
+			return setAgentStatus(u, status.Paused, "hook execution paused", nil)
+		}
 		return setAgentStatus(u, status.Idle, "", nil)
 	}
 
@@ -328,6 +338,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			Secrets: secrets.NewSecretsResolver(watcher.SecretRotated),
 		}
 		uniterResolver := NewUniterResolver(cfg)
 
@@ -534,7 +545,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.remoteExec,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -570,7 +581,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 			return errors.Trace(err)
 		}
 	}
-	operationExecutor, err := u.newOperationExecutor(u.paths.State.OperationsFile, initialState, u.acquireExecutionLock)
+	operationExecutor, err := u.newOperationExecutor(u.paths.State.OperationsFile, initialState, u.acquireExecutionLock, u.recordHookExecution)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -645,7 +656,30 @@ func (u *Uniter) acquireExecutionLock() (mutex.Releaser, error) {
 	return releaser, nil
 }
 
-func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+// recordHookExecution records the execution of a hook in the unit's
+// hook history on the controller.
+func (u *Uniter) recordHookExecution(execution operation.HookExecution) error {
+	hookInfo := execution.Info
+	hookName := string(hookInfo.Kind)
+	relationId := -1
+	switch {
+	case hookInfo.Kind.IsRelation():
+		relationId = hookInfo.RelationId
+		// The relation is forgotten once its relation-broken
+		// hook has been committed, but that happens after the
+		// hook is recorded.
+		if relationName, err := u.relations.Name(relationId); err == nil {
+			hookName = fmt.Sprintf("%s-%s", relationName, hookInfo.Kind)
+		}
+	case hookInfo.Kind.IsStorage():
+		if storageName, err := names.StorageName(hookInfo.StorageId); err == nil {
+			hookName = fmt.Sprintf("%s-%s", storageName, hookInfo.Kind)
+		}
+	}
+	return u.unit.RecordHookExecution(hookName, relationId, execution.Started, execution.Duration, execution.ExitCode)
+}
+
+func (u *Uniter) reportHookError(hookInfo hook.Info, timedOut bool) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
@@ -664,5 +698,8 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if timedOut {
+		statusMessage = fmt.Sprintf("hook timed out: %q", hookName)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }
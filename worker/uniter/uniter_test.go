@@ -199,8 +199,8 @@ func (m *noopExecutor) Run(op operation.Operation) error {
 }
 
 func (s *UniterSuite) TestUniterStartupStatus(c *gc.C) {
-	executorFunc := func(stateFilePath string, initialState operation.State, acquireLock func() (mutex.Releaser, error)) (operation.Executor, error) {
-		e, err := operation.NewExecutor(stateFilePath, initialState, acquireLock)
+	executorFunc := func(stateFilePath string, initialState operation.State, acquireLock func() (mutex.Releaser, error), recordHook operation.HookRecorder) (operation.Executor, error) {
+		e, err := operation.NewExecutor(stateFilePath, initialState, acquireLock, recordHook)
 		c.Assert(err, jc.ErrorIsNil)
 		return &mockExecutor{e}, nil
 	}
@@ -1937,8 +1937,8 @@ func (m *mockExecutor) Run(op operation.Operation) error {
 }
 
 func (s *UniterSuite) TestOperationErrorReported(c *gc.C) {
-	executorFunc := func(stateFilePath string, initialState operation.State, acquireLock func() (mutex.Releaser, error)) (operation.Executor, error) {
-		e, err := operation.NewExecutor(stateFilePath, initialState, acquireLock)
+	executorFunc := func(stateFilePath string, initialState operation.State, acquireLock func() (mutex.Releaser, error), recordHook operation.HookRecorder) (operation.Executor, error) {
+		e, err := operation.NewExecutor(stateFilePath, initialState, acquireLock, recordHook)
 		c.Assert(err, jc.ErrorIsNil)
 		return &mockExecutor{e}, nil
 	}
@@ -1958,8 +1958,8 @@ func (s *UniterSuite) TestOperationErrorReported(c *gc.C) {
 }
 
 func (s *UniterSuite) TestTranslateResolverError(c *gc.C) {
-	executorFunc := func(stateFilePath string, initialState operation.State, acquireLock func() (mutex.Releaser, error)) (operation.Executor, error) {
-		e, err := operation.NewExecutor(stateFilePath, initialState, acquireLock)
+	executorFunc := func(stateFilePath string, initialState operation.State, acquireLock func() (mutex.Releaser, error), recordHook operation.HookRecorder) (operation.Executor, error) {
+		e, err := operation.NewExecutor(stateFilePath, initialState, acquireLock, recordHook)
 		c.Assert(err, jc.ErrorIsNil)
 		return &mockExecutor{e}, nil
 	}